@@ -0,0 +1,163 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package breaker provides the circuit breakers used by the generated SDK
+// to fail fast once the upstream keeps failing, instead of piling up the
+// calls bound to time out. The Breaker interface allows plugging in any
+// implementation, while New provides one tripping on the consecutive
+// failures.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned for the calls rejected while the breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Breaker guards the calls made to the upstream
+type Breaker interface {
+	// Allow reports whether the call may proceed, returning ErrOpen
+	// otherwise, along with the function to be called with the outcome
+	// of the call once complete
+	Allow() (done func(success bool), err error)
+}
+
+// State is the state of the breaker
+type State int
+
+const (
+	// Closed allows all the calls, counting the consecutive failures
+	Closed State = iota
+	// Open rejects all the calls until the open timeout elapses
+	Open
+	// HalfOpen allows the limited number of trial calls, closing the
+	// breaker on success and opening it again on failure
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Config describes the breaker tripping on the consecutive failures
+type Config struct {
+	// FailureThreshold is the number of consecutive failures opening
+	// the breaker, defaults to 5
+	FailureThreshold int
+
+	// OpenTimeout is the duration the breaker stays open before
+	// allowing the trial calls, defaults to 30 seconds
+	OpenTimeout time.Duration
+
+	// HalfOpenCalls is the number of concurrent trial calls allowed
+	// while half open, defaults to 1
+	HalfOpenCalls int
+
+	// OnStateChange is notified of the state transitions if provided,
+	// called with the lock of the breaker held
+	OnStateChange func(from, to State)
+}
+
+// breaker trips on the consecutive failures as per the config
+type breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	inflight int
+	openedAt time.Time
+	// generation is bumped on every transition, ignoring the outcomes
+	// of the calls allowed in the previous state
+	generation uint64
+}
+
+// New creates the breaker opening after the configured number of
+// consecutive failures, allowing the trial calls once the open timeout
+// elapses
+func New(cfg Config) Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenCalls <= 0 {
+		cfg.HalfOpenCalls = 1
+	}
+	return &breaker{cfg: cfg, now: time.Now}
+}
+
+func (b *breaker) Allow() (func(success bool), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setState(HalfOpen)
+	}
+	switch b.state {
+	case Open:
+		return nil, ErrOpen
+	case HalfOpen:
+		if b.inflight >= b.cfg.HalfOpenCalls {
+			return nil, ErrOpen
+		}
+	}
+	b.inflight++
+	generation := b.generation
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() {
+			b.done(generation, success)
+		})
+	}, nil
+}
+
+// done records the outcome of the call allowed in the given generation
+func (b *breaker) done(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation != b.generation {
+		return
+	}
+	b.inflight--
+	switch {
+	case success && b.state == HalfOpen:
+		b.setState(Closed)
+	case success:
+		b.failures = 0
+	case b.state == HalfOpen:
+		b.setState(Open)
+	default:
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.setState(Open)
+		}
+	}
+}
+
+// setState moves the breaker to the given state, resetting the counters
+func (b *breaker) setState(state State) {
+	from := b.state
+	b.state = state
+	b.failures = 0
+	b.inflight = 0
+	b.generation++
+	if state == Open {
+		b.openedAt = b.now()
+	}
+	if b.cfg.OnStateChange != nil && from != state {
+		b.cfg.OnStateChange(from, state)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	var transitions []string
+	b := New(Config{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	}).(*breaker)
+	b.now = func() time.Time { return now }
+
+	call := func(success bool) error {
+		done, err := b.Allow()
+		if err != nil {
+			return err
+		}
+		done(success)
+		return nil
+	}
+
+	// success resets the consecutive failures
+	for _, success := range []bool{false, true, false} {
+		if err := call(success); err != nil {
+			t.Fatalf("Allow() while closed failed with %v; want success", err)
+		}
+	}
+	if err := call(false); err != nil {
+		t.Fatalf("Allow() while closed failed with %v; want success", err)
+	}
+	if err := call(true); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() after consecutive failures = %v; want ErrOpen", err)
+	}
+
+	// single trial call is allowed once the open timeout elapses
+	now = now.Add(time.Minute)
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() after open timeout failed with %v; want success", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("second Allow() while half open = %v; want ErrOpen", err)
+	}
+	done(false)
+	if err := call(true); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() after failed trial = %v; want ErrOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := call(true); err != nil {
+		t.Fatalf("trial Allow() failed with %v; want success", err)
+	}
+	if b.state != Closed {
+		t.Errorf("state after successful trial = %s; want closed", b.state)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v; want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v; want %v", transitions, want)
+			break
+		}
+	}
+}
+
+func TestBreakerIgnoresStaleOutcomes(t *testing.T) {
+	b := New(Config{FailureThreshold: 1}).(*breaker)
+	stale, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() failed with %v; want success", err)
+	}
+	done, _ := b.Allow()
+	done(false)
+	// outcome of the call allowed before tripping is ignored
+	stale(true)
+	stale(true)
+	if b.state != Open {
+		t.Errorf("state after stale success = %s; want open", b.state)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package compression provides the registry of the content codings used
+// by the generated SDK and the generated routes to compress the bodies,
+// negotiated using the Accept-Encoding and the Content-Encoding headers.
+// Gzip, deflate and zstd are registered by default, while others like
+// br are plugged in using Register, without the runtime depending on
+// their implementations.
+package compression
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Names of the well known content codings
+const (
+	Gzip    = "gzip"
+	Deflate = "deflate"
+	Zstd    = "zstd"
+	Brotli  = "br"
+)
+
+// DefaultThreshold is the size of the body beyond which the body is
+// compressed, used when not provided explicitly
+const DefaultThreshold = 1024
+
+var (
+	// DefaultCodecs are the codings used unless configured otherwise,
+	// understood by virtually every client, proxy and server
+	DefaultCodecs = []string{Gzip}
+
+	// InternalCodecs are the codings recommended for the traffic within
+	// the deployment, where both the ends are known to support zstd,
+	// compressing better than gzip at a fraction of the cost
+	InternalCodecs = []string{Zstd, Gzip}
+)
+
+// Codec implements a content coding
+type Codec interface {
+	// Name returns the name of the coding as used in the
+	// Content-Encoding header
+	Name() string
+	// NewReader returns the reader decoding the data read from r
+	NewReader(r io.Reader) (io.ReadCloser, error)
+	// NewWriter returns the writer encoding the data written to w,
+	// flushing the encoded data once closed
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]Codec{}
+)
+
+func init() {
+	Register(gzipCodec{})
+	Register(deflateCodec{})
+	Register(zstdCodec{})
+}
+
+// Register registers the codec under its name, replacing the one
+// already registered for the name, expected to be called during the
+// initialization
+//
+//	compression.Register(brotliCodec{})
+func Register(c Codec) {
+	mu.Lock()
+	defer mu.Unlock()
+	codecs[strings.ToLower(c.Name())] = c
+}
+
+// Lookup returns the codec registered for the coding, case insensitive,
+// treating x-gzip as gzip
+func Lookup(name string) (Codec, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "x-gzip" {
+		name = Gzip
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := codecs[name]
+	return c, ok
+}
+
+// Negotiate returns the coding among the offered ones to be used for the
+// response as per the Accept-Encoding header of the request, preferring
+// the higher weights and the order of the offered codings for the equal
+// weights, empty if none of the registered ones is acceptable
+func Negotiate(acceptEncoding string, offered []string) string {
+	weights := map[string]float64{}
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if w, err := strconv.ParseFloat(v, 64); err == nil {
+				q = w
+			}
+		}
+		switch name {
+		case "*":
+			wildcard = q
+		case "x-gzip":
+			weights[Gzip] = q
+		default:
+			weights[name] = q
+		}
+	}
+	best, bestWeight := "", 0.0
+	for _, name := range offered {
+		if _, ok := Lookup(name); !ok {
+			continue
+		}
+		q, ok := weights[strings.ToLower(name)]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestWeight {
+			best, bestWeight = name, q
+		}
+	}
+	return best
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string {
+	return Gzip
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+// deflateCodec handles the deflate coding, which as per the http spec
+// is zlib wrapped, while some of the clients send it raw
+type deflateCodec struct{}
+
+func (deflateCodec) Name() string {
+	return Deflate
+}
+
+func (deflateCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+func (deflateCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriter(w), nil
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Name() string {
+	return Zstd
+}
+
+func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// upperCodec is the test codec, encoding by upper casing the data
+type upperCodec struct{}
+
+func (upperCodec) Name() string {
+	return "upper"
+}
+
+func (upperCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	data, err := io.ReadAll(r)
+	return io.NopCloser(strings.NewReader(strings.ToLower(string(data)))), err
+}
+
+func (upperCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return &upperWriter{w: w}, nil
+}
+
+type upperWriter struct {
+	w io.Writer
+}
+
+func (u *upperWriter) Write(p []byte) (int, error) {
+	return u.w.Write(bytes.ToUpper(p))
+}
+
+func (u *upperWriter) Close() error {
+	return nil
+}
+
+func TestCodecs(t *testing.T) {
+	payload := []byte(strings.Repeat("compressible payload ", 100))
+	for _, name := range []string{Gzip, "X-GZIP", Deflate, Zstd} {
+		codec, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("Lookup(%q) not found; want registered", name)
+		}
+		var buf bytes.Buffer
+		w, err := codec.NewWriter(&buf)
+		if err != nil {
+			t.Fatalf("%s.NewWriter() failed with %v; want success", name, err)
+		}
+		_, _ = w.Write(payload)
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s.Close() failed with %v; want success", name, err)
+		}
+		if buf.Len() >= len(payload) {
+			t.Errorf("%s encoded %d bytes to %d; want compressed", name, len(payload), buf.Len())
+		}
+		r, err := codec.NewReader(&buf)
+		if err != nil {
+			t.Fatalf("%s.NewReader() failed with %v; want success", name, err)
+		}
+		got, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil || !bytes.Equal(got, payload) {
+			t.Errorf("%s decoded %d bytes, %v; want %d bytes", name, len(got), err, len(payload))
+		}
+	}
+
+	if _, ok := Lookup(Brotli); ok {
+		t.Fatalf("Lookup(%q) found; want registered only by the application", Brotli)
+	}
+	Register(upperCodec{})
+	if _, ok := Lookup("Upper"); !ok {
+		t.Errorf("Lookup(Upper) after Register() not found; want registered")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	Register(upperCodec{})
+	for _, spec := range []struct {
+		accept  string
+		offered []string
+		want    string
+	}{
+		{accept: "", offered: InternalCodecs, want: ""},
+		{accept: "gzip", offered: InternalCodecs, want: Gzip},
+		{accept: "x-gzip", offered: DefaultCodecs, want: Gzip},
+		{accept: "gzip, deflate, br, zstd", offered: InternalCodecs, want: Zstd},
+		{accept: "zstd;q=0.5, gzip", offered: InternalCodecs, want: Gzip},
+		{accept: "zstd;q=0, *", offered: InternalCodecs, want: Gzip},
+		{accept: "*;q=0", offered: InternalCodecs, want: ""},
+		{accept: "br", offered: []string{Brotli, Gzip}, want: ""},
+		{accept: "identity", offered: InternalCodecs, want: ""},
+		{accept: "upper", offered: []string{"upper"}, want: "upper"},
+	} {
+		if got := Negotiate(spec.accept, spec.offered); got != spec.want {
+			t.Errorf("Negotiate(%q, %v) = %q; want %q", spec.accept, spec.offered, got, spec.want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package envelope provides the envelope encryption of the request
+// fields marked using (api.encrypted), sealed by the generated SDK and
+// opened by the generated routes. Every value is encrypted using a
+// fresh data key, which is wrapped using the master key held by a
+// pluggable KMS, such that the value stays opaque to the intermediate
+// proxies and logs.
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// prefix identifies the sealed values along with the format version
+const prefix = "enc:v1:"
+
+// ErrNotSealed is returned while opening a field that is not sealed
+var ErrNotSealed = errors.New("value is not sealed")
+
+// KMS wraps the data keys using the master keys it holds
+type KMS interface {
+	// WrapKey encrypts the data key using the current master key,
+	// returning the wrapped key along with the id of the master key
+	WrapKey(ctx context.Context, key []byte) ([]byte, string, error)
+
+	// UnwrapKey decrypts the data key wrapped using the master key
+	// with the given id
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// sealed is the envelope of a sealed value
+type sealed struct {
+	KeyID string `json:"kid"`
+	Key   []byte `json:"key"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// IsSealed reports whether the value is sealed
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// seal encrypts the plaintext using a fresh data key, authenticating
+// the additional data along with it
+func seal(ctx context.Context, kms KMS, plaintext, aad []byte) (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	wrapped, keyID, err := kms.WrapKey(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	env := &sealed{
+		KeyID: keyID,
+		Key:   wrapped,
+		Nonce: make([]byte, aead.NonceSize()),
+	}
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return "", err
+	}
+	env.Data = aead.Seal(nil, env.Nonce, plaintext, aad)
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// open decrypts the sealed value, verifying the additional data
+func open(ctx context.Context, kms KMS, value string, aad []byte) ([]byte, error) {
+	if !IsSealed(value) {
+		return nil, ErrNotSealed
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value[len(prefix):])
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed value: %w", err)
+	}
+	env := &sealed{}
+	if err := json.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("invalid sealed value: %w", err)
+	}
+	key, err := kms.UnwrapKey(ctx, env.KeyID, env.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid sealed value: bad nonce")
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Data, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SealFields seals the given string or bytes fields of the message in
+// place, the fields not set or already sealed are left as is
+func SealFields(ctx context.Context, kms KMS, msg proto.Message, fields ...string) error {
+	m := msg.ProtoReflect()
+	for _, name := range fields {
+		fd, err := lookupField(m, name)
+		if err != nil {
+			return err
+		}
+		if !m.Has(fd) {
+			continue
+		}
+		plaintext, isString := fieldValue(m, fd)
+		if isString && IsSealed(string(plaintext)) {
+			continue
+		}
+		value, err := seal(ctx, kms, plaintext, []byte(fd.FullName()))
+		if err != nil {
+			return fmt.Errorf("failed to seal field %s: %w", name, err)
+		}
+		if isString {
+			m.Set(fd, protoreflect.ValueOfString(value))
+		} else {
+			m.Set(fd, protoreflect.ValueOfBytes([]byte(value)))
+		}
+	}
+	return nil
+}
+
+// OpenFields opens the given sealed fields of the message in place,
+// the fields not set are left as is, while the fields set without
+// being sealed fail with ErrNotSealed
+func OpenFields(ctx context.Context, kms KMS, msg proto.Message, fields ...string) error {
+	m := msg.ProtoReflect()
+	for _, name := range fields {
+		fd, err := lookupField(m, name)
+		if err != nil {
+			return err
+		}
+		if !m.Has(fd) {
+			continue
+		}
+		value, isString := fieldValue(m, fd)
+		plaintext, err := open(ctx, kms, string(value), []byte(fd.FullName()))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		if isString {
+			m.Set(fd, protoreflect.ValueOfString(string(plaintext)))
+		} else {
+			m.Set(fd, protoreflect.ValueOfBytes(plaintext))
+		}
+	}
+	return nil
+}
+
+func lookupField(m protoreflect.Message, name string) (protoreflect.FieldDescriptor, error) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return nil, fmt.Errorf("field %s not found in %s", name, m.Descriptor().FullName())
+	}
+	if fd.IsList() || fd.IsMap() || (fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind) {
+		return nil, fmt.Errorf("field %s of %s must be a singular string or bytes", name, m.Descriptor().FullName())
+	}
+	return fd, nil
+}
+
+// fieldValue returns the value of the string or bytes field, reporting
+// whether the field is a string
+func fieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) ([]byte, bool) {
+	if fd.Kind() == protoreflect.StringKind {
+		return []byte(m.Get(fd).String()), true
+	}
+	return m.Get(fd).Bytes(), false
+}
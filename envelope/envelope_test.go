@@ -0,0 +1,85 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newTestKMS(t *testing.T, current string) KMS {
+	t.Helper()
+	kms, err := NewLocalKMS(current, map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 32),
+	})
+	if err != nil {
+		t.Fatalf("NewLocalKMS() failed with %v; want success", err)
+	}
+	return kms
+}
+
+func TestSealOpenFields(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t, "k1")
+
+	msg := wrapperspb.String("secret")
+	if err := SealFields(ctx, kms, msg, "value"); err != nil {
+		t.Fatalf("SealFields() failed with %v; want success", err)
+	}
+	if !IsSealed(msg.GetValue()) || strings.Contains(msg.GetValue(), "secret") {
+		t.Fatalf("SealFields() = %q; want sealed value", msg.GetValue())
+	}
+	sealedValue := msg.GetValue()
+	// sealing again leaves the sealed value as is
+	if err := SealFields(ctx, kms, msg, "value"); err != nil || msg.GetValue() != sealedValue {
+		t.Errorf("SealFields() on sealed value = %q, %v; want unchanged", msg.GetValue(), err)
+	}
+
+	// values sealed before rotation are opened using the previous key
+	rotated := newTestKMS(t, "k2")
+	if err := OpenFields(ctx, rotated, msg, "value"); err != nil {
+		t.Fatalf("OpenFields() failed with %v; want success", err)
+	}
+	if got, want := msg.GetValue(), "secret"; got != want {
+		t.Errorf("OpenFields() = %q; want %q", got, want)
+	}
+
+	data := wrapperspb.Bytes([]byte{0, 1, 2})
+	if err := SealFields(ctx, kms, data, "value"); err != nil {
+		t.Fatalf("SealFields() failed with %v; want success", err)
+	}
+	if err := OpenFields(ctx, kms, data, "value"); err != nil || !bytes.Equal(data.GetValue(), []byte{0, 1, 2}) {
+		t.Errorf("OpenFields() = %v, %v; want [0 1 2]", data.GetValue(), err)
+	}
+}
+
+func TestOpenFieldsRejected(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t, "k1")
+
+	if err := OpenFields(ctx, kms, wrapperspb.String("plain"), "value"); !errors.Is(err, ErrNotSealed) {
+		t.Errorf("OpenFields() on plain value = %v; want ErrNotSealed", err)
+	}
+
+	// sealed value bound to the field it was sealed for
+	api := &apipb.Api{Name: "secret"}
+	if err := SealFields(ctx, kms, api, "name"); err != nil {
+		t.Fatalf("SealFields() failed with %v; want success", err)
+	}
+	api.Version = api.Name
+	if err := OpenFields(ctx, kms, api, "version"); err == nil {
+		t.Errorf("OpenFields() on value moved across fields succeeded; want error")
+	}
+
+	if err := SealFields(ctx, kms, api, "methods"); err == nil {
+		t.Errorf("SealFields() on repeated message field succeeded; want error")
+	}
+}
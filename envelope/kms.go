@@ -0,0 +1,68 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// localKMS wraps the data keys using the master keys held in memory
+type localKMS struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewLocalKMS creates the KMS holding the AES master keys, keyed by id,
+// in memory, wrapping the data keys using the current master key. The
+// previous master keys are retained to unwrap the values sealed before
+// the rotation. Meant for tests and the deployments managing the master
+// keys on their own, an external KMS is expected to be used otherwise
+func NewLocalKMS(current string, keys map[string][]byte) (KMS, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current master key %q not found", current)
+	}
+	k := &localKMS{
+		current: current,
+		keys:    map[string][]byte{},
+	}
+	for id, key := range keys {
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("invalid size %d of master key %q", len(key), id)
+		}
+		k.keys[id] = append([]byte(nil), key...)
+	}
+	return k, nil
+}
+
+func (k *localKMS) WrapKey(ctx context.Context, key []byte) ([]byte, string, error) {
+	aead, err := newAEAD(k.keys[k.current])
+	if err != nil {
+		return nil, "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	return aead.Seal(nonce, nonce, key, []byte(k.current)), k.current, nil
+}
+
+func (k *localKMS) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	master, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid wrapped key")
+	}
+	nonce, data := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	return aead.Open(nil, nonce, data, []byte(keyID))
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package errcode provides the canonical mapping between the grpc codes
+// and the http status codes, shared by the generated routes while
+// writing the errors and by the generated SDK while decoding them, such
+// that both the ends agree on the codes.
+package errcode
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Mapping translates the grpc codes to the http status codes and back
+type Mapping struct {
+	status map[codes.Code]int
+	codes  map[int]codes.Code
+}
+
+// Option customizes the mapping
+type Option func(*Mapping)
+
+// WithStatus overrides the http status code used for the grpc code,
+// the http status code is translated back to the same grpc code
+func WithStatus(code codes.Code, status int) Option {
+	return func(m *Mapping) {
+		m.status[code] = status
+		m.codes[status] = code
+	}
+}
+
+// New returns the mapping as recommended by google.rpc.Code, along with
+// the given overrides
+func New(opts ...Option) *Mapping {
+	m := &Mapping{
+		status: map[codes.Code]int{
+			codes.OK:                 http.StatusOK,
+			codes.Canceled:           499,
+			codes.Unknown:            http.StatusInternalServerError,
+			codes.InvalidArgument:    http.StatusBadRequest,
+			codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+			codes.NotFound:           http.StatusNotFound,
+			codes.AlreadyExists:      http.StatusConflict,
+			codes.PermissionDenied:   http.StatusForbidden,
+			codes.Unauthenticated:    http.StatusUnauthorized,
+			codes.ResourceExhausted:  http.StatusTooManyRequests,
+			codes.FailedPrecondition: http.StatusBadRequest,
+			codes.Aborted:            http.StatusConflict,
+			codes.OutOfRange:         http.StatusBadRequest,
+			codes.Unimplemented:      http.StatusNotImplemented,
+			codes.Internal:           http.StatusInternalServerError,
+			codes.Unavailable:        http.StatusServiceUnavailable,
+			codes.DataLoss:           http.StatusInternalServerError,
+		},
+		// status codes shared by multiple grpc codes are translated
+		// to the most generic one
+		codes: map[int]codes.Code{
+			http.StatusOK:                    codes.OK,
+			499:                              codes.Canceled,
+			http.StatusBadRequest:            codes.InvalidArgument,
+			http.StatusUnauthorized:          codes.Unauthenticated,
+			http.StatusForbidden:             codes.PermissionDenied,
+			http.StatusNotFound:              codes.NotFound,
+			http.StatusConflict:              codes.Aborted,
+			http.StatusPreconditionFailed:    codes.FailedPrecondition,
+			http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+			http.StatusUnsupportedMediaType:  codes.InvalidArgument,
+			http.StatusTooManyRequests:       codes.ResourceExhausted,
+			http.StatusInternalServerError:   codes.Internal,
+			http.StatusNotImplemented:        codes.Unimplemented,
+			http.StatusServiceUnavailable:    codes.Unavailable,
+			http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Default is the mapping used by the generated routes and SDK unless
+// configured otherwise, replace it during init to apply overrides
+// across both
+var Default = New()
+
+// HTTPStatus returns the http status code for the grpc code
+func (m *Mapping) HTTPStatus(code codes.Code) int {
+	if status, ok := m.status[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Code returns the grpc code for the http status code, used when the
+// response does not carry the grpc code
+func (m *Mapping) Code(status int) codes.Code {
+	if code, ok := m.codes[status]; ok {
+		return code
+	}
+	if status >= 200 && status < 300 {
+		return codes.OK
+	}
+	return codes.Unknown
+}
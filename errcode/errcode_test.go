@@ -0,0 +1,55 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package errcode
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestMapping(t *testing.T) {
+	m := New()
+	for _, spec := range []struct {
+		code   codes.Code
+		status int
+		back   codes.Code
+	}{
+		{code: codes.OK, status: http.StatusOK, back: codes.OK},
+		{code: codes.InvalidArgument, status: http.StatusBadRequest, back: codes.InvalidArgument},
+		{code: codes.FailedPrecondition, status: http.StatusBadRequest, back: codes.InvalidArgument},
+		{code: codes.AlreadyExists, status: http.StatusConflict, back: codes.Aborted},
+		{code: codes.NotFound, status: http.StatusNotFound, back: codes.NotFound},
+		{code: codes.Canceled, status: 499, back: codes.Canceled},
+		{code: codes.Code(42), status: http.StatusInternalServerError, back: codes.Internal},
+	} {
+		if got := m.HTTPStatus(spec.code); got != spec.status {
+			t.Errorf("HTTPStatus(%v) = %d; want %d", spec.code, got, spec.status)
+		}
+		if got := m.Code(spec.status); got != spec.back {
+			t.Errorf("Code(%d) = %v; want %v", spec.status, got, spec.back)
+		}
+	}
+	if got := m.Code(http.StatusTeapot); got != codes.Unknown {
+		t.Errorf("Code(%d) = %v; want %v", http.StatusTeapot, got, codes.Unknown)
+	}
+}
+
+func TestMappingWithStatus(t *testing.T) {
+	m := New(WithStatus(codes.FailedPrecondition, http.StatusPreconditionFailed), WithStatus(codes.AlreadyExists, http.StatusConflict))
+	if got := m.HTTPStatus(codes.FailedPrecondition); got != http.StatusPreconditionFailed {
+		t.Errorf("HTTPStatus(FailedPrecondition) = %d; want %d", got, http.StatusPreconditionFailed)
+	}
+	if got := m.Code(http.StatusPreconditionFailed); got != codes.FailedPrecondition {
+		t.Errorf("Code(412) = %v; want FailedPrecondition", got)
+	}
+	if got := m.Code(http.StatusConflict); got != codes.AlreadyExists {
+		t.Errorf("Code(409) = %v; want AlreadyExists", got)
+	}
+	// default mapping is not affected by the overrides
+	if got := Default.Code(http.StatusConflict); got != codes.Aborted {
+		t.Errorf("Default.Code(409) = %v; want Aborted", got)
+	}
+}
@@ -0,0 +1,52 @@
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SkippedService is the service skipped by the best effort loading of the
+// registry, as its options failed the validation
+type SkippedService struct {
+	// Name is the fully qualified name of the service
+	Name string
+	// File is the name of the proto file defining the service
+	File string
+	// Err is the failure of the validation
+	Err error
+}
+
+// skipService records the service skipped along with the failure, for
+// the file and the consolidated report
+func (r *Registry) skipService(file *File, sd *descriptorpb.ServiceDescriptorProto, err error) {
+	name := sd.GetName()
+	if pkg := file.GetPackage(); pkg != "" {
+		name = pkg + "." + name
+	}
+	s := &SkippedService{Name: name, File: file.GetName(), Err: err}
+	file.Skipped = append(file.Skipped, s)
+	r.skipped = append(r.skipped, s)
+}
+
+// SkippedServices returns the services skipped by the best effort loading
+// in the order loaded
+func (r *Registry) SkippedServices() []*SkippedService {
+	return r.skipped
+}
+
+// SkippedServicesReport returns the consolidated report of the services
+// skipped by the best effort loading, listing the failure of each for the
+// owners to fix their options, empty if none were skipped
+func (r *Registry) SkippedServicesReport() string {
+	if len(r.skipped) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "skipped %d service(s) failing the validation:", len(r.skipped))
+	for _, s := range r.skipped {
+		fmt.Fprintf(&b, "\n\t%s: %s: %v", s.File, s.Name, s.Err)
+	}
+	return b.String()
+}
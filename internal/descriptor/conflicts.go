@@ -0,0 +1,259 @@
+package descriptor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	myoptions "github.com/go-core-stack/grpc-core/coreapis/api"
+)
+
+// AnnotationConflict is the set of bindings of a service annotated with
+// the same HTTP method and path template
+type AnnotationConflict struct {
+	HTTPMethod   string
+	PathTemplate string
+	Bindings     []*Binding
+}
+
+// DuplicateAnnotationError reports all the conflicting annotations of
+// the services, allowing them to be fixed in one go
+type DuplicateAnnotationError struct {
+	Conflicts []*AnnotationConflict
+}
+
+func (e *DuplicateAnnotationError) Error() string {
+	var b strings.Builder
+	for i, c := range e.Conflicts {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "duplicate annotation: method=%s, template=%s", c.HTTPMethod, c.PathTemplate)
+		for _, binding := range c.Bindings {
+			fmt.Fprintf(&b, "\n\t%s: %s", binding.Location(), binding.Method.FQMN())
+		}
+	}
+	return b.String()
+}
+
+// CheckDuplicateBindings checks the bindings of the services for the
+// duplicate annotations, reporting all the conflicts found using
+// *DuplicateAnnotationError
+func (r *Registry) CheckDuplicateBindings(services []*Service) error {
+	var conflicts []*AnnotationConflict
+	for _, svc := range services {
+		seen := map[annotationIdentifier]*AnnotationConflict{}
+		for _, m := range svc.Methods {
+			for _, b := range m.Bindings {
+				a := annotationIdentifier{method: b.HTTPMethod, pathTemplate: b.PathTmpl.Template, service: svc}
+				c, ok := seen[a]
+				if !ok {
+					seen[a] = &AnnotationConflict{HTTPMethod: b.HTTPMethod, PathTemplate: b.PathTmpl.Template, Bindings: []*Binding{b}}
+					continue
+				}
+				if len(c.Bindings) == 1 {
+					conflicts = append(conflicts, c)
+				}
+				c.Bindings = append(c.Bindings, b)
+			}
+		}
+	}
+	if len(conflicts) != 0 {
+		return &DuplicateAnnotationError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// Location returns the position of the method of the binding in the
+// proto file, as file:line:column, only the file name when the source
+// info is not available
+func (b *Binding) Location() string {
+	svc := b.Method.Service
+	file := svc.File
+	si := indexOf(file.GetService(), svc.ServiceDescriptorProto)
+	mi := indexOf(svc.GetMethod(), b.Method.MethodDescriptorProto)
+	if si < 0 || mi < 0 {
+		return file.GetName()
+	}
+	// method path as per descriptor.proto, 6 => service, 2 => method
+	path := []int32{6, int32(si), 2, int32(mi)}
+	for _, loc := range file.GetSourceCodeInfo().GetLocation() {
+		if len(loc.GetSpan()) >= 2 && equalPath(loc.GetPath(), path) {
+			return fmt.Sprintf("%s:%d:%d", file.GetName(), loc.GetSpan()[0]+1, loc.GetSpan()[1]+1)
+		}
+	}
+	return file.GetName()
+}
+
+func indexOf[T any](list []*T, item *T) int {
+	for i, v := range list {
+		if v == item {
+			return i
+		}
+	}
+	return -1
+}
+
+func equalPath(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fileOwner returns the owner of the services in the file as per the
+// (api.owner) option, empty if not set
+func fileOwner(file *descriptorpb.FileDescriptorProto) string {
+	if file.Options == nil || !proto.HasExtension(file.Options, myoptions.E_Owner) {
+		return ""
+	}
+	return proto.GetExtension(file.Options, myoptions.E_Owner).(string)
+}
+
+// conflictRow describes a conflicting binding in the report
+type conflictRow struct {
+	Method     string
+	Location   string
+	Owner      string
+	Suggestion string
+}
+
+// conflictSection describes a conflict in the report
+type conflictSection struct {
+	HTTPMethod   string
+	PathTemplate string
+	Service      string
+	Rows         []conflictRow
+}
+
+// suggest returns the suggested disambiguation for the conflicting
+// binding, the first binding of the conflict is retained as is
+func suggest(c *AnnotationConflict, i int) string {
+	b := c.Bindings[i]
+	if i == 0 {
+		return "keep as is"
+	}
+	for _, prev := range c.Bindings[:i] {
+		if prev.Method == b.Method {
+			return "remove the duplicate binding of the method"
+		}
+	}
+	if b.PathTmpl.Verb == "" {
+		verb := strings.ToLower(b.Method.GetName()[:1]) + b.Method.GetName()[1:]
+		return fmt.Sprintf("add a custom verb, e.g. %s:%s, or use another HTTP method", c.PathTemplate, verb)
+	}
+	return "use a distinct path template or another HTTP method"
+}
+
+func (e *DuplicateAnnotationError) sections() []conflictSection {
+	var sections []conflictSection
+	for _, c := range e.Conflicts {
+		s := conflictSection{
+			HTTPMethod:   c.HTTPMethod,
+			PathTemplate: c.PathTemplate,
+			Service:      c.Bindings[0].Method.Service.FQSN(),
+		}
+		for i, b := range c.Bindings {
+			owner := fileOwner(b.Method.Service.File.FileDescriptorProto)
+			if owner == "" {
+				owner = "unknown"
+			}
+			s.Rows = append(s.Rows, conflictRow{
+				Method:     b.Method.FQMN(),
+				Location:   b.Location(),
+				Owner:      owner,
+				Suggestion: suggest(c, i),
+			})
+		}
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+var markdownReport = template.Must(template.New("markdown").Parse(`# Duplicate HTTP annotations
+
+{{ len . }} conflict(s) found, every HTTP method and path template is
+expected to be bound to a single method of the service.
+{{ range . }}
+## {{ .HTTPMethod }} {{ .PathTemplate }}
+
+Service: {{ .Service }}
+
+| Method | Location | Owner | Suggestion |
+|--------|----------|-------|------------|
+{{- range .Rows }}
+| {{ .Method }} | {{ .Location }} | {{ .Owner }} | {{ .Suggestion }} |
+{{- end }}
+{{ end -}}
+`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("html").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Duplicate HTTP annotations</title></head>
+<body>
+<h1>Duplicate HTTP annotations</h1>
+<p>{{ len . }} conflict(s) found, every HTTP method and path template is
+expected to be bound to a single method of the service.</p>
+{{- range . }}
+<h2>{{ .HTTPMethod }} {{ .PathTemplate }}</h2>
+<p>Service: {{ .Service }}</p>
+<table border="1">
+<tr><th>Method</th><th>Location</th><th>Owner</th><th>Suggestion</th></tr>
+{{- range .Rows }}
+<tr><td>{{ .Method }}</td><td>{{ .Location }}</td><td>{{ .Owner }}</td><td>{{ .Suggestion }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+// Report renders the conflicts as HTML when html is set, as markdown
+// otherwise, listing the location, the owner and the suggested
+// disambiguation of every conflicting binding
+func (e *DuplicateAnnotationError) Report(html bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if html {
+		err = htmlReport.Execute(&buf, e.sections())
+	} else {
+		err = markdownReport.Execute(&buf, e.sections())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteConflictReport writes the report of the conflicting annotations
+// to the file at path, as HTML for the files with .html extension and
+// as markdown otherwise, when err is a *DuplicateAnnotationError. The
+// error is returned as is otherwise, mentioning the report if written
+func WriteConflictReport(path string, err error) error {
+	var dup *DuplicateAnnotationError
+	if path == "" || !errors.As(err, &dup) {
+		return err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	data, rerr := dup.Report(ext == ".html" || ext == ".htm")
+	if rerr == nil {
+		rerr = os.WriteFile(path, data, 0o644)
+	}
+	if rerr != nil {
+		return fmt.Errorf("%w\nfailed to write conflict report: %v", err, rerr)
+	}
+	return fmt.Errorf("%w\nsee conflict report at %s", err, path)
+}
@@ -0,0 +1,97 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckDuplicateBindings(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		options <
+			[api.owner]: "team-a"
+		>
+		message_type <
+			name: "StringMessage"
+			field <
+				name: "string"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ExampleService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						get: "/v1/example/{string}"
+					>
+				>
+			>
+			method <
+				name: "Lookup"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						get: "/v1/example/{string}"
+					>
+				>
+			>
+		>
+		source_code_info <
+			location <
+				path: [6, 0, 2, 1]
+				span: [20, 2, 28, 3]
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+	}
+	target := "path/to/example.proto"
+	reg := NewRegistry()
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto: &fd,
+	})
+	if err := reg.loadServices(reg.files[target]); err != nil {
+		t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+	}
+
+	err := reg.CheckDuplicateBindings(reg.files[target].Services)
+	var dup *DuplicateAnnotationError
+	if !errors.As(err, &dup) {
+		t.Fatalf("CheckDuplicateBindings() = %v; want *DuplicateAnnotationError", err)
+	}
+	if len(dup.Conflicts) != 1 || len(dup.Conflicts[0].Bindings) != 2 {
+		t.Fatalf("CheckDuplicateBindings() = %v; want one conflict of two bindings", err)
+	}
+	if got, want := dup.Conflicts[0].Bindings[1].Location(), "path/to/example.proto:21:3"; got != want {
+		t.Errorf("Location() = %q; want %q", got, want)
+	}
+
+	report, rerr := dup.Report(false)
+	if rerr != nil {
+		t.Fatalf("Report() failed with %v; want success", rerr)
+	}
+	for _, want := range []string{
+		"## GET /v1/example/{string}",
+		"| .example.ExampleService.Echo | path/to/example.proto | team-a | keep as is |",
+		"| .example.ExampleService.Lookup | path/to/example.proto:21:3 | team-a | add a custom verb, e.g. /v1/example/{string}:lookup, or use another HTTP method |",
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("Report() = %s; want containing %q", report, want)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package descriptor
+
+import (
+	"encoding/json"
+	"sort"
+
+	myoptions "github.com/go-core-stack/grpc-core/coreapis/api"
+)
+
+// SnapshotVersion is the version of the format of the snapshot
+// produced by Registry.Export, bumped on incompatible changes
+const SnapshotVersion = 1
+
+// Snapshot is the resolved model of the registry as seen by the
+// generators, to be consumed by external tooling
+type Snapshot struct {
+	Version int             `json:"version"`
+	Files   []*SnapshotFile `json:"files"`
+}
+
+// SnapshotFile describes a proto file providing services
+type SnapshotFile struct {
+	Name      string             `json:"name"`
+	Package   string             `json:"package"`
+	GoPackage string             `json:"go_package"`
+	JSONNames string             `json:"json_names,omitempty"`
+	Services  []*SnapshotService `json:"services"`
+}
+
+// SnapshotService describes a service along with its methods
+type SnapshotService struct {
+	Name    string            `json:"name"`
+	FQSN    string            `json:"fqsn"`
+	Product string            `json:"product,omitempty"`
+	Methods []*SnapshotMethod `json:"methods"`
+}
+
+// SnapshotMethod describes a method along with its bindings
+type SnapshotMethod struct {
+	Name              string             `json:"name"`
+	FQMN              string             `json:"fqmn"`
+	RequestType       string             `json:"request_type"`
+	ResponseType      string             `json:"response_type"`
+	Role              *SnapshotRole      `json:"role,omitempty"`
+	AllowedStatus     []int32            `json:"allowed_status,omitempty"`
+	Defaults          map[string]string  `json:"defaults,omitempty"`
+	Required          []string           `json:"required,omitempty"`
+	Encrypted         []string           `json:"encrypted,omitempty"`
+	FromIdentity      map[string]string  `json:"from_identity,omitempty"`
+	Immutable         []string           `json:"immutable,omitempty"`
+	OutputOnly        []string           `json:"output_only,omitempty"`
+	Sensitive         []string           `json:"sensitive,omitempty"`
+	SensitiveResponse []string           `json:"sensitive_response,omitempty"`
+	Version           string             `json:"version,omitempty"`
+	Signed            bool               `json:"signed,omitempty"`
+	LongPoll          bool               `json:"long_poll,omitempty"`
+	MaxConcurrency    uint32             `json:"max_concurrency,omitempty"`
+	Bulkhead          string             `json:"bulkhead,omitempty"`
+	Invalidates       []string           `json:"invalidates,omitempty"`
+	SurrogateKey      string             `json:"surrogate_key,omitempty"`
+	Retry             string             `json:"retry,omitempty"`
+	RetryPolicy       *SnapshotRetry     `json:"retry_policy,omitempty"`
+	Timeout           string             `json:"timeout,omitempty"`
+	Upsert            bool               `json:"upsert,omitempty"`
+	LogSampleRate     *float64           `json:"log_sample_rate,omitempty"`
+	FeatureFlag       string             `json:"feature_flag,omitempty"`
+	ShadowRate        float64            `json:"shadow_rate,omitempty"`
+	Objective         *SnapshotObjective `json:"slo,omitempty"`
+	DebugOnly         bool               `json:"debug_only,omitempty"`
+	WatchObject       string             `json:"watch_object,omitempty"`
+	Bindings          []*SnapshotBinding `json:"bindings"`
+}
+
+// SnapshotRole describes the role associated with a method
+type SnapshotRole struct {
+	Resource string   `json:"resource"`
+	Scopes   []string `json:"scopes,omitempty"`
+	Verb     string   `json:"verb"`
+}
+
+// SnapshotRetry describes the retry policy of a method
+type SnapshotRetry struct {
+	MaxAttempts    int     `json:"max_attempts"`
+	InitialBackoff string  `json:"initial_backoff,omitempty"`
+	MaxBackoff     string  `json:"max_backoff,omitempty"`
+	StatusCodes    []int32 `json:"status_codes,omitempty"`
+}
+
+// SnapshotObjective describes the service level objective of a method
+type SnapshotObjective struct {
+	Latency           string  `json:"latency,omitempty"`
+	LatencyPercentile float64 `json:"latency_percentile,omitempty"`
+	Availability      float64 `json:"availability,omitempty"`
+}
+
+// SnapshotBinding describes an HTTP binding of a method, classifying
+// the request fields into path, body and query params
+type SnapshotBinding struct {
+	HTTPMethod   string   `json:"http_method"`
+	Path         string   `json:"path"`
+	PathParams   []string `json:"path_params,omitempty"`
+	Body         string   `json:"body,omitempty"`
+	ResponseBody string   `json:"response_body,omitempty"`
+	QueryParams  []string `json:"query_params,omitempty"`
+}
+
+// bodyPath returns the field path of the body, "*" when the body is
+// mapped to the whole message and empty when there is no body
+func bodyPath(b *Body) string {
+	if b == nil {
+		return ""
+	}
+	if len(b.FieldPath) == 0 {
+		return "*"
+	}
+	return b.FieldPath.String()
+}
+
+// QueryParams returns the names of the request fields which are
+// expected as query params, that is neither part of the path nor body
+func (b *Binding) QueryParams() []string {
+	if b.Body != nil && len(b.Body.FieldPath) == 0 {
+		return nil
+	}
+	skip := map[string]bool{}
+	if b.Body != nil {
+		skip[b.Body.FieldPath.String()] = true
+	}
+	for _, p := range b.PathParams {
+		skip[p.FieldPath.String()] = true
+	}
+	var params []string
+	for _, f := range b.Method.RequestType.Fields {
+		if !skip[f.GetName()] {
+			params = append(params, f.GetName())
+		}
+	}
+	return params
+}
+
+// Snapshot returns the resolved model of the files providing services,
+// ordered by the file name and declaration order within the files
+func (r *Registry) Snapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Files:   []*SnapshotFile{},
+	}
+	var names []string
+	for name, f := range r.files {
+		if len(f.Services) != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := r.files[name]
+		sf := &SnapshotFile{
+			Name:      f.GetName(),
+			Package:   f.GetPackage(),
+			GoPackage: f.GoPkg.Path,
+			JSONNames: jsonNamesName(r.JSONNames(f)),
+		}
+		for _, svc := range f.Services {
+			ss := &SnapshotService{
+				Name:    svc.GetName(),
+				FQSN:    svc.FQSN(),
+				Product: svc.Product,
+			}
+			for _, m := range svc.Methods {
+				sm := &SnapshotMethod{
+					Name:              m.GetName(),
+					FQMN:              m.FQMN(),
+					RequestType:       m.RequestType.FQMN(),
+					ResponseType:      m.ResponseType.FQMN(),
+					AllowedStatus:     m.AllowedStatus,
+					Signed:            m.Signed,
+					LongPoll:          m.LongPoll,
+					MaxConcurrency:    m.MaxConcurrency,
+					Bulkhead:          m.Bulkhead,
+					Invalidates:       m.Invalidates,
+					SurrogateKey:      m.SurrogateKey,
+					OutputOnly:        m.OutputOnlyFields,
+					Sensitive:         m.SensitiveRequestFields,
+					SensitiveResponse: m.SensitiveResponseFields,
+					Version:           m.VersionField,
+					Retry:             m.RetrySafety.String(),
+					RetryPolicy:       snapshotRetry(m.RetryPolicy),
+					Upsert:            m.Upsert,
+					LogSampleRate:     m.LogSampleRate,
+					FeatureFlag:       m.FeatureFlag,
+					ShadowRate:        m.ShadowRate,
+					Objective:         snapshotObjective(m.Objective),
+					DebugOnly:         m.DebugOnly,
+					Bindings:          []*SnapshotBinding{},
+				}
+				if m.Timeout != 0 {
+					sm.Timeout = m.Timeout.String()
+				}
+				if m.Watch != nil {
+					sm.WatchObject = m.Watch.Object.FQMN()
+				}
+				for _, f := range m.RequiredFields {
+					sm.Required = append(sm.Required, f.GetName())
+				}
+				for _, f := range m.EncryptedFields {
+					sm.Encrypted = append(sm.Encrypted, f.GetName())
+				}
+				for _, f := range m.IdentityFields {
+					if sm.FromIdentity == nil {
+						sm.FromIdentity = map[string]string{}
+					}
+					sm.FromIdentity[f.Field.GetName()] = f.Identity
+				}
+				if m.Immutable != nil {
+					for _, f := range m.Immutable.Fields {
+						sm.Immutable = append(sm.Immutable, f.GetName())
+					}
+				}
+				for _, d := range m.Defaults {
+					if sm.Defaults == nil {
+						sm.Defaults = map[string]string{}
+					}
+					sm.Defaults[d.Field.GetName()] = d.Value
+				}
+				if m.Role != nil {
+					sm.Role = &SnapshotRole{
+						Resource: m.Role.Resource,
+						Scopes:   m.Role.Scopes,
+						Verb:     m.Role.Verb,
+					}
+				}
+				for _, b := range m.Bindings {
+					sb := &SnapshotBinding{
+						HTTPMethod:   b.HTTPMethod,
+						Path:         b.PathTmpl.Template,
+						Body:         bodyPath(b.Body),
+						ResponseBody: bodyPath(b.ResponseBody),
+						QueryParams:  b.QueryParams(),
+					}
+					for _, p := range b.PathParams {
+						sb.PathParams = append(sb.PathParams, p.FieldPath.String())
+					}
+					sm.Bindings = append(sm.Bindings, sb)
+				}
+				ss.Methods = append(ss.Methods, sm)
+			}
+			sf.Services = append(sf.Services, ss)
+		}
+		snapshot.Files = append(snapshot.Files, sf)
+	}
+	return snapshot
+}
+
+// Export returns the stable JSON encoding of the registry snapshot,
+// allowing tools not written in go to consume exactly what the
+// generators see
+func (r *Registry) Export() ([]byte, error) {
+	data, err := json.MarshalIndent(r.Snapshot(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// jsonNamesName returns the name of the naming of the fields in JSON as
+// accepted by Registry.SetJSONNames, empty if not chosen
+func jsonNamesName(names myoptions.JsonNames) string {
+	switch names {
+	case myoptions.JsonNames_LOWER_CAMEL_CASE:
+		return "camel"
+	case myoptions.JsonNames_PROTO_NAMES:
+		return "proto"
+	}
+	return ""
+}
+
+// snapshotRetry returns the snapshot of the retry policy, with the
+// durations as per time.Duration.String, nil if no policy
+func snapshotObjective(o *Objective) *SnapshotObjective {
+	if o == nil {
+		return nil
+	}
+	s := &SnapshotObjective{LatencyPercentile: o.LatencyPercentile, Availability: o.Availability}
+	if o.Latency != 0 {
+		s.Latency = o.Latency.String()
+	}
+	return s
+}
+
+func snapshotRetry(p *RetryPolicy) *SnapshotRetry {
+	if p == nil {
+		return nil
+	}
+	r := &SnapshotRetry{MaxAttempts: p.MaxAttempts, StatusCodes: p.StatusCodes}
+	if p.InitialBackoff != 0 {
+		r.InitialBackoff = p.InitialBackoff.String()
+	}
+	if p.MaxBackoff != 0 {
+		r.MaxBackoff = p.MaxBackoff.String()
+	}
+	return r
+}
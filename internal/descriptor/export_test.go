@@ -0,0 +1,158 @@
+package descriptor
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRegistryExport(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		options <
+			go_package: "example.com/path/to/example"
+			[api.product]: "compute"
+		>
+		message_type <
+			name: "ObjectMessage"
+			field <
+				name: "name"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+			field <
+				name: "desc"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ObjectService"
+			method <
+				name: "Create"
+				input_type: "ObjectMessage"
+				output_type: "ObjectMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/objects"
+						body: "*"
+					>
+					[api.role] <
+						resource: "object"
+						scope: "tenant"
+						verb: "create"
+					>
+					[api.allowed_status]: 409
+				>
+			>
+			method <
+				name: "Get"
+				input_type: "ObjectMessage"
+				output_type: "ObjectMessage"
+				options <
+					[google.api.http] <
+						get: "/v1/objects/{name}"
+						response_body: "desc"
+					>
+				>
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+	}
+	reg := NewRegistry()
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto:        &fd,
+		GoImportPath: "example.com/path/to/example",
+	})
+	if err := reg.loadServices(reg.files[fd.GetName()]); err != nil {
+		t.Fatalf("loadServices(%q) failed with %v; want success", fd.GetName(), err)
+	}
+
+	got, err := reg.Export()
+	if err != nil {
+		t.Fatalf("reg.Export() failed with %v; want success", err)
+	}
+	want := `{
+  "version": 1,
+  "files": [
+    {
+      "name": "path/to/example.proto",
+      "package": "example",
+      "go_package": "example.com/path/to/example",
+      "services": [
+        {
+          "name": "ObjectService",
+          "fqsn": ".example.ObjectService",
+          "product": "compute",
+          "methods": [
+            {
+              "name": "Create",
+              "fqmn": ".example.ObjectService.Create",
+              "request_type": ".example.ObjectMessage",
+              "response_type": ".example.ObjectMessage",
+              "role": {
+                "resource": "object",
+                "scopes": [
+                  "tenant"
+                ],
+                "verb": "create"
+              },
+              "allowed_status": [
+                409
+              ],
+              "retry": "unsafe",
+              "bindings": [
+                {
+                  "http_method": "POST",
+                  "path": "/v1/objects",
+                  "body": "*"
+                }
+              ]
+            },
+            {
+              "name": "Get",
+              "fqmn": ".example.ObjectService.Get",
+              "request_type": ".example.ObjectMessage",
+              "response_type": ".example.ObjectMessage",
+              "bindings": [
+                {
+                  "http_method": "GET",
+                  "path": "/v1/objects/{name}",
+                  "path_params": [
+                    "name"
+                  ],
+                  "response_body": "desc",
+                  "query_params": [
+                    "desc"
+                  ]
+                }
+              ]
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+	if string(got) != want {
+		t.Errorf("reg.Export() = %s; want %s", got, want)
+	}
+
+	// export is expected to be stable across the invocations
+	again, err := reg.Export()
+	if err != nil {
+		t.Fatalf("reg.Export() failed with %v; want success", err)
+	}
+	if string(again) != string(got) {
+		t.Errorf("reg.Export() is not stable, got %s and %s", got, again)
+	}
+}
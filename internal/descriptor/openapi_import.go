@@ -0,0 +1,153 @@
+package descriptor
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/grpclog"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDocument is the subset of the OpenAPI v2 and v3 documents used
+// to import the routes
+type openAPIDocument struct {
+	// BasePath is the prefix of all the paths of the v2 documents
+	BasePath string `yaml:"basePath"`
+	// Paths maps the path templates to the path items, holding the
+	// operations by http method besides the path level fields
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type openAPIOperation struct {
+	OperationID string `yaml:"operationId"`
+	Parameters  []struct {
+		In string `yaml:"in"`
+	} `yaml:"parameters"`
+	RequestBody any `yaml:"requestBody"`
+}
+
+// hasBody reports whether the operation carries the request body, as
+// the requestBody of v3 or the body parameter of v2
+func (op openAPIOperation) hasBody() bool {
+	if op.RequestBody != nil {
+		return true
+	}
+	for _, p := range op.Parameters {
+		if p.In == "body" {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIRule is the http rule of an operation mapped onto a method
+type openAPIRule struct {
+	// selector is the fully qualified name of the method, if known
+	selector string
+	// operationID is the Service_Method form of the operation id
+	operationID string
+	rule        *annotations.HttpRule
+}
+
+func loadOpenAPIRoutes(contents []byte, sourceLogName string) ([]*openAPIRule, error) {
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document %q: %w", sourceLogName, err)
+	}
+	basePath := strings.TrimSuffix(doc.BasePath, "/")
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var rules []*openAPIRule
+	for _, p := range paths {
+		ops := doc.Paths[p]
+		methods := make([]string, 0, len(ops))
+		for m := range ops {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		for _, m := range methods {
+			var op openAPIOperation
+			rule := &annotations.HttpRule{}
+			tmpl := basePath + p
+			switch m {
+			case "get":
+				rule.Pattern = &annotations.HttpRule_Get{Get: tmpl}
+			case "put":
+				rule.Pattern = &annotations.HttpRule_Put{Put: tmpl}
+			case "post":
+				rule.Pattern = &annotations.HttpRule_Post{Post: tmpl}
+			case "delete":
+				rule.Pattern = &annotations.HttpRule_Delete{Delete: tmpl}
+			case "patch":
+				rule.Pattern = &annotations.HttpRule_Patch{Patch: tmpl}
+			default:
+				// path level fields like parameters and the http
+				// methods without a mapping onto the http rule
+				continue
+			}
+			node := ops[m]
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s in OpenAPI document %q: %w", strings.ToUpper(m), p, sourceLogName, err)
+			}
+			rule.Selector = op.OperationID
+			if op.hasBody() {
+				rule.Body = "*"
+			}
+
+			r := &openAPIRule{rule: rule}
+			switch {
+			case strings.Contains(op.OperationID, "."):
+				r.selector = "." + op.OperationID
+			case strings.Count(op.OperationID, "_") == 1:
+				r.operationID = op.OperationID
+			default:
+				grpclog.Warningf("Skipping %s %s in %s, operationId %q is neither Service_Method nor package.Service.Method",
+					strings.ToUpper(m), tmpl, sourceLogName, op.OperationID)
+				continue
+			}
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// LoadOpenAPIRoutes loads the OpenAPI v2 or v3 document, in YAML or JSON
+// format, from the given file and registers its operations as the
+// external http rules of the methods, mapped by the operationId being
+// either Service_Method, as named by protoc-gen-openapiv2, or the fully
+// qualified package.Service.Method. Operations with a body are bound
+// with body "*". This allows the services migrating from REST first to
+// keep the established paths. This must be done before loading the
+// proto file.
+func (r *Registry) LoadOpenAPIRoutes(file string) error {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenAPI document from %q: %w", file, err)
+	}
+	rules, err := loadOpenAPIRoutes(contents, file)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.selector != "" {
+			r.AddExternalHTTPRule(rule.selector, rule.rule)
+			continue
+		}
+		r.operationHTTPRules[rule.operationID] = append(r.operationHTTPRules[rule.operationID], rule.rule)
+	}
+	return nil
+}
+
+// lookupOperationHTTPRules looks up the http rules imported for the
+// operation id of the method in Service_Method form
+func (r *Registry) lookupOperationHTTPRules(svc *Service, md string) []*annotations.HttpRule {
+	return r.operationHTTPRules[svc.GetName()+"_"+md]
+}
@@ -0,0 +1,164 @@
+package descriptor
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestLoadOpenAPIRoutesV3(t *testing.T) {
+	rules, err := loadOpenAPIRoutes([]byte(`
+openapi: 3.0.3
+paths:
+  /v1/items/{id}:
+    parameters:
+    - name: id
+      in: path
+    get:
+      operationId: ItemService_GetItem
+    patch:
+      operationId: example.ItemService.UpdateItem
+      requestBody:
+        content:
+          application/json: {}
+    head:
+      operationId: ItemService_HeadItem
+  /v1/items:
+    post:
+      operationId: createItem
+`), "example")
+	if err != nil {
+		t.Fatalf("loadOpenAPIRoutes() failed with %v; want success", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("loadOpenAPIRoutes() = %d rules; want 2", len(rules))
+	}
+	if got := rules[0]; got.operationID != "ItemService_GetItem" || got.rule.GetGet() != "/v1/items/{id}" || got.rule.GetBody() != "" {
+		t.Errorf("rules[0] = %q %v; want GET /v1/items/{id} of ItemService_GetItem", got.operationID, got.rule)
+	}
+	if got := rules[1]; got.selector != ".example.ItemService.UpdateItem" || got.rule.GetPatch() != "/v1/items/{id}" || got.rule.GetBody() != "*" {
+		t.Errorf("rules[1] = %q %v; want PATCH /v1/items/{id} of .example.ItemService.UpdateItem with body", got.selector, got.rule)
+	}
+}
+
+func TestLoadOpenAPIRoutesV2(t *testing.T) {
+	rules, err := loadOpenAPIRoutes([]byte(`{
+		"swagger": "2.0",
+		"basePath": "/api/",
+		"paths": {
+			"/v1/items": {
+				"post": {
+					"operationId": "ItemService_CreateItem",
+					"parameters": [{"name": "body", "in": "body"}]
+				},
+				"get": {
+					"operationId": "ItemService_ListItems",
+					"parameters": [{"name": "filter", "in": "query"}]
+				}
+			}
+		}
+	}`), "example")
+	if err != nil {
+		t.Fatalf("loadOpenAPIRoutes() failed with %v; want success", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("loadOpenAPIRoutes() = %d rules; want 2", len(rules))
+	}
+	if got := rules[0].rule; got.GetGet() != "/api/v1/items" || got.GetBody() != "" {
+		t.Errorf("rules[0] = %v; want GET /api/v1/items without body", got)
+	}
+	if got := rules[1].rule; got.GetPost() != "/api/v1/items" || got.GetBody() != "*" {
+		t.Errorf("rules[1] = %v; want POST /api/v1/items with body", got)
+	}
+}
+
+func TestLoadOpenAPIRoutesRejectInvalid(t *testing.T) {
+	if _, err := loadOpenAPIRoutes([]byte(`paths: [`), "invalid"); err == nil {
+		t.Errorf("loadOpenAPIRoutes() succeeded; want error")
+	}
+}
+
+func TestExtractServicesWithOpenAPIRoutes(t *testing.T) {
+	src := `
+		name: "path/to/example.proto",
+		package: "example"
+		message_type <
+			name: "Item"
+			field <
+				name: "id"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ItemService"
+			method <
+				name: "GetItem"
+				input_type: "Item"
+				output_type: "Item"
+			>
+			method <
+				name: "UpdateItem"
+				input_type: "Item"
+				output_type: "Item"
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal (%s, &fd) failed with %v; want success", src, err)
+	}
+	file := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(file, []byte(`
+paths:
+  /v1/items/{id}:
+    get:
+      operationId: ItemService_GetItem
+    put:
+      operationId: example.ItemService.UpdateItem
+      requestBody: {}
+  /v1/other:
+    get:
+      operationId: OtherService_GetOther
+`), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() failed with %v; want success", err)
+	}
+
+	reg := NewRegistry()
+	if err := reg.LoadOpenAPIRoutes(file); err != nil {
+		t.Fatalf("LoadOpenAPIRoutes() failed with %v; want success", err)
+	}
+	reg.loadFile(fd.GetName(), &protogen.File{Proto: &fd})
+	if err := reg.loadServices(reg.files[fd.GetName()]); err != nil {
+		t.Fatalf("loadServices() failed with %v; want success", err)
+	}
+
+	methods := reg.files[fd.GetName()].Services[0].Methods
+	for i, want := range []struct {
+		httpMethod, path string
+		body             bool
+	}{
+		{httpMethod: http.MethodGet, path: "/v1/items/{id}"},
+		{httpMethod: http.MethodPut, path: "/v1/items/{id}", body: true},
+	} {
+		if len(methods[i].Bindings) != 1 {
+			t.Errorf("%s has %d bindings; want 1", methods[i].GetName(), len(methods[i].Bindings))
+			continue
+		}
+		b := methods[i].Bindings[0]
+		if b.HTTPMethod != want.httpMethod || b.PathTmpl.Template != want.path || (b.Body != nil) != want.body {
+			t.Errorf("%s is bound to %s %s, body %v; want %s %s, body %v",
+				methods[i].GetName(), b.HTTPMethod, b.PathTmpl.Template, b.Body != nil, want.httpMethod, want.path, want.body)
+		}
+	}
+
+	if got := reg.UnboundExternalHTTPRules(); len(got) != 1 || got[0] != "OtherService_GetOther" {
+		t.Errorf("UnboundExternalHTTPRules() = %v; want [OtherService_GetOther]", got)
+	}
+}
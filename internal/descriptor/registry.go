@@ -11,9 +11,12 @@ import (
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 
+	myoptions "github.com/go-core-stack/grpc-core/coreapis/api"
+	"github.com/go-core-stack/grpc-core/internal/casing"
 	"github.com/go-core-stack/grpc-core/internal/codegenerator"
 	"github.com/go-core-stack/grpc-core/internal/descriptor/openapiconfig"
 )
@@ -47,6 +50,12 @@ type Registry struct {
 	// externalHttpRules is a mapping from fully qualified service method names to additional HttpRules applicable besides the ones found in annotations.
 	externalHTTPRules map[string][]*annotations.HttpRule
 
+	// validators are the custom checks run over the files being generated.
+	validators []Validator
+
+	// operationHTTPRules is a mapping from the Service_Method operation ids of the imported OpenAPI documents to their HttpRules.
+	operationHTTPRules map[string][]*annotations.HttpRule
+
 	// allowMerge generation one OpenAPI file out of multiple protos
 	allowMerge bool
 
@@ -140,6 +149,20 @@ type Registry struct {
 	// omitPackageDoc, if false, causes a package comment to be included in the generated code.
 	omitPackageDoc bool
 
+	// includeExperimental causes the registry to load the services from
+	// the files marked experimental, which are skipped otherwise
+	includeExperimental bool
+
+	// bestEffort causes the registry to skip the services failing the
+	// validation, recording them in skipped, instead of failing the load
+	bestEffort bool
+	skipped    []*SkippedService
+
+	// jsonNames is the naming of the fields in the JSON emitted by the
+	// generated code, unless overridden by the (api.json_names) option
+	// of the file
+	jsonNames myoptions.JsonNames
+
 	// recursiveDepth sets the maximum depth of a field parameter
 	recursiveDepth int
 
@@ -205,6 +228,7 @@ func NewRegistry() *Registry {
 		pkgMap:                         make(map[string]string),
 		pkgAliases:                     make(map[string]string),
 		externalHTTPRules:              make(map[string][]*annotations.HttpRule),
+		operationHTTPRules:             make(map[string][]*annotations.HttpRule),
 		openAPINamingStrategy:          "legacy",
 		visibilityRestrictionSelectors: make(map[string]bool),
 		repeatedPathParamSeparator: repeatedFieldSeparator{
@@ -253,6 +277,9 @@ func (r *Registry) load(gen *protogen.Plugin) error {
 		if !gen.FilesByPath[filePath].Generate {
 			continue
 		}
+		if err := r.runValidators(gen.FilesByPath[filePath]); err != nil {
+			return err
+		}
 		file := r.files[filePath]
 		if err := r.loadServices(file); err != nil {
 			return err
@@ -434,6 +461,7 @@ func (r *Registry) UnboundExternalHTTPRules() []string {
 			for _, m := range s.GetMethod() {
 				method := &Method{Service: svc, MethodDescriptorProto: m}
 				allServiceMethods[method.FQMN()] = struct{}{}
+				allServiceMethods[s.GetName()+"_"+m.GetName()] = struct{}{}
 			}
 		}
 	}
@@ -444,6 +472,11 @@ func (r *Registry) UnboundExternalHTTPRules() []string {
 			missingMethods = append(missingMethods, httpRuleMethod)
 		}
 	}
+	for operationID := range r.operationHTTPRules {
+		if _, ok := allServiceMethods[operationID]; !ok {
+			missingMethods = append(missingMethods, operationID)
+		}
+	}
 	return missingMethods
 }
 
@@ -724,6 +757,50 @@ func (r *Registry) SetGenerateUnboundMethods(generate bool) {
 	r.generateUnboundMethods = generate
 }
 
+// SetIncludeExperimental controls whether the services of the files
+// marked experimental are included
+func (r *Registry) SetIncludeExperimental(include bool) {
+	r.includeExperimental = include
+}
+
+// SetBestEffort controls whether the services failing the validation
+// are skipped, reported using SkippedServicesReport, instead of failing
+// the load of the files
+func (r *Registry) SetBestEffort(bestEffort bool) {
+	r.bestEffort = bestEffort
+}
+
+// SetJSONNames sets the naming of the fields in the JSON emitted by the
+// generated code for the files not using the (api.json_names) option.
+// Allowed names are 'camel' for lowerCamelCase and 'proto' for the
+// original names, empty retains the JSON in lowerCamelCase along with
+// the query params using the original names
+func (r *Registry) SetJSONNames(name string) error {
+	switch name {
+	case "":
+		r.jsonNames = myoptions.JsonNames_JSON_NAMES_UNSPECIFIED
+	case "camel":
+		r.jsonNames = myoptions.JsonNames_LOWER_CAMEL_CASE
+	case "proto":
+		r.jsonNames = myoptions.JsonNames_PROTO_NAMES
+	default:
+		return fmt.Errorf("unknown json names: %s", name)
+	}
+	return nil
+}
+
+// JSONNames returns the naming of the fields in the JSON emitted for the
+// messages of the file, as per the (api.json_names) option of the file
+// falling back to the one set using SetJSONNames
+func (r *Registry) JSONNames(file *File) myoptions.JsonNames {
+	if file != nil && file.Options != nil && proto.HasExtension(file.Options, myoptions.E_JsonNames) {
+		if names := proto.GetExtension(file.Options, myoptions.E_JsonNames).(myoptions.JsonNames); names != myoptions.JsonNames_JSON_NAMES_UNSPECIFIED {
+			return names
+		}
+	}
+	return r.jsonNames
+}
+
 // SetOmitPackageDoc controls whether the generated code contains a package comment (if set to false, it will contain one)
 func (r *Registry) SetOmitPackageDoc(omit bool) {
 	r.omitPackageDoc = omit
@@ -841,12 +918,41 @@ func (r *Registry) GetOpenAPIFieldOption(qualifiedField string) (*options.JSONSc
 }
 
 func (r *Registry) FieldName(f *Field) string {
+	switch r.JSONNames(f.Message.File) {
+	case myoptions.JsonNames_LOWER_CAMEL_CASE:
+		return JSONName(f)
+	case myoptions.JsonNames_PROTO_NAMES:
+		return f.GetName()
+	}
 	if r.useJSONNamesForFields {
 		return f.GetJsonName()
 	}
 	return f.GetName()
 }
 
+// UseProtoNames reports whether the JSON emitted for the messages of the
+// file uses the original names of the fields instead of lowerCamelCase
+func (r *Registry) UseProtoNames(file *File) bool {
+	return r.JSONNames(file) == myoptions.JsonNames_PROTO_NAMES
+}
+
+// UseCamelQueryParams reports whether the fields of the requests of the
+// file are sent as the query params in lowerCamelCase, which is the case
+// only when the JSON names are chosen explicitly to be in lowerCamelCase,
+// the original names of the fields are used otherwise
+func (r *Registry) UseCamelQueryParams(file *File) bool {
+	return r.JSONNames(file) == myoptions.JsonNames_LOWER_CAMEL_CASE
+}
+
+// JSONName returns the lowerCamelCase name of the field in JSON, as per
+// its json_name falling back to the one derived by protoc
+func JSONName(f *Field) string {
+	if name := f.GetJsonName(); name != "" {
+		return name
+	}
+	return casing.JSONCamelCase(f.GetName())
+}
+
 func (r *Registry) CheckDuplicateAnnotation(httpMethod string, httpTemplate string, svc *Service) error {
 	a := annotationIdentifier{method: httpMethod, pathTemplate: httpTemplate, service: svc}
 	if _, ok := r.annotationMap[a]; ok {
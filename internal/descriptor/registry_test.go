@@ -707,6 +707,70 @@ func TestRegisterOpenAPIOptions(t *testing.T) {
 	}
 }
 
+func TestJSONNames(t *testing.T) {
+	load := func(t *testing.T, names string, options string) (*Registry, *Field) {
+		t.Helper()
+		src := `
+			name: "example.proto"
+			package: "example"
+			` + options + `
+			message_type <
+				name: "ListRequest"
+				field <
+					name: "page_token"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", src, err)
+		}
+		reg := NewRegistry()
+		if err := reg.SetJSONNames(names); err != nil {
+			t.Fatalf("SetJSONNames(%q) failed with %v; want success", names, err)
+		}
+		reg.loadFile(fd.GetName(), &protogen.File{Proto: &fd})
+		msg, err := reg.LookupMsg("", ".example.ListRequest")
+		if err != nil {
+			t.Fatalf("LookupMsg() failed with %v; want success", err)
+		}
+		return reg, msg.Fields[0]
+	}
+
+	for _, spec := range []struct {
+		names, options string
+		protoNames     bool
+		camelQuery     bool
+		fieldName      string
+	}{
+		{fieldName: "page_token"},
+		{names: "camel", camelQuery: true, fieldName: "pageToken"},
+		{names: "proto", protoNames: true, fieldName: "page_token"},
+		// file option takes precedence over the plugin parameter
+		{names: "camel", options: "options < [api.json_names]: PROTO_NAMES >", protoNames: true, fieldName: "page_token"},
+		{names: "proto", options: "options < [api.json_names]: LOWER_CAMEL_CASE >", camelQuery: true, fieldName: "pageToken"},
+	} {
+		reg, f := load(t, spec.names, spec.options)
+		file := f.Message.File
+		if got := reg.UseProtoNames(file); got != spec.protoNames {
+			t.Errorf("UseProtoNames() with %q %s = %v; want %v", spec.names, spec.options, got, spec.protoNames)
+		}
+		if got := reg.UseCamelQueryParams(file); got != spec.camelQuery {
+			t.Errorf("UseCamelQueryParams() with %q %s = %v; want %v", spec.names, spec.options, got, spec.camelQuery)
+		}
+		if got := reg.FieldName(f); got != spec.fieldName {
+			t.Errorf("FieldName() with %q %s = %q; want %q", spec.names, spec.options, got, spec.fieldName)
+		}
+	}
+
+	if err := NewRegistry().SetJSONNames("snake"); err == nil {
+		t.Errorf("SetJSONNames(snake) succeeded; want failure")
+	}
+}
+
 func assertStringSlice(t *testing.T, message string, got, want []string) {
 	if len(got) != len(want) {
 		t.Errorf("%s = %#v len(%d); want %#v len(%d)", message, got, len(got), want, len(want))
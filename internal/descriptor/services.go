@@ -1,14 +1,18 @@
 package descriptor
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	options "google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 
 	myoptions "github.com/go-core-stack/grpc-core/coreapis/api"
@@ -18,8 +22,9 @@ import (
 // Regular expression to validate kebab-case format
 var kebabCaseRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 
-// validateKebabCase checks if a string is in valid kebab-case format
-func validateKebabCase(field, value string) error {
+// ValidateKebabCase checks if a string is in valid kebab-case format, as
+// enforced for the products and the resource, verb and scopes of roles
+func ValidateKebabCase(field, value string) error {
 	if value == "" {
 		return nil // Empty values are allowed
 	}
@@ -36,71 +41,242 @@ func (r *Registry) loadServices(file *File) error {
 	if grpclog.V(1) {
 		grpclog.Infof("Loading services from %s", file.GetName())
 	}
+	if isExperimental(file.FileDescriptorProto) && !r.includeExperimental {
+		if grpclog.V(1) {
+			grpclog.Infof("Skipping experimental services from %s", file.GetName())
+		}
+		return nil
+	}
 	var svcs []*Service
 	for _, sd := range file.GetService() {
-		if grpclog.V(2) {
-			grpclog.Infof("Registering %s", sd.GetName())
-		}
-		svc := &Service{
-			File:                   file,
-			ServiceDescriptorProto: sd,
-			ForcePrefixedName:      r.standalone,
-		}
-		for _, md := range sd.GetMethod() {
-			if grpclog.V(2) {
-				grpclog.Infof("Processing %s.%s", sd.GetName(), md.GetName())
-			}
-			opts, err := extractAPIOptions(md)
-			if err != nil {
-				grpclog.Errorf("Failed to extract HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
-				return err
-			}
-			role, err := extractRoleOptions(md)
-			if err != nil {
-				grpclog.Errorf("Failed to extract HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
-				return err
-			}
-			optsList := r.LookupExternalHTTPRules((&Method{Service: svc, MethodDescriptorProto: md}).FQMN())
-			if opts != nil {
-				optsList = append(optsList, opts)
-			}
-			if len(optsList) == 0 {
-				if r.generateUnboundMethods {
-					defaultOpts, err := defaultAPIOptions(svc, md)
-					if err != nil {
-						grpclog.Errorf("Failed to generate default HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
-						return err
-					}
-					optsList = append(optsList, defaultOpts)
-				} else {
-					if grpclog.V(1) {
-						logFn := grpclog.Infof
-						if r.warnOnUnboundMethods {
-							logFn = grpclog.Warningf
-						}
-						logFn("No HttpRule found for method: %s.%s", svc.GetName(), md.GetName())
-					}
-				}
-			}
-			meth, err := r.newMethod(svc, md, optsList, role)
-			if err != nil {
+		svc, err := r.loadService(file, sd)
+		if err != nil {
+			if !r.bestEffort {
 				return err
 			}
-			svc.Methods = append(svc.Methods, meth)
-			r.meths[meth.FQMN()] = meth
+			r.skipService(file, sd, err)
+			continue
 		}
 		if len(svc.Methods) == 0 {
 			continue
 		}
+		svcs = append(svcs, svc)
+	}
+	var resolved []*Service
+	operations := unaryOperations(svcs)
+	for _, svc := range svcs {
+		if err := resolveInvalidations(svc, operations); err != nil {
+			grpclog.Errorf("Failed to resolve invalidations in %s: %v", file.GetName(), err)
+			if !r.bestEffort {
+				return err
+			}
+			r.skipService(file, svc.ServiceDescriptorProto, err)
+			continue
+		}
+		for _, meth := range svc.Methods {
+			r.meths[meth.FQMN()] = meth
+		}
 		if grpclog.V(2) {
 			grpclog.Infof("Registered %s with %d method(s)", svc.GetName(), len(svc.Methods))
 		}
-		svcs = append(svcs, svc)
+		resolved = append(resolved, svc)
 	}
-	file.Services = svcs
+	file.Services = resolved
 	return nil
 }
 
+// loadService loads the service along with its methods, failing on the
+// first of the options failing the validation
+func (r *Registry) loadService(file *File, sd *descriptorpb.ServiceDescriptorProto) (*Service, error) {
+	if grpclog.V(2) {
+		grpclog.Infof("Registering %s", sd.GetName())
+	}
+	svc := &Service{
+		File:                   file,
+		ServiceDescriptorProto: sd,
+		ForcePrefixedName:      r.standalone,
+	}
+	product, err := extractProductOptions(file.FileDescriptorProto, sd)
+	if err != nil {
+		grpclog.Errorf("Failed to extract product from %s: %v", svc.GetName(), err)
+		return nil, err
+	}
+	svc.Product = product
+	for _, md := range sd.GetMethod() {
+		if grpclog.V(2) {
+			grpclog.Infof("Processing %s.%s", sd.GetName(), md.GetName())
+		}
+		opts, err := extractAPIOptions(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		role, err := extractRoleOptions(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		optsList := r.LookupExternalHTTPRules((&Method{Service: svc, MethodDescriptorProto: md}).FQMN())
+		if imported := r.lookupOperationHTTPRules(svc, md.GetName()); len(imported) > 0 {
+			optsList = append(optsList[:len(optsList):len(optsList)], imported...)
+		}
+		if opts != nil {
+			optsList = append(optsList, opts)
+		}
+		if len(optsList) == 0 {
+			if r.generateUnboundMethods {
+				defaultOpts, err := defaultAPIOptions(svc, md)
+				if err != nil {
+					grpclog.Errorf("Failed to generate default HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
+					return nil, err
+				}
+				optsList = append(optsList, defaultOpts)
+			} else {
+				if grpclog.V(1) {
+					logFn := grpclog.Infof
+					if r.warnOnUnboundMethods {
+						logFn = grpclog.Warningf
+					}
+					logFn("No HttpRule found for method: %s.%s", svc.GetName(), md.GetName())
+				}
+			}
+		}
+		meth, err := r.newMethod(svc, md, optsList, role)
+		if err != nil {
+			return nil, err
+		}
+		meth.AllowedStatus, err = extractAllowedStatusOptions(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract allowed status from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.LocaleField, err = extractLocaleField(meth.RequestType)
+		if err != nil {
+			grpclog.Errorf("Failed to extract locale field from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Defaults, err = r.extractFieldDefaults(meth.RequestType)
+		if err != nil {
+			grpclog.Errorf("Failed to extract field defaults from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.RequiredFields = extractRequiredFields(meth.RequestType)
+		meth.EncryptedFields, err = extractEncryptedFields(meth.RequestType)
+		if err == nil && len(meth.EncryptedFields) != 0 && md.GetClientStreaming() {
+			// streamed request messages are not sealed by the SDK
+			err = fmt.Errorf("encrypted fields are not supported for client streaming method %s", md.GetName())
+		}
+		if err != nil {
+			grpclog.Errorf("Failed to extract encrypted fields from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.IdentityFields, err = extractIdentityFields(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract identity fields from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Immutable, err = r.extractImmutable(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract immutable fields from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.SensitiveRequestFields, err = r.extractSensitiveFields(meth.RequestType)
+		if err == nil {
+			err = checkSensitivePathParams(meth)
+		}
+		if err == nil {
+			meth.SensitiveResponseFields, err = r.extractSensitiveFields(meth.ResponseType)
+		}
+		if err != nil {
+			grpclog.Errorf("Failed to extract sensitive fields from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.VersionField, err = r.extractVersionField(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract version field from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.OutputOnlyFields, err = r.extractOutputOnlyFields(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract output only fields from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Signed, err = extractSignedOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract signed option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.LongPoll, err = extractLongPollOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract long poll option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.MaxConcurrency, err = extractMaxConcurrencyOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract max concurrency option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Bulkhead, err = extractBulkheadOptions(sd, md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract bulkhead from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Invalidates, err = extractInvalidatesOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract invalidates option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.RetryPolicy, err = extractRetryPolicy(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract retry policy from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.RetrySafety = classifyRetrySafety(meth)
+		meth.Timeout, err = extractTimeoutOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract timeout from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Upsert, err = extractUpsertOption(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract upsert option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.LogSampleRate, err = extractLogSampleRateOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract log sample rate from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.FeatureFlag, err = extractFeatureFlagOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract feature flag from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.ShadowRate, err = extractShadowRateOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract shadow rate from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Objective, err = extractObjectiveOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract service level objective from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.DebugOnly, err = extractDebugOnlyOption(md)
+		if err != nil {
+			grpclog.Errorf("Failed to extract debug only option from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		meth.Watch, err = r.extractWatch(meth)
+		if err != nil {
+			grpclog.Errorf("Failed to extract watch events from %s.%s: %v", svc.GetName(), md.GetName(), err)
+			return nil, err
+		}
+		svc.Methods = append(svc.Methods, meth)
+	}
+	return svc, nil
+}
+
 func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProto, optsList []*options.HttpRule, role *myoptions.Role) (*Method, error) {
 	requestType, err := r.LookupMsg(svc.File.GetPackage(), md.GetInputType())
 	if err != nil {
@@ -255,14 +431,14 @@ func extractRoleOptions(meth *descriptorpb.MethodDescriptorProto) (*myoptions.Ro
 	}
 
 	// Validate Role fields for kebab-case format
-	if err := validateKebabCase("resource", role.Resource); err != nil {
+	if err := ValidateKebabCase("resource", role.Resource); err != nil {
 		return nil, fmt.Errorf("invalid role in method %s: %w", meth.GetName(), err)
 	}
-	if err := validateKebabCase("verb", role.Verb); err != nil {
+	if err := ValidateKebabCase("verb", role.Verb); err != nil {
 		return nil, fmt.Errorf("invalid role in method %s: %w", meth.GetName(), err)
 	}
 	for i, scope := range role.Scope {
-		if err := validateKebabCase(fmt.Sprintf("scope[%d]", i), scope); err != nil {
+		if err := ValidateKebabCase(fmt.Sprintf("scope[%d]", i), scope); err != nil {
 			return nil, fmt.Errorf("invalid role in method %s: %w", meth.GetName(), err)
 		}
 	}
@@ -270,6 +446,806 @@ func extractRoleOptions(meth *descriptorpb.MethodDescriptorProto) (*myoptions.Ro
 	return role, nil
 }
 
+// isExperimental reports whether the file is marked experimental
+func isExperimental(file *descriptorpb.FileDescriptorProto) bool {
+	if file.Options == nil || !proto.HasExtension(file.Options, myoptions.E_Experimental) {
+		return false
+	}
+	return proto.GetExtension(file.Options, myoptions.E_Experimental).(bool)
+}
+
+// extractProductOptions returns the product the service is grouped
+// under, service level option takes precedence over the file level one
+func extractProductOptions(file *descriptorpb.FileDescriptorProto, svc *descriptorpb.ServiceDescriptorProto) (string, error) {
+	product := ""
+	if file.Options != nil && proto.HasExtension(file.Options, myoptions.E_Product) {
+		product = proto.GetExtension(file.Options, myoptions.E_Product).(string)
+	}
+	if svc.Options != nil && proto.HasExtension(svc.Options, myoptions.E_ServiceProduct) {
+		product = proto.GetExtension(svc.Options, myoptions.E_ServiceProduct).(string)
+	}
+	if err := ValidateKebabCase("product", product); err != nil {
+		return "", fmt.Errorf("invalid product for service %s: %w", svc.GetName(), err)
+	}
+	return product, nil
+}
+
+// extractBulkheadOptions returns the bulkhead the unary method is
+// isolated in, method level option takes precedence over the service
+// level one, which applies only to the unary methods
+func extractBulkheadOptions(svc *descriptorpb.ServiceDescriptorProto, meth *descriptorpb.MethodDescriptorProto) (string, error) {
+	streaming := meth.GetClientStreaming() || meth.GetServerStreaming()
+	bulkhead := ""
+	if svc.Options != nil && proto.HasExtension(svc.Options, myoptions.E_ServiceBulkhead) && !streaming {
+		bulkhead = proto.GetExtension(svc.Options, myoptions.E_ServiceBulkhead).(string)
+	}
+	if meth.Options != nil && proto.HasExtension(meth.Options, myoptions.E_Bulkhead) {
+		bulkhead = proto.GetExtension(meth.Options, myoptions.E_Bulkhead).(string)
+		if bulkhead != "" && streaming {
+			return "", fmt.Errorf("bulkhead is not supported for streaming method %s", meth.GetName())
+		}
+	}
+	if err := ValidateKebabCase("bulkhead", bulkhead); err != nil {
+		return "", fmt.Errorf("invalid bulkhead for method %s: %w", meth.GetName(), err)
+	}
+	return bulkhead, nil
+}
+
+// extractInvalidatesOption returns the operation ids of the methods
+// invalidated by the unary method
+func extractInvalidatesOption(meth *descriptorpb.MethodDescriptorProto) ([]string, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Invalidates) {
+		return nil, nil
+	}
+	ids := proto.GetExtension(meth.Options, myoptions.E_Invalidates).([]string)
+	if len(ids) != 0 && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return nil, fmt.Errorf("invalidates is not supported for streaming method %s", meth.GetName())
+	}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate operation %q invalidated by method %s", id, meth.GetName())
+		}
+		seen[id] = true
+	}
+	return ids, nil
+}
+
+// unaryOperations returns the methods of the services keyed by the
+// operation id, as Service_Method, the methods may invalidate
+func unaryOperations(svcs []*Service) map[string]*Method {
+	operations := map[string]*Method{}
+	for _, svc := range svcs {
+		for _, m := range svc.Methods {
+			operations[svc.GetName()+"_"+m.GetName()] = m
+		}
+	}
+	return operations
+}
+
+// resolveInvalidations resolves the operation ids invalidated by the
+// methods of the service against the unary methods bound to GET,
+// marking them with the surrogate key, while the invalidating methods
+// are required to be bound to the other http methods. The operations
+// are marked only once all the invalidations of the service are valid
+func resolveInvalidations(svc *Service, operations map[string]*Method) error {
+	targets := map[*Method]string{}
+	for _, m := range svc.Methods {
+		if len(m.Invalidates) == 0 {
+			continue
+		}
+		if len(m.Bindings) == 0 {
+			return fmt.Errorf("method %s invalidating %v has no bindings", m.GetName(), m.Invalidates)
+		}
+		for _, b := range m.Bindings {
+			if b.HTTPMethod == "GET" {
+				return fmt.Errorf("GET method %s can not invalidate %v", m.GetName(), m.Invalidates)
+			}
+		}
+		for _, id := range m.Invalidates {
+			target, ok := operations[id]
+			if !ok {
+				return fmt.Errorf("unknown operation %q invalidated by method %s, expected Service_Method of this file", id, m.GetName())
+			}
+			if !hasGetBinding(target) || target.GetClientStreaming() || target.GetServerStreaming() {
+				return fmt.Errorf("operation %q invalidated by method %s is not a unary GET method", id, m.GetName())
+			}
+			targets[target] = id
+		}
+	}
+	for target, id := range targets {
+		target.SurrogateKey = id
+	}
+	return nil
+}
+
+func hasGetBinding(m *Method) bool {
+	for _, b := range m.Bindings {
+		if b.HTTPMethod == "GET" {
+			return true
+		}
+	}
+	return false
+}
+
+// extractAllowedStatusOptions returns the status codes, apart from
+// success, expected from the method
+func extractAllowedStatusOptions(meth *descriptorpb.MethodDescriptorProto) ([]int32, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_AllowedStatus) {
+		return nil, nil
+	}
+	codes := proto.GetExtension(meth.Options, myoptions.E_AllowedStatus).([]int32)
+	seen := map[int32]bool{}
+	for _, code := range codes {
+		if code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid allowed status %d in method %s", code, meth.GetName())
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("duplicate allowed status %d in method %s", code, meth.GetName())
+		}
+		seen[code] = true
+	}
+	return codes, nil
+}
+
+// extractLocaleField returns the field of the request message marked
+// to be populated with the locale preferred by the client
+func extractLocaleField(msg *Message) (*Field, error) {
+	var locale *Field
+	for _, f := range msg.Fields {
+		if f.Options == nil || !proto.HasExtension(f.Options, myoptions.E_Locale) {
+			continue
+		}
+		if !proto.GetExtension(f.Options, myoptions.E_Locale).(bool) {
+			continue
+		}
+		if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING || f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			return nil, fmt.Errorf("locale field %s must be a singular string", f.FQFN())
+		}
+		if locale != nil {
+			return nil, fmt.Errorf("multiple locale fields %s and %s in %s", locale.GetName(), f.GetName(), msg.FQMN())
+		}
+		locale = f
+	}
+	return locale, nil
+}
+
+// extractRequiredFields returns the fields of the request message
+// marked as required
+func extractRequiredFields(msg *Message) []*Field {
+	var fields []*Field
+	for _, f := range msg.Fields {
+		if f.Options == nil || !proto.HasExtension(f.Options, myoptions.E_Required) {
+			continue
+		}
+		if proto.GetExtension(f.Options, myoptions.E_Required).(bool) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// extractSignedOption reports whether the response of the method is
+// signed, signing is supported only for unary methods
+func extractSignedOption(meth *descriptorpb.MethodDescriptorProto) (bool, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Signed) {
+		return false, nil
+	}
+	signed := proto.GetExtension(meth.Options, myoptions.E_Signed).(bool)
+	if signed && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return false, fmt.Errorf("signed responses are not supported for streaming method %s", meth.GetName())
+	}
+	return signed, nil
+}
+
+// safeVerbs are the verbs of the role reading the resources, safe to be
+// retried
+var safeVerbs = map[string]bool{
+	"get":   true,
+	"list":  true,
+	"watch": true,
+}
+
+// classifyRetrySafety classifies the method for the retries, the methods
+// annotated with the retry policy or marked using the idempotency_level
+// option are safe, otherwise the method is classified based on the verb
+// of the role, get, list and watch being safe while the remaining verbs
+// are unsafe. Methods with neither are left unclassified.
+//
+// The retry policy overrides the verb, such that the create, update and
+// delete methods annotated using (api.retry) are retried as well. That
+// is safe only since the generated SDK sets the Idempotency-Key header
+// on the POST and PATCH requests of such methods for the server to
+// deduplicate the attempts, DELETE being idempotent on its own
+func classifyRetrySafety(meth *Method) RetrySafety {
+	if meth.RetryPolicy != nil {
+		return RetrySafe
+	}
+	switch meth.GetOptions().GetIdempotencyLevel() {
+	case descriptorpb.MethodOptions_NO_SIDE_EFFECTS, descriptorpb.MethodOptions_IDEMPOTENT:
+		return RetrySafe
+	}
+	if meth.Role == nil || meth.Role.Verb == "" {
+		return RetryUnclassified
+	}
+	if safeVerbs[meth.Role.Verb] {
+		return RetrySafe
+	}
+	return RetryUnsafe
+}
+
+// extractWatch returns the events streamed by the server streaming
+// method with the watch verb, expected to be messages with the type
+// field, a string or an enum, and the object field, a message defined
+// in the same go package as the method
+func (r *Registry) extractWatch(meth *Method) (*Watch, error) {
+	if meth.Role == nil || meth.Role.Verb != "watch" || !meth.GetServerStreaming() || meth.GetClientStreaming() {
+		return nil, nil
+	}
+	w := &Watch{}
+	for _, f := range meth.ResponseType.Fields {
+		if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			continue
+		}
+		switch {
+		case f.GetName() == "type" && (f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING || f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM):
+			w.TypeField = f
+		case f.GetName() == "object" && f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+			w.ObjectField = f
+		}
+	}
+	if w.TypeField == nil || w.ObjectField == nil {
+		return nil, fmt.Errorf("events %s of watch method %s must have singular type and object fields", meth.ResponseType.FQMN(), meth.GetName())
+	}
+	object, err := r.LookupMsg("", w.ObjectField.GetTypeName())
+	if err != nil {
+		return nil, err
+	}
+	if object.File.GoPkg.Path != meth.Service.File.GoPkg.Path {
+		return nil, fmt.Errorf("object %s of watch method %s must be defined in the same go package", object.FQMN(), meth.GetName())
+	}
+	w.Object = object
+	return w, nil
+}
+
+// extractRetryPolicy returns the retry policy of the method as per the
+// (api.retry) option, supported only for unary methods
+func extractRetryPolicy(meth *descriptorpb.MethodDescriptorProto) (*RetryPolicy, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Retry) {
+		return nil, nil
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return nil, fmt.Errorf("retry policy is not supported for streaming method %s", meth.GetName())
+	}
+	opt := proto.GetExtension(meth.Options, myoptions.E_Retry).(*myoptions.RetryPolicy)
+	if opt.GetMaxAttempts() < 1 {
+		return nil, fmt.Errorf("invalid max attempts %d in retry policy of method %s", opt.GetMaxAttempts(), meth.GetName())
+	}
+	policy := &RetryPolicy{MaxAttempts: int(opt.GetMaxAttempts())}
+	for _, d := range []struct {
+		name  string
+		value string
+		out   *time.Duration
+	}{
+		{name: "initial backoff", value: opt.GetInitialBackoff(), out: &policy.InitialBackoff},
+		{name: "max backoff", value: opt.GetMaxBackoff(), out: &policy.MaxBackoff},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid %s %q in retry policy of method %s", d.name, d.value, meth.GetName())
+		}
+		*d.out = v
+	}
+	if policy.MaxBackoff != 0 && policy.MaxBackoff < policy.InitialBackoff {
+		return nil, fmt.Errorf("max backoff %s is less than initial backoff %s in retry policy of method %s", policy.MaxBackoff, policy.InitialBackoff, meth.GetName())
+	}
+	seen := map[int32]bool{}
+	for _, code := range opt.GetStatusCodes() {
+		if code < 400 || code > 599 {
+			return nil, fmt.Errorf("invalid retryable status %d in retry policy of method %s", code, meth.GetName())
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("duplicate retryable status %d in retry policy of method %s", code, meth.GetName())
+		}
+		seen[code] = true
+		policy.StatusCodes = append(policy.StatusCodes, code)
+	}
+	return policy, nil
+}
+
+// extractTimeoutOption returns the default timeout of the method,
+// supported only for unary methods
+func extractTimeoutOption(meth *descriptorpb.MethodDescriptorProto) (time.Duration, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Timeout) {
+		return 0, nil
+	}
+	value := proto.GetExtension(meth.Options, myoptions.E_Timeout).(string)
+	if value == "" {
+		return 0, nil
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return 0, fmt.Errorf("timeout is not supported for streaming method %s", meth.GetName())
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q of method %s", value, meth.GetName())
+	}
+	return timeout, nil
+}
+
+// extractLogSampleRateOption returns the fraction of the successful
+// calls of the method logged by the routes, nil if not annotated
+func extractLogSampleRateOption(meth *descriptorpb.MethodDescriptorProto) (*float64, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_LogSampleRate) {
+		return nil, nil
+	}
+	rate := proto.GetExtension(meth.Options, myoptions.E_LogSampleRate).(float64)
+	if !(rate >= 0 && rate <= 1) {
+		return nil, fmt.Errorf("invalid log sample rate %v of method %s, expected within [0, 1]", rate, meth.GetName())
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return nil, fmt.Errorf("log sample rate is not supported for streaming method %s", meth.GetName())
+	}
+	return &rate, nil
+}
+
+// extractFeatureFlagOption returns the name of the feature flag guarding
+// the method, which is supported only for the unary methods served by
+// the generated routes
+func extractFeatureFlagOption(meth *descriptorpb.MethodDescriptorProto) (string, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_FeatureFlag) {
+		return "", nil
+	}
+	flag := proto.GetExtension(meth.Options, myoptions.E_FeatureFlag).(string)
+	if flag == "" {
+		return "", nil
+	}
+	if strings.TrimSpace(flag) != flag {
+		return "", fmt.Errorf("invalid feature flag %q of method %s, expected without the surrounding spaces", flag, meth.GetName())
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return "", fmt.Errorf("feature flag is not supported for streaming method %s", meth.GetName())
+	}
+	return flag, nil
+}
+
+// extractShadowRateOption returns the fraction of the calls of the
+// method copied to the shadow target, which is supported only for the
+// unary methods served by the generated routes
+func extractShadowRateOption(meth *descriptorpb.MethodDescriptorProto) (float64, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_ShadowRate) {
+		return 0, nil
+	}
+	rate := proto.GetExtension(meth.Options, myoptions.E_ShadowRate).(float64)
+	if !(rate >= 0 && rate <= 1) {
+		return 0, fmt.Errorf("invalid shadow rate %v of method %s, expected within [0, 1]", rate, meth.GetName())
+	}
+	if rate != 0 && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return 0, fmt.Errorf("shadow rate is not supported for streaming method %s", meth.GetName())
+	}
+	return rate, nil
+}
+
+// extractObjectiveOption returns the service level objective of the
+// method as per the (api.slo) option, the target latency is supported
+// only for unary methods
+func extractObjectiveOption(meth *descriptorpb.MethodDescriptorProto) (*Objective, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Slo) {
+		return nil, nil
+	}
+	opt := proto.GetExtension(meth.Options, myoptions.E_Slo).(*myoptions.Objective)
+	if opt.GetLatency() == "" && opt.GetAvailability() == 0 {
+		return nil, fmt.Errorf("service level objective of method %s is missing the target latency and availability", meth.GetName())
+	}
+	slo := &Objective{Availability: opt.GetAvailability()}
+	if !(slo.Availability >= 0 && slo.Availability < 100) {
+		return nil, fmt.Errorf("invalid availability %v in service level objective of method %s, expected within [0, 100)", slo.Availability, meth.GetName())
+	}
+	if opt.GetLatency() == "" {
+		if opt.GetLatencyPercentile() != 0 {
+			return nil, fmt.Errorf("latency percentile without the target latency in service level objective of method %s", meth.GetName())
+		}
+		return slo, nil
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return nil, fmt.Errorf("target latency is not supported for streaming method %s", meth.GetName())
+	}
+	latency, err := time.ParseDuration(opt.GetLatency())
+	if err != nil || latency <= 0 {
+		return nil, fmt.Errorf("invalid latency %q in service level objective of method %s", opt.GetLatency(), meth.GetName())
+	}
+	slo.Latency = latency
+	slo.LatencyPercentile = opt.GetLatencyPercentile()
+	if slo.LatencyPercentile == 0 {
+		slo.LatencyPercentile = 99
+	}
+	if !(slo.LatencyPercentile > 0 && slo.LatencyPercentile <= 100) {
+		return nil, fmt.Errorf("invalid latency percentile %v in service level objective of method %s, expected within (0, 100]", slo.LatencyPercentile, meth.GetName())
+	}
+	return slo, nil
+}
+
+// extractUpsertOption reports whether the method is the upsert, which
+// is supported only for the unary methods bound to PUT alone
+func extractUpsertOption(meth *Method) (bool, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_Upsert) {
+		return false, nil
+	}
+	upsert := proto.GetExtension(meth.Options, myoptions.E_Upsert).(bool)
+	if !upsert {
+		return false, nil
+	}
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return false, fmt.Errorf("upsert is not supported for streaming method %s", meth.GetName())
+	}
+	if len(meth.Bindings) == 0 {
+		return false, fmt.Errorf("upsert method %s has no bindings", meth.GetName())
+	}
+	for _, b := range meth.Bindings {
+		if b.HTTPMethod != "PUT" {
+			return false, fmt.Errorf("upsert method %s is bound to %s; want PUT", meth.GetName(), b.HTTPMethod)
+		}
+	}
+	return true, nil
+}
+
+// extractDebugOnlyOption reports whether the method is debug only,
+// which is supported only for unary methods
+func extractDebugOnlyOption(meth *descriptorpb.MethodDescriptorProto) (bool, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_DebugOnly) {
+		return false, nil
+	}
+	debugOnly := proto.GetExtension(meth.Options, myoptions.E_DebugOnly).(bool)
+	if debugOnly && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return false, fmt.Errorf("debug only is not supported for streaming method %s", meth.GetName())
+	}
+	return debugOnly, nil
+}
+
+// extractLongPollOption reports whether the method is long polling,
+// supported only for unary methods
+func extractLongPollOption(meth *descriptorpb.MethodDescriptorProto) (bool, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_LongPoll) {
+		return false, nil
+	}
+	longPoll := proto.GetExtension(meth.Options, myoptions.E_LongPoll).(bool)
+	if longPoll && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return false, fmt.Errorf("long polling is not supported for streaming method %s", meth.GetName())
+	}
+	return longPoll, nil
+}
+
+// extractMaxConcurrencyOption returns the limit of the concurrent calls
+// of the method, supported only for unary methods
+func extractMaxConcurrencyOption(meth *descriptorpb.MethodDescriptorProto) (uint32, error) {
+	if meth.Options == nil || !proto.HasExtension(meth.Options, myoptions.E_MaxConcurrency) {
+		return 0, nil
+	}
+	limit := proto.GetExtension(meth.Options, myoptions.E_MaxConcurrency).(uint32)
+	if limit != 0 && (meth.GetClientStreaming() || meth.GetServerStreaming()) {
+		return 0, fmt.Errorf("max concurrency is not supported for streaming method %s", meth.GetName())
+	}
+	return limit, nil
+}
+
+// extractEncryptedFields returns the fields of the request message
+// marked for envelope encryption
+func extractEncryptedFields(msg *Message) ([]*Field, error) {
+	var fields []*Field
+	for _, f := range msg.Fields {
+		if f.Options == nil || !proto.HasExtension(f.Options, myoptions.E_Encrypted) {
+			continue
+		}
+		if !proto.GetExtension(f.Options, myoptions.E_Encrypted).(bool) {
+			continue
+		}
+		switch {
+		case f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+			return nil, fmt.Errorf("encrypted field %s must not be repeated", f.FQFN())
+		case f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING && f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+			return nil, fmt.Errorf("encrypted field %s must be a string or bytes", f.FQFN())
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// extractIdentityFields returns the fields of the request message bound
+// to the identity of the authenticated principal, such fields can not be
+// provided by the client and so are not bound to the path or the body
+func extractIdentityFields(meth *Method) ([]*IdentityField, error) {
+	var fields []*IdentityField
+	for _, f := range meth.RequestType.Fields {
+		if f.Options == nil || !proto.HasExtension(f.Options, myoptions.E_FromIdentity) {
+			continue
+		}
+		identity := proto.GetExtension(f.Options, myoptions.E_FromIdentity).(myoptions.Identity)
+		if identity == myoptions.Identity_IDENTITY_UNSPECIFIED {
+			continue
+		}
+		switch {
+		case f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED || f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING:
+			return nil, fmt.Errorf("identity field %s must be a singular string", f.FQFN())
+		case proto.GetExtension(f.Options, myoptions.E_Required).(bool):
+			return nil, fmt.Errorf("identity field %s must not be required, always populated by the routes", f.FQFN())
+		case meth.GetClientStreaming():
+			return nil, fmt.Errorf("identity field %s is not supported for client streaming method %s", f.FQFN(), meth.GetName())
+		}
+		for _, b := range meth.Bindings {
+			for _, p := range b.PathParams {
+				if p.FieldPath.String() == f.GetName() {
+					return nil, fmt.Errorf("identity field %s is bound to the path %s of method %s", f.FQFN(), b.PathTmpl.Template, meth.GetName())
+				}
+			}
+			if b.Body != nil && b.Body.FieldPath.String() == f.GetName() {
+				return nil, fmt.Errorf("identity field %s is bound to the body of method %s", f.FQFN(), meth.GetName())
+			}
+		}
+		fields = append(fields, &IdentityField{Field: f, Identity: identity.String()})
+	}
+	return fields, nil
+}
+
+// extractOutputOnlyFields returns the paths of the fields of the request
+// message, including the fields of the nested messages, marked output
+// only, which can not be required, bound to the path or carry the
+// version of the resource. The streamed request messages are left as is
+func (r *Registry) extractOutputOnlyFields(meth *Method) ([]string, error) {
+	if meth.GetClientStreaming() {
+		return nil, nil
+	}
+	fields, err := r.markedFields(meth.RequestType, myoptions.E_OutputOnly)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, f := range fields {
+		switch {
+		case proto.GetExtension(f.field.Options, myoptions.E_Required).(bool):
+			return nil, fmt.Errorf("output only field %s of %s must not be required", f.path, meth.RequestType.FQMN())
+		case f.path == meth.VersionField:
+			return nil, fmt.Errorf("output only field %s of %s must not carry the version", f.path, meth.RequestType.FQMN())
+		}
+		for _, b := range meth.Bindings {
+			for _, p := range b.PathParams {
+				if p.FieldPath.String() == f.path {
+					return nil, fmt.Errorf("output only field %s is bound to the path %s of method %s", f.path, b.PathTmpl.Template, meth.GetName())
+				}
+			}
+		}
+		paths = append(paths, f.path)
+	}
+	return paths, nil
+}
+
+// extractImmutable returns the immutable fields of the resource updated
+// by the unary method, the resource being the message carried as the
+// body of its PUT and PATCH bindings, which must agree on the field
+// carrying it
+func (r *Registry) extractImmutable(meth *Method) (*Immutable, error) {
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return nil, nil
+	}
+	var body *Body
+	for _, b := range meth.Bindings {
+		if b.Body == nil || (b.HTTPMethod != "PUT" && b.HTTPMethod != "PATCH") {
+			continue
+		}
+		if body != nil && body.FieldPath.String() != b.Body.FieldPath.String() {
+			return nil, fmt.Errorf("bindings of update method %s carry different bodies %q and %q", meth.GetName(), body.FieldPath.String(), b.Body.FieldPath.String())
+		}
+		body = b.Body
+	}
+	if body == nil {
+		return nil, nil
+	}
+	immutable := &Immutable{Resource: meth.RequestType}
+	switch len(body.FieldPath) {
+	case 0:
+	case 1:
+		f := body.FieldPath[0].Target
+		if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE || f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			// not a resource
+			return nil, nil
+		}
+		resource, err := r.LookupMsg("", f.GetTypeName())
+		if err != nil {
+			return nil, err
+		}
+		immutable.BodyField, immutable.Resource = f, resource
+	default:
+		// immutability is enforced only for the top level resources
+		return nil, nil
+	}
+	for _, f := range immutable.Resource.Fields {
+		if f.Options == nil || !proto.GetExtension(f.Options, myoptions.E_Immutable).(bool) {
+			continue
+		}
+		immutable.Fields = append(immutable.Fields, f)
+	}
+	if len(immutable.Fields) == 0 {
+		return nil, nil
+	}
+	if immutable.Resource.File.GoPkg.Path != meth.Service.File.GoPkg.Path {
+		return nil, fmt.Errorf("resource %s with immutable fields of update method %s must be defined in the same go package", immutable.Resource.FQMN(), meth.GetName())
+	}
+	return immutable, nil
+}
+
+// markedField is a field, possibly nested, marked using a field option
+type markedField struct {
+	// path of the field from the root message
+	path  string
+	field *Field
+	// repeated is set when the field or any of the enclosing fields is
+	// repeated
+	repeated bool
+}
+
+// markedFields returns the fields of the message, including the fields
+// of the nested messages, marked using the boolean field option
+func (r *Registry) markedFields(msg *Message, ext protoreflect.ExtensionType) ([]markedField, error) {
+	var fields []markedField
+	var walk func(msg *Message, prefix string, repeated bool, seen map[string]bool) error
+	walk = func(msg *Message, prefix string, repeated bool, seen map[string]bool) error {
+		for _, f := range msg.Fields {
+			path := prefix + f.GetName()
+			fieldRepeated := repeated || f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+			if f.Options != nil && proto.GetExtension(f.Options, ext).(bool) {
+				fields = append(fields, markedField{path: path, field: f, repeated: fieldRepeated})
+				continue
+			}
+			if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				continue
+			}
+			nested, err := r.LookupMsg(msg.FQMN(), f.GetTypeName())
+			if err != nil {
+				return err
+			}
+			// recursive messages are walked once per path
+			if seen[nested.FQMN()] {
+				continue
+			}
+			seen[nested.FQMN()] = true
+			err = walk(nested, path+".", fieldRepeated, seen)
+			delete(seen, nested.FQMN())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(msg, "", false, map[string]bool{msg.FQMN(): true}); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// extractSensitiveFields returns the paths of the fields of the message,
+// including the fields of the nested messages, marked sensitive
+func (r *Registry) extractSensitiveFields(msg *Message) ([]string, error) {
+	fields, err := r.markedFields(msg, myoptions.E_Sensitive)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, f := range fields {
+		paths = append(paths, f.path)
+	}
+	return paths, nil
+}
+
+// extractVersionField returns the path of the field of the request of
+// the unary method carrying the version of the resource, the field is
+// ignored for the streaming methods sharing the message
+func (r *Registry) extractVersionField(meth *Method) (string, error) {
+	if meth.GetClientStreaming() || meth.GetServerStreaming() {
+		return "", nil
+	}
+	fields, err := r.markedFields(meth.RequestType, myoptions.E_Version)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(fields) == 0:
+		return "", nil
+	case len(fields) > 1:
+		return "", fmt.Errorf("multiple version fields %s and %s in %s", fields[0].path, fields[1].path, meth.RequestType.FQMN())
+	case fields[0].repeated:
+		return "", fmt.Errorf("version field %s of %s must not be repeated or nested in a repeated field", fields[0].path, meth.RequestType.FQMN())
+	case fields[0].field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return "", fmt.Errorf("version field %s must be a string", fields[0].field.FQFN())
+	}
+	return fields[0].path, nil
+}
+
+// checkSensitivePathParams fails for the sensitive fields of the request
+// bound to the path of the method, which can not be redacted from the
+// URLs logged by the intermediate proxies
+func checkSensitivePathParams(meth *Method) error {
+	for _, b := range meth.Bindings {
+		for _, p := range b.PathParams {
+			param := p.FieldPath.String()
+			for _, path := range meth.SensitiveRequestFields {
+				if path == param || strings.HasPrefix(param, path+".") {
+					return fmt.Errorf("sensitive field %s is bound to the path %s of method %s", path, b.PathTmpl.Template, meth.GetName())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// extractFieldDefaults returns the default values of the fields of the
+// request message, validating them against the type of the field
+func (r *Registry) extractFieldDefaults(msg *Message) ([]*FieldDefault, error) {
+	var defaults []*FieldDefault
+	for _, f := range msg.Fields {
+		if f.Options == nil || !proto.HasExtension(f.Options, myoptions.E_Default) {
+			continue
+		}
+		value := proto.GetExtension(f.Options, myoptions.E_Default).(string)
+		if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			return nil, fmt.Errorf("default of %s is not supported for repeated fields", f.FQFN())
+		}
+		if err := r.validateDefault(f, value); err != nil {
+			return nil, fmt.Errorf("invalid default %q of %s: %v", value, f.FQFN(), err)
+		}
+		defaults = append(defaults, &FieldDefault{Field: f, Value: value})
+	}
+	return defaults, nil
+}
+
+// validateDefault ensures the default value can be parsed as per the
+// type of the field, the same way as a query param
+func (r *Registry) validateDefault(f *Field, value string) error {
+	var err error
+	switch f.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		_, err = strconv.ParseBool(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		_, err = strconv.ParseInt(value, 10, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		_, err = strconv.ParseUint(value, 10, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		_, err = strconv.ParseUint(value, 10, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		_, err = strconv.ParseFloat(value, 32)
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		_, err = strconv.ParseFloat(value, 64)
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		_, err = base64.StdEncoding.DecodeString(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		enum, lerr := r.LookupEnum(f.Message.FQMN(), f.GetTypeName())
+		if lerr != nil {
+			return lerr
+		}
+		for _, v := range enum.GetValue() {
+			if v.GetName() == value {
+				return nil
+			}
+		}
+		if _, perr := strconv.ParseInt(value, 10, 32); perr != nil {
+			err = fmt.Errorf("not a value of %s", enum.FQEN())
+		}
+	default:
+		err = errors.New("only scalar and enum fields support default")
+	}
+	return err
+}
+
 func extractAPIOptions(meth *descriptorpb.MethodDescriptorProto) (*options.HttpRule, error) {
 	if meth.Options == nil {
 		return nil, nil
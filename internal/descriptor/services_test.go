@@ -1,9 +1,11 @@
 package descriptor
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/encoding/prototext"
@@ -1446,3 +1448,2701 @@ func TestOptionalProto3URLPathMappingError(t *testing.T) {
 		t.Errorf("loadServices(%q, %q) expected an error %s, got nil", target, input, wantErrMsg)
 	}
 }
+
+func TestExtractServicesWithProduct(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		options <
+			[api.product]: "compute"
+		>
+		message_type <
+			name: "StringMessage"
+			field <
+				name: "string"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ExampleService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/example/echo"
+						body: "*"
+					>
+				>
+			>
+		>
+		service <
+			name: "StorageService"
+			options <
+				[api.service_product]: "block-storage"
+			>
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/storage/echo"
+						body: "*"
+					>
+				>
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+	}
+	target := "path/to/example.proto"
+	reg := NewRegistry()
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto: &fd,
+	})
+	if err := reg.loadServices(reg.files[target]); err != nil {
+		t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+	}
+	svcs := reg.files[target].Services
+	if got, want := len(svcs), 2; got != want {
+		t.Fatalf("len(svcs) = %d; want %d", got, want)
+	}
+	if got, want := svcs[0].Product, "compute"; got != want {
+		t.Errorf("svcs[0].Product = %q; want %q", got, want)
+	}
+	if got, want := svcs[1].Product, "block-storage"; got != want {
+		t.Errorf("svcs[1].Product = %q; want %q", got, want)
+	}
+}
+
+func TestExtractServicesWithInvalidProduct(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		options <
+			[api.product]: "Compute_Engine"
+		>
+		message_type <
+			name: "StringMessage"
+		>
+		service <
+			name: "ExampleService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+	}
+	target := "path/to/example.proto"
+	reg := NewRegistry()
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto: &fd,
+	})
+	wantErrMsg := "is not in kebab-case format"
+	err := reg.loadServices(reg.files[target])
+	if err == nil || !strings.Contains(err.Error(), wantErrMsg) {
+		t.Errorf("loadServices(%q) = %v; want error containing %q", target, err, wantErrMsg)
+	}
+}
+
+func TestExtractServicesWithAllowedStatus(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		want    []int32
+		wantErr bool
+	}{
+		{
+			options: `[api.allowed_status]: 202 [api.allowed_status]: 409`,
+			want:    []int32{202, 409},
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.allowed_status]: 42`,
+			wantErr: true,
+		},
+		{
+			options: `[api.allowed_status]: 409 [api.allowed_status]: 409`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meth := reg.files[target].Services[0].Methods[0]
+		if got := meth.AllowedStatus; !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("meth.AllowedStatus = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithLocaleField(t *testing.T) {
+	for _, spec := range []struct {
+		fields  string
+		want    string
+		wantErr bool
+	}{
+		{
+			fields: `
+				field <
+					name: "locale"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.locale]: true
+					>
+				>`,
+			want: "locale",
+		},
+		{
+			fields: ``,
+		},
+		{
+			fields: `
+				field <
+					name: "locale"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.locale]: false
+					>
+				>`,
+		},
+		{
+			fields: `
+				field <
+					name: "locale"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_INT32
+					options <
+						[api.locale]: true
+					>
+				>`,
+			wantErr: true,
+		},
+		{
+			fields: `
+				field <
+					name: "locale"
+					number: 2
+					label: LABEL_REPEATED
+					type: TYPE_STRING
+					options <
+						[api.locale]: true
+					>
+				>`,
+			wantErr: true,
+		},
+		{
+			fields: `
+				field <
+					name: "locale"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.locale]: true
+					>
+				>
+				field <
+					name: "language"
+					number: 3
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.locale]: true
+					>
+				>`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				` + spec.fields + `
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.fields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meth := reg.files[target].Services[0].Methods[0]
+		var got string
+		if meth.LocaleField != nil {
+			got = meth.LocaleField.GetName()
+		}
+		if got != spec.want {
+			t.Errorf("meth.LocaleField = %q; want %q", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithFieldDefaults(t *testing.T) {
+	for _, spec := range []struct {
+		field   string
+		want    string
+		wantErr bool
+	}{
+		{
+			field: `name: "limit" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 options < [api.default]: "50" >`,
+			want:  "50",
+		},
+		{
+			field: `name: "limit" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32`,
+		},
+		{
+			field: `name: "kind" number: 2 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".example.Kind" options < [api.default]: "KIND_ONE" >`,
+			want:  "KIND_ONE",
+		},
+		{
+			field: `name: "enabled" number: 2 label: LABEL_OPTIONAL type: TYPE_BOOL options < [api.default]: "true" >`,
+			want:  "true",
+		},
+		{
+			field:   `name: "limit" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 options < [api.default]: "fifty" >`,
+			wantErr: true,
+		},
+		{
+			field:   `name: "kind" number: 2 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".example.Kind" options < [api.default]: "KIND_TWO" >`,
+			wantErr: true,
+		},
+		{
+			field:   `name: "limit" number: 2 label: LABEL_REPEATED type: TYPE_INT32 options < [api.default]: "50" >`,
+			wantErr: true,
+		},
+		{
+			field:   `name: "nested" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".example.StringMessage" options < [api.default]: "{}" >`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			enum_type <
+				name: "Kind"
+				value < name: "KIND_UNSPECIFIED" number: 0 >
+				value < name: "KIND_ONE" number: 1 >
+			>
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field < ` + spec.field + ` >
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							get: "/v1/example/echo"
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meth := reg.files[target].Services[0].Methods[0]
+		var got string
+		if len(meth.Defaults) != 0 {
+			got = meth.Defaults[0].Value
+		}
+		if len(meth.Defaults) > 1 || got != spec.want {
+			t.Errorf("meth.Defaults = %v; want %q", meth.Defaults, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithRequiredFields(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		message_type <
+			name: "StringMessage"
+			field <
+				name: "string"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+				options <
+					[api.required]: true
+				>
+			>
+			field <
+				name: "optional"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+				options <
+					[api.required]: false
+				>
+			>
+			field <
+				name: "limit"
+				number: 3
+				label: LABEL_OPTIONAL
+				type: TYPE_INT32
+				options <
+					[api.required]: true
+				>
+			>
+		>
+		service <
+			name: "ExampleService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						get: "/v1/example/echo/{string}"
+					>
+				>
+			>
+		>
+	`
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+	}
+	target := "path/to/example.proto"
+	reg := NewRegistry()
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto: &fd,
+	})
+	if err := reg.loadServices(reg.files[target]); err != nil {
+		t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+	}
+	var got []string
+	for _, f := range reg.files[target].Services[0].Methods[0].RequiredFields {
+		got = append(got, f.GetName())
+	}
+	if want := []string{"string", "limit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("meth.RequiredFields = %v; want %v", got, want)
+	}
+}
+
+func TestExtractServicesWithEncryptedFields(t *testing.T) {
+	for _, spec := range []struct {
+		fieldType string
+		label     string
+		streaming bool
+		want      []string
+		wantErr   bool
+	}{
+		{fieldType: "TYPE_STRING", label: "LABEL_OPTIONAL", want: []string{"secret"}},
+		{fieldType: "TYPE_BYTES", label: "LABEL_OPTIONAL", want: []string{"secret"}},
+		{fieldType: "TYPE_INT32", label: "LABEL_OPTIONAL", wantErr: true},
+		{fieldType: "TYPE_STRING", label: "LABEL_REPEATED", wantErr: true},
+		{fieldType: "TYPE_STRING", label: "LABEL_OPTIONAL", streaming: true, wantErr: true},
+	} {
+		src := fmt.Sprintf(`
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field <
+					name: "secret"
+					number: 2
+					label: %s
+					type: %s
+					options <
+						[api.encrypted]: true
+					>
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					client_streaming: %t
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+					>
+				>
+			>
+		`, spec.label, spec.fieldType, spec.streaming)
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) succeeded for %s %s streaming=%t; want error", target, spec.label, spec.fieldType, spec.streaming)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		var got []string
+		for _, f := range reg.files[target].Services[0].Methods[0].EncryptedFields {
+			got = append(got, f.GetName())
+		}
+		if !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("meth.EncryptedFields = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesExperimental(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		options <
+			[api.experimental]: true
+		>
+		message_type <
+			name: "StringMessage"
+			field <
+				name: "string"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ExampleService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/example/echo"
+						body: "*"
+					>
+				>
+			>
+		>
+	`
+	for _, include := range []bool{false, true} {
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.SetIncludeExperimental(include)
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		if err := reg.loadServices(reg.files[target]); err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		want := 0
+		if include {
+			want = 1
+		}
+		if got := len(reg.files[target].Services); got != want {
+			t.Errorf("len(svcs) = %d with includeExperimental=%v; want %d", got, include, want)
+		}
+		if _, ok := reg.meths[".example.ExampleService.Echo"]; ok != include {
+			t.Errorf("method registered = %v with includeExperimental=%v; want %v", ok, include, include)
+		}
+	}
+}
+
+func TestExtractServicesWithSigned(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			options: `[api.signed]: true`,
+			want:    true,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.signed]: true`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].Signed; got != spec.want {
+			t.Errorf("meth.Signed = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithLongPoll(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			options: `[api.long_poll]: true`,
+			want:    true,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.long_poll]: false`,
+		},
+		{
+			options: `[api.long_poll]: true`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+		{
+			options: `[api.long_poll]: true`,
+			stream:  `client_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].LongPoll; got != spec.want {
+			t.Errorf("meth.LongPoll = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithMaxConcurrency(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    uint32
+		wantErr bool
+	}{
+		{
+			options: `[api.max_concurrency]: 10`,
+			want:    10,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.max_concurrency]: 0`,
+		},
+		{
+			options: `[api.max_concurrency]: 10`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+		{
+			options: `[api.max_concurrency]: 10`,
+			stream:  `client_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].MaxConcurrency; got != spec.want {
+			t.Errorf("meth.MaxConcurrency = %d; want %d", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithTimeout(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			options: `[api.timeout]: "5s"`,
+			want:    5 * time.Second,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.timeout]: ""`,
+		},
+		{
+			options: `[api.timeout]: "5"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.timeout]: "-1s"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.timeout]: "5s"`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].Timeout; got != spec.want {
+			t.Errorf("meth.Timeout = %s; want %s", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithBulkhead(t *testing.T) {
+	for _, spec := range []struct {
+		svcOptions string
+		options    string
+		stream     string
+		want       string
+		wantErr    bool
+	}{
+		{
+			options: `[api.bulkhead]: "reports"`,
+			want:    "reports",
+		},
+		{},
+		{
+			svcOptions: `[api.service_bulkhead]: "billing"`,
+			want:       "billing",
+		},
+		{
+			svcOptions: `[api.service_bulkhead]: "billing"`,
+			options:    `[api.bulkhead]: "reports"`,
+			want:       "reports",
+		},
+		{
+			svcOptions: `[api.service_bulkhead]: "billing"`,
+			stream:     `server_streaming: true`,
+		},
+		{
+			options: `[api.bulkhead]: "reports"`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+		{
+			options: `[api.bulkhead]: "Reports"`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				options <
+					` + spec.svcOptions + `
+				>
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].Bulkhead; got != spec.want {
+			t.Errorf("meth.Bulkhead = %q; want %q", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithWatch(t *testing.T) {
+	for _, spec := range []struct {
+		verb      string
+		stream    string
+		eventType string
+		object    string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			verb:      "watch",
+			stream:    `server_streaming: true`,
+			eventType: `type: TYPE_ENUM type_name: ".example.EventType"`,
+			object:    `type: TYPE_MESSAGE type_name: ".example.Object"`,
+			want:      true,
+		},
+		{
+			verb:      "watch",
+			stream:    `server_streaming: true`,
+			eventType: `type: TYPE_STRING`,
+			object:    `type: TYPE_MESSAGE type_name: ".example.Object"`,
+			want:      true,
+		},
+		{
+			verb:      "list",
+			stream:    `server_streaming: true`,
+			eventType: `type: TYPE_STRING`,
+			object:    `type: TYPE_MESSAGE type_name: ".example.Object"`,
+		},
+		{
+			verb:      "watch",
+			eventType: `type: TYPE_STRING`,
+			object:    `type: TYPE_MESSAGE type_name: ".example.Object"`,
+		},
+		{
+			verb:      "watch",
+			stream:    `server_streaming: true`,
+			eventType: `type: TYPE_INT32`,
+			object:    `type: TYPE_MESSAGE type_name: ".example.Object"`,
+			wantErr:   true,
+		},
+		{
+			verb:      "watch",
+			stream:    `server_streaming: true`,
+			eventType: `type: TYPE_STRING`,
+			object:    `type: TYPE_STRING`,
+			wantErr:   true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "Object"
+				field <
+					name: "name"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			message_type <
+				name: "Event"
+				field <
+					name: "type"
+					number: 1
+					label: LABEL_OPTIONAL
+					` + spec.eventType + `
+				>
+				field <
+					name: "object"
+					number: 2
+					label: LABEL_OPTIONAL
+					` + spec.object + `
+				>
+			>
+			enum_type <
+				name: "EventType"
+				value <
+					name: "EVENT_TYPE_UNSPECIFIED"
+					number: 0
+				>
+				value <
+					name: "EVENT_TYPE_ADDED"
+					number: 1
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "WatchObjects"
+					input_type: "Object"
+					output_type: "Event"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							get: "/v1/objects:watch"
+						>
+						[api.role] <
+							resource: "object"
+							verb: "` + spec.verb + `"
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with type %s and object %s succeeded; want error", target, spec.eventType, spec.object)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		w := reg.files[target].Services[0].Methods[0].Watch
+		if !spec.want {
+			if w != nil {
+				t.Errorf("meth.Watch = %+v for verb %q with %q; want nil", w, spec.verb, spec.stream)
+			}
+			continue
+		}
+		if w == nil {
+			t.Fatalf("meth.Watch = nil for verb %q with %q; want watch", spec.verb, spec.stream)
+		}
+		if w.TypeField.GetName() != "type" || w.ObjectField.GetName() != "object" {
+			t.Errorf("meth.Watch fields = %s, %s; want type, object", w.TypeField.GetName(), w.ObjectField.GetName())
+		}
+		if got := w.Object.FQMN(); got != ".example.Object" {
+			t.Errorf("meth.Watch.Object = %s; want .example.Object", got)
+		}
+	}
+}
+
+func TestExtractServicesWithRetrySafety(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		want    RetrySafety
+	}{
+		{
+			options: ``,
+			want:    RetryUnclassified,
+		},
+		{
+			options: `[api.role] < resource: "example" verb: "get" >`,
+			want:    RetrySafe,
+		},
+		{
+			options: `[api.role] < resource: "example" verb: "watch" >`,
+			want:    RetrySafe,
+		},
+		{
+			options: `[api.role] < resource: "example" verb: "update" >`,
+			want:    RetryUnsafe,
+		},
+		{
+			options: `[api.role] < resource: "example" verb: "update" > idempotency_level: IDEMPOTENT`,
+			want:    RetrySafe,
+		},
+		{
+			options: `idempotency_level: NO_SIDE_EFFECTS`,
+			want:    RetrySafe,
+		},
+		{
+			options: `[api.role] < resource: "example" verb: "create" > [api.retry] < max_attempts: 3 >`,
+			want:    RetrySafe,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		if err := reg.loadServices(reg.files[target]); err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].RetrySafety; got != spec.want {
+			t.Errorf("meth.RetrySafety with %s = %q; want %q", spec.options, got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithRetryPolicy(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    *RetryPolicy
+		wantErr bool
+	}{
+		{
+			options: ``,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 initial_backoff: "100ms" max_backoff: "1s" status_codes: 503 >`,
+			want: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: 100 * time.Millisecond,
+				MaxBackoff:     time.Second,
+				StatusCodes:    []int32{503},
+			},
+		},
+		{
+			options: `[api.retry] < max_attempts: 2 >`,
+			want:    &RetryPolicy{MaxAttempts: 2},
+		},
+		{
+			options: `[api.retry] < initial_backoff: "100ms" >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 initial_backoff: "soon" >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 initial_backoff: "1s" max_backoff: "100ms" >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 status_codes: 200 >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 status_codes: 503 status_codes: 503 >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.retry] < max_attempts: 3 >`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meth := reg.files[target].Services[0].Methods[0]
+		if !reflect.DeepEqual(meth.RetryPolicy, spec.want) {
+			t.Errorf("meth.RetryPolicy with %s = %+v; want %+v", spec.options, meth.RetryPolicy, spec.want)
+		}
+		if spec.want != nil && meth.RetrySafety != RetrySafe {
+			t.Errorf("meth.RetrySafety with %s = %q; want %q", spec.options, meth.RetrySafety, RetrySafe)
+		}
+	}
+}
+
+func TestExtractServicesWithInvalidates(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		binding string
+		stream  string
+		wantKey string
+		wantErr bool
+	}{
+		{
+			options: `[api.invalidates]: "ExampleService_Get"`,
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+			wantKey: "ExampleService_Get",
+		},
+		{
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+		},
+		{
+			options: `[api.invalidates]: "ExampleService_Get" [api.invalidates]: "ExampleService_Get"`,
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.invalidates]: "ExampleService_List"`,
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.invalidates]: "ExampleService_Get"`,
+			binding: `get: "/v1/example/{string}:fresh"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.invalidates]: "ExampleService_Update"`,
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.invalidates]: "ExampleService_Get"`,
+			binding: `patch: "/v1/example/{string}" body: "*"`,
+			stream:  `client_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Get"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							get: "/v1/example/{string}"
+						>
+					>
+				>
+				method <
+					name: "Update"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							` + spec.binding + `
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meths := reg.files[target].Services[0].Methods
+		if got := meths[0].SurrogateKey; got != spec.wantKey {
+			t.Errorf("meth.SurrogateKey = %q; want %q", got, spec.wantKey)
+		}
+		if spec.wantKey != "" && !reflect.DeepEqual(meths[1].Invalidates, []string{spec.wantKey}) {
+			t.Errorf("meth.Invalidates = %v; want [%s]", meths[1].Invalidates, spec.wantKey)
+		}
+	}
+}
+
+func TestExtractServicesWithSensitiveFields(t *testing.T) {
+	for _, spec := range []struct {
+		binding      string
+		wantRequest  []string
+		wantResponse []string
+		wantErr      bool
+	}{
+		{
+			binding:      `post: "/v1/example/echo" body: "*"`,
+			wantRequest:  []string{"token", "nested.token"},
+			wantResponse: []string{"token", "nested.token"},
+		},
+		{
+			binding: `get: "/v1/example/echo/{token}"`,
+			wantErr: true,
+		},
+		{
+			binding: `get: "/v1/example/echo/{nested.token}"`,
+			wantErr: true,
+		},
+		{
+			binding:      `get: "/v1/example/echo/{name}"`,
+			wantRequest:  []string{"token", "nested.token"},
+			wantResponse: []string{"token", "nested.token"},
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "SecretMessage"
+				field <
+					name: "name"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field <
+					name: "token"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.sensitive]: true
+					>
+				>
+				field <
+					name: "nested"
+					number: 3
+					label: LABEL_OPTIONAL
+					type: TYPE_MESSAGE
+					type_name: "Inner"
+				>
+			>
+			message_type <
+				name: "Inner"
+				field <
+					name: "token"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.sensitive]: true
+					>
+				>
+				field <
+					name: "parent"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_MESSAGE
+					type_name: "Inner"
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "SecretMessage"
+					output_type: "SecretMessage"
+					options <
+						[google.api.http] <
+							` + spec.binding + `
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.binding)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		meth := reg.files[target].Services[0].Methods[0]
+		if !reflect.DeepEqual(meth.SensitiveRequestFields, spec.wantRequest) {
+			t.Errorf("meth.SensitiveRequestFields = %v; want %v", meth.SensitiveRequestFields, spec.wantRequest)
+		}
+		if !reflect.DeepEqual(meth.SensitiveResponseFields, spec.wantResponse) {
+			t.Errorf("meth.SensitiveResponseFields = %v; want %v", meth.SensitiveResponseFields, spec.wantResponse)
+		}
+	}
+}
+
+func TestExtractServicesWithVersionField(t *testing.T) {
+	for _, spec := range []struct {
+		fields  string
+		stream  string
+		want    string
+		wantErr bool
+	}{
+		{
+			fields: `field <
+				name: "etag"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+				options < [api.version]: true >
+			>`,
+			want: "etag",
+		},
+		{
+			fields: `field <
+				name: "object"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_MESSAGE
+				type_name: "Versioned"
+			>`,
+			want: "object.etag",
+		},
+		{},
+		{
+			fields: `field <
+				name: "etag"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+				options < [api.version]: true >
+			>`,
+			stream: `server_streaming: true`,
+		},
+		{
+			fields: `field <
+				name: "objects"
+				number: 2
+				label: LABEL_REPEATED
+				type: TYPE_MESSAGE
+				type_name: "Versioned"
+			>`,
+			wantErr: true,
+		},
+		{
+			fields: `field <
+				name: "etag"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_INT64
+				options < [api.version]: true >
+			>`,
+			wantErr: true,
+		},
+		{
+			fields: `field <
+				name: "etag"
+				number: 2
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+				options < [api.version]: true >
+			>
+			field <
+				name: "object"
+				number: 3
+				label: LABEL_OPTIONAL
+				type: TYPE_MESSAGE
+				type_name: "Versioned"
+			>`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "Versioned"
+				field <
+					name: "etag"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options < [api.version]: true >
+				>
+			>
+			message_type <
+				name: "UpdateRequest"
+				field <
+					name: "name"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				` + spec.fields + `
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Update"
+					input_type: "UpdateRequest"
+					output_type: "Versioned"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							put: "/v1/example/{name}"
+							body: "*"
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.fields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].VersionField; got != spec.want {
+			t.Errorf("meth.VersionField = %q; want %q", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithUpsert(t *testing.T) {
+	for _, spec := range []struct {
+		binding string
+		options string
+		stream  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			binding: `put: "/v1/example/{string}" body: "*"`,
+			options: `[api.upsert]: true`,
+			want:    true,
+		},
+		{
+			binding: `put: "/v1/example/{string}" body: "*"`,
+		},
+		{
+			binding: `put: "/v1/example/{string}" body: "*"`,
+			options: `[api.upsert]: false`,
+		},
+		{
+			binding: `post: "/v1/example/{string}" body: "*"`,
+			options: `[api.upsert]: true`,
+			wantErr: true,
+		},
+		{
+			binding: `put: "/v1/example/{string}" body: "*" additional_bindings < patch: "/v1/example/{string}" body: "*" >`,
+			options: `[api.upsert]: true`,
+			wantErr: true,
+		},
+		{
+			binding: `put: "/v1/example/{string}" body: "*"`,
+			options: `[api.upsert]: true`,
+			stream:  `client_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							` + spec.binding + `
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s %s succeeded; want error", target, spec.binding, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].Upsert; got != spec.want {
+			t.Errorf("meth.Upsert = %t; want %t", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithIdentityFields(t *testing.T) {
+	for _, spec := range []struct {
+		binding string
+		field   string
+		want    string
+		wantErr bool
+	}{
+		{
+			binding: `post: "/v1/example/{string}" body: "*"`,
+			field:   `type: TYPE_STRING options < [api.from_identity]: SUBJECT >`,
+			want:    "SUBJECT",
+		},
+		{
+			binding: `get: "/v1/example/{string}"`,
+			field:   `type: TYPE_STRING options < [api.from_identity]: TENANT >`,
+			want:    "TENANT",
+		},
+		{
+			binding: `get: "/v1/example/{string}"`,
+			field:   `type: TYPE_STRING`,
+		},
+		{
+			binding: `get: "/v1/example/{string}"`,
+			field:   `type: TYPE_INT32 options < [api.from_identity]: SUBJECT >`,
+			wantErr: true,
+		},
+		{
+			binding: `get: "/v1/example/{string}"`,
+			field:   `type: TYPE_STRING options < [api.from_identity]: EMAIL [api.required]: true >`,
+			wantErr: true,
+		},
+		{
+			binding: `get: "/v1/example/{string}/{owner}"`,
+			field:   `type: TYPE_STRING options < [api.from_identity]: SUBJECT >`,
+			wantErr: true,
+		},
+		{
+			binding: `put: "/v1/example/{string}" body: "owner"`,
+			field:   `type: TYPE_STRING options < [api.from_identity]: SUBJECT >`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field <
+					name: "owner"
+					number: 2
+					label: LABEL_OPTIONAL
+					` + spec.field + `
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					options <
+						[google.api.http] <
+							` + spec.binding + `
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s %s succeeded; want error", target, spec.binding, spec.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		fields := reg.files[target].Services[0].Methods[0].IdentityFields
+		switch {
+		case spec.want == "" && len(fields) != 0:
+			t.Errorf("meth.IdentityFields = %v; want none", fields)
+		case spec.want != "" && (len(fields) != 1 || fields[0].Field.GetName() != "owner" || fields[0].Identity != spec.want):
+			t.Errorf("meth.IdentityFields = %v; want owner bound to %s", fields, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithImmutable(t *testing.T) {
+	for _, spec := range []struct {
+		bindings string
+		want     []string
+		body     string
+		wantErr  bool
+	}{
+		{
+			bindings: `put: "/v1/objects/{name}" body: "object"`,
+			want:     []string{"id", "created_by"},
+			body:     "object",
+		},
+		{
+			bindings: `patch: "/v1/objects/{name}" body: "object" additional_bindings < put: "/v1/objects/{name}" body: "object" >`,
+			want:     []string{"id", "created_by"},
+			body:     "object",
+		},
+		{
+			bindings: `put: "/v1/objects/{name}" body: "*"`,
+		},
+		{
+			bindings: `post: "/v1/objects/{name}" body: "object"`,
+		},
+		{
+			bindings: `put: "/v1/objects/{name}" body: "object" additional_bindings < patch: "/v1/objects/{name}" body: "*" >`,
+			wantErr:  true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "Object"
+				field <
+					name: "id"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.immutable]: true
+					>
+				>
+				field <
+					name: "created_by"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.immutable]: true
+					>
+				>
+				field <
+					name: "size"
+					number: 3
+					label: LABEL_OPTIONAL
+					type: TYPE_INT32
+				>
+			>
+			message_type <
+				name: "UpdateRequest"
+				field <
+					name: "name"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field <
+					name: "object"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_MESSAGE
+					type_name: ".example.Object"
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Update"
+					input_type: "UpdateRequest"
+					output_type: "Object"
+					options <
+						[google.api.http] <
+							` + spec.bindings + `
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.bindings)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) with %s failed with %v; want success", target, spec.bindings, err)
+		}
+		immutable := reg.files[target].Services[0].Methods[0].Immutable
+		if spec.want == nil {
+			if immutable != nil {
+				t.Errorf("meth.Immutable with %s = %+v; want nil", spec.bindings, immutable)
+			}
+			continue
+		}
+		if immutable == nil {
+			t.Fatalf("meth.Immutable with %s = nil; want %v", spec.bindings, spec.want)
+		}
+		var got []string
+		for _, f := range immutable.Fields {
+			got = append(got, f.GetName())
+		}
+		if !reflect.DeepEqual(got, spec.want) || immutable.BodyField.GetName() != spec.body || immutable.Resource.GetName() != "Object" {
+			t.Errorf("meth.Immutable with %s = %v of %s carried by %s; want %v of Object carried by %s", spec.bindings, got, immutable.Resource.GetName(), immutable.BodyField.GetName(), spec.want, spec.body)
+		}
+	}
+}
+
+func TestExtractServicesWithOutputOnlyFields(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		binding string
+		want    []string
+		wantErr bool
+	}{
+		{
+			options: `[api.output_only]: true`,
+			binding: `put: "/v1/objects/{name}" body: "object"`,
+			want:    []string{"object.id", "id"},
+		},
+		{
+			options: `[api.output_only]: true [api.required]: true`,
+			binding: `put: "/v1/objects/{name}" body: "object"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.output_only]: true`,
+			binding: `put: "/v1/objects/{id}" body: "object"`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "Object"
+				field <
+					name: "id"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						[api.output_only]: true
+					>
+				>
+			>
+			message_type <
+				name: "UpdateRequest"
+				field <
+					name: "name"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+				field <
+					name: "object"
+					number: 2
+					label: LABEL_OPTIONAL
+					type: TYPE_MESSAGE
+					type_name: ".example.Object"
+				>
+				field <
+					name: "id"
+					number: 3
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+					options <
+						` + spec.options + `
+					>
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Update"
+					input_type: "UpdateRequest"
+					output_type: "Object"
+					options <
+						[google.api.http] <
+							` + spec.binding + `
+						>
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s %s succeeded; want error", target, spec.options, spec.binding)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].OutputOnlyFields; !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("meth.OutputOnlyFields = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithLogSampleRate(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    *float64
+		wantErr bool
+	}{
+		{
+			options: `[api.log_sample_rate]: 0.25`,
+			want:    proto.Float64(0.25),
+		},
+		{
+			options: `[api.log_sample_rate]: 0`,
+			want:    proto.Float64(0),
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.log_sample_rate]: 1.5`,
+			wantErr: true,
+		},
+		{
+			options: `[api.log_sample_rate]: -0.5`,
+			wantErr: true,
+		},
+		{
+			options: `[api.log_sample_rate]: 0.25`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].LogSampleRate; !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("meth.LogSampleRate = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithFeatureFlag(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    string
+		wantErr bool
+	}{
+		{
+			options: `[api.feature_flag]: "echo-v2"`,
+			want:    "echo-v2",
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.feature_flag]: " echo-v2"`,
+			wantErr: true,
+		},
+		{
+			options: `[api.feature_flag]: "echo-v2"`,
+			stream:  `client_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].FeatureFlag; got != spec.want {
+			t.Errorf("meth.FeatureFlag = %q; want %q", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithShadowRate(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    float64
+		wantErr bool
+	}{
+		{
+			options: `[api.shadow_rate]: 0.1`,
+			want:    0.1,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.shadow_rate]: 2`,
+			wantErr: true,
+		},
+		{
+			options: `[api.shadow_rate]: 0.1`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].ShadowRate; got != spec.want {
+			t.Errorf("meth.ShadowRate = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithObjective(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    *Objective
+		wantErr bool
+	}{
+		{
+			options: `[api.slo] < latency: "200ms" availability: 99.9 >`,
+			want:    &Objective{Latency: 200 * time.Millisecond, LatencyPercentile: 99, Availability: 99.9},
+		},
+		{
+			options: `[api.slo] < latency: "1s" latency_percentile: 95 >`,
+			want:    &Objective{Latency: time.Second, LatencyPercentile: 95},
+		},
+		{
+			options: `[api.slo] < availability: 99.5 >`,
+			stream:  `server_streaming: true`,
+			want:    &Objective{Availability: 99.5},
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.slo] < >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.slo] < latency: "fast" >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.slo] < availability: 100 >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.slo] < latency: "1s" latency_percentile: 120 >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.slo] < availability: 99 latency_percentile: 99 >`,
+			wantErr: true,
+		},
+		{
+			options: `[api.slo] < latency: "1s" >`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].Objective; !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("meth.Objective = %+v; want %+v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithDebugOnly(t *testing.T) {
+	for _, spec := range []struct {
+		options string
+		stream  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			options: `[api.debug_only]: true`,
+			want:    true,
+		},
+		{
+			options: ``,
+		},
+		{
+			options: `[api.debug_only]: false`,
+			stream:  `server_streaming: true`,
+		},
+		{
+			options: `[api.debug_only]: true`,
+			stream:  `server_streaming: true`,
+			wantErr: true,
+		},
+	} {
+		src := `
+			name: "path/to/example.proto"
+			package: "example"
+			message_type <
+				name: "StringMessage"
+				field <
+					name: "string"
+					number: 1
+					label: LABEL_OPTIONAL
+					type: TYPE_STRING
+				>
+			>
+			service <
+				name: "ExampleService"
+				method <
+					name: "Echo"
+					input_type: "StringMessage"
+					output_type: "StringMessage"
+					` + spec.stream + `
+					options <
+						[google.api.http] <
+							post: "/v1/example/echo"
+							body: "*"
+						>
+						` + spec.options + `
+					>
+				>
+			>
+		`
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("loadServices(%q) with %s succeeded; want error", target, spec.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) failed with %v; want success", target, err)
+		}
+		if got := reg.files[target].Services[0].Methods[0].DebugOnly; got != spec.want {
+			t.Errorf("meth.DebugOnly = %v; want %v", got, spec.want)
+		}
+	}
+}
+
+func TestExtractServicesWithBestEffort(t *testing.T) {
+	src := `
+		name: "path/to/example.proto"
+		package: "example"
+		message_type <
+			name: "StringMessage"
+			field <
+				name: "string"
+				number: 1
+				label: LABEL_OPTIONAL
+				type: TYPE_STRING
+			>
+		>
+		service <
+			name: "ValidService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/valid/echo"
+						body: "*"
+					>
+				>
+			>
+		>
+		service <
+			name: "DebugService"
+			method <
+				name: "Echo"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				server_streaming: true
+				options <
+					[google.api.http] <
+						post: "/v1/debug/echo"
+						body: "*"
+					>
+					[api.debug_only]: true
+				>
+			>
+		>
+		service <
+			name: "CacheService"
+			method <
+				name: "Purge"
+				input_type: "StringMessage"
+				output_type: "StringMessage"
+				options <
+					[google.api.http] <
+						post: "/v1/cache/purge"
+						body: "*"
+					>
+					[api.invalidates]: "DebugService_Echo"
+				>
+			>
+		>
+	`
+	for _, bestEffort := range []bool{false, true} {
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+			t.Fatalf("proto.UnmarshalText(%s, &fd) failed with %v; want success", src, err)
+		}
+		target := "path/to/example.proto"
+		reg := NewRegistry()
+		reg.SetBestEffort(bestEffort)
+		reg.loadFile(fd.GetName(), &protogen.File{
+			Proto: &fd,
+		})
+		err := reg.loadServices(reg.files[target])
+		if !bestEffort {
+			if err == nil {
+				t.Errorf("loadServices(%q) succeeded; want error", target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("loadServices(%q) with best effort failed with %v; want success", target, err)
+		}
+		file := reg.files[target]
+		if len(file.Services) != 1 || file.Services[0].GetName() != "ValidService" {
+			t.Errorf("file.Services = %v; want [ValidService]", file.Services)
+		}
+		var skipped []string
+		for _, s := range reg.SkippedServices() {
+			skipped = append(skipped, s.Name)
+			if s.File != target || s.Err == nil {
+				t.Errorf("skipped service %s = %+v; want the file %s and the error", s.Name, s, target)
+			}
+		}
+		if want := []string{"example.DebugService", "example.CacheService"}; !reflect.DeepEqual(skipped, want) {
+			t.Errorf("reg.SkippedServices() = %v; want %v", skipped, want)
+		}
+		if !reflect.DeepEqual(file.Skipped, reg.SkippedServices()) {
+			t.Errorf("file.Skipped = %v; want %v", file.Skipped, reg.SkippedServices())
+		}
+		if len(reg.meths) != 1 {
+			t.Errorf("reg.meths = %v; want only the method of ValidService", reg.meths)
+		}
+		report := reg.SkippedServicesReport()
+		for _, want := range []string{"skipped 2 service(s)", target + ": example.DebugService: ", target + ": example.CacheService: "} {
+			if !strings.Contains(report, want) {
+				t.Errorf("reg.SkippedServicesReport() = %q; want containing %q", report, want)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package descriptor
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
@@ -64,6 +65,9 @@ type File struct {
 	Enums []*Enum
 	// Services is the list of services defined in this file.
 	Services []*Service
+	// Skipped is the list of services of this file skipped by the best
+	// effort loading, as their options failed the validation.
+	Skipped []*SkippedService
 }
 
 // Pkg returns package name or alias if it's present
@@ -169,6 +173,9 @@ type Service struct {
 	Methods []*Method
 	// ForcePrefixedName when set to true, prefixes a type with a package prefix.
 	ForcePrefixedName bool
+	// Product is the name of the product the service is grouped under
+	// for the generated SDK, empty if the service is not part of any.
+	Product string
 }
 
 // FQSN returns the fully qualified service name of this service.
@@ -209,6 +216,185 @@ type Method struct {
 	ResponseType *Message
 	Bindings     []*Binding
 	Role         *Role
+	// AllowedStatus is the list of status codes, apart from success,
+	// expected from the method as part of business flow
+	AllowedStatus []int32
+	// LocaleField is the field of the request to be populated with the
+	// locale preferred by the client, nil if not annotated
+	LocaleField *Field
+	// Defaults are the default values of the request fields, applied
+	// by the generated routes when not set by the client
+	Defaults []*FieldDefault
+	// RequiredFields are the fields of the request which are required
+	// to be set by the client
+	RequiredFields []*Field
+	// EncryptedFields are the fields of the request sealed by the SDK
+	// using envelope encryption and opened by the routes
+	EncryptedFields []*Field
+	// IdentityFields are the fields of the request bound to the identity
+	// of the authenticated principal, populated by the routes and
+	// omitted by the SDK
+	IdentityFields []*IdentityField
+	// OutputOnlyFields are the paths of the fields, nested ones
+	// included, of the request marked using the (api.output_only)
+	// option, omitted by the SDK and cleared by the routes
+	OutputOnlyFields []string
+	// Immutable describes the immutable fields of the resource updated
+	// by the method, nil if not an update method or none is immutable
+	Immutable *Immutable
+	// SensitiveRequestFields and SensitiveResponseFields are the paths
+	// of the fields, nested ones included, of the request and the
+	// response marked using the (api.sensitive) option, redacted from
+	// the logs by the SDK
+	SensitiveRequestFields  []string
+	SensitiveResponseFields []string
+	// VersionField is the path of the field of the request carrying the
+	// version of the resource as per the (api.version) option, sent by
+	// the SDK as the If-Match header, empty if none
+	VersionField string
+	// Signed marks the response to be signed by the routes and
+	// verified by the SDK
+	Signed bool
+	// LongPoll marks the method to hold the request until a change is
+	// available, served and called with extended timeouts
+	LongPoll bool
+	// MaxConcurrency is the limit of the concurrent calls served by the
+	// routes as per the (api.max_concurrency) option, zero if unlimited
+	MaxConcurrency uint32
+	// Bulkhead is the name of the bulkhead the calls are isolated in by
+	// the SDK as per the (api.bulkhead) option, empty if not isolated
+	Bulkhead string
+	// Invalidates are the operation ids of the GET methods whose cached
+	// responses are invalidated by the successful calls as per the
+	// (api.invalidates) option
+	Invalidates []string
+	// SurrogateKey is the operation id of the method when invalidated by
+	// the other methods, tagging the cached responses, empty otherwise
+	SurrogateKey string
+	// RetryPolicy is the retry policy of the method as per the
+	// (api.retry) option, nil if not annotated
+	RetryPolicy *RetryPolicy
+	// RetrySafety classifies the method for the retries by the SDK
+	RetrySafety RetrySafety
+	// Timeout is the default timeout of the calls made by the SDK as per
+	// the (api.timeout) option, zero if not annotated
+	Timeout time.Duration
+	// Upsert marks the PUT method creating the resource when missing,
+	// as per the (api.upsert) option
+	Upsert bool
+	// LogSampleRate is the fraction of the successful calls logged by
+	// the routes as per the (api.log_sample_rate) option, nil if not
+	// annotated
+	LogSampleRate *float64
+	// FeatureFlag is the name of the flag guarding the routes of the
+	// method as per the (api.feature_flag) option, empty if not guarded
+	FeatureFlag string
+	// ShadowRate is the fraction of the calls copied to the shadow
+	// target as per the (api.shadow_rate) option, zero if not copied
+	ShadowRate float64
+	// Objective is the service level objective of the method as per the
+	// (api.slo) option, nil if not annotated
+	Objective *Objective
+	// DebugOnly marks the method served by the routes only when the
+	// debug endpoints are enabled, as per the (api.debug_only) option
+	DebugOnly bool
+	// Watch describes the events streamed by the server streaming
+	// methods with the watch verb, nil for the other methods
+	Watch *Watch
+}
+
+// Immutable describes the fields of the resource, carried as the body of
+// the update method, which can not be changed once created
+type Immutable struct {
+	// BodyField is the field of the request carrying the resource, nil
+	// when the body is the request itself
+	BodyField *Field
+	// Resource is the message type of the resource
+	Resource *Message
+	// Fields are the fields of the resource marked using the
+	// (api.immutable) option
+	Fields []*Field
+}
+
+// Watch describes the events streamed by a watch method, decoded by the
+// SDK as the typed events of the object
+type Watch struct {
+	// TypeField is the string or enum field of the event carrying the
+	// type of the change like ADDED, MODIFIED or DELETED
+	TypeField *Field
+	// ObjectField is the message field of the event carrying the object
+	ObjectField *Field
+	// Object is the message type of the object
+	Object *Message
+}
+
+// RetryPolicy is the retry policy of the method applied by the SDK
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a call
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between the retries, unbounded if zero
+	MaxBackoff time.Duration
+	// StatusCodes are the status codes of the responses to be retried
+	StatusCodes []int32
+}
+
+// Objective is the service level objective of the method
+type Objective struct {
+	// Latency is the target latency of the calls, zero if unset
+	Latency time.Duration
+	// LatencyPercentile is the percentile of the calls meeting the
+	// target latency, like 99 for the p99 latency
+	LatencyPercentile float64
+	// Availability is the target percentage of the successful calls,
+	// zero if unset
+	Availability float64
+}
+
+// RetrySafety classifies whether the method is safe to be retried
+type RetrySafety int
+
+const (
+	// RetryUnclassified leaves the decision to the HTTP method
+	RetryUnclassified RetrySafety = iota
+	// RetrySafe marks the method safe to be retried
+	RetrySafe
+	// RetryUnsafe marks the method unsafe to be retried
+	RetryUnsafe
+)
+
+// Safe reports whether the method is classified safe to be retried
+func (s RetrySafety) Safe() bool {
+	return s == RetrySafe
+}
+
+func (s RetrySafety) String() string {
+	switch s {
+	case RetrySafe:
+		return "safe"
+	case RetryUnsafe:
+		return "unsafe"
+	}
+	return ""
+}
+
+// FieldDefault is the default value of a request field as per the
+// (api.default) option
+type FieldDefault struct {
+	// Field the default value applies to
+	Field *Field
+	// Value in the same format as when provided as a query param
+	Value string
+}
+
+// IdentityField is a request field bound to the identity of the
+// authenticated principal as per the (api.from_identity) option
+type IdentityField struct {
+	// Field bound to the identity
+	Field *Field
+	// Identity is the name of the identity, like SUBJECT
+	Identity string
 }
 
 // FQMN returns a fully qualified rpc method name of this method.
@@ -260,6 +446,35 @@ func (b *Binding) ExplicitParams() []string {
 	return result
 }
 
+// HTTPBodyType is the fully qualified name of google.api.HttpBody, the
+// message carrying the raw bytes of the body along with its content type
+const HTTPBodyType = ".google.api.HttpBody"
+
+// IsHTTPBody reports whether the request body of "b" is mapped to the
+// google.api.HttpBody, sent as the raw bytes with the declared content
+// type instead of JSON
+func (b *Binding) IsHTTPBody() bool {
+	if b.Body == nil {
+		return false
+	}
+	if len(b.Body.FieldPath) == 0 {
+		return b.Method.RequestType.FQMN() == HTTPBodyType
+	}
+	f := b.Body.FieldPath[len(b.Body.FieldPath)-1].Target
+	return f.GetTypeName() == HTTPBodyType && f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+}
+
+// IsHTTPBodyResponse reports whether the response body of "b" is the
+// google.api.HttpBody, received as the raw bytes with the declared
+// content type instead of JSON
+func (b *Binding) IsHTTPBodyResponse() bool {
+	if b.ResponseBody == nil || len(b.ResponseBody.FieldPath) == 0 {
+		return b.Method.ResponseType.FQMN() == HTTPBodyType
+	}
+	f := b.ResponseBody.FieldPath[len(b.ResponseBody.FieldPath)-1].Target
+	return f.GetTypeName() == HTTPBodyType && f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+}
+
 // Field wraps descriptorpb.FieldDescriptorProto for richer features.
 type Field struct {
 	*descriptorpb.FieldDescriptorProto
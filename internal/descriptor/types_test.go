@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -267,3 +268,57 @@ func TestGoType(t *testing.T) {
 	}
 
 }
+
+func TestBindingIsHTTPBody(t *testing.T) {
+	field := func(typeName string, label descriptorpb.FieldDescriptorProto_Label) FieldPath {
+		return FieldPath{{Name: "body", Target: &Field{FieldDescriptorProto: &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String("body"),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(typeName),
+			Label:    label.Enum(),
+		}}}}
+	}
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: proto.String("google.api")}}
+	meth := &Method{RequestType: &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: proto.String("HttpBody")}}}
+	for _, spec := range []struct {
+		body *Body
+		want bool
+	}{
+		{body: nil},
+		{body: &Body{}, want: true},
+		{body: &Body{FieldPath: field(HTTPBodyType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL)}, want: true},
+		{body: &Body{FieldPath: field(HTTPBodyType, descriptorpb.FieldDescriptorProto_LABEL_REPEATED)}},
+		{body: &Body{FieldPath: field(".example.Object", descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL)}},
+	} {
+		b := &Binding{Method: meth, Body: spec.body}
+		if got := b.IsHTTPBody(); got != spec.want {
+			t.Errorf("IsHTTPBody() with body %v = %v; want %v", spec.body, got, spec.want)
+		}
+	}
+}
+
+func TestBindingIsHTTPBodyResponse(t *testing.T) {
+	field := func(typeName string) FieldPath {
+		return FieldPath{{Name: "content", Target: &Field{FieldDescriptorProto: &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String("content"),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(typeName),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}}}}
+	}
+	file := &File{FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: proto.String("example")}}
+	meth := &Method{ResponseType: &Message{File: file, DescriptorProto: &descriptorpb.DescriptorProto{Name: proto.String("Attachment")}}}
+	for _, spec := range []struct {
+		body *Body
+		want bool
+	}{
+		{body: nil},
+		{body: &Body{FieldPath: field(HTTPBodyType)}, want: true},
+		{body: &Body{FieldPath: field(".example.Object")}},
+	} {
+		b := &Binding{Method: meth, ResponseBody: spec.body}
+		if got := b.IsHTTPBodyResponse(); got != spec.want {
+			t.Errorf("IsHTTPBodyResponse() with body %v = %v; want %v", spec.body, got, spec.want)
+		}
+	}
+}
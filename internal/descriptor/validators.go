@@ -0,0 +1,73 @@
+package descriptor
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Validator is a custom check run over the services, the methods and
+// the fields of the messages of the files being generated, while the
+// registry is loaded, enabling the rules beyond the built in checks.
+// Checks receive the protogen descriptors, providing access to both
+// the options and the comments, any of them may be nil.
+type Validator struct {
+	// Name of the rule, reported along with the failures
+	Name    string
+	Service func(*protogen.Service) error
+	Method  func(*protogen.Method) error
+	Field   func(*protogen.Field) error
+}
+
+// AddValidator adds the validator run while loading the files
+func (r *Registry) AddValidator(v Validator) {
+	r.validators = append(r.validators, v)
+}
+
+// runValidators runs the validators over the file, reporting all the
+// failures at once, each prefixed with the file, the element failing
+// the check and the name of the rule
+func (r *Registry) runValidators(file *protogen.File) error {
+	if len(r.validators) == 0 {
+		return nil
+	}
+	var errs []error
+	check := func(name string, v Validator, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %s: %w", file.Desc.Path(), name, v.Name, err))
+		}
+	}
+	var checkFields func(msgs []*protogen.Message)
+	checkFields = func(msgs []*protogen.Message) {
+		for _, msg := range msgs {
+			if msg.Desc.IsMapEntry() {
+				continue
+			}
+			for _, f := range msg.Fields {
+				for _, v := range r.validators {
+					if v.Field != nil {
+						check(string(f.Desc.FullName()), v, v.Field(f))
+					}
+				}
+			}
+			checkFields(msg.Messages)
+		}
+	}
+	for _, svc := range file.Services {
+		for _, v := range r.validators {
+			if v.Service != nil {
+				check(string(svc.Desc.FullName()), v, v.Service(svc))
+			}
+		}
+		for _, m := range svc.Methods {
+			for _, v := range r.validators {
+				if v.Method != nil {
+					check(string(m.Desc.FullName()), v, v.Method(m))
+				}
+			}
+		}
+	}
+	checkFields(file.Messages)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package descriptor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestRegistryValidators(t *testing.T) {
+	src := `
+		name: 'path/to/example.proto'
+		package: 'example'
+		options < go_package: 'example.com/example' >
+		message_type <
+			name: 'Item'
+			field < name: 'id' number: 1 label: LABEL_OPTIONAL type: TYPE_STRING >
+			field < name: 'Desc' number: 2 label: LABEL_OPTIONAL type: TYPE_STRING >
+			nested_type <
+				name: 'Label'
+				field < name: 'Value' number: 1 label: LABEL_OPTIONAL type: TYPE_STRING >
+			>
+		>
+		service <
+			name: 'ItemService'
+			method < name: 'GetItem' input_type: '.example.Item' output_type: '.example.Item' >
+			method < name: 'ListItems' input_type: '.example.Item' output_type: '.example.Item' >
+		>
+		source_code_info <
+			location < path: [6, 0, 2, 0] span: [1, 1, 1] leading_comments: ' Gets the item\n' >
+		>
+	`
+	var services, methods []string
+	reg := NewRegistry()
+	reg.AddValidator(Validator{
+		Name: "lower-case-fields",
+		Field: func(f *protogen.Field) error {
+			if name := string(f.Desc.Name()); name != strings.ToLower(name) {
+				return fmt.Errorf("field %s is not lower case", name)
+			}
+			return nil
+		},
+	})
+	reg.AddValidator(Validator{
+		Name: "method-comments",
+		Service: func(svc *protogen.Service) error {
+			services = append(services, string(svc.Desc.Name()))
+			return nil
+		},
+		Method: func(m *protogen.Method) error {
+			methods = append(methods, string(m.Desc.Name()))
+			if m.Comments.Leading == "" {
+				return errors.New("method is not documented")
+			}
+			return nil
+		},
+	})
+
+	plugin, err := newGeneratorFromSources(&pluginpb.CodeGeneratorRequest{}, src)
+	if err != nil {
+		t.Fatalf("failed to create a generator: %v", err)
+	}
+	err = reg.LoadFromPlugin(plugin)
+	want := []string{
+		"path/to/example.proto: example.ItemService.ListItems: method-comments: method is not documented",
+		"path/to/example.proto: example.Item.Desc: lower-case-fields: field Desc is not lower case",
+		"path/to/example.proto: example.Item.Label.Value: lower-case-fields: field Value is not lower case",
+	}
+	if err == nil || err.Error() != strings.Join(want, "\n") {
+		t.Errorf("LoadFromPlugin() = %v; want\n%s", err, strings.Join(want, "\n"))
+	}
+	if fmt.Sprint(services, methods) != "[ItemService] [GetItem ListItems]" {
+		t.Errorf("validated services %v, methods %v; want [ItemService], [GetItem ListItems]", services, methods)
+	}
+}
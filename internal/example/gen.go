@@ -1,3 +1,3 @@
 package example
 
-//go:generate protoc -I . -I ../../ -I ../third_party --go_out=. --go_opt=paths=source_relative --sdk_out . --sdk_opt paths=source_relative --routes_out . --routes_opt paths=source_relative test.proto
+//go:generate protoc -I . -I ../../ -I ../third_party --go_out=. --go_opt=paths=source_relative --sdk_out . --sdk_opt paths=source_relative,batched_list_decoding=true,bidi_websocket=true,long_poll_fallback=true,race_tests=true,request_tracing=true,mocks=true,fake_server=true,fake_server_cmd=true,examples=true,grpc_fallback=true,wire_compat_tests=true --routes_out . --routes_opt paths=source_relative,strict_query=true,router=http test.proto
@@ -12,8 +12,10 @@ package example
 import (
 	_ "github.com/go-core-stack/grpc-core/coreapis/api"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -26,6 +28,109 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// State of the object
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED State = 0
+	State_STATE_ACTIVE      State = 1
+	State_STATE_DELETED     State = 2
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_ACTIVE",
+		2: "STATE_DELETED",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_ACTIVE":      1,
+		"STATE_DELETED":     2,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[0].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[0]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
+// Type of the change to the object
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_TYPE_ADDED       EventType = 1
+	EventType_EVENT_TYPE_MODIFIED    EventType = 2
+	EventType_EVENT_TYPE_DELETED     EventType = 3
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_ADDED",
+		2: "EVENT_TYPE_MODIFIED",
+		3: "EVENT_TYPE_DELETED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_ADDED":       1,
+		"EVENT_TYPE_MODIFIED":    2,
+		"EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[1].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[1]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
 type PostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// name of the object
@@ -89,19 +194,251 @@ func (x *PostRequest) GetTest() bool {
 	return false
 }
 
-type PostResponse struct {
+type UpdateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// name of the object
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// object to update, sent as the request body
+	Object *PostResponse `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// validate the update without applying it
+	ValidateOnly  bool `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetObject() *PostResponse {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type CredentialsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the object
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// password to access the object, sealed by the SDK and redacted
+	// from the logs
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// principal updating the credentials, populated by the routes
+	UpdatedBy     string `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialsRequest) Reset() {
+	*x = CredentialsRequest{}
+	mi := &file_test_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialsRequest) ProtoMessage() {}
+
+func (x *CredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialsRequest.ProtoReflect.Descriptor instead.
+func (*CredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CredentialsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CredentialsRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CredentialsRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type UploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the object
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// attachment sent as is along with its content type
+	Attachment    *httpbody.HttpBody `protobuf:"bytes,2,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_test_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadRequest) GetAttachment() *httpbody.HttpBody {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type AttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// attachment returned as is along with its content type
+	Attachment    *httpbody.HttpBody `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentResponse) Reset() {
+	*x = AttachmentResponse{}
+	mi := &file_test_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentResponse) ProtoMessage() {}
+
+func (x *AttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentResponse.ProtoReflect.Descriptor instead.
+func (*AttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AttachmentResponse) GetAttachment() *httpbody.HttpBody {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type PostResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the object, can not be changed once created
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// description of the object
-	Desc          string `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	Desc string `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	// version of the object, updates are conditional on it
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// state of the object
+	State State `protobuf:"varint,4,opt,name=state,proto3,enum=example.State" json:"state,omitempty"`
+	// creation time of the object, set by the server alone
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostResponse) Reset() {
 	*x = PostResponse{}
-	mi := &file_test_proto_msgTypes[1]
+	mi := &file_test_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -113,7 +450,7 @@ func (x *PostResponse) String() string {
 func (*PostResponse) ProtoMessage() {}
 
 func (x *PostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_test_proto_msgTypes[1]
+	mi := &file_test_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,7 +463,7 @@ func (x *PostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostResponse.ProtoReflect.Descriptor instead.
 func (*PostResponse) Descriptor() ([]byte, []int) {
-	return file_test_proto_rawDescGZIP(), []int{1}
+	return file_test_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PostResponse) GetName() string {
@@ -143,27 +480,318 @@ func (x *PostResponse) GetDesc() string {
 	return ""
 }
 
+func (x *PostResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *PostResponse) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *PostResponse) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// maximum number of objects to return
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// locale to describe the objects in, defaults to the one
+	// preferred by the client
+	Locale string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	// state of the objects to return, all if unspecified
+	State State `protobuf:"varint,3,opt,name=state,proto3,enum=example.State" json:"state,omitempty"`
+	// return only the objects modified after the given time
+	ModifiedAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_after,json=modifiedAfter,proto3" json:"modified_after,omitempty"`
+	// token of the page to return, the next_page_token of the previous
+	// page, the first page if empty
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_test_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListRequest) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *ListRequest) GetModifiedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAfter
+	}
+	return nil
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// list of objects
+	Items []*PostResponse `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// total number of objects available
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// token of the next page, empty for the last page
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_test_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListResponse) GetItems() []*PostResponse {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ObjectEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type of the change
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=example.EventType" json:"type,omitempty"`
+	// object after the change, or the last state if deleted
+	Object        *PostResponse `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectEvent) Reset() {
+	*x = ObjectEvent{}
+	mi := &file_test_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectEvent) ProtoMessage() {}
+
+func (x *ObjectEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectEvent.ProtoReflect.Descriptor instead.
+func (*ObjectEvent) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ObjectEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ObjectEvent) GetObject() *PostResponse {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
 var File_test_proto protoreflect.FileDescriptor
 
 const file_test_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"test.proto\x12\aexample\x1a\x17coreapis/api/role.proto\x1a\x1cgoogle/api/annotations.proto\"W\n" +
-	"\vPostRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"test.proto\x12\aexample\x1a\x1acoreapis/api/options.proto\x1a\x17coreapis/api/role.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"]\n" +
+	"\vPostRequest\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\x98\xb5\x18\x01R\x04name\x12\x12\n" +
 	"\x04desc\x18\x02 \x01(\tR\x04desc\x12\x17\n" +
 	"\x04test\x18\x03 \x01(\bH\x00R\x04test\x88\x01\x01B\a\n" +
-	"\x05_test\"6\n" +
-	"\fPostResponse\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04desc\x18\x02 \x01(\tR\x04desc2\xf3\x01\n" +
+	"\x05_test\"}\n" +
+	"\rUpdateRequest\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\x98\xb5\x18\x01R\x04name\x12-\n" +
+	"\x06object\x18\x02 \x01(\v2\x15.example.PostResponseR\x06object\x12#\n" +
+	"\rvalidate_only\x18\x03 \x01(\bR\fvalidateOnly\"y\n" +
+	"\x12CredentialsRequest\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\x98\xb5\x18\x01R\x04name\x12$\n" +
+	"\bpassword\x18\x02 \x01(\tB\b\xa0\xb5\x18\x01\xa8\xb5\x18\x01R\bpassword\x12#\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tB\x04\xb8\xb5\x18\x01R\tupdatedBy\"_\n" +
+	"\rUploadRequest\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\x98\xb5\x18\x01R\x04name\x124\n" +
+	"\n" +
+	"attachment\x18\x02 \x01(\v2\x14.google.api.HttpBodyR\n" +
+	"attachment\"J\n" +
+	"\x12AttachmentResponse\x124\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x14.google.api.HttpBodyR\n" +
+	"attachment\"\xbf\x01\n" +
+	"\fPostResponse\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\xc0\xb5\x18\x01R\x04name\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\tR\x04desc\x12\x18\n" +
+	"\x04etag\x18\x03 \x01(\tB\x04\xb0\xb5\x18\x01R\x04etag\x12$\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x0e.example.StateR\x05state\x12A\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x04ȵ\x18\x01R\n" +
+	"createTime\"\xd1\x01\n" +
+	"\vListRequest\x12\x1c\n" +
+	"\x05limit\x18\x01 \x01(\x05B\x06\x92\xb5\x18\x0250R\x05limit\x12\x1c\n" +
+	"\x06locale\x18\x02 \x01(\tB\x04\x88\xb5\x18\x01R\x06locale\x12$\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x0e.example.StateR\x05state\x12A\n" +
+	"\x0emodified_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rmodifiedAfter\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"y\n" +
+	"\fListResponse\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.example.PostResponseR\x05items\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"d\n" +
+	"\vObjectEvent\x12&\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x12.example.EventTypeR\x04type\x12-\n" +
+	"\x06object\x18\x02 \x01(\v2\x15.example.PostResponseR\x06object*C\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fSTATE_ACTIVE\x10\x01\x12\x11\n" +
+	"\rSTATE_DELETED\x10\x02*n\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10EVENT_TYPE_ADDED\x10\x01\x12\x17\n" +
+	"\x13EVENT_TYPE_MODIFIED\x10\x02\x12\x16\n" +
+	"\x12EVENT_TYPE_DELETED\x10\x032\xee\x0e\n" +
 	"\n" +
-	"HelloWorld\x12u\n" +
+	"HelloWorld\x12\x8c\x01\n" +
 	"\n" +
-	"PostObject\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\":\x8a\xb5\x18\x1a\n" +
-	"\x06object\x12\x03abc\x12\x03def\x1a\x06create\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/object/{name}\x12n\n" +
-	"\tGetObject\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\"4\x8a\xb5\x18\x17\n" +
-	"\x06object\x12\x03abc\x12\x03def\x1a\x03get\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/object/{name}B6Z4github.com/Prabhjot-Sethi/grpc-core/internal/exampleb\x06proto3"
+	"PostObject\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\"Q\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06create\x92\xb5\x18\x02\x99\x03\xaa\xb5\x18\r\b\x03\x12\x05200ms\"\x02\xf7\x03\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/object/{name}\x12\xad\x01\n" +
+	"\tGetObject\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\"s\x8a\xb5\x18\x17\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x03get\x98\xb5\x18\x01\xe9\xb5\x18\x9a\x99\x99\x99\x99\x99\xa9?\xf2\xb5\x18\x10\n" +
+	"\x05200ms\x19\x9a\x99\x99\x99\x99\xf9X@\x82\xd3\xe4\x93\x02/Z\x1a\x12\x18/v1/legacy/object/{name}\x12\x11/v1/object/{name}\x12\xc0\x01\n" +
+	"\vListObjects\x12\x14.example.ListRequest\x1a\x15.example.ListResponse\"\x83\x01\x8a\xb5\x18\x18\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x04list\xaa\xb5\x18\x13\b\x04\x12\x05100ms\x1a\x022s\"\x04\xf6\x03\xf7\x03\xb0\xb5\x18\n" +
+	"\xba\xb5\x18\alistingʵ\x18\x0330sٵ\x18{\x14\xaeG\xe1z\x84?\x82\xd3\xe4\x93\x02)Z\x1ab\x05items\x12\x11/v1/objects:items\x12\v/v1/objects\x12v\n" +
+	"\rStreamObjects\x12\x14.example.ListRequest\x1a\x15.example.PostResponse\"6\x8a\xb5\x18\x18\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x04list\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/objects:stream0\x01\x12\x81\x01\n" +
+	"\fWatchObjects\x12\x14.example.ListRequest\x1a\x14.example.ObjectEvent\"C\x8a\xb5\x18\x19\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x05watch\xf2\xb5\x18\t\x19\x00\x00\x00\x00\x00\xe0X@\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/objects:watch0\x01\x12\x80\x01\n" +
+	"\rCreateObjects\x12\x14.example.PostRequest\x1a\x15.example.ListResponse\"@\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06create\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/objects:batchCreate(\x01\x12y\n" +
+	"\vSyncObjects\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\"9\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06update\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/objects:sync(\x010\x01\x12\xb4\x01\n" +
+	"\fUpdateObject\x12\x16.example.UpdateRequest\x1a\x15.example.PostResponse\"u\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06updateµ\x18\x14HelloWorld_GetObjectµ\x18\x16HelloWorld_ListObjectsе\x18\x01\x82\xd3\xe4\x93\x02\x1b:\x06object\x1a\x11/v1/object/{name}\x12\x8f\x01\n" +
+	"\x0eSetCredentials\x12\x1b.example.CredentialsRequest\x1a\x15.example.PostResponse\"I\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06update\x82\xd3\xe4\x93\x02%:\x01*\" /v1/object/{name}:setCredentials\x12\x91\x01\n" +
+	"\x10UploadAttachment\x12\x16.example.UploadRequest\x1a\x15.example.PostResponse\"N\x8a\xb5\x18\x1a\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x06update\x82\xd3\xe4\x93\x02*:\n" +
+	"attachment\x1a\x1c/v1/object/{name}/attachment\x12\xab\x01\n" +
+	"\x12DownloadAttachment\x12\x14.example.PostRequest\x1a\x1b.example.AttachmentResponse\"b\x8a\xb5\x18\x17\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x03get\xe2\xb5\x18\x13attachment-download\x82\xd3\xe4\x93\x02*b\n" +
+	"attachment\x12\x1c/v1/object/{name}/attachment\x12\x80\x01\n" +
+	"\vWatchObject\x12\x14.example.PostRequest\x1a\x15.example.PostResponse\"D\x8a\xb5\x18\x17\n" +
+	"\x06object\x12\x03abc\x12\x03def\x1a\x03get\xa0\xb5\x18\x01ʵ\x18\x021m\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/object/{name}:watch\x12V\n" +
+	"\vDumpObjects\x12\x14.example.ListRequest\x1a\x15.example.ListResponse\"\x1a\xf8\xb5\x18\x01\x82\xd3\xe4\x93\x02\x10\x12\x0e/debug/objectsB=\x8a\xb5\x18\x04demoZ3github.com/go-core-stack/grpc-core/internal/exampleb\x06proto3"
 
 var (
 	file_test_proto_rawDescOnce sync.Once
@@ -177,21 +805,65 @@ func file_test_proto_rawDescGZIP() []byte {
 	return file_test_proto_rawDescData
 }
 
-var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_test_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_test_proto_goTypes = []any{
-	(*PostRequest)(nil),  // 0: example.PostRequest
-	(*PostResponse)(nil), // 1: example.PostResponse
+	(State)(0),                    // 0: example.State
+	(EventType)(0),                // 1: example.EventType
+	(*PostRequest)(nil),           // 2: example.PostRequest
+	(*UpdateRequest)(nil),         // 3: example.UpdateRequest
+	(*CredentialsRequest)(nil),    // 4: example.CredentialsRequest
+	(*UploadRequest)(nil),         // 5: example.UploadRequest
+	(*AttachmentResponse)(nil),    // 6: example.AttachmentResponse
+	(*PostResponse)(nil),          // 7: example.PostResponse
+	(*ListRequest)(nil),           // 8: example.ListRequest
+	(*ListResponse)(nil),          // 9: example.ListResponse
+	(*ObjectEvent)(nil),           // 10: example.ObjectEvent
+	(*httpbody.HttpBody)(nil),     // 11: google.api.HttpBody
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
 }
 var file_test_proto_depIdxs = []int32{
-	0, // 0: example.HelloWorld.PostObject:input_type -> example.PostRequest
-	0, // 1: example.HelloWorld.GetObject:input_type -> example.PostRequest
-	1, // 2: example.HelloWorld.PostObject:output_type -> example.PostResponse
-	1, // 3: example.HelloWorld.GetObject:output_type -> example.PostResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	7,  // 0: example.UpdateRequest.object:type_name -> example.PostResponse
+	11, // 1: example.UploadRequest.attachment:type_name -> google.api.HttpBody
+	11, // 2: example.AttachmentResponse.attachment:type_name -> google.api.HttpBody
+	0,  // 3: example.PostResponse.state:type_name -> example.State
+	12, // 4: example.PostResponse.create_time:type_name -> google.protobuf.Timestamp
+	0,  // 5: example.ListRequest.state:type_name -> example.State
+	12, // 6: example.ListRequest.modified_after:type_name -> google.protobuf.Timestamp
+	7,  // 7: example.ListResponse.items:type_name -> example.PostResponse
+	1,  // 8: example.ObjectEvent.type:type_name -> example.EventType
+	7,  // 9: example.ObjectEvent.object:type_name -> example.PostResponse
+	2,  // 10: example.HelloWorld.PostObject:input_type -> example.PostRequest
+	2,  // 11: example.HelloWorld.GetObject:input_type -> example.PostRequest
+	8,  // 12: example.HelloWorld.ListObjects:input_type -> example.ListRequest
+	8,  // 13: example.HelloWorld.StreamObjects:input_type -> example.ListRequest
+	8,  // 14: example.HelloWorld.WatchObjects:input_type -> example.ListRequest
+	2,  // 15: example.HelloWorld.CreateObjects:input_type -> example.PostRequest
+	2,  // 16: example.HelloWorld.SyncObjects:input_type -> example.PostRequest
+	3,  // 17: example.HelloWorld.UpdateObject:input_type -> example.UpdateRequest
+	4,  // 18: example.HelloWorld.SetCredentials:input_type -> example.CredentialsRequest
+	5,  // 19: example.HelloWorld.UploadAttachment:input_type -> example.UploadRequest
+	2,  // 20: example.HelloWorld.DownloadAttachment:input_type -> example.PostRequest
+	2,  // 21: example.HelloWorld.WatchObject:input_type -> example.PostRequest
+	8,  // 22: example.HelloWorld.DumpObjects:input_type -> example.ListRequest
+	7,  // 23: example.HelloWorld.PostObject:output_type -> example.PostResponse
+	7,  // 24: example.HelloWorld.GetObject:output_type -> example.PostResponse
+	9,  // 25: example.HelloWorld.ListObjects:output_type -> example.ListResponse
+	7,  // 26: example.HelloWorld.StreamObjects:output_type -> example.PostResponse
+	10, // 27: example.HelloWorld.WatchObjects:output_type -> example.ObjectEvent
+	9,  // 28: example.HelloWorld.CreateObjects:output_type -> example.ListResponse
+	7,  // 29: example.HelloWorld.SyncObjects:output_type -> example.PostResponse
+	7,  // 30: example.HelloWorld.UpdateObject:output_type -> example.PostResponse
+	7,  // 31: example.HelloWorld.SetCredentials:output_type -> example.PostResponse
+	7,  // 32: example.HelloWorld.UploadAttachment:output_type -> example.PostResponse
+	6,  // 33: example.HelloWorld.DownloadAttachment:output_type -> example.AttachmentResponse
+	7,  // 34: example.HelloWorld.WatchObject:output_type -> example.PostResponse
+	9,  // 35: example.HelloWorld.DumpObjects:output_type -> example.ListResponse
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_test_proto_init() }
@@ -205,13 +877,14 @@ func file_test_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_test_proto_goTypes,
 		DependencyIndexes: file_test_proto_depIdxs,
+		EnumInfos:         file_test_proto_enumTypes,
 		MessageInfos:      file_test_proto_msgTypes,
 	}.Build()
 	File_test_proto = out.File
@@ -3,7 +3,34 @@
 
 package example
 
-import "github.com/go-core-stack/auth/model"
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-core-stack/auth/model"
+	"github.com/go-core-stack/grpc-core/envelope"
+	"github.com/go-core-stack/grpc-core/routes"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Suppress "imported and not used" errors
+var (
+	_ codes.Code
+	_ io.Reader
+	_ status.Status
+	_ = errors.New
+	_ = runtime.String
+	_ = utilities.NewDoubleArray
+	_ = metadata.Join
+)
 
 var RoutesHelloWorld = []*model.Route{}
 
@@ -25,4 +52,1129 @@ func init() {
 	route.Scopes = append(route.Scopes, "def")
 	route.Verb = "get"
 	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for GetObject RPC
+	route = model.NewRoute("/v1/legacy/object/{name}", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "get"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for ListObjects RPC
+	route = model.NewRoute("/v1/objects", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "list"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for ListObjects RPC
+	route = model.NewRoute("/v1/objects:items", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "list"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for StreamObjects RPC
+	route = model.NewRoute("/v1/objects:stream", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "list"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for WatchObjects RPC
+	route = model.NewRoute("/v1/objects:watch", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "watch"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for CreateObjects RPC
+	route = model.NewRoute("/v1/objects:batchCreate", "POST")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "create"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for SyncObjects RPC
+	route = model.NewRoute("/v1/objects:sync", "POST")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "update"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for UpdateObject RPC
+	route = model.NewRoute("/v1/object/{name}", "PUT")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "update"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for SetCredentials RPC
+	route = model.NewRoute("/v1/object/{name}:setCredentials", "POST")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "update"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for UploadAttachment RPC
+	route = model.NewRoute("/v1/object/{name}/attachment", "PUT")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "update"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for DownloadAttachment RPC
+	route = model.NewRoute("/v1/object/{name}/attachment", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "get"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for WatchObject RPC
+	route = model.NewRoute("/v1/object/{name}:watch", "GET")
+	route.Resource = "object"
+	route.Scopes = append(route.Scopes, "abc")
+	route.Scopes = append(route.Scopes, "def")
+	route.Verb = "get"
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+
+	// Adding Route information for DumpObjects RPC
+	route = model.NewRoute("/debug/objects", "GET")
+	RoutesHelloWorld = append(RoutesHelloWorld, route)
+}
+
+// AdmitHelloWorldRoutes records the routes of HelloWorld service
+// as expected on the tracker, allowing the server to verify that a
+// handler is registered for every one of them before serving
+func AdmitHelloWorldRoutes(t *routes.Tracker) {
+	t.Expect(".example.HelloWorld.PostObject", "POST", "/v1/object/{name}")
+	t.Expect(".example.HelloWorld.GetObject", "GET", "/v1/object/{name}")
+	t.Expect(".example.HelloWorld.GetObject", "GET", "/v1/legacy/object/{name}")
+	t.Expect(".example.HelloWorld.ListObjects", "GET", "/v1/objects")
+	t.Expect(".example.HelloWorld.ListObjects", "GET", "/v1/objects:items")
+	t.Expect(".example.HelloWorld.StreamObjects", "GET", "/v1/objects:stream")
+	t.Expect(".example.HelloWorld.WatchObjects", "GET", "/v1/objects:watch")
+	t.Expect(".example.HelloWorld.CreateObjects", "POST", "/v1/objects:batchCreate")
+	t.Expect(".example.HelloWorld.SyncObjects", "POST", "/v1/objects:sync")
+	t.Expect(".example.HelloWorld.UpdateObject", "PUT", "/v1/object/{name}")
+	t.Expect(".example.HelloWorld.SetCredentials", "POST", "/v1/object/{name}:setCredentials")
+	t.Expect(".example.HelloWorld.UploadAttachment", "PUT", "/v1/object/{name}/attachment")
+	t.Expect(".example.HelloWorld.DownloadAttachment", "GET", "/v1/object/{name}/attachment")
+	t.Expect(".example.HelloWorld.WatchObject", "GET", "/v1/object/{name}:watch")
+	if routes.Debug(t) != nil {
+		t.Expect(".example.HelloWorld.DumpObjects", "GET", "/debug/objects")
+	}
+}
+
+// DescribeHelloWorldRoutes returns the routes of HelloWorld service,
+// along with the rpc methods serving them and their roles, for the server
+// to register the routes dynamically, feed the API gateways or print the
+// route table at startup using routes.WriteRouteTable
+func DescribeHelloWorldRoutes() []routes.RouteInfo {
+	return []routes.RouteInfo{
+		{
+			Route: routes.Route{Method: "POST", Path: "/v1/object/{name}"},
+			FQMN:  ".example.HelloWorld.PostObject",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "create",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/object/{name}"},
+			FQMN:  ".example.HelloWorld.GetObject",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "get",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/legacy/object/{name}"},
+			FQMN:  ".example.HelloWorld.GetObject",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "get",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/objects"},
+			FQMN:  ".example.HelloWorld.ListObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "list",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/objects:items"},
+			FQMN:  ".example.HelloWorld.ListObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "list",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/objects:stream"},
+			FQMN:  ".example.HelloWorld.StreamObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "list",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/objects:watch"},
+			FQMN:  ".example.HelloWorld.WatchObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "watch",
+			},
+		},
+		{
+			Route: routes.Route{Method: "POST", Path: "/v1/objects:batchCreate"},
+			FQMN:  ".example.HelloWorld.CreateObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "create",
+			},
+		},
+		{
+			Route: routes.Route{Method: "POST", Path: "/v1/objects:sync"},
+			FQMN:  ".example.HelloWorld.SyncObjects",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "update",
+			},
+		},
+		{
+			Route: routes.Route{Method: "PUT", Path: "/v1/object/{name}"},
+			FQMN:  ".example.HelloWorld.UpdateObject",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "update",
+			},
+		},
+		{
+			Route: routes.Route{Method: "POST", Path: "/v1/object/{name}:setCredentials"},
+			FQMN:  ".example.HelloWorld.SetCredentials",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "update",
+			},
+		},
+		{
+			Route: routes.Route{Method: "PUT", Path: "/v1/object/{name}/attachment"},
+			FQMN:  ".example.HelloWorld.UploadAttachment",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "update",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/object/{name}/attachment"},
+			FQMN:  ".example.HelloWorld.DownloadAttachment",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "get",
+			},
+		},
+		{
+			Route: routes.Route{Method: "GET", Path: "/v1/object/{name}:watch"},
+			FQMN:  ".example.HelloWorld.WatchObject",
+			Role: &routes.RouteRole{
+				Resource: "object",
+				Scopes:   []string{"abc", "def"},
+				Verb:     "get",
+			},
+		},
+		{
+			Route:     routes.Route{Method: "GET", Path: "/debug/objects"},
+			FQMN:      ".example.HelloWorld.DumpObjects",
+			DebugOnly: true,
+		},
+	}
+}
+
+// route_log_HelloWorld_PostObject_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_PostObject_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/PostObject",
+}
+
+func route_request_HelloWorld_PostObject_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq PostRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := routes.Decompress(req); err != nil {
+		return nil, metadata, err
+	}
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, routes.DecodeError(err)
+	}
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := routes.CheckQueryParams(nil, req.URL.Query(), nil); err != nil {
+		return nil, metadata, err
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.PostObject(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_GetObject_0 = &utilities.DoubleArray{Encoding: map[string]int{"name": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
+// route_log_HelloWorld_GetObject_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_GetObject_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/GetObject",
+}
+
+func route_request_HelloWorld_GetObject_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq PostRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_GetObject_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_GetObject_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.GetObject(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_GetObject_1 = &utilities.DoubleArray{Encoding: map[string]int{"name": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
+// route_log_HelloWorld_GetObject_1 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_GetObject_1 = &routes.LogRoute{
+	Method: "/example.HelloWorld/GetObject",
+}
+
+func route_request_HelloWorld_GetObject_1(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq PostRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_GetObject_1); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_GetObject_1); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.GetObject(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_ListObjects_0 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
+
+var route_defaults_HelloWorld_ListObjects_0 = []routes.Default{
+	{Field: "limit", Value: "50"},
+}
+
+// route_log_HelloWorld_ListObjects_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_ListObjects_0 = &routes.LogRoute{
+	Method:     "/example.HelloWorld/ListObjects",
+	SampleRate: proto.Float64(0.01),
+}
+
+func route_request_HelloWorld_ListObjects_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq ListRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_ListObjects_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_ListObjects_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.PopulateDefaults(&protoReq, route_defaults_HelloWorld_ListObjects_0); err != nil {
+		return nil, metadata, status.Errorf(codes.Internal, "%v", err)
+	}
+	ctx = routes.NewLocaleContext(ctx, req)
+	if protoReq.Locale == "" {
+		protoReq.Locale = routes.Locale(ctx)
+	}
+	msg, err := server.ListObjects(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_ListObjects_1 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
+
+var route_defaults_HelloWorld_ListObjects_1 = []routes.Default{
+	{Field: "limit", Value: "50"},
+}
+
+// route_log_HelloWorld_ListObjects_1 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_ListObjects_1 = &routes.LogRoute{
+	Method:     "/example.HelloWorld/ListObjects",
+	SampleRate: proto.Float64(0.01),
+}
+
+func route_request_HelloWorld_ListObjects_1(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq ListRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_ListObjects_1); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_ListObjects_1); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.PopulateDefaults(&protoReq, route_defaults_HelloWorld_ListObjects_1); err != nil {
+		return nil, metadata, status.Errorf(codes.Internal, "%v", err)
+	}
+	ctx = routes.NewLocaleContext(ctx, req)
+	if protoReq.Locale == "" {
+		protoReq.Locale = routes.Locale(ctx)
+	}
+	msg, err := server.ListObjects(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_UpdateObject_0 = &utilities.DoubleArray{Encoding: map[string]int{"object": 0, "name": 1}, Base: []int{1, 1, 2, 0, 0}, Check: []int{0, 1, 1, 2, 3}}
+
+// route_log_HelloWorld_UpdateObject_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_UpdateObject_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/UpdateObject",
+}
+
+func route_request_HelloWorld_UpdateObject_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq UpdateRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := routes.Decompress(req); err != nil {
+		return nil, metadata, err
+	}
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Object); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, routes.DecodeError(err)
+	}
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_UpdateObject_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_UpdateObject_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	// set by the server alone, cleared when echoed back by the client
+	routes.ClearFields(&protoReq, "object.create_time")
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	// immutable fields of PostResponse are never changed by the update
+	if getter, ok := server.(HelloWorldUpdateObjectCurrent); ok {
+		current, err := getter.CurrentUpdateObject(ctx, &protoReq)
+		switch {
+		case status.Code(err) == codes.NotFound:
+			// nothing to compare against, left to the server
+		case err != nil:
+			return nil, metadata, err
+		default:
+			if err := routes.CheckImmutable(protoReq.Object, current, "name"); err != nil {
+				return nil, metadata, err
+			}
+		}
+	} else {
+		routes.StripImmutable(protoReq.Object, "name")
+	}
+	msg, err := server.UpdateObject(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+// route_log_HelloWorld_SetCredentials_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_SetCredentials_0 = &routes.LogRoute{
+	Method:        "/example.HelloWorld/SetCredentials",
+	RequestFields: []string{"password"},
+	RequestBody:   "*",
+}
+
+func route_request_HelloWorld_SetCredentials_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string, kms envelope.KMS) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq CredentialsRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := routes.Decompress(req); err != nil {
+		return nil, metadata, err
+	}
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, routes.DecodeError(err)
+	}
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := routes.CheckQueryParams(nil, req.URL.Query(), nil); err != nil {
+		return nil, metadata, err
+	}
+	// bound to the identity of the principal, never provided by the client
+	identity, err := routes.Identity(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	protoReq.UpdatedBy = identity.UserName
+	if err := routes.OpenFields(ctx, kms, &protoReq, "password"); err != nil {
+		return nil, metadata, err
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.SetCredentials(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+// route_log_HelloWorld_UploadAttachment_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_UploadAttachment_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/UploadAttachment",
+}
+
+func route_request_HelloWorld_UploadAttachment_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq UploadRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := routes.Decompress(req); err != nil {
+		return nil, metadata, err
+	}
+	// raw bytes of the body along with its declared content type
+	body, err := routes.ReadHTTPBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	protoReq.Attachment = body
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := routes.CheckQueryParams(nil, req.URL.Query(), nil); err != nil {
+		return nil, metadata, err
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.UploadAttachment(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_DownloadAttachment_0 = &utilities.DoubleArray{Encoding: map[string]int{"name": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
+// route_log_HelloWorld_DownloadAttachment_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_DownloadAttachment_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/DownloadAttachment",
+}
+
+func route_request_HelloWorld_DownloadAttachment_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq PostRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_DownloadAttachment_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_DownloadAttachment_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.DownloadAttachment(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_WatchObject_0 = &utilities.DoubleArray{Encoding: map[string]int{"name": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
+
+// route_log_HelloWorld_WatchObject_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_WatchObject_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/WatchObject",
+}
+
+func route_request_HelloWorld_WatchObject_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq PostRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	val, ok := pathParams["name"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "name")
+	}
+	protoReq.Name, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_WatchObject_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_WatchObject_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckRequired(&protoReq, "name"); err != nil {
+		return nil, metadata, err
+	}
+	msg, err := server.WatchObject(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+var route_filter_HelloWorld_DumpObjects_0 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
+
+var route_defaults_HelloWorld_DumpObjects_0 = []routes.Default{
+	{Field: "limit", Value: "50"},
+}
+
+// route_log_HelloWorld_DumpObjects_0 describes the route for the logging enabled using routes.WithLogging
+var route_log_HelloWorld_DumpObjects_0 = &routes.LogRoute{
+	Method: "/example.HelloWorld/DumpObjects",
+}
+
+func route_request_HelloWorld_DumpObjects_0(ctx context.Context, marshaler runtime.Marshaler, server HelloWorldRouteServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq ListRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_HelloWorld_DumpObjects_0); err != nil {
+		return nil, metadata, err
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_HelloWorld_DumpObjects_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := routes.PopulateDefaults(&protoReq, route_defaults_HelloWorld_DumpObjects_0); err != nil {
+		return nil, metadata, status.Errorf(codes.Internal, "%v", err)
+	}
+	ctx = routes.NewLocaleContext(ctx, req)
+	if protoReq.Locale == "" {
+		protoReq.Locale = routes.Locale(ctx)
+	}
+	msg, err := server.DumpObjects(ctx, &protoReq)
+	return msg, metadata, err
+}
+
+// HelloWorldRouteServer is the server API for HelloWorld service
+// served by the generated routes, this is satisfied by the
+// HelloWorldServer generated for grpc
+type HelloWorldRouteServer interface {
+	PostObject(context.Context, *PostRequest) (*PostResponse, error)
+	GetObject(context.Context, *PostRequest) (*PostResponse, error)
+	ListObjects(context.Context, *ListRequest) (*ListResponse, error)
+	UpdateObject(context.Context, *UpdateRequest) (*PostResponse, error)
+	SetCredentials(context.Context, *CredentialsRequest) (*PostResponse, error)
+	UploadAttachment(context.Context, *UploadRequest) (*PostResponse, error)
+	DownloadAttachment(context.Context, *PostRequest) (*AttachmentResponse, error)
+	WatchObject(context.Context, *PostRequest) (*PostResponse, error)
+	DumpObjects(context.Context, *ListRequest) (*ListResponse, error)
+}
+
+// HelloWorldUpdateObjectCurrent is optionally implemented by the
+// HelloWorldRouteServer to fetch the current PostResponse updated by
+// UpdateObject, against which the generated routes reject the changes to
+// its immutable fields, stripped from the update otherwise. NotFound
+// error skips the check, like for the upserts creating the resource
+type HelloWorldUpdateObjectCurrent interface {
+	CurrentUpdateObject(context.Context, *UpdateRequest) (*PostResponse, error)
+}
+
+// RegisterHelloWorldRoutes registers the http handlers for service
+// HelloWorld to "mux", calling the server directly. Request bodies
+// encoded using the codings registered with the compression package are
+// decompressed transparently, while the responses are compressed once
+// enabled using routes.WithCompression and the calls are logged once
+// enabled using routes.WithLogging. Streaming methods are currently
+// unsupported.
+func RegisterHelloWorldRoutes(ctx context.Context, mux routes.Mux, server HelloWorldRouteServer) error {
+	serveMux := routes.ServeMux(mux)
+	compress := routes.Compression(mux)
+	kms := routes.KMS(mux)
+	if kms == nil {
+		return errors.New("KMS is required to open the encrypted fields of HelloWorld service, see routes.WithKMS")
+	}
+	signer := routes.Signer(mux)
+	if signer == nil {
+		return errors.New("signer is required to sign the responses of HelloWorld service, see routes.WithSigner")
+	}
+	purge := routes.Purge(mux)
+	// methods guarded by the feature flags are disabled unless the flags
+	// are provided using routes.WithFlags
+	flags := routes.Flags(mux)
+	shadow := routes.Shadow(mux)
+	// debug only methods are served once enabled using routes.WithDebug
+	debug := routes.Debug(mux)
+	logCall := routes.Logging(mux)
+	// concurrent calls of ListObjects are limited across the bindings
+	limitListObjects := routes.NewLimiter("/example.HelloWorld/ListObjects", 10)
+	if err := mux.HandlePath(http.MethodPost, "/v1/object/{name}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, logged := logCall(w, req, route_log_HelloWorld_PostObject_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/PostObject", runtime.WithHTTPPathPattern("/v1/object/{name}"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_PostObject_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/object/{name}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		// copied to the shadow target, once enabled using routes.WithShadow
+		shadow(req, "/example.HelloWorld/GetObject", 0.05)
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, signed := routes.SignResponse(ctx, w, signer)
+		defer signed()
+		// tagged for the caches to purge once invalidated
+		routes.SetSurrogateKey(w, "HelloWorld_GetObject")
+		w, logged := logCall(w, req, route_log_HelloWorld_GetObject_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/GetObject", runtime.WithHTTPPathPattern("/v1/object/{name}"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_GetObject_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/legacy/object/{name}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		// copied to the shadow target, once enabled using routes.WithShadow
+		shadow(req, "/example.HelloWorld/GetObject", 0.05)
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, signed := routes.SignResponse(ctx, w, signer)
+		defer signed()
+		// tagged for the caches to purge once invalidated
+		routes.SetSurrogateKey(w, "HelloWorld_GetObject")
+		w, logged := logCall(w, req, route_log_HelloWorld_GetObject_1)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/GetObject", runtime.WithHTTPPathPattern("/v1/legacy/object/{name}"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_GetObject_1(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/objects", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		release, err := limitListObjects.Acquire()
+		if err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		defer release()
+		w, compressed := compress(w, req)
+		defer compressed()
+		// tagged for the caches to purge once invalidated
+		routes.SetSurrogateKey(w, "HelloWorld_ListObjects")
+		w, logged := logCall(w, req, route_log_HelloWorld_ListObjects_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/ListObjects", runtime.WithHTTPPathPattern("/v1/objects"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_ListObjects_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/objects:items", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		release, err := limitListObjects.Acquire()
+		if err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		defer release()
+		w, compressed := compress(w, req)
+		defer compressed()
+		// tagged for the caches to purge once invalidated
+		routes.SetSurrogateKey(w, "HelloWorld_ListObjects")
+		w, logged := logCall(w, req, route_log_HelloWorld_ListObjects_1)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/ListObjects", runtime.WithHTTPPathPattern("/v1/objects:items"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_ListObjects_1(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, route_response_HelloWorld_ListObjects_1{resp.(*ListResponse)}, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/objects:stream", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported by the generated routes")
+		_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/objects:watch", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported by the generated routes")
+		_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, "/v1/objects:batchCreate", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported by the generated routes")
+		_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, "/v1/objects:sync", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported by the generated routes")
+		_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPut, "/v1/object/{name}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, logged := logCall(w, req, route_log_HelloWorld_UpdateObject_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/UpdateObject", runtime.WithHTTPPathPattern("/v1/object/{name}"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_UpdateObject_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		created := routes.Created(&md)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		purge(annotatedContext, "HelloWorld_GetObject", "HelloWorld_ListObjects")
+		if created {
+			w = routes.WithStatus(w, http.StatusCreated)
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, "/v1/object/{name}:setCredentials", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, logged := logCall(w, req, route_log_HelloWorld_SetCredentials_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/SetCredentials", runtime.WithHTTPPathPattern("/v1/object/{name}:setCredentials"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_SetCredentials_0(annotatedContext, inboundMarshaler, server, req, pathParams, kms)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPut, "/v1/object/{name}/attachment", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, logged := logCall(w, req, route_log_HelloWorld_UploadAttachment_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/UploadAttachment", runtime.WithHTTPPathPattern("/v1/object/{name}/attachment"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_UploadAttachment_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/object/{name}/attachment", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		if err := routes.CheckFlag(ctx, flags, "attachment-download"); err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		w, compressed := compress(w, req)
+		defer compressed()
+		w, logged := logCall(w, req, route_log_HelloWorld_DownloadAttachment_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/DownloadAttachment", runtime.WithHTTPPathPattern("/v1/object/{name}/attachment"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_DownloadAttachment_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		// raw bytes of the body written as is with its declared content type
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp.(*AttachmentResponse).Attachment, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/v1/object/{name}:watch", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		w = routes.LongPoll(w)
+		w, logged := logCall(w, req, route_log_HelloWorld_WatchObject_0)
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/WatchObject", runtime.WithHTTPPathPattern("/v1/object/{name}:watch"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_HelloWorld_WatchObject_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+	}); err != nil {
+		return err
+	}
+	if debug != nil {
+		if err := mux.HandlePath(http.MethodGet, "/debug/objects", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+			ctx, cancel := context.WithCancel(req.Context())
+			defer cancel()
+			if err := debug(ctx, "/example.HelloWorld/DumpObjects"); err != nil {
+				_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+				runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+				return
+			}
+			w, compressed := compress(w, req)
+			defer compressed()
+			w, logged := logCall(w, req, route_log_HelloWorld_DumpObjects_0)
+			defer logged()
+			var stream runtime.ServerTransportStream
+			ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+			inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/example.HelloWorld/DumpObjects", runtime.WithHTTPPathPattern("/debug/objects"))
+			if err != nil {
+				runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+				return
+			}
+			resp, md, err := route_request_HelloWorld_DumpObjects_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+			md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+			annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+			if err != nil {
+				runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+				return
+			}
+			runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type route_response_HelloWorld_ListObjects_1 struct {
+	*ListResponse
+}
+
+func (m route_response_HelloWorld_ListObjects_1) XXX_ResponseBody() interface{} {
+	return m.Items
 }
@@ -4,17 +4,19 @@
 package example
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
 
-	auth "github.com/go-core-stack/auth/client"
+	"github.com/go-core-stack/grpc-core/sdk"
 )
 
 // HelloWorldService
@@ -23,44 +25,306 @@ type HelloWorldService interface {
 	// sample post request
 	// comment line 1
 	// comment line 2
-	PostObject(ctx context.Context, req *PostRequest) (*PostResponse, error)
+	PostObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// PostObjectInto is same as PostObject, decoding the response
+	// into the provided message to allow reusing the allocations
+	PostObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// PostObjectResult is same as PostObject, additionally providing the
+	// outcome for the expected status codes 409 instead of an error
+	PostObjectResult(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*sdk.Result[PostResponse], error)
 	// sample get request
 	// comment line 1
-	GetObject(ctx context.Context, req *PostRequest) (*PostResponse, error)
+	// Always sent using the http client, even if configured using
+	// sdk.WithConn, relying on the routes to handle the fields of the
+	// request or to sign the response
+	GetObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// GetObjectInto is same as GetObject, decoding the response
+	// into the provided message to allow reusing the allocations
+	GetObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// GetObjectBinding1 is same as GetObject, using the additional binding
+	// GET /v1/legacy/object/{name}
+	GetObjectBinding1(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// sample list request
+	ListObjects(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error)
+	// ListObjectsInto is same as ListObjects, decoding the response
+	// into the provided message to allow reusing the allocations
+	ListObjectsInto(ctx context.Context, req *ListRequest, out *ListResponse, opts ...sdk.CallOption) error
+	// ListObjectsBinding1 is same as ListObjects, using the additional binding
+	// GET /v1/objects:items
+	ListObjectsBinding1(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error)
+	// sample server streaming request
+	StreamObjects(ctx context.Context, req *ListRequest) (*sdk.Stream[PostResponse, *PostResponse], error)
+	// SubscribeStreamObjects is same as StreamObjects, receiving the messages
+	// as server sent events with fallback to long polling when streaming is
+	// not supported by the network, resuming transparently across reconnects
+	SubscribeStreamObjects(ctx context.Context, req *ListRequest, opts ...sdk.SubscribeOption) (*sdk.Subscription[PostResponse, *PostResponse], error)
+	// sample watch request, streaming the changes to the objects
+	WatchObjects(ctx context.Context, req *ListRequest) (sdk.Watcher[*PostResponse], error)
+	// SubscribeWatchObjects is same as WatchObjects, receiving the messages
+	// as server sent events with fallback to long polling when streaming is
+	// not supported by the network, resuming transparently across reconnects
+	SubscribeWatchObjects(ctx context.Context, req *ListRequest, opts ...sdk.SubscribeOption) (*sdk.Subscription[ObjectEvent, *ObjectEvent], error)
+	// sample client streaming request
+	CreateObjects(ctx context.Context) (*sdk.ClientStream[*PostRequest, ListResponse, *ListResponse], error)
+	// sample bidirectional streaming request
+	SyncObjects(ctx context.Context) (*sdk.BidiStream[*PostRequest, PostResponse, *PostResponse], error)
+	// sample request with the body mapped to a field
+	UpdateObject(ctx context.Context, req *UpdateRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// UpdateObjectInto is same as UpdateObject, decoding the response
+	// into the provided message to allow reusing the allocations
+	UpdateObjectInto(ctx context.Context, req *UpdateRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// UpdateObjectResult is same as UpdateObject, additionally providing the
+	// status code, reporting whether the upsert created the resource
+	UpdateObjectResult(ctx context.Context, req *UpdateRequest, opts ...sdk.CallOption) (*sdk.Result[PostResponse], error)
+	// sample request with encrypted field
+	// Always sent using the http client, even if configured using
+	// sdk.WithConn, relying on the routes to handle the fields of the
+	// request or to sign the response
+	SetCredentials(ctx context.Context, req *CredentialsRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// SetCredentialsInto is same as SetCredentials, decoding the response
+	// into the provided message to allow reusing the allocations
+	SetCredentialsInto(ctx context.Context, req *CredentialsRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// sample upload of the raw bytes of the attachment of the object
+	UploadAttachment(ctx context.Context, req *UploadRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// UploadAttachmentInto is same as UploadAttachment, decoding the response
+	// into the provided message to allow reusing the allocations
+	UploadAttachmentInto(ctx context.Context, req *UploadRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// sample download of the raw bytes of the attachment of the object
+	DownloadAttachment(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*AttachmentResponse, error)
+	// DownloadAttachmentInto is same as DownloadAttachment, decoding the response
+	// into the provided message to allow reusing the allocations
+	DownloadAttachmentInto(ctx context.Context, req *PostRequest, out *AttachmentResponse, opts ...sdk.CallOption) error
+	// DownloadAttachmentDownload is same as DownloadAttachment, streaming the raw bytes
+	// of the response body instead of reading them in memory, like the
+	// large file downloads, the returned download must be closed once done.
+	// Always sent using the http client, even if configured using
+	// sdk.WithConn, failing with sdk.ErrNoClient when constructed using
+	// only the grpc connection
+	DownloadAttachmentDownload(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*sdk.Download, error)
+	// sample long polling request, waiting for the object to change
+	WatchObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error)
+	// WatchObjectInto is same as WatchObject, decoding the response
+	// into the provided message to allow reusing the allocations
+	WatchObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error
+	// sample debug only request, dumping the objects held by the server
+	DumpObjects(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error)
+	// DumpObjectsInto is same as DumpObjects, decoding the response
+	// into the provided message to allow reusing the allocations
+	DumpObjectsInto(ctx context.Context, req *ListRequest, out *ListResponse, opts ...sdk.CallOption) error
 }
 
 type implHelloWorldService struct {
-	client auth.Client
+	client sdk.Doer
+	config *sdk.ServiceConfig
 }
 
 // NewHelloWorldService
-// creates a new SDK wrapper for HelloWorld service
-// function expects to be provided with an auth client to
-// trigger request to service
-func NewHelloWorldService(client auth.Client) HelloWorldService {
+// creates a new SDK wrapper for HelloWorld service, safe for
+// concurrent use, triggering the requests using the client, see
+// package sdk for the ServiceOptions
+func NewHelloWorldService(client sdk.Doer, opts ...sdk.ServiceOption) HelloWorldService {
+	// service level objectives of the methods as per (api.slo), declared
+	// to the metrics implementing sdk.ObjectiveMetrics
+	opts = append([]sdk.ServiceOption{sdk.WithObjectives(map[string]sdk.Objective{
+		"GetObject":    {Latency: 200 * time.Millisecond, LatencyPercentile: 99, Availability: 99.9},
+		"WatchObjects": {Availability: 99.5},
+	})}, opts...)
+	config := sdk.NewServiceConfig("example.HelloWorld", opts...)
 	return &implHelloWorldService{
-		client: client,
+		client: config.Client(client),
+		config: config,
 	}
 }
 
-func (s *implHelloWorldService) PostObject(ctx context.Context, req *PostRequest) (*PostResponse, error) {
+// NewHelloWorldServiceFromConn
+// creates a new SDK wrapper for HelloWorld service sending the
+// unary calls natively over the grpc connection, skipping the gateway
+// hop for the internal services, the calls requiring the http client,
+// like the streaming methods, fail with sdk.ErrNoClient. Use
+// NewHelloWorldService along with sdk.WithConn to fall back to the
+// http client for them instead
+func NewHelloWorldServiceFromConn(conn grpc.ClientConnInterface, opts ...sdk.ServiceOption) HelloWorldService {
+	return NewHelloWorldService(sdk.NoClient, append(opts, sdk.WithConn(conn))...)
+}
+
+func (s *implHelloWorldService) PostObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error) {
+	out := &PostResponse{}
+	if err := s.PostObjectInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) PostObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doPostObject(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+func (s *implHelloWorldService) PostObjectResult(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*sdk.Result[PostResponse], error) {
+	out := &PostResponse{}
+	status, body, err := s.doPostObject(ctx, req, out, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case status >= 200 && status < 300:
+		return &sdk.Result[PostResponse]{StatusCode: status, Response: out}, nil
+	case status == 409:
+		return &sdk.Result[PostResponse]{StatusCode: status, Body: body}, nil
+	}
+	return nil, sdk.DecodeError(status, body)
+}
+
+// doPostObject triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doPostObject(ctx context.Context, req *PostRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		return s.config.Invoke(ctx, "/example.HelloWorld/PostObject", req, out, opts)
+	}
+	uri := "/v1/object/{name}"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	// encoded as protobuf if configured using sdk.WithProtobuf, JSON otherwise
+	marshaller := s.config.Marshaler()
+
+	// large request messages are streamed instead of encoding in memory
+	r, err := sdk.NewRequest(ctx, "POST", s.config.URL(uri), marshaller, req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+
+	r.Header.Set("Content-Type", marshaller.ContentType(nil))
+	r.Header.Set("Accept", marshaller.ContentType(nil))
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/PostObject",
+		HTTPMethod:   "POST",
+		PathTemplate: "/v1/object/{name}",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		Body: "*",
+	})
+	// retry policy of the method, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetry(sdk.RetryConfig{
+		MaxAttempts: 3,
+		Backoff:     200 * time.Millisecond,
+		StatusCodes: []int{503},
+	})}, opts...)
+	// retried POST carries the idempotency key generated per call,
+	// allowing the server to deduplicate the attempts
+	opts = append([]sdk.CallOption{sdk.WithIdempotencyKey("")}, opts...)
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("PostObject")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// decoded as per the content type of the response
+	if err := sdk.ResponseMarshaler(resp, marshaller).Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) GetObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error) {
+	out := &PostResponse{}
+	if err := s.GetObjectInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) GetObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doGetObject(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doGetObject triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doGetObject(ctx context.Context, req *PostRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
 	uri := "/v1/object/{name}"
 	// ensure replacing the variables in the uri before triggering client
 	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
 
 	// use marshaller for grpc Gateway since we are working protobuf files
-	marshaller := &runtime.JSONPb{}
+	marshaller := s.config.JSONMarshaler()
 
-	inData, _ := marshaller.Marshal(req)
-	r, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewBuffer(inData))
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed create request: %s", err)
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("desc", fmt.Sprintf("%v", req.GetDesc()))
+	if req.Test != nil {
+		q.Add("test", fmt.Sprintf("%v", req.GetTest()))
 	}
+	r.URL.RawQuery = q.Encode()
 
 	r.Header.Set("Content-Type", "application/json")
-	resp, err := s.client.Do(r)
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/GetObject",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/object/{name}",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		QueryParams: []string{"desc", "test"},
+	})
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	// cached until invalidated, when the service is configured using sdk.WithCache
+	opts = append([]sdk.CallOption{sdk.WithCacheTag("HelloWorld_GetObject")}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("GetObject")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
 	defer func() {
@@ -70,33 +334,51 @@ func (s *implHelloWorldService) PostObject(ctx context.Context, req *PostRequest
 	}()
 	outBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return resp.StatusCode, outBytes, nil
+	}
+
+	if err := sdk.VerifySignature(s.config, resp.Header, outBytes); err != nil {
+		return 0, nil, err
+	}
+
+	if err := marshaller.Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
 	}
+	return resp.StatusCode, nil, nil
+}
 
+func (s *implHelloWorldService) GetObjectBinding1(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error) {
 	out := &PostResponse{}
-	err = marshaller.Unmarshal(outBytes, out)
+	status, body, err := s.doGetObjectBinding1(ctx, req, out, opts)
 	if err != nil {
 		return nil, err
 	}
-
+	if status < 200 || status >= 300 {
+		return nil, sdk.DecodeError(status, body)
+	}
 	return out, nil
 }
 
-func (s *implHelloWorldService) GetObject(ctx context.Context, req *PostRequest) (*PostResponse, error) {
-	uri := "/v1/object/{name}"
+// doGetObjectBinding1 triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doGetObjectBinding1(ctx context.Context, req *PostRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	uri := "/v1/legacy/object/{name}"
 	// ensure replacing the variables in the uri before triggering client
 	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
 
 	// use marshaller for grpc Gateway since we are working protobuf files
-	marshaller := &runtime.JSONPb{}
+	marshaller := s.config.JSONMarshaler()
 
-	r, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed create request: %s", err)
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
 	}
 	q := url.Values{}
 	q.Add("desc", fmt.Sprintf("%v", req.GetDesc()))
@@ -106,10 +388,492 @@ func (s *implHelloWorldService) GetObject(ctx context.Context, req *PostRequest)
 	r.URL.RawQuery = q.Encode()
 
 	r.Header.Set("Content-Type", "application/json")
-	resp, err := s.client.Do(r)
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/GetObject",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/legacy/object/{name}",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		QueryParams: []string{"desc", "test"},
+	})
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	// cached until invalidated, when the service is configured using sdk.WithCache
+	opts = append([]sdk.CallOption{sdk.WithCacheTag("HelloWorld_GetObject")}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("GetObject")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	if err := sdk.VerifySignature(s.config, resp.Header, outBytes); err != nil {
+		return 0, nil, err
+	}
+
+	if err := marshaller.Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) ListObjects(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error) {
+	out := &ListResponse{}
+	if err := s.ListObjectsInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) ListObjectsInto(ctx context.Context, req *ListRequest, out *ListResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doListObjects(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doListObjects triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doListObjects(ctx context.Context, req *ListRequest, out *ListResponse, opts []sdk.CallOption) (int, []byte, error) {
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		opts = append([]sdk.CallOption{sdk.WithDefaultTimeout(30 * time.Second)}, opts...)
+		return s.config.Invoke(ctx, "/example.HelloWorld/ListObjects", req, out, opts)
+	}
+	uri := "/v1/objects"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("limit", fmt.Sprintf("%v", req.GetLimit()))
+	q.Add("locale", fmt.Sprintf("%v", req.GetLocale()))
+	q.Add("state", req.GetState().String())
+	if req.ModifiedAfter != nil {
+		q.Add("modified_after", sdk.FormatTimestamp(req.GetModifiedAfter()))
+	}
+	q.Add("page_token", fmt.Sprintf("%v", req.GetPageToken()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/ListObjects",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/objects",
+		QueryParams:  []string{"limit", "locale", "state", "modified_after", "page_token"},
+	})
+	// retry policy of the method, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetry(sdk.RetryConfig{
+		MaxAttempts: 4,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		StatusCodes: []int{502, 503},
+	})}, opts...)
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	// isolated in the listing bulkhead, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithBulkhead("listing")}, opts...)
+	// cached until invalidated, when the service is configured using sdk.WithCache
+	opts = append([]sdk.CallOption{sdk.WithCacheTag("HelloWorld_ListObjects")}, opts...)
+	// bounded by the default timeout of the method unless the context has
+	// a deadline, the options of the caller override the timeout
+	opts = append([]sdk.CallOption{sdk.WithDefaultTimeout(30 * time.Second)}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("ListObjects")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// decode the items of the list in batch to reduce allocations
+	items, err := sdk.UnmarshalList[PostResponse](marshaller, outBytes, out, "items")
+	if err != nil {
+		return 0, nil, err
+	}
+	out.Items = items
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) ListObjectsBinding1(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error) {
+	out := &ListResponse{}
+	status, body, err := s.doListObjectsBinding1(ctx, req, out, opts)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, sdk.DecodeError(status, body)
+	}
+	return out, nil
+}
+
+// doListObjectsBinding1 triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doListObjectsBinding1(ctx context.Context, req *ListRequest, out *ListResponse, opts []sdk.CallOption) (int, []byte, error) {
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		opts = append([]sdk.CallOption{sdk.WithDefaultTimeout(30 * time.Second)}, opts...)
+		return s.config.Invoke(ctx, "/example.HelloWorld/ListObjects", req, out, opts)
+	}
+	uri := "/v1/objects:items"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("limit", fmt.Sprintf("%v", req.GetLimit()))
+	q.Add("locale", fmt.Sprintf("%v", req.GetLocale()))
+	q.Add("state", req.GetState().String())
+	if req.ModifiedAfter != nil {
+		q.Add("modified_after", sdk.FormatTimestamp(req.GetModifiedAfter()))
+	}
+	q.Add("page_token", fmt.Sprintf("%v", req.GetPageToken()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/ListObjects",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/objects:items",
+		QueryParams:  []string{"limit", "locale", "state", "modified_after", "page_token"},
+	})
+	// retry policy of the method, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetry(sdk.RetryConfig{
+		MaxAttempts: 4,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		StatusCodes: []int{502, 503},
+	})}, opts...)
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	// isolated in the listing bulkhead, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithBulkhead("listing")}, opts...)
+	// cached until invalidated, when the service is configured using sdk.WithCache
+	opts = append([]sdk.CallOption{sdk.WithCacheTag("HelloWorld_ListObjects")}, opts...)
+	// bounded by the default timeout of the method unless the context has
+	// a deadline, the options of the caller override the timeout
+	opts = append([]sdk.CallOption{sdk.WithDefaultTimeout(30 * time.Second)}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("ListObjects")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// payload carries only the items field of the response
+	if err := marshaller.Unmarshal(outBytes, &out.Items); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+// StreamObjects opens the stream of messages sent by the server, the
+// returned stream must be closed once done
+func (s *implHelloWorldService) StreamObjects(ctx context.Context, req *ListRequest) (*sdk.Stream[PostResponse, *PostResponse], error) {
+	r, marshaller, err := s.newStreamObjectsRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	observed := s.config.Observe("StreamObjects")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, sdk.DecodeError(resp.StatusCode, body)
+	}
+	return sdk.NewStream[PostResponse](marshaller, resp), nil
+}
+
+func (s *implHelloWorldService) SubscribeStreamObjects(ctx context.Context, req *ListRequest, opts ...sdk.SubscribeOption) (*sdk.Subscription[PostResponse, *PostResponse], error) {
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		r, _, err := s.newStreamObjectsRequest(ctx, req)
+		return r, err
+	}
+	return sdk.NewSubscription[PostResponse](ctx, s.client, newRequest, s.config.JSONMarshaler(), opts...)
+}
+
+// newStreamObjectsRequest creates the request for StreamObjects along with
+// the marshaller to decode the messages of the stream
+func (s *implHelloWorldService) newStreamObjectsRequest(ctx context.Context, req *ListRequest) (*http.Request, runtime.Marshaler, error) {
+	uri := "/v1/objects:stream"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("limit", fmt.Sprintf("%v", req.GetLimit()))
+	q.Add("locale", fmt.Sprintf("%v", req.GetLocale()))
+	q.Add("state", req.GetState().String())
+	if req.ModifiedAfter != nil {
+		q.Add("modified_after", sdk.FormatTimestamp(req.GetModifiedAfter()))
+	}
+	q.Add("page_token", fmt.Sprintf("%v", req.GetPageToken()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/StreamObjects",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/objects:stream",
+		QueryParams:  []string{"limit", "locale", "state", "modified_after", "page_token"},
+	})
+	return r, marshaller, nil
+}
+
+// WatchObjects watches the changes sent by the server as typed events,
+// the returned watcher must be stopped once done
+func (s *implHelloWorldService) WatchObjects(ctx context.Context, req *ListRequest) (sdk.Watcher[*PostResponse], error) {
+	r, marshaller, err := s.newWatchObjectsRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	observed := s.config.Observe("WatchObjects")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, sdk.DecodeError(resp.StatusCode, body)
+	}
+	return sdk.NewWatcher(sdk.NewStream[ObjectEvent](marshaller, resp), func(e *ObjectEvent) sdk.Event[*PostResponse] {
+		return sdk.Event[*PostResponse]{
+			Type:   sdk.ParseEventType(e.GetType().String()),
+			Object: e.GetObject(),
+		}
+	}), nil
+}
+
+func (s *implHelloWorldService) SubscribeWatchObjects(ctx context.Context, req *ListRequest, opts ...sdk.SubscribeOption) (*sdk.Subscription[ObjectEvent, *ObjectEvent], error) {
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		r, _, err := s.newWatchObjectsRequest(ctx, req)
+		return r, err
+	}
+	return sdk.NewSubscription[ObjectEvent](ctx, s.client, newRequest, s.config.JSONMarshaler(), opts...)
+}
+
+// newWatchObjectsRequest creates the request for WatchObjects along with
+// the marshaller to decode the messages of the stream
+func (s *implHelloWorldService) newWatchObjectsRequest(ctx context.Context, req *ListRequest) (*http.Request, runtime.Marshaler, error) {
+	uri := "/v1/objects:watch"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("limit", fmt.Sprintf("%v", req.GetLimit()))
+	q.Add("locale", fmt.Sprintf("%v", req.GetLocale()))
+	q.Add("state", req.GetState().String())
+	if req.ModifiedAfter != nil {
+		q.Add("modified_after", sdk.FormatTimestamp(req.GetModifiedAfter()))
+	}
+	q.Add("page_token", fmt.Sprintf("%v", req.GetPageToken()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/WatchObjects",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/objects:watch",
+		QueryParams:  []string{"limit", "locale", "state", "modified_after", "page_token"},
+	})
+	return r, marshaller, nil
+}
+
+// CreateObjects opens the stream to send messages to the server, the
+// response is received once the stream is closed using CloseAndRecv
+func (s *implHelloWorldService) CreateObjects(ctx context.Context) (*sdk.ClientStream[*PostRequest, ListResponse, *ListResponse], error) {
+	uri := "/v1/objects:batchCreate"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+	return sdk.NewClientStream[*PostRequest, ListResponse](ctx, s.client, "POST", s.config.URL(uri), marshaller)
+}
+
+// SyncObjects opens the websocket stream to exchange messages with
+// the server, the returned stream must be closed once done
+func (s *implHelloWorldService) SyncObjects(ctx context.Context) (*sdk.BidiStream[*PostRequest, PostResponse, *PostResponse], error) {
+	uri := "/v1/objects:sync"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+	return sdk.NewBidiStream[*PostRequest, PostResponse](ctx, s.client, "POST", s.config.URL(uri), marshaller)
+}
+
+func (s *implHelloWorldService) UpdateObject(ctx context.Context, req *UpdateRequest, opts ...sdk.CallOption) (*PostResponse, error) {
+	out := &PostResponse{}
+	if err := s.UpdateObjectInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) UpdateObjectInto(ctx context.Context, req *UpdateRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doUpdateObject(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+func (s *implHelloWorldService) UpdateObjectResult(ctx context.Context, req *UpdateRequest, opts ...sdk.CallOption) (*sdk.Result[PostResponse], error) {
+	out := &PostResponse{}
+	status, body, err := s.doUpdateObject(ctx, req, out, opts)
 	if err != nil {
 		return nil, err
 	}
+	switch {
+	case status >= 200 && status < 300:
+		return &sdk.Result[PostResponse]{StatusCode: status, Response: out}, nil
+	}
+	return nil, sdk.DecodeError(status, body)
+}
+
+// doUpdateObject triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doUpdateObject(ctx context.Context, req *UpdateRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	// set by the server alone, never sent
+	req = sdk.OmitFields(req, "object.create_time")
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		return s.config.Invoke(ctx, "/example.HelloWorld/UpdateObject", req, out, opts)
+	}
+	uri := "/v1/object/{name}"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	// large request messages are streamed instead of encoding in memory
+	r, err := sdk.NewRequest(ctx, "PUT", s.config.URL(uri), marshaller, req.GetObject())
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("validate_only", fmt.Sprintf("%v", req.GetValidateOnly()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/UpdateObject",
+		HTTPMethod:   "PUT",
+		PathTemplate: "/v1/object/{name}",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		QueryParams: []string{"validate_only"},
+		Body:        "object",
+	})
+	// classified unsafe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(false)}, opts...)
+	// conditional on the version of the resource, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithIfMatch(req.GetObject().GetEtag())}, opts...)
+	// invalidates the cached responses of HelloWorld_GetObject, HelloWorld_ListObjects
+	opts = append([]sdk.CallOption{sdk.WithInvalidation("HelloWorld_GetObject", "HelloWorld_ListObjects")}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("UpdateObject")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	defer func() {
 		if resp.Body != nil {
@@ -118,18 +882,547 @@ func (s *implHelloWorldService) GetObject(ctx context.Context, req *PostRequest)
 	}()
 	outBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode == 409 || resp.StatusCode == 412 {
+		return 0, nil, sdk.DecodeConflict(resp.StatusCode, resp.Header, outBytes)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	if err := marshaller.Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) SetCredentials(ctx context.Context, req *CredentialsRequest, opts ...sdk.CallOption) (*PostResponse, error) {
+	out := &PostResponse{}
+	if err := s.SetCredentialsInto(ctx, req, out, opts...); err != nil {
 		return nil, err
 	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) SetCredentialsInto(ctx context.Context, req *CredentialsRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doSetCredentials(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doSetCredentials triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doSetCredentials(ctx context.Context, req *CredentialsRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	// populated from the identity of the principal by the server instead
+	req = sdk.OmitFields(req, "updated_by")
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	req, err := sdk.SealFields(ctx, s.config, req, "password")
+	if err != nil {
+		return 0, nil, err
+	}
+	uri := "/v1/object/{name}:setCredentials"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	// encoded as protobuf if configured using sdk.WithProtobuf, JSON otherwise
+	marshaller := s.config.Marshaler()
+
+	// large request messages are streamed instead of encoding in memory
+	r, err := sdk.NewRequest(ctx, "POST", s.config.URL(uri), marshaller, req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+
+	r.Header.Set("Content-Type", marshaller.ContentType(nil))
+	r.Header.Set("Accept", marshaller.ContentType(nil))
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/SetCredentials",
+		HTTPMethod:   "POST",
+		PathTemplate: "/v1/object/{name}:setCredentials",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		Body: "*",
+	})
+	// classified unsafe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(false)}, opts...)
+	// sensitive fields redacted by sdk.LoggingInterceptor
+	opts = append([]sdk.CallOption{sdk.WithRedaction(&sdk.Redaction{
+		RequestFields: []string{"password"},
+		RequestBody:   "*",
+	})}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("SetCredentials")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// decoded as per the content type of the response
+	if err := sdk.ResponseMarshaler(resp, marshaller).Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
 	}
+	return resp.StatusCode, nil, nil
+}
 
+func (s *implHelloWorldService) UploadAttachment(ctx context.Context, req *UploadRequest, opts ...sdk.CallOption) (*PostResponse, error) {
 	out := &PostResponse{}
-	err = marshaller.Unmarshal(outBytes, out)
+	if err := s.UploadAttachmentInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) UploadAttachmentInto(ctx context.Context, req *UploadRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doUploadAttachment(ctx, req, out, opts)
 	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doUploadAttachment triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doUploadAttachment(ctx context.Context, req *UploadRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		return s.config.Invoke(ctx, "/example.HelloWorld/UploadAttachment", req, out, opts)
+	}
+	uri := "/v1/object/{name}/attachment"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	// raw bytes of the body sent as is with its declared content type
+	r, err := sdk.NewHTTPBodyRequest(ctx, "PUT", s.config.URL(uri), req.GetAttachment())
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/UploadAttachment",
+		HTTPMethod:   "PUT",
+		PathTemplate: "/v1/object/{name}/attachment",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		Body: "attachment",
+	})
+	// classified unsafe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(false)}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("UploadAttachment")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	if err := marshaller.Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) DownloadAttachment(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*AttachmentResponse, error) {
+	out := &AttachmentResponse{}
+	if err := s.DownloadAttachmentInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) DownloadAttachmentInto(ctx context.Context, req *PostRequest, out *AttachmentResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doDownloadAttachment(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+func (s *implHelloWorldService) DownloadAttachmentDownload(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*sdk.Download, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return nil, err
+	}
+	r, opts, err := s.newDownloadAttachmentRequest(ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	observed := s.config.Observe("DownloadAttachment")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		cancel()
 		return nil, err
 	}
+	// body is streamed until the download is closed
+	return sdk.NewDownload(resp, cancel)
+}
 
+// doDownloadAttachment triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doDownloadAttachment(ctx context.Context, req *PostRequest, out *AttachmentResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		return s.config.Invoke(ctx, "/example.HelloWorld/DownloadAttachment", req, out, opts)
+	}
+	r, opts, err := s.newDownloadAttachmentRequest(ctx, req, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("DownloadAttachment")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// payload carries the raw bytes of the attachment field of the response
+	out.Attachment = sdk.HTTPBodyOf(resp, outBytes)
+	return resp.StatusCode, nil, nil
+}
+
+// newDownloadAttachmentRequest creates the request of the call along with its
+// options, the defaults of the method followed by the given ones
+func (s *implHelloWorldService) newDownloadAttachmentRequest(ctx context.Context, req *PostRequest, opts []sdk.CallOption) (*http.Request, []sdk.CallOption, error) {
+	uri := "/v1/object/{name}/attachment"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("desc", fmt.Sprintf("%v", req.GetDesc()))
+	if req.Test != nil {
+		q.Add("test", fmt.Sprintf("%v", req.GetTest()))
+	}
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/DownloadAttachment",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/object/{name}/attachment",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		QueryParams: []string{"desc", "test"},
+	})
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	return r, opts, nil
+}
+
+func (s *implHelloWorldService) WatchObject(ctx context.Context, req *PostRequest, opts ...sdk.CallOption) (*PostResponse, error) {
+	out := &PostResponse{}
+	if err := s.WatchObjectInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
+
+func (s *implHelloWorldService) WatchObjectInto(ctx context.Context, req *PostRequest, out *PostResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doWatchObject(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doWatchObject triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doWatchObject(ctx context.Context, req *PostRequest, out *PostResponse, opts []sdk.CallOption) (int, []byte, error) {
+	if err := sdk.CheckRequired(req, "name"); err != nil {
+		return 0, nil, err
+	}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		opts = append([]sdk.CallOption{sdk.WithLongPoll(1 * time.Minute)}, opts...)
+		return s.config.Invoke(ctx, "/example.HelloWorld/WatchObject", req, out, opts)
+	}
+	uri := "/v1/object/{name}:watch"
+	// ensure replacing the variables in the uri before triggering client
+	uri = strings.Replace(uri, "{"+"name"+"}", url.PathEscape(fmt.Sprintf("%v", req.Name)), -1)
+
+	// encoded as protobuf if configured using sdk.WithProtobuf, JSON otherwise
+	marshaller := s.config.Marshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("desc", fmt.Sprintf("%v", req.GetDesc()))
+	if req.Test != nil {
+		q.Add("test", fmt.Sprintf("%v", req.GetTest()))
+	}
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", marshaller.ContentType(nil))
+	r.Header.Set("Accept", marshaller.ContentType(nil))
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/WatchObject",
+		HTTPMethod:   "GET",
+		PathTemplate: "/v1/object/{name}:watch",
+		PathParams: map[string]string{
+			"name": fmt.Sprintf("%v", req.Name),
+		},
+		QueryParams: []string{"desc", "test"},
+	})
+	// classified safe to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe(true)}, opts...)
+	// long polling, the options of the caller override the timeout
+	opts = append([]sdk.CallOption{sdk.WithLongPoll(1 * time.Minute)}, opts...)
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("WatchObject")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// decoded as per the content type of the response
+	if err := sdk.ResponseMarshaler(resp, marshaller).Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+}
+
+func (s *implHelloWorldService) DumpObjects(ctx context.Context, req *ListRequest, opts ...sdk.CallOption) (*ListResponse, error) {
+	out := &ListResponse{}
+	if err := s.DumpObjectsInto(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *implHelloWorldService) DumpObjectsInto(ctx context.Context, req *ListRequest, out *ListResponse, opts ...sdk.CallOption) error {
+	status, body, err := s.doDumpObjects(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+
+// doDumpObjects triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *implHelloWorldService) doDumpObjects(ctx context.Context, req *ListRequest, out *ListResponse, opts []sdk.CallOption) (int, []byte, error) {
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		return s.config.Invoke(ctx, "/example.HelloWorld/DumpObjects", req, out, opts)
+	}
+	uri := "/debug/objects"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+
+	r, err := http.NewRequestWithContext(ctx, "GET", s.config.URL(uri), nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	q := url.Values{}
+	q.Add("limit", fmt.Sprintf("%v", req.GetLimit()))
+	q.Add("locale", fmt.Sprintf("%v", req.GetLocale()))
+	q.Add("state", req.GetState().String())
+	if req.ModifiedAfter != nil {
+		q.Add("modified_after", sdk.FormatTimestamp(req.GetModifiedAfter()))
+	}
+	q.Add("page_token", fmt.Sprintf("%v", req.GetPageToken()))
+	r.URL.RawQuery = q.Encode()
+
+	r.Header.Set("Content-Type", "application/json")
+	sdk.SetMetadataHeaders(r)
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/example.HelloWorld/DumpObjects",
+		HTTPMethod:   "GET",
+		PathTemplate: "/debug/objects",
+		QueryParams:  []string{"limit", "locale", "state", "modified_after", "page_token"},
+	})
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe("DumpObjects")
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+
+	// decode the items of the list in batch to reduce allocations
+	items, err := sdk.UnmarshalList[PostResponse](marshaller, outBytes, out, "items")
+	if err != nil {
+		return 0, nil, err
+	}
+	out.Items = items
+	return resp.StatusCode, nil, nil
+}
+
+// NewHelloWorldObjectInformer creates the informer caching the
+// objects of object resource of HelloWorld service keyed by the name,
+// listed using ListObjects and watched using WatchObjects
+func NewHelloWorldObjectInformer(svc HelloWorldService, listReq *ListRequest, watchReq *ListRequest, opts ...sdk.InformerOption) *sdk.Informer[*PostResponse] {
+	return sdk.NewInformer(
+		func(ctx context.Context) ([]*PostResponse, error) {
+			resp, err := svc.ListObjects(ctx, listReq)
+			if err != nil {
+				return nil, err
+			}
+			return resp.GetItems(), nil
+		},
+		func(ctx context.Context) (sdk.Watcher[*PostResponse], error) {
+			return svc.WatchObjects(ctx, watchReq)
+		},
+		func(obj *PostResponse) string {
+			return obj.GetName()
+		},
+		opts...,
+	)
+}
+
+// IterateHelloWorldListObjects returns an iterator over the items of all the pages
+// of ListObjects, following the next_page_token until exhausted, stopping
+// after yielding the error of any page
+func IterateHelloWorldListObjects(ctx context.Context, svc HelloWorldService, req *ListRequest, opts ...sdk.CallOption) iter.Seq2[*PostResponse, error] {
+	return sdk.Items(IterateHelloWorldListObjectsPages(ctx, svc, req, opts...), (*ListResponse).GetItems)
+}
+
+// IterateHelloWorldListObjectsPages returns an iterator over the pages of
+// ListObjects, starting at the page_token of the request and following the
+// next_page_token until exhausted, see sdk.Pages
+func IterateHelloWorldListObjectsPages(ctx context.Context, svc HelloWorldService, req *ListRequest, opts ...sdk.CallOption) iter.Seq2[*ListResponse, error] {
+	return sdk.Pages(ctx, req, svc.ListObjects, opts...)
+}
+
+// IterateHelloWorldDumpObjects returns an iterator over the items of all the pages
+// of DumpObjects, following the next_page_token until exhausted, stopping
+// after yielding the error of any page
+func IterateHelloWorldDumpObjects(ctx context.Context, svc HelloWorldService, req *ListRequest, opts ...sdk.CallOption) iter.Seq2[*PostResponse, error] {
+	return sdk.Items(IterateHelloWorldDumpObjectsPages(ctx, svc, req, opts...), (*ListResponse).GetItems)
+}
+
+// IterateHelloWorldDumpObjectsPages returns an iterator over the pages of
+// DumpObjects, starting at the page_token of the request and following the
+// next_page_token until exhausted, see sdk.Pages
+func IterateHelloWorldDumpObjectsPages(ctx context.Context, svc HelloWorldService, req *ListRequest, opts ...sdk.CallOption) iter.Seq2[*ListResponse, error] {
+	return sdk.Pages(ctx, req, svc.DumpObjects, opts...)
+}
@@ -0,0 +1,228 @@
+// Package golden runs the generators against the fixture descriptor
+// sets, comparing the generated files with the golden files, such that
+// the changes of the templates are reviewed as the diffs of the
+// generated code. The generated files are type checked along with the
+// go types of the fixture as well, for the generated code failing to
+// compile to fail the tests. Golden files are updated by running the
+// tests with the -update flag
+//
+//	go test ./protoc-gen-sdk/... ./protoc-gen-routes/... -run TestGolden -update
+package golden
+
+//go:generate protoc -I testdata -I ../.. -I ../third_party --include_imports --include_source_info --descriptor_set_out=testdata/example.pb example.proto
+//go:generate protoc -I testdata -I ../.. -I ../third_party --go_out=testdata --go_opt=paths=source_relative example.proto
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+)
+
+var update = flag.Bool("update", false, "update the golden files with the generated files")
+
+// Case is a generator run compared with the golden files
+type Case struct {
+	// Name of the case, the golden files are kept in testdata/golden
+	// of the package running the test, under the directory Name
+	Name string
+	// DescriptorSet is the path of the descriptor set built along with
+	// the imports, generating the files not imported by the others
+	DescriptorSet string
+	// Generate runs the generator over the loaded registry
+	Generate func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error)
+	// Check optionally asserts the properties of the generated files,
+	// by name, which are not evident from the golden files alone
+	Check func(t *testing.T, files map[string]string)
+}
+
+// Fixture returns the path of the descriptor set of the fixture shared
+// by the generators, built from testdata/<name>.proto, the go types of
+// which are kept in testdata/<name>.pb.go
+func Fixture(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", name+".pb")
+}
+
+// Run runs the cases as the subtests, failing for the generated files
+// differing from the golden files along with the golden files no
+// longer generated
+func Run(t *testing.T, cases ...Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			files, goPkg, err := generate(c)
+			if err != nil {
+				t.Fatalf("generating %s failed with %v; want success", c.Name, err)
+			}
+			compare(t, filepath.Join("testdata", "golden", c.Name), files)
+			goTypes := strings.TrimSuffix(c.DescriptorSet, ".pb") + ".pb.go"
+			for _, err := range typeCheck(goTypes, goPkg, files) {
+				t.Errorf("generated code of %s does not compile: %v", c.Name, err)
+			}
+			if c.Check != nil {
+				c.Check(t, files)
+			}
+		})
+	}
+}
+
+// generate loads the descriptor set into the registry and runs the
+// generator, returning the generated files by name along with the go
+// import path of the package of the targets
+func generate(c Case) (map[string]string, string, error) {
+	data, err := os.ReadFile(c.DescriptorSet)
+	if err != nil {
+		return nil, "", err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, "", fmt.Errorf("failed to decode descriptor set %s: %w", c.DescriptorSet, err)
+	}
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: targets(&set),
+		ProtoFile:      set.GetFile(),
+		// golden files are named after the fixtures
+		Parameter: proto.String("paths=source_relative"),
+	}
+	reg := descriptor.NewRegistry()
+	if err := reg.Load(req); err != nil {
+		return nil, "", err
+	}
+	var files []*descriptor.File
+	for _, name := range req.FileToGenerate {
+		f, err := reg.LookupFile(name)
+		if err != nil {
+			return nil, "", err
+		}
+		files = append(files, f)
+	}
+	out, err := c.Generate(reg, files)
+	if err != nil {
+		return nil, "", err
+	}
+	generated := map[string]string{}
+	for _, f := range out {
+		generated[f.GetName()] = f.GetContent()
+	}
+	return generated, files[0].GoPkg.Path, nil
+}
+
+// targets returns the files of the set not imported by the others
+func targets(set *descriptorpb.FileDescriptorSet) []string {
+	imported := map[string]bool{}
+	for _, f := range set.GetFile() {
+		for _, dep := range f.GetDependency() {
+			imported[dep] = true
+		}
+	}
+	var names []string
+	for _, f := range set.GetFile() {
+		if !imported[f.GetName()] {
+			names = append(names, f.GetName())
+		}
+	}
+	return names
+}
+
+// compare compares the generated files with the golden files in dir,
+// named after the generated files with the .golden suffix, rewriting
+// the golden files instead when updating
+func compare(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	existing := map[string]bool{}
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			existing[path] = true
+		}
+		return nil
+	})
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name)+".golden")
+		delete(existing, path)
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+			}
+			if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+				t.Fatalf("failed to update %s: %v", path, err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("generated %s has no golden file, run with -update to create it: %v", name, err)
+			continue
+		}
+		if diff := lineDiff(string(want), files[name]); diff != "" {
+			t.Errorf("generated %s differs from %s, run with -update if expected:\n%s", name, path, diff)
+		}
+	}
+
+	stale := make([]string, 0, len(existing))
+	for path := range existing {
+		stale = append(stale, path)
+	}
+	sort.Strings(stale)
+	for _, path := range stale {
+		if *update {
+			if err := os.Remove(path); err != nil {
+				t.Fatalf("failed to remove %s: %v", path, err)
+			}
+			continue
+		}
+		t.Errorf("golden file %s is no longer generated, run with -update to remove it", path)
+	}
+}
+
+// lineDiff returns the differing lines of the files, with a few lines
+// of the context, empty if the files are the same
+func lineDiff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	wl, gl := strings.Split(want, "\n"), strings.Split(got, "\n")
+	// skip the common prefix and the suffix of the lines
+	start := 0
+	for start < len(wl) && start < len(gl) && wl[start] == gl[start] {
+		start++
+	}
+	we, ge := len(wl), len(gl)
+	for we > start && ge > start && wl[we-1] == gl[ge-1] {
+		we--
+		ge--
+	}
+
+	const context = 3
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ line %d @@\n", start+1)
+	for i := max(0, start-context); i < start; i++ {
+		fmt.Fprintf(&b, "  %s\n", wl[i])
+	}
+	for _, l := range wl[start:we] {
+		fmt.Fprintf(&b, "- %s\n", l)
+	}
+	for _, l := range gl[start:ge] {
+		fmt.Fprintf(&b, "+ %s\n", l)
+	}
+	for i := we; i < min(len(wl), we+context); i++ {
+		fmt.Fprintf(&b, "  %s\n", wl[i])
+	}
+	return b.String()
+}
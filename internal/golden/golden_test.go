@@ -0,0 +1,110 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestTargets(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		{Name: proto.String("google/api/http.proto")},
+		{Name: proto.String("google/api/annotations.proto"), Dependency: []string{"google/api/http.proto"}},
+		{Name: proto.String("a.proto"), Dependency: []string{"google/api/annotations.proto"}},
+		{Name: proto.String("b.proto")},
+	}}
+	if got := strings.Join(targets(set), ","); got != "a.proto,b.proto" {
+		t.Errorf("targets() = %s; want a.proto,b.proto", got)
+	}
+}
+
+func TestFixture(t *testing.T) {
+	if _, err := os.Stat(Fixture("example")); err != nil {
+		t.Errorf("Fixture(example) = %v; want the descriptor set", err)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	if got := lineDiff("a\nb\n", "a\nb\n"); got != "" {
+		t.Errorf("lineDiff() of the same files = %q; want empty", got)
+	}
+	want := "@@ line 5 @@\n  b\n  c\n  d\n- e\n+ x\n+ y\n  f\n"
+	if got := lineDiff("a\nb\nc\nd\ne\nf", "a\nb\nc\nd\nx\ny\nf"); got != want {
+		t.Errorf("lineDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stale.go.golden"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	*update = true
+	compare(t, dir, map[string]string{"sub/a.go": "package a\n"})
+	*update = false
+	if _, err := os.Stat(filepath.Join(dir, "stale.go.golden")); !os.IsNotExist(err) {
+		t.Errorf("stale golden file is not removed on update")
+	}
+
+	// comparing with the updated golden files succeeds
+	compare(t, dir, map[string]string{"sub/a.go": "package a\n"})
+}
+
+func TestTypeCheck(t *testing.T) {
+	const goPkg = "github.com/go-core-stack/grpc-core/internal/example"
+	goTypes := strings.TrimSuffix(Fixture("example"), ".pb") + ".pb.go"
+	for _, spec := range []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "compiling",
+			files: map[string]string{
+				"example.a.go":      "package example\n\nimport \"github.com/gin-gonic/gin\"\n\nfunc Routes(e *gin.Engine) *PostRequest { return &PostRequest{} }\n",
+				"example_test.go":   "package example_test\n\nimport \"github.com/go-core-stack/grpc-core/internal/example\"\n\nvar _ = example.Routes\n",
+				"cmd/main/main.go":  "package main\n\nimport \"github.com/go-core-stack/grpc-core/sdk\"\n\nfunc main() { _ = sdk.NoClient }\n",
+				"example.a.go.note": "not a go file",
+			},
+		},
+		{
+			name: "clashing declarations",
+			files: map[string]string{
+				"example.a.go": "package example\n\nfunc Routes() {}\n",
+				"example.b.go": "package example\n\nfunc Routes() {}\n",
+			},
+			wantErr: "Routes redeclared",
+		},
+		{
+			name: "undefined fixture type",
+			files: map[string]string{
+				"example.a.go": "package example\n\nvar _ = &MissingRequest{}\n",
+			},
+			wantErr: "undefined: MissingRequest",
+		},
+		{
+			name: "undefined symbol of the package imported",
+			files: map[string]string{
+				"example.a.go": "package example\n\nimport \"github.com/go-core-stack/grpc-core/sdk\"\n\nvar _ = sdk.Missing\n",
+			},
+			wantErr: "undefined: sdk.Missing",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var got []string
+			for _, err := range typeCheck(goTypes, goPkg, spec.files) {
+				got = append(got, err.Error())
+			}
+			if spec.wantErr == "" && len(got) != 0 {
+				t.Errorf("typeCheck() = %q; want none", got)
+			}
+			if spec.wantErr != "" && !strings.Contains(strings.Join(got, "\n"), spec.wantErr) {
+				t.Errorf("typeCheck() = %q; want %q", got, spec.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package golden
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// packageImporter imports the packages type checked from the generated
+// files, the others from the export data built by the go command, while
+// the packages not available in the build environment, like the ones of
+// the optional companion files, are imported as empty
+type packageImporter struct {
+	local   map[string]*types.Package
+	exports map[string]string
+	gc      types.Importer
+	missing map[string]bool
+}
+
+// newPackageImporter creates the importer of the packages imported by
+// the files, building their export data using go list
+func newPackageImporter(fset *token.FileSet, files []*ast.File) (*packageImporter, error) {
+	i := &packageImporter{
+		local:   map[string]*types.Package{},
+		exports: map[string]string{},
+		missing: map[string]bool{},
+	}
+	paths := map[string]bool{}
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			paths[strings.Trim(spec.Path.Value, `"`)] = true
+		}
+	}
+	// go list runs against a copy of go.mod, left as is while adding
+	// the requirements missing
+	modfile, err := copyModFile()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(modfile))
+	args := []string{"list", "-modfile=" + modfile, "-e", "-export", "-deps", "-f", "{{ .ImportPath }}={{ .Export }}"}
+	for p := range paths {
+		args = append(args, p)
+	}
+	out, err := exec.Command("go", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the export data of the imports: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if importPath, export, ok := strings.Cut(line, "="); ok && export != "" {
+			i.exports[importPath] = export
+		}
+	}
+	i.gc = importer.ForCompiler(fset, "gc", func(importPath string) (io.ReadCloser, error) {
+		export, ok := i.exports[importPath]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", importPath)
+		}
+		return os.Open(export)
+	})
+	return i, nil
+}
+
+// copyModFile copies go.mod along with go.sum of the main module to a
+// temporary directory, returning the path of the copy of go.mod
+func copyModFile() (string, error) {
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find go.mod: %w", err)
+	}
+	gomod := strings.TrimSpace(string(out))
+	dir, err := os.MkdirTemp("", "golden")
+	if err != nil {
+		return "", err
+	}
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(gomod), name))
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, name), data, 0o644)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			_ = os.RemoveAll(dir)
+			return "", err
+		}
+	}
+	return filepath.Join(dir, "go.mod"), nil
+}
+
+// Import returns the package with the import path
+func (i *packageImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := i.local[importPath]; ok {
+		return pkg, nil
+	}
+	if _, ok := i.exports[importPath]; ok {
+		return i.gc.Import(importPath)
+	}
+	pkg := types.NewPackage(importPath, packageName(importPath))
+	pkg.MarkComplete()
+	i.missing[importPath] = true
+	return pkg, nil
+}
+
+// packageName returns the name of the package with the import path,
+// the last element of the path skipping the major version suffix
+func packageName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// typeCheck type checks the generated files, grouped into the packages
+// by the directory and the package clause, along with the go types of
+// the fixture generated by protoc-gen-go for the package with the go
+// import path goPkg, such that the generated code failing to compile,
+// like the declarations clashing across the generated files, fails the
+// case. The errors referring to the packages not available in the
+// build environment are ignored
+func typeCheck(goTypes, goPkg string, files map[string]string) []error {
+	fset := token.NewFileSet()
+	var errs []error
+	type pkgFiles struct {
+		dir, name string
+		files     []*ast.File
+	}
+	groups := map[string]*pkgFiles{}
+	add := func(dir string, f *ast.File) {
+		key := dir + ":" + f.Name.Name
+		if groups[key] == nil {
+			groups[key] = &pkgFiles{dir: dir, name: f.Name.Name}
+		}
+		groups[key].files = append(groups[key].files, f)
+	}
+	fixture, err := parser.ParseFile(fset, goTypes, nil, 0)
+	if err != nil {
+		return []error{err}
+	}
+	add(".", fixture)
+	for name, content := range files {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, content, 0)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		add(filepath.Dir(filepath.FromSlash(name)), f)
+	}
+
+	var all []*ast.File
+	for _, g := range groups {
+		all = append(all, g.files...)
+	}
+	imp, err := newPackageImporter(fset, all)
+	if err != nil {
+		return append(errs, err)
+	}
+
+	// package of the fixture is checked first, to be imported by the
+	// others, like the external tests
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if fa, fb := groups[keys[a]].files[0] == fixture, groups[keys[b]].files[0] == fixture; fa != fb {
+			return fa
+		}
+		return keys[a] < keys[b]
+	})
+	for _, key := range keys {
+		g := groups[key]
+		pkgPath := path.Join(goPkg, filepath.ToSlash(g.dir))
+		if strings.HasSuffix(g.name, "_test") {
+			pkgPath += "_test"
+		}
+		var pkgErrs []types.Error
+		conf := types.Config{
+			Importer: imp,
+			Error: func(err error) {
+				pkgErrs = append(pkgErrs, err.(types.Error))
+			},
+		}
+		pkg, _ := conf.Check(pkgPath, fset, g.files, nil)
+		if g.files[0] == fixture {
+			imp.local[goPkg] = pkg
+		}
+		for _, err := range pkgErrs {
+			if !refersMissing(err, g.files, imp.missing) {
+				errs = append(errs, fmt.Errorf("%s", err))
+			}
+		}
+	}
+	return errs
+}
+
+// refersMissing reports whether the error refers to a package imported
+// as empty by the file the error is reported for
+func refersMissing(err types.Error, files []*ast.File, missing map[string]bool) bool {
+	for _, f := range files {
+		if err.Fset.File(f.Pos()) != err.Fset.File(err.Pos) {
+			continue
+		}
+		for _, spec := range f.Imports {
+			importPath := strings.Trim(spec.Path.Value, `"`)
+			if !missing[importPath] {
+				continue
+			}
+			name := packageName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if strings.Contains(err.Msg, "undefined: "+name+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
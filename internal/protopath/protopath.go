@@ -0,0 +1,70 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+// Package protopath operates on the fields of the messages given by
+// their paths, like object.create_time, descending into the repeated
+// and the map fields on the way, shared by the SDK and the routes
+package protopath
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Has reports whether the field at the path is set in the message, in
+// any of the elements when the path crosses a repeated or a map field
+func Has(m protoreflect.Message, path string) bool {
+	return walk(m, strings.Split(path, "."), false)
+}
+
+// Clear clears the field at the path in the message, in all of the
+// elements when the path crosses a repeated or a map field
+func Clear(m protoreflect.Message, path string) {
+	walk(m, strings.Split(path, "."), true)
+}
+
+// walk visits the field at the path, clearing it if asked, reporting
+// whether it is set anywhere. The map values are addressed as value,
+// like labels.value.owner, as the fields of the map entries
+func walk(m protoreflect.Message, path []string, clear bool) bool {
+	if !m.IsValid() {
+		return false
+	}
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil || !m.Has(fd) {
+		return false
+	}
+	if len(path) == 1 {
+		if clear {
+			m.Clear(fd)
+		}
+		return true
+	}
+	get := m.Get
+	if clear {
+		get = m.Mutable
+	}
+	found := false
+	switch {
+	case fd.IsMap():
+		if len(path) < 3 || path[1] != "value" || fd.MapValue().Message() == nil {
+			return false
+		}
+		get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			found = walk(v.Message(), path[2:], clear) || found
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() == nil {
+			return false
+		}
+		l := get(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			found = walk(l.Get(i).Message(), path[1:], clear) || found
+		}
+	case fd.Message() != nil:
+		found = walk(get(fd).Message(), path[1:], clear)
+	}
+	return found
+}
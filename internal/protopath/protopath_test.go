@@ -0,0 +1,48 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package protopath
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestClear(t *testing.T) {
+	msg, err := structpb.NewStruct(map[string]any{
+		"a": map[string]any{"b": "c"},
+		"d": []any{"e"},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct() failed with %v; want success", err)
+	}
+	for path, want := range map[string]bool{
+		"fields":                                      true,
+		"fields.value.struct_value":                   true,
+		"fields.value.list_value.values":              true,
+		"fields.value.list_value.values.string_value": true,
+		"fields.value.list_value.values.bool_value":   false,
+		"fields.value.number_value":                   false,
+		"fields.key":                                  false,
+		"unknown":                                     false,
+		"fields.value.struct_value.bogus":             false,
+	} {
+		if got := Has(msg.ProtoReflect(), path); got != want {
+			t.Errorf("Has(%s) = %v; want %v", path, got, want)
+		}
+	}
+
+	Clear(msg.ProtoReflect(), "fields.value.struct_value.fields.value.string_value")
+	if got := msg.GetFields()["a"].GetStructValue().GetFields()["b"]; got == nil || got.GetKind() != nil {
+		t.Errorf("Clear() nested in the maps left %v; want cleared value", got)
+	}
+	Clear(msg.ProtoReflect(), "fields.value.list_value")
+	if got := msg.GetFields()["d"]; !proto.Equal(got, &structpb.Value{}) {
+		t.Errorf("Clear() nested in the map left %v; want cleared value", got)
+	}
+	if Has(msg.ProtoReflect(), "fields.value.list_value") {
+		t.Errorf("Has() after Clear() = true; want false")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+// Package redact redacts the values of the sensitive fields, given by
+// their dotted paths, from the query params and the JSON bodies logged
+// by the SDK and the routes, matching the fields irrespective of the
+// JSON or the proto naming
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Value replaces the values of the sensitive fields
+const Value = "[REDACTED]"
+
+// URL returns the URL with the values of the query params of the
+// sensitive fields redacted
+func URL(u *url.URL, paths []string) string {
+	if len(paths) == 0 || u.RawQuery == "" {
+		return u.String()
+	}
+	q := u.Query()
+	redacted := false
+	for key, values := range q {
+		if matchPath(paths, key) {
+			for i := range values {
+				values[i] = Value
+			}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
+
+// RelativePaths returns the paths relative to the field sent as the
+// body, the empty path redacting the whole body
+func RelativePaths(paths []string, body string) []string {
+	if body == "" || body == "*" {
+		return paths
+	}
+	body = normalizePath(body)
+	var relative []string
+	for _, p := range paths {
+		p = normalizePath(p)
+		switch {
+		case p == body || strings.HasPrefix(body, p+"."):
+			return []string{""}
+		case strings.HasPrefix(p, body+"."):
+			relative = append(relative, p[len(body)+1:])
+		}
+	}
+	return relative
+}
+
+// Body returns the JSON body with the values of the fields at the
+// paths redacted, the bodies which can not be redacted are omitted
+// while the ones without the sensitive fields are logged as is,
+// truncated beyond max
+func Body(body []byte, max int, paths []string) string {
+	if len(paths) == 0 {
+		if len(body) > max {
+			return string(body[:max]) + "...(truncated)"
+		}
+		return string(body)
+	}
+	if len(paths) == 1 && paths[0] == "" {
+		return Value
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if len(body) > max || dec.Decode(&v) != nil {
+		return "(omitted)"
+	}
+	for _, p := range paths {
+		v = redactValue(v, strings.Split(normalizePath(p), "."))
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "(omitted)"
+	}
+	return string(data)
+}
+
+// redactValue redacts the value at the path, applying the path to each
+// element of the arrays along the way
+func redactValue(v any, path []string) any {
+	switch v := v.(type) {
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i], path)
+		}
+		return v
+	case map[string]any:
+		for key, field := range v {
+			if normalizeName(key) != path[0] {
+				continue
+			}
+			if len(path) == 1 {
+				v[key] = Value
+			} else {
+				v[key] = redactValue(field, path[1:])
+			}
+		}
+		return v
+	}
+	return v
+}
+
+// matchPath reports whether the path of the query param is one of the
+// paths, or is nested under one of them
+func matchPath(paths []string, path string) bool {
+	path = normalizePath(path)
+	for _, p := range paths {
+		p = normalizePath(p)
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePath normalizes the names of the components of the path
+func normalizePath(path string) string {
+	parts := strings.Split(path, ".")
+	for i := range parts {
+		parts[i] = normalizeName(parts[i])
+	}
+	return strings.Join(parts, ".")
+}
+
+// normalizeName returns the name irrespective of the JSON lowerCamel or
+// the proto snake_case naming
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
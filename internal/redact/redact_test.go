@@ -0,0 +1,27 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package redact
+
+import (
+	"testing"
+)
+
+func TestBody(t *testing.T) {
+	for _, spec := range []struct {
+		body  string
+		paths []string
+		want  string
+	}{
+		{body: `{"a":1}`, want: `{"a":1}`},
+		{body: `0123456789abcdefghijklmnopqrstuvwxyz`, want: `0123456789abcdefghijklmno...(truncated)`},
+		{body: `{"password":"0123456789abcdef"}`, paths: []string{"password"}, want: "(omitted)"},
+		{body: `not json`, paths: []string{"password"}, want: "(omitted)"},
+		{body: `[{"p":1},{"p":2,"q":3}]`, paths: []string{"p"}, want: `[{"p":"[REDACTED]"},{"p":"[REDACTED]","q":3}]`},
+		{body: `{"p":{"q":1}}`, paths: []string{""}, want: Value},
+	} {
+		if got := Body([]byte(spec.body), 25, spec.paths); got != spec.want {
+			t.Errorf("Body(%s, %v) = %s; want %s", spec.body, spec.paths, got, spec.want)
+		}
+	}
+}
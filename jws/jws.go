@@ -0,0 +1,192 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package jws provides the detached JSON Web Signatures, as per RFC 7515
+// appendix F, used by the generated routes to sign the responses of the
+// methods marked using (api.signed) and by the generated SDK to verify
+// them, providing end to end integrity of the response beyond TLS.
+package jws
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Header is the http header carrying the detached signature of the
+// response body
+const Header = "X-Jws-Signature"
+
+// ErrInvalidSignature is returned when the signature fails to verify
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Signer signs the payload using the key it holds, allowing the keys to
+// be held by an external KMS or HSM
+type Signer interface {
+	// KeyID returns the id of the key, sent as kid of the signature
+	KeyID() string
+
+	// Algorithm returns the JWS algorithm of the signature
+	Algorithm() string
+
+	// Sign returns the signature of the signing input
+	Sign(ctx context.Context, input []byte) ([]byte, error)
+}
+
+// KeySet is the set of public keys, keyed by id, used to verify the
+// signatures, retaining the previous keys allows rotating them
+type KeySet map[string]crypto.PublicKey
+
+// header is the protected header of the signature
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// localSigner signs the payload using the private key held in memory
+type localSigner struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+// NewSigner creates the signer using the Ed25519 or the ECDSA P-256 and
+// P-384 private key, signing with EdDSA, ES256 and ES384 respectively
+func NewSigner(kid string, key crypto.Signer) (Signer, error) {
+	alg, err := algorithm(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &localSigner{kid: kid, alg: alg, key: key}, nil
+}
+
+func (s *localSigner) KeyID() string {
+	return s.kid
+}
+
+func (s *localSigner) Algorithm() string {
+	return s.alg
+}
+
+func (s *localSigner) Sign(ctx context.Context, input []byte) ([]byte, error) {
+	key, ok := s.key.(*ecdsa.PrivateKey)
+	if !ok {
+		return s.key.Sign(rand.Reader, input, crypto.Hash(0))
+	}
+	der, err := ecdsa.SignASN1(rand.Reader, key, digest(s.alg, input))
+	if err != nil {
+		return nil, err
+	}
+	// JWS expects the fixed size concatenation of R and S
+	var sig struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, err
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	sig.R.FillBytes(out[:size])
+	sig.S.FillBytes(out[size:])
+	return out, nil
+}
+
+// algorithm returns the JWS algorithm used for the public key
+func algorithm(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return "EdDSA", nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+	}
+	return "", fmt.Errorf("unsupported key type %T", key)
+}
+
+func digest(alg string, input []byte) []byte {
+	if alg == "ES384" {
+		sum := sha512.Sum384(input)
+		return sum[:]
+	}
+	sum := sha256.Sum256(input)
+	return sum[:]
+}
+
+// Sign returns the detached signature of the payload, in the compact
+// serialization with the payload omitted
+func Sign(ctx context.Context, s Signer, payload []byte) (string, error) {
+	data, err := json.Marshal(&header{Algorithm: s.Algorithm(), KeyID: s.KeyID()})
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(data)
+	sig, err := s.Sign(ctx, signingInput(protected, payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// Verify verifies the detached signature of the payload using the key
+// of the set it was signed with, the algorithm is required to match the
+// one used for the key
+func Verify(keys KeySet, signature string, payload []byte) error {
+	protected, sig, ok := strings.Cut(signature, "..")
+	if !ok || strings.Contains(sig, ".") {
+		return fmt.Errorf("%w: malformed detached signature", ErrInvalidSignature)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	h := &header{}
+	if err := json.Unmarshal(data, h); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	key, ok := keys[h.KeyID]
+	if !ok {
+		return fmt.Errorf("%w: unknown key %q", ErrInvalidSignature, h.KeyID)
+	}
+	if alg, err := algorithm(key); err != nil || alg != h.Algorithm {
+		return fmt.Errorf("%w: algorithm %q not allowed for key %q", ErrInvalidSignature, h.Algorithm, h.KeyID)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	input := signingInput(protected, payload)
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, input, raw)
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(raw) != 2*size {
+			return ErrInvalidSignature
+		}
+		r, s := new(big.Int).SetBytes(raw[:size]), new(big.Int).SetBytes(raw[size:])
+		ok = ecdsa.Verify(k, digest(h.Algorithm, input), r, s)
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func signingInput(protected string, payload []byte) []byte {
+	return []byte(protected + "." + base64.RawURLEncoding.EncodeToString(payload))
+}
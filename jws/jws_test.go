@@ -0,0 +1,68 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package jws
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	keys := KeySet{
+		"ed":   edKey.Public(),
+		"p256": p256.Public(),
+		"p384": p384.Public(),
+	}
+	payload := []byte(`{"amount":"100"}`)
+	for kid, key := range map[string]crypto.Signer{"ed": edKey, "p256": p256, "p384": p384} {
+		t.Run(kid, func(t *testing.T) {
+			s, err := NewSigner(kid, key)
+			if err != nil {
+				t.Fatalf("NewSigner() failed with %v; want success", err)
+			}
+			sig, err := Sign(context.Background(), s, payload)
+			if err != nil {
+				t.Fatalf("Sign() failed with %v; want success", err)
+			}
+			if err := Verify(keys, sig, payload); err != nil {
+				t.Errorf("Verify() failed with %v; want success", err)
+			}
+			if err := Verify(keys, sig, []byte(`{"amount":"900"}`)); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() of tampered payload = %v; want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejected(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s, _ := NewSigner("ed", edKey)
+	payload := []byte("data")
+	sig, err := Sign(context.Background(), s, payload)
+	if err != nil {
+		t.Fatalf("Sign() failed with %v; want success", err)
+	}
+	for name, tc := range map[string]struct {
+		keys KeySet
+		sig  string
+	}{
+		"unknown key":    {keys: KeySet{"other": edKey.Public()}, sig: sig},
+		"algorithm swap": {keys: KeySet{"ed": other.Public()}, sig: sig},
+		"malformed":      {keys: KeySet{"ed": edKey.Public()}, sig: "abc.def.ghi"},
+	} {
+		if err := Verify(tc.keys, tc.sig, payload); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Verify() with %s = %v; want ErrInvalidSignature", name, err)
+		}
+	}
+}
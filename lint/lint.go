@@ -0,0 +1,95 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package lint allows the organizations to enforce their own rules over
+// the proto files, beyond the built in checks of protoc-gen-sdk and
+// protoc-gen-routes, by compiling the custom validators into a wrapper
+// protoc plugin
+//
+//	func main() {
+//		lint.Register(lint.Validator{
+//			Name: "method-comments",
+//			Method: func(m *protogen.Method) error {
+//				if m.Comments.Leading == "" {
+//					return errors.New("method is not documented")
+//				}
+//				return nil
+//			},
+//		})
+//		lint.Main()
+//	}
+//
+// The wrapper plugin is run along with the generators, failing the
+// generation with all the violations found
+//
+//	protoc --lint_out=. --sdk_out=. service.proto
+package lint
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/go-core-stack/grpc-core/internal/codegenerator"
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+)
+
+// Validator is the custom check run over the services, the methods and
+// the fields of the messages of the files being generated, having
+// access to the options and the comments through the protogen
+// descriptors. Any of the checks may be nil
+type Validator = descriptor.Validator
+
+var (
+	mu         sync.Mutex
+	validators []Validator
+)
+
+// Register registers the validator run by Main, panicking if the name
+// is empty or is already registered
+func Register(v Validator) {
+	mu.Lock()
+	defer mu.Unlock()
+	if v.Name == "" {
+		panic("lint: validator name is empty")
+	}
+	for _, known := range validators {
+		if known.Name == v.Name {
+			panic(fmt.Sprintf("lint: validator %q is already registered", v.Name))
+		}
+	}
+	validators = append(validators, v)
+}
+
+// Registered returns the validators registered so far, in the order
+// they are registered
+func Registered() []Validator {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Validator(nil), validators...)
+}
+
+// Main runs the protoc plugin loading the files to be generated, as
+// done by the generators, running the built in checks along with the
+// registered validators, without generating any files. Parameters of
+// the plugin are set as the flags of the command line
+func Main() {
+	flag.Parse()
+	protogen.Options{
+		ParamFunc: flag.CommandLine.Set,
+	}.Run(func(gen *protogen.Plugin) error {
+		codegenerator.SetSupportedFeaturesOnPluginGen(gen)
+		return load(gen, Registered())
+	})
+}
+
+// load loads the files into the registry running the validators
+func load(gen *protogen.Plugin, validators []Validator) error {
+	reg := descriptor.NewRegistry()
+	for _, v := range validators {
+		reg.AddValidator(v)
+	}
+	return reg.LoadFromPlugin(gen)
+}
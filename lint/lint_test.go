@@ -0,0 +1,63 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package lint
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestRegister(t *testing.T) {
+	defer func() { validators = nil }()
+
+	Register(Validator{Name: "first"})
+	Register(Validator{Name: "second"})
+	if got := Registered(); len(got) != 2 || got[0].Name != "first" || got[1].Name != "second" {
+		t.Errorf("Registered() = %v; want [first second]", got)
+	}
+	for _, v := range []Validator{{}, {Name: "first"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) succeeded; want panic", v.Name)
+				}
+			}()
+			Register(v)
+		}()
+	}
+}
+
+func TestLoad(t *testing.T) {
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"example.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("example.proto"),
+			Package: proto.String("example"),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/example")},
+			Service: []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("legacy_service")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("protogen.Options.New() failed with %v; want success", err)
+	}
+	err = load(gen, []Validator{{
+		Name: "service-names",
+		Service: func(svc *protogen.Service) error {
+			if strings.Contains(string(svc.Desc.Name()), "_") {
+				return errors.New("service name is not camel case")
+			}
+			return nil
+		},
+	}})
+	want := "example.proto: example.legacy_service: service-names: service name is not camel case"
+	if err == nil || err.Error() != want {
+		t.Errorf("load() = %v; want %s", err, want)
+	}
+}
@@ -1,8 +1,12 @@
 package genroute
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"go/format"
+	"path"
+	"text/template"
 
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/proto"
@@ -14,23 +18,102 @@ import (
 
 var errNoTargetService = errors.New("no target service defined in the file")
 
+// envelopePkg is imported by the files with methods having encrypted
+// request fields
+var envelopePkg = descriptor.GoPackage{
+	Path: "github.com/go-core-stack/grpc-core/envelope",
+	Name: "envelope",
+}
+
 type generator struct {
 	reg                *descriptor.Registry
+	baseImports        []descriptor.GoPackage
 	useRequestContext  bool
 	registerFuncSuffix string
 	allowPatchFeature  bool
 	standalone         bool
+	acceptLanguage     bool
+	wire               bool
+	fx                 bool
+	strictQuery        bool
+	router             string
+}
+
+// Options configures the code generated along with the routes
+type Options struct {
+	// UseRequestContext uses the context of the http requests
+	UseRequestContext bool
+	// RegisterFuncSuffix is used to construct the names of the generated
+	// Register*<Suffix> functions
+	RegisterFuncSuffix string
+	// AllowPatchFeature uses the update masks for the PATCH methods
+	AllowPatchFeature bool
+	// Standalone generates the package importing the target service
+	// package
+	Standalone bool
+	// AcceptLanguage parses the Accept-Language header into the context
+	// of the requests
+	AcceptLanguage bool
+	// Wire and Fx generate the google/wire provider sets and the Fx
+	// modules registering the routes
+	Wire bool
+	Fx   bool
+	// StrictQuery rejects the requests carrying the query parameters not
+	// mapping to the request
+	StrictQuery bool
+	// Router generates the registration of the routes on the router,
+	// gin, echo or http for http.ServeMux, none if empty
+	Router string
 }
 
-// New returns a new generator which generates grpc gateway files.
-func New(reg *descriptor.Registry, useRequestContext bool, registerFuncSuffix string,
-	allowPatchFeature, standalone bool) gen.Generator {
+// New returns a new generator which generates the routes of the
+// services as configured using opts
+func New(reg *descriptor.Registry, opts Options) gen.Generator {
+	var imports []descriptor.GoPackage
+	for _, pkgpath := range []string{
+		"context",
+		"errors",
+		"io",
+		"net/http",
+		"github.com/go-core-stack/auth/model",
+		"github.com/go-core-stack/grpc-core/routes",
+		"github.com/grpc-ecosystem/grpc-gateway/v2/runtime",
+		"github.com/grpc-ecosystem/grpc-gateway/v2/utilities",
+		"google.golang.org/protobuf/proto",
+		"google.golang.org/grpc",
+		"google.golang.org/grpc/codes",
+		"google.golang.org/grpc/metadata",
+		"google.golang.org/grpc/status",
+	} {
+		pkg := descriptor.GoPackage{
+			Path: pkgpath,
+			Name: path.Base(pkgpath),
+		}
+		if err := reg.ReserveGoPackageAlias(pkg.Name, pkg.Path); err != nil {
+			for i := 0; ; i++ {
+				alias := fmt.Sprintf("%s_%d", pkg.Name, i)
+				if err := reg.ReserveGoPackageAlias(alias, pkg.Path); err != nil {
+					continue
+				}
+				pkg.Alias = alias
+				break
+			}
+		}
+		imports = append(imports, pkg)
+	}
+
 	return &generator{
 		reg:                reg,
-		useRequestContext:  useRequestContext,
-		registerFuncSuffix: registerFuncSuffix,
-		allowPatchFeature:  allowPatchFeature,
-		standalone:         standalone,
+		baseImports:        imports,
+		useRequestContext:  opts.UseRequestContext,
+		registerFuncSuffix: opts.RegisterFuncSuffix,
+		allowPatchFeature:  opts.AllowPatchFeature,
+		standalone:         opts.Standalone,
+		acceptLanguage:     opts.AcceptLanguage,
+		wire:               opts.Wire,
+		fx:                 opts.Fx,
+		strictQuery:        opts.StrictQuery,
+		router:             opts.Router,
 	}
 }
 
@@ -42,6 +125,14 @@ func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.Response
 		}
 
 		code, err := g.generate(file)
+		skipped := err == errNoTargetService && len(file.Skipped) != 0
+		if skipped {
+			// all the services of the file are skipped using best_effort,
+			// the file carries the notes reporting them alone
+			w := bytes.NewBuffer(nil)
+			err = skippedtemplate.Execute(w, file)
+			code = w.String()
+		}
 		if err == errNoTargetService {
 			if grpclog.V(1) {
 				grpclog.Infof("%s: %v", file.GetName(), err)
@@ -63,20 +154,107 @@ func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.Response
 				Content: proto.String(string(formatted)),
 			},
 		})
+		if skipped {
+			continue
+		}
+		for _, c := range []struct {
+			enabled bool
+			tmpl    *template.Template
+			suffix  string
+		}{
+			{enabled: g.wire, tmpl: wiretemplate, suffix: ".pb.route.wire.go"},
+			{enabled: g.fx, tmpl: fxtemplate, suffix: ".pb.route.fx.go"},
+			{enabled: g.router == "gin", tmpl: gintemplate, suffix: ".pb.route.gin.go"},
+			{enabled: g.router == "echo", tmpl: echotemplate, suffix: ".pb.route.echo.go"},
+			{enabled: g.router == "http", tmpl: httptemplate, suffix: ".pb.route.http.go"},
+		} {
+			if !c.enabled {
+				continue
+			}
+			code, err := applyFileTemplate(c.tmpl, file)
+			if err != nil {
+				return nil, err
+			}
+			formatted, err := format.Source([]byte(code))
+			if err != nil {
+				grpclog.Errorf("%v: %s", err, code)
+				return nil, err
+			}
+			files = append(files, &descriptor.ResponseFile{
+				GoPkg: file.GoPkg,
+				CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+					Name:    proto.String(file.GeneratedFilenamePrefix + c.suffix),
+					Content: proto.String(string(formatted)),
+				},
+			})
+		}
 	}
 	return files, nil
 }
 
 func (g *generator) generate(file *descriptor.File) (string, error) {
+	pkgSeen := make(map[string]bool)
+	var imports []descriptor.GoPackage
+	for _, pkg := range g.baseImports {
+		pkgSeen[pkg.Path] = true
+		imports = append(imports, pkg)
+	}
+
+	if g.standalone {
+		imports = append(imports, file.GoPkg)
+	}
+
+	for _, svc := range file.Services {
+		for _, m := range svc.Methods {
+			if len(m.Bindings) == 0 {
+				continue
+			}
+			imports = append(imports, g.addEnumPathParamImports(file, m, pkgSeen)...)
+			if isUnary(m) && len(m.EncryptedFields) != 0 && !pkgSeen[envelopePkg.Path] {
+				pkgSeen[envelopePkg.Path] = true
+				imports = append(imports, envelopePkg)
+			}
+			for _, pkg := range []descriptor.GoPackage{m.RequestType.File.GoPkg, m.ResponseType.File.GoPkg} {
+				if pkg == file.GoPkg || pkgSeen[pkg.Path] {
+					continue
+				}
+				pkgSeen[pkg.Path] = true
+				imports = append(imports, pkg)
+			}
+		}
+	}
 
 	params := param{
 		File:               file,
+		Imports:            imports,
 		UseRequestContext:  g.useRequestContext,
 		RegisterFuncSuffix: g.registerFuncSuffix,
 		AllowPatchFeature:  g.allowPatchFeature,
+		AcceptLanguage:     g.acceptLanguage,
+		StrictQuery:        g.strictQuery,
 	}
 	if g.reg != nil {
 		params.OmitPackageDoc = g.reg.GetOmitPackageDoc()
 	}
 	return applyTemplate(params, g.reg)
 }
+
+// addEnumPathParamImports handles adding import of enum path parameter go packages
+func (g *generator) addEnumPathParamImports(file *descriptor.File, m *descriptor.Method, pkgSeen map[string]bool) []descriptor.GoPackage {
+	var imports []descriptor.GoPackage
+	for _, b := range m.Bindings {
+		for _, p := range b.PathParams {
+			e, err := g.reg.LookupEnum("", p.Target.GetTypeName())
+			if err != nil {
+				continue
+			}
+			pkg := e.File.GoPkg
+			if pkg == file.GoPkg || pkgSeen[pkg.Path] {
+				continue
+			}
+			pkgSeen[pkg.Path] = true
+			imports = append(imports, pkg)
+		}
+	}
+	return imports
+}
@@ -0,0 +1,322 @@
+package genroute
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+)
+
+// exampleFile is the file with a service having a single method bound
+// to GET, used to verify the generated files
+const exampleFile = `
+	name: "example.proto"
+	package: "example"
+	options < go_package: "example.com/example;example" >
+	message_type <
+		name: "StringMessage"
+		field <
+			name: "string"
+			number: 1
+			label: LABEL_OPTIONAL
+			type: TYPE_STRING
+		>
+	>
+	service <
+		name: "ExampleService"
+		method <
+			name: "Echo"
+			input_type: "StringMessage"
+			output_type: "StringMessage"
+			options <
+				[google.api.http] <
+					get: "/v1/example/{string}"
+				>
+			>
+		>
+	>
+`
+
+func TestGenerateDependencyInjection(t *testing.T) {
+	for _, spec := range []struct {
+		wire bool
+		fx   bool
+		want map[string][]string
+	}{
+		{
+			want: map[string][]string{"example.pb.route.go": nil},
+		},
+		{
+			wire: true,
+			fx:   true,
+			want: map[string][]string{
+				"example.pb.route.go": nil,
+				"example.pb.route.wire.go": {
+					`"github.com/google/wire"`,
+					"type ExampleServiceRoutes struct{}",
+					"func ProvideExampleServiceRoutes(ctx context.Context, mux routes.Mux, server ExampleServiceRouteServer) (ExampleServiceRoutes, error)",
+					"var ExampleServiceRoutesProviderSet = wire.NewSet(ProvideExampleServiceRoutes)",
+				},
+				"example.pb.route.fx.go": {
+					`"go.uber.org/fx"`,
+					`var ExampleServiceRoutesModule = fx.Module("example.ExampleService.routes",`,
+					"return RegisterExampleServiceRoutes(context.Background(), mux, server)",
+				},
+			},
+		},
+	} {
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(exampleFile), &fd); err != nil {
+			t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", exampleFile, err)
+		}
+		reg := descriptor.NewRegistry()
+		if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+			FileToGenerate: []string{fd.GetName()},
+			ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+			Parameter:      proto.String(""),
+		}); err != nil {
+			t.Fatalf("reg.Load() failed with %v; want success", err)
+		}
+		file, err := reg.LookupFile(fd.GetName())
+		if err != nil {
+			t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+		}
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Wire: spec.wire, Fx: spec.fx})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with wire=%v, fx=%v failed with %v; want success", spec.wire, spec.fx, err)
+		}
+		if len(files) != len(spec.want) {
+			t.Errorf("Generate() with wire=%v, fx=%v returned %d files; want %d", spec.wire, spec.fx, len(files), len(spec.want))
+		}
+		for _, f := range files {
+			want, ok := spec.want[path.Base(f.GetName())]
+			if !ok {
+				t.Errorf("Generate() with wire=%v, fx=%v returned unexpected file %s", spec.wire, spec.fx, f.GetName())
+				continue
+			}
+			for _, w := range want {
+				if !strings.Contains(f.GetContent(), w) {
+					t.Errorf("%s missing %s in\n%s", f.GetName(), w, f.GetContent())
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateRouter(t *testing.T) {
+	for _, spec := range []struct {
+		router string
+		file   string
+		want   []string
+	}{
+		{
+			router: "gin",
+			file:   "example.pb.route.gin.go",
+			want: []string{
+				`"github.com/gin-gonic/gin"`,
+				"func RegisterExampleServiceGinRoutes(ctx context.Context, router gin.IRoutes, server ExampleServiceRouteServer, wrap ...func(routes.Mux) routes.Mux) error",
+				"routes.NewGinMux(",
+			},
+		},
+		{
+			router: "echo",
+			file:   "example.pb.route.echo.go",
+			want: []string{
+				`"github.com/labstack/echo/v4"`,
+				"func RegisterExampleServiceEchoRoutes(ctx context.Context, group *echo.Group, server ExampleServiceRouteServer, role func(routes.RouteRole) echo.MiddlewareFunc, wrap ...func(routes.Mux) routes.Mux) error",
+				"for _, info := range DescribeExampleServiceRoutes() {",
+				"router = routes.NewEchoMux(",
+				"if route, ok := router.Route(meth, path, params); ok && roles[route] != nil {",
+			},
+		},
+		{
+			router: "http",
+			file:   "example.pb.route.http.go",
+			want: []string{
+				"func RegisterExampleServiceHTTPRoutes(ctx context.Context, serveMux *http.ServeMux, server ExampleServiceRouteServer, wrap ...func(routes.Mux) routes.Mux) error",
+				"var mux routes.Mux = routes.NewHTTPMux(serveMux)",
+			},
+		},
+	} {
+		var fd descriptorpb.FileDescriptorProto
+		if err := prototext.Unmarshal([]byte(exampleFile), &fd); err != nil {
+			t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", exampleFile, err)
+		}
+		reg := descriptor.NewRegistry()
+		if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+			FileToGenerate: []string{fd.GetName()},
+			ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+			Parameter:      proto.String(""),
+		}); err != nil {
+			t.Fatalf("reg.Load() failed with %v; want success", err)
+		}
+		file, err := reg.LookupFile(fd.GetName())
+		if err != nil {
+			t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+		}
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Router: spec.router})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with router=%s failed with %v; want success", spec.router, err)
+		}
+		if len(files) != 2 {
+			t.Fatalf("Generate() with router=%s returned %d files; want 2", spec.router, len(files))
+		}
+		if got := path.Base(files[1].GetName()); got != spec.file {
+			t.Errorf("Generate() with router=%s returned %s; want %s", spec.router, got, spec.file)
+		}
+		for _, w := range spec.want {
+			if !strings.Contains(files[1].GetContent(), w) {
+				t.Errorf("%s missing %s in\n%s", files[1].GetName(), w, files[1].GetContent())
+			}
+		}
+	}
+}
+
+func TestGenerateStrictQuery(t *testing.T) {
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(exampleFile), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", exampleFile, err)
+	}
+	reg := descriptor.NewRegistry()
+	if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+		Parameter:      proto.String(""),
+	}); err != nil {
+		t.Fatalf("reg.Load() failed with %v; want success", err)
+	}
+	file, err := reg.LookupFile(fd.GetName())
+	if err != nil {
+		t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+	}
+	for _, strict := range []bool{false, true} {
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, StrictQuery: strict})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with strictQuery=%v failed with %v; want success", strict, err)
+		}
+		if len(files) != 1 {
+			t.Fatalf("Generate() with strictQuery=%v returned %d files; want 1", strict, len(files))
+		}
+		// all the fields of the request are bound from the path
+		want := "routes.CheckQueryParams(nil, req.URL.Query(), nil)"
+		if got := strings.Contains(files[0].GetContent(), want); got != strict {
+			t.Errorf("Generate() with strictQuery=%v contains %s = %v; want %v", strict, want, got, strict)
+		}
+	}
+}
+
+func TestGenerateBestEffort(t *testing.T) {
+	reg, file := loadBestEffort(t, exampleFile+brokenService)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with best effort failed with %v; want success", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Generate() with best effort returned %d files; want 1", len(files))
+	}
+	content := files[0].GetContent()
+	for _, want := range []string{
+		`//go:generate echo "protoc-gen-routes: skipped example.BrokenService: `,
+		"func RegisterExampleServiceRoutes(",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing %s in\n%s", files[0].GetName(), want, content)
+		}
+	}
+	if strings.Contains(content, "RegisterBrokenServiceRoutes") {
+		t.Errorf("%s registers the routes of the skipped BrokenService", files[0].GetName())
+	}
+}
+
+// brokenService is the service failing the validation, skipped using
+// best_effort
+const brokenService = `
+	service <
+		name: "BrokenService"
+		method <
+			name: "Echo"
+			input_type: "StringMessage"
+			output_type: "StringMessage"
+			server_streaming: true
+			options <
+				[google.api.http] <
+					get: "/v1/broken/{string}"
+				>
+				[api.debug_only]: true
+			>
+		>
+	>
+`
+
+// loadBestEffort loads the file from the source in prototext into the
+// registry skipping the services failing the validation
+func loadBestEffort(t *testing.T, src string) (*descriptor.Registry, *descriptor.File) {
+	t.Helper()
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", src, err)
+	}
+	reg := descriptor.NewRegistry()
+	reg.SetBestEffort(true)
+	if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+		Parameter:      proto.String(""),
+	}); err != nil {
+		t.Fatalf("reg.Load() with best effort failed with %v; want success", err)
+	}
+	file, err := reg.LookupFile(fd.GetName())
+	if err != nil {
+		t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+	}
+	return reg, file
+}
+
+func TestGenerateAllServicesSkipped(t *testing.T) {
+	src := `
+	name: "broken.proto"
+	package: "example"
+	options < go_package: "example.com/example;example" >
+	message_type <
+		name: "StringMessage"
+		field <
+			name: "string"
+			number: 1
+			label: LABEL_OPTIONAL
+			type: TYPE_STRING
+		>
+	>
+` + brokenService
+	reg, file := loadBestEffort(t, src)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Wire: true, Fx: true, Router: "http"})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with all the services skipped failed with %v; want success", err)
+	}
+	// the file carries the notes alone, without the companion files
+	if len(files) != 1 {
+		t.Fatalf("Generate() with all the services skipped returned %d files; want 1", len(files))
+	}
+	content := files[0].GetContent()
+	for _, want := range []string{
+		"package example",
+		`//go:generate echo "protoc-gen-routes: skipped example.BrokenService: `,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing %s in\n%s", files[0].GetName(), want, content)
+		}
+	}
+	if strings.Contains(content, "import") || strings.Contains(content, "func ") {
+		t.Errorf("%s generates more than the notes of the skipped services:\n%s", files[0].GetName(), content)
+	}
+}
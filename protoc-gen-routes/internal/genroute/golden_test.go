@@ -0,0 +1,50 @@
+package genroute
+
+import (
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+	"github.com/go-core-stack/grpc-core/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Run(t,
+		golden.Case{
+			Name:          "default",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true}).Generate(targets)
+			},
+		},
+		golden.Case{
+			Name:          "all_features",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{
+					UseRequestContext:  true,
+					RegisterFuncSuffix: "Handler",
+					AllowPatchFeature:  true,
+					AcceptLanguage:     true,
+					Wire:               true,
+					Fx:                 true,
+					StrictQuery:        true,
+					Router:             "gin",
+				}).Generate(targets)
+			},
+		},
+		golden.Case{
+			Name:          "echo",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Router: "echo"}).Generate(targets)
+			},
+		},
+		golden.Case{
+			Name:          "http",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Router: "http"}).Generate(targets)
+			},
+		},
+	)
+}
@@ -2,8 +2,13 @@ package genroute
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"text/template"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
 	"google.golang.org/grpc/grpclog"
 
 	"github.com/go-core-stack/grpc-core/internal/casing"
@@ -12,11 +17,136 @@ import (
 
 type param struct {
 	*descriptor.File
+	Imports            []descriptor.GoPackage
 	UseRequestContext  bool
 	RegisterFuncSuffix string
 	AllowPatchFeature  bool
 	OmitPackageDoc     bool
 	PathPrefix         string
+	AcceptLanguage     bool
+	StrictQuery        bool
+}
+
+type binding struct {
+	*descriptor.Binding
+	Registry          *descriptor.Registry
+	AllowPatchFeature bool
+	AcceptLanguage    bool
+	StrictQuery       bool
+}
+
+// GetBodyFieldPath returns the binding body's field path.
+func (b binding) GetBodyFieldPath() string {
+	if b.Body != nil && len(b.Body.FieldPath) != 0 {
+		return b.Body.FieldPath.String()
+	}
+	return "*"
+}
+
+// GetBodyFieldStructName returns the binding body's struct field name.
+func (b binding) GetBodyFieldStructName() (string, error) {
+	if b.Body != nil && len(b.Body.FieldPath) != 0 {
+		return casing.Camel(b.Body.FieldPath.String()), nil
+	}
+	return "", errors.New("no body field found")
+}
+
+// HasQueryParam determines if the binding needs parameters in query string.
+//
+// It sometimes returns true even though actually the binding does not need.
+// But it is not serious because it just results in a small amount of extra codes generated.
+func (b binding) HasQueryParam() bool {
+	if b.Body != nil && len(b.Body.FieldPath) == 0 {
+		return false
+	}
+	fields := make(map[string]bool)
+	for _, f := range b.Method.RequestType.Fields {
+		fields[f.GetName()] = true
+	}
+	if b.Body != nil {
+		delete(fields, b.Body.FieldPath.String())
+	}
+	for _, p := range b.PathParams {
+		delete(fields, p.FieldPath.String())
+	}
+	return len(fields) > 0
+}
+
+func (b binding) QueryParamFilter() queryParamFilter {
+	var seqs [][]string
+	if b.Body != nil {
+		seqs = append(seqs, strings.Split(b.Body.FieldPath.String(), "."))
+	}
+	for _, p := range b.PathParams {
+		seqs = append(seqs, strings.Split(p.FieldPath.String(), "."))
+	}
+	return queryParamFilter{utilities.NewDoubleArray(seqs)}
+}
+
+// HasEnumPathParam returns true if the path parameter slice contains a parameter
+// that maps to an enum proto field that is not repeated, if not false is returned.
+func (b binding) HasEnumPathParam() bool {
+	return b.hasEnumPathParam(false)
+}
+
+// HasRepeatedEnumPathParam returns true if the path parameter slice contains a parameter
+// that maps to a repeated enum proto field, if not false is returned.
+func (b binding) HasRepeatedEnumPathParam() bool {
+	return b.hasEnumPathParam(true)
+}
+
+// hasEnumPathParam returns true if the path parameter slice contains a parameter
+// that maps to an enum proto field and that the enum proto field is or isn't repeated
+// based on the provided 'repeated' parameter.
+func (b binding) hasEnumPathParam(repeated bool) bool {
+	for _, p := range b.PathParams {
+		if p.IsEnum() && p.IsRepeated() == repeated {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupEnum looks up an enum type by path parameter.
+func (b binding) LookupEnum(p descriptor.Parameter) *descriptor.Enum {
+	e, err := b.Registry.LookupEnum("", p.Target.GetTypeName())
+	if err != nil {
+		return nil
+	}
+	return e
+}
+
+// FieldMaskField returns the golang-style name of the variable for a FieldMask, if there is exactly one of that type in
+// the message. Otherwise, it returns an empty string.
+func (b binding) FieldMaskField() string {
+	var fieldMaskField *descriptor.Field
+	for _, f := range b.Method.RequestType.Fields {
+		if f.GetTypeName() == ".google.protobuf.FieldMask" {
+			// if there is more than 1 FieldMask for this request, then return none
+			if fieldMaskField != nil {
+				return ""
+			}
+			fieldMaskField = f
+		}
+	}
+	if fieldMaskField != nil {
+		return casing.Camel(fieldMaskField.GetName())
+	}
+	return ""
+}
+
+// queryParamFilter is a wrapper of utilities.DoubleArray which provides String() to output DoubleArray.Encoding in a stable and predictable format.
+type queryParamFilter struct {
+	*utilities.DoubleArray
+}
+
+func (f queryParamFilter) String() string {
+	encodings := make([]string, len(f.Encoding))
+	for str, enc := range f.Encoding {
+		encodings[enc] = fmt.Sprintf("%q: %d", str, enc)
+	}
+	e := strings.Join(encodings, ", ")
+	return fmt.Sprintf("&utilities.DoubleArray{Encoding: map[string]int{%s}, Base: %#v, Check: %#v}", e, f.Base, f.Check)
 }
 
 type trailerParams struct {
@@ -27,6 +157,141 @@ type trailerParams struct {
 	PathPrefix         string
 }
 
+// isUnary reports whether the method is neither client nor server
+// streaming, only unary methods are served by the generated handlers
+func isUnary(m *descriptor.Method) bool {
+	return !m.GetClientStreaming() && !m.GetServerStreaming()
+}
+
+// identityField returns the field of the auth info of the principal
+// carrying the identity as per the (api.from_identity) option
+func identityField(identity string) string {
+	switch identity {
+	case "TENANT":
+		return "Realm"
+	case "EMAIL":
+		return "Email"
+	}
+	return "UserName"
+}
+
+// hasEncryptedFields reports whether any of the methods of the service
+// served by the generated handlers has encrypted request fields
+func hasEncryptedFields(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && isUnary(m) && len(m.EncryptedFields) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSignedMethods reports whether any of the methods of the service
+// served by the generated handlers signs the response
+func hasSignedMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && m.Signed {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInvalidatingMethods reports whether any of the methods of the
+// service served by the generated handlers invalidates the cached
+// responses of the other methods
+func hasInvalidatingMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && len(m.Invalidates) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCompressedMethods reports whether any of the methods of the service
+// served by the generated handlers has the responses compressed, the
+// unary methods except the long polling ones
+func hasCompressedMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && isUnary(m) && !m.LongPoll {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFlaggedMethods reports whether any of the methods of the service
+// served by the generated handlers is guarded by a feature flag
+func hasFlaggedMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && m.FeatureFlag != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDebugMethods reports whether any of the methods of the service
+// served by the generated handlers is debug only
+func hasDebugMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && m.DebugOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// hasShadowedMethods reports whether any of the methods of the service
+// served by the generated handlers is copied to the shadow target
+func hasShadowedMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && m.ShadowRate != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLoggedMethods reports whether any of the methods of the service
+// served by the generated handlers is logged, the unary methods
+func hasLoggedMethods(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && isUnary(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileParams describes the services of the file having the routes, for
+// the companion files generated along with the routes
+type fileParams struct {
+	File     *descriptor.File
+	Services []*descriptor.Service
+}
+
+// applyFileTemplate returns the companion file generated using the
+// template for the services of the file having the routes, like the
+// dependency injection glue
+func applyFileTemplate(tmpl *template.Template, file *descriptor.File) (string, error) {
+	p := &fileParams{File: file}
+	for _, svc := range file.Services {
+		for _, m := range svc.Methods {
+			if len(m.Bindings) != 0 {
+				p.Services = append(p.Services, svc)
+				break
+			}
+		}
+	}
+	w := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(w, p); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
 func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	var targetServices []*descriptor.Service
 
@@ -35,6 +300,11 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 		msg.Name = &msgName
 	}
 
+	if err := reg.CheckDuplicateBindings(p.Services); err != nil {
+		return "", err
+	}
+
+	handlers := bytes.NewBuffer(nil)
 	for _, svc := range p.Services {
 		var methodWithBindingsSeen bool
 		svcName := casing.Camel(*svc.Name)
@@ -47,11 +317,19 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 			methName := casing.Camel(*meth.Name)
 			meth.Name = &methName
 			for _, b := range meth.Bindings {
-				if err := reg.CheckDuplicateAnnotation(b.HTTPMethod, b.PathTmpl.Template, svc); err != nil {
+				methodWithBindingsSeen = true
+				if !isUnary(meth) {
+					continue
+				}
+				if err := handlerTemplate.Execute(handlers, binding{
+					Binding:           b,
+					Registry:          reg,
+					AllowPatchFeature: p.AllowPatchFeature,
+					AcceptLanguage:    p.AcceptLanguage,
+					StrictQuery:       p.StrictQuery,
+				}); err != nil {
 					return "", err
 				}
-
-				methodWithBindingsSeen = true
 			}
 		}
 		if methodWithBindingsSeen {
@@ -74,18 +352,70 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	if err := rtemplate.Execute(w, tp); err != nil {
 		return "", err
 	}
+	if _, err := handlers.WriteTo(w); err != nil {
+		return "", err
+	}
+	if err := registerTemplate.Execute(w, tp); err != nil {
+		return "", err
+	}
 
 	return w.String(), nil
 }
 
 var (
+	httpMethods = map[string]string{
+		http.MethodGet:     "http.MethodGet",
+		http.MethodHead:    "http.MethodHead",
+		http.MethodPost:    "http.MethodPost",
+		http.MethodPut:     "http.MethodPut",
+		http.MethodPatch:   "http.MethodPatch",
+		http.MethodDelete:  "http.MethodDelete",
+		http.MethodConnect: "http.MethodConnect",
+		http.MethodOptions: "http.MethodOptions",
+		http.MethodTrace:   "http.MethodTrace",
+	}
+
+	funcMap template.FuncMap = map[string]interface{}{
+		"camel":           casing.Camel,
+		"identityField":   identityField,
+		"camelIdentifier": casing.CamelIdentifier,
+		"toHTTPMethod": func(method string) string {
+			return httpMethods[method]
+		},
+		"isUnary":                isUnary,
+		"hasEncryptedFields":     hasEncryptedFields,
+		"hasSignedMethods":       hasSignedMethods,
+		"hasInvalidatingMethods": hasInvalidatingMethods,
+		"hasCompressedMethods":   hasCompressedMethods,
+		"hasLoggedMethods":       hasLoggedMethods,
+		"hasFlaggedMethods":      hasFlaggedMethods,
+		"hasShadowedMethods":     hasShadowedMethods,
+		"hasDebugMethods":        hasDebugMethods,
+	}
+
 	rtemplate = template.Must(template.New("header").Parse(`
 // Code generated by protoc-gen-routes. DO NOT EDIT.
 // source: {{.P.GetName}}
 
 package {{.P.GoPkg.Name}}
+{{- template "skipped-notes" .P }}
 
-import "github.com/go-core-stack/auth/model"
+import (
+	{{ range $i := .P.Imports }}{{ if $i.Standard }}{{ $i | printf "%s\n" }}{{ end }}{{ end }}
+
+	{{ range $i := .P.Imports }}{{ if not $i.Standard }}{{ $i | printf "%s\n" }}{{ end }}{{ end }}
+)
+
+// Suppress "imported and not used" errors
+var (
+	_ codes.Code
+	_ io.Reader
+	_ status.Status
+	_ = errors.New
+	_ = runtime.String
+	_ = utilities.NewDoubleArray
+	_ = metadata.Join
+)
 
 {{range $svc := .Services}}
 var Routes{{$svc.GetName}} = []*model.Route{}
@@ -110,5 +440,689 @@ func init() {
 {{- end}}
 {{- end}}
 {{- end}}
-}`))
+}
+{{range $svc := .Services}}
+// Admit{{$svc.GetName}}Routes records the routes of {{$svc.GetName}} service
+// as expected on the tracker, allowing the server to verify that a
+// handler is registered for every one of them before serving
+func Admit{{$svc.GetName}}Routes(t *routes.Tracker) {
+{{- range $m := $svc.Methods}}
+{{- range $b := $m.Bindings}}
+{{- if $m.DebugOnly }}
+	if routes.Debug(t) != nil {
+		t.Expect("{{$m.FQMN}}", {{$b.HTTPMethod | printf "%q"}}, "{{ $b.PathTmpl.Template }}")
+	}
+{{- else }}
+	t.Expect("{{$m.FQMN}}", {{$b.HTTPMethod | printf "%q"}}, "{{ $b.PathTmpl.Template }}")
+{{- end}}
+{{- end}}
+{{- end}}
+}
+
+// Describe{{$svc.GetName}}Routes returns the routes of {{$svc.GetName}} service,
+// along with the rpc methods serving them and their roles, for the server
+// to register the routes dynamically, feed the API gateways or print the
+// route table at startup using routes.WriteRouteTable
+func Describe{{$svc.GetName}}Routes() []routes.RouteInfo {
+	return []routes.RouteInfo{
+	{{- range $m := $svc.Methods}}
+	{{- range $b := $m.Bindings}}
+		{
+			Route: routes.Route{Method: {{$b.HTTPMethod | printf "%q"}}, Path: "{{ $b.PathTmpl.Template }}"},
+			FQMN:  "{{$m.FQMN}}",
+			{{- with $m.Role }}
+			Role: &routes.RouteRole{
+				Resource: {{ .Resource | printf "%q" }},
+				{{- if .Scopes }}
+				Scopes:   []string{ {{- range $i, $scope := .Scopes }}{{ if $i }}, {{ end }}{{ $scope | printf "%q" }}{{ end -}} },
+				{{- end }}
+				Verb:     {{ .Verb | printf "%q" }},
+			},
+			{{- end }}
+			{{- if $m.DebugOnly }}
+			DebugOnly: true,
+			{{- end }}
+		},
+	{{- end}}
+	{{- end}}
+	}
+}
+{{end}}
+{{- define "skipped-notes" }}
+{{- if .Skipped }}
+
+// The services skipped using best_effort, as their options failed the
+// validation, are reported by go generate until fixed
+{{- range $s := .Skipped }}
+//go:generate echo {{ printf "protoc-gen-routes: skipped %s: %v" $s.Name $s.Err | printf "%q" }}
+{{- end }}
+{{ end }}
+{{- end }}`))
+
+	// skippedtemplate generates the file of the proto file all the
+	// services of which are skipped using best_effort, carrying the
+	// notes reporting them alone
+	skippedtemplate = template.Must(rtemplate.New("skipped").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{.GetName}}
+
+package {{.GoPkg.Name}}
+{{- template "skipped-notes" . }}
+`))
+
+	handlerTemplate = template.Must(template.New("handler").Funcs(funcMap).Parse(`
+{{ $AllowPatchFeature := .AllowPatchFeature }}
+{{ if .HasQueryParam }}
+var route_filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = {{ .QueryParamFilter }}
+{{ end }}
+{{- if .Method.Defaults }}
+var route_defaults_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = []routes.Default{
+{{- range .Method.Defaults }}
+	{Field: {{ .Field.GetName | printf "%q" }}, Value: {{ .Value | printf "%q" }}},
+{{- end }}
+}
+{{ end }}
+// route_log_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} describes the route for the logging enabled using routes.WithLogging
+var route_log_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = &routes.LogRoute{
+	Method: "/{{ .Method.Service.File.GetPackage }}.{{ .Method.Service.GetName }}/{{ .Method.GetName }}",
+	{{- with .Method.LogSampleRate }}
+	SampleRate: proto.Float64({{ . }}),
+	{{- end }}
+	{{- with .Method.SensitiveRequestFields }}
+	RequestFields: []string{ {{- range $i, $f := . }}{{ if $i }}, {{ end }}{{ $f | printf "%q" }}{{ end -}} },
+	{{- end }}
+	{{- if .Method.SensitiveRequestFields }}
+	{{- with .Body }}
+	RequestBody: "{{ with .FieldPath.String }}{{ . }}{{ else }}*{{ end }}",
+	{{- end }}
+	{{- end }}
+	{{- with .Method.SensitiveResponseFields }}
+	ResponseFields: []string{ {{- range $i, $f := . }}{{ if $i }}, {{ end }}{{ $f | printf "%q" }}{{ end -}} },
+	{{- end }}
+	{{- if .Method.SensitiveResponseFields }}
+	{{- with .ResponseBody }}
+	ResponseBody: {{ .FieldPath.String | printf "%q" }},
+	{{- end }}
+	{{- end }}
+}
+
+func route_request_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}(ctx context.Context, marshaler runtime.Marshaler, server {{ .Method.Service.GetName }}RouteServer, req *http.Request, pathParams map[string]string{{ if .Method.EncryptedFields }}, kms envelope.KMS{{ end }}) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq {{ .Method.RequestType.GoType .Method.Service.File.GoPkg.Path }}
+		metadata runtime.ServerMetadata
+{{- if .PathParams }}
+{{- if .HasEnumPathParam }}
+		e int32
+{{- end }}
+{{- if .HasRepeatedEnumPathParam }}
+		es []int32
+{{- end }}
+		err error
+{{- end }}
+	)
+{{- if .Body }}
+	if err := routes.Decompress(req); err != nil {
+		return nil, metadata, err
+	}
+	{{- if .IsHTTPBody }}
+	// raw bytes of the body along with its declared content type
+	body, err := routes.ReadHTTPBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	{{- if eq "*" .GetBodyFieldPath }}
+	proto.Merge(&protoReq, body)
+	{{- else }}
+	{{- $protoReq := .Body.AssignableExprPrep "protoReq" .Method.Service.File.GoPkg.Path -}}
+	{{- if ne "" $protoReq }}
+	{{ printf "%s" $protoReq }}
+	{{- end }}
+	{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }} = body
+	{{- end }}
+	{{- else }}
+	{{- $isFieldMask := and $AllowPatchFeature (eq (.HTTPMethod) "PATCH") (.FieldMaskField) (not (eq "*" .GetBodyFieldPath)) }}
+	{{- if $isFieldMask }}
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, routes.DecodeError(berr)
+	}
+	{{- end }}
+	{{- $protoReq := .Body.AssignableExprPrep "protoReq" .Method.Service.File.GoPkg.Path -}}
+	{{- if ne "" $protoReq }}
+	{{ printf "%s" $protoReq }}
+	{{- end }}
+	{{- if not $isFieldMask }}
+	if err := marshaler.NewDecoder(req.Body).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, routes.DecodeError(err)
+	}
+	{{- end }}
+	{{- if $isFieldMask }}
+	if err := marshaler.NewDecoder(newReader()).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, routes.DecodeError(err)
+	}
+	if protoReq.{{ .FieldMaskField }} == nil || len(protoReq.{{ .FieldMaskField }}.GetPaths()) == 0 {
+		if fieldMask, err := runtime.FieldMaskFromRequestBody(newReader(), protoReq.{{ .GetBodyFieldStructName }}); err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		} else {
+			protoReq.{{ .FieldMaskField }} = fieldMask
+		}
+	}
+	{{- end }}
+	{{- end }}
+{{- end }}
+{{- if .PathParams }}
+	{{- $binding := . }}
+	{{- range $index, $param := .PathParams }}
+	{{- $enum := $binding.LookupEnum $param }}
+	val, ok {{ if eq $index 0 }}:{{ end }}= pathParams[{{ $param | printf "%q" }}]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", {{ $param | printf "%q" }})
+	}
+{{- if $param.IsNestedProto3 }}
+	err = runtime.PopulateFieldFromPath(&protoReq, {{ $param | printf "%q" }}, val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", {{ $param | printf "%q" }}, err)
+	}
+	{{- if $enum }}
+		e{{ if $param.IsRepeated }}s{{ end }}, err = {{ $param.ConvertFuncExpr }}(val{{ if $param.IsRepeated }}, {{ $binding.Registry.GetRepeatedPathParamSeparator | printf "%c" | printf "%q" }}{{ end }}, {{ $enum.GoType $param.Method.Service.File.GoPkg.Path | camelIdentifier }}_value)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "could not parse path as enum value, parameter: %s, error: %v", {{ $param | printf "%q" }}, err)
+		}
+	{{- end }}
+{{- else if $enum }}
+	e{{ if $param.IsRepeated }}s{{ end }}, err = {{ $param.ConvertFuncExpr }}(val{{ if $param.IsRepeated }}, {{ $binding.Registry.GetRepeatedPathParamSeparator | printf "%c" | printf "%q" }}{{ end }}, {{ $enum.GoType $param.Method.Service.File.GoPkg.Path | camelIdentifier }}_value)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", {{ $param | printf "%q" }}, err)
+	}
+{{- else }}
+	{{- $protoReq := $param.AssignableExprPrep "protoReq" $binding.Method.Service.File.GoPkg.Path -}}
+	{{- if ne "" $protoReq }}
+	{{ printf "%s" $protoReq }}
+	{{- end }}
+	{{ $param.AssignableExpr "protoReq" $binding.Method.Service.File.GoPkg.Path }}, err = {{ $param.ConvertFuncExpr }}(val{{ if $param.IsRepeated }}, {{ $binding.Registry.GetRepeatedPathParamSeparator | printf "%c" | printf "%q" }}{{ end }})
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", {{ $param | printf "%q" }}, err)
+	}
+{{- end }}
+{{- if and $enum $param.IsRepeated }}
+	s := make([]{{ $enum.GoType $param.Method.Service.File.GoPkg.Path }}, len(es))
+	for i, v := range es {
+		s[i] = {{ $enum.GoType $param.Method.Service.File.GoPkg.Path }}(v)
+	}
+	{{ $param.AssignableExpr "protoReq" $binding.Method.Service.File.GoPkg.Path }} = s
+{{- else if $enum }}
+	{{ $param.AssignableExpr "protoReq" $binding.Method.Service.File.GoPkg.Path }} = {{ $enum.GoType $param.Method.Service.File.GoPkg.Path | camelIdentifier }}(e)
+{{- end }}
+	{{- end }}
+{{- end }}
+{{- if .HasQueryParam }}
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+{{- if .StrictQuery }}
+	if err := routes.CheckQueryParams(&protoReq, req.URL.Query(), route_filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, route_filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+{{- else if .StrictQuery }}
+	if err := routes.CheckQueryParams(nil, req.URL.Query(), nil); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
+{{- with .Method.OutputOnlyFields }}
+	// set by the server alone, cleared when echoed back by the client
+	routes.ClearFields(&protoReq{{ range . }}, {{ . | printf "%q" }}{{ end }})
+{{- end }}
+{{- if .Method.Defaults }}
+	if err := routes.PopulateDefaults(&protoReq, route_defaults_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
+		return nil, metadata, status.Errorf(codes.Internal, "%v", err)
+	}
+{{- end }}
+{{- if or .AcceptLanguage .Method.LocaleField }}
+	ctx = routes.NewLocaleContext(ctx, req)
+{{- end }}
+{{- with .Method.LocaleField }}
+{{- if .GetProto3Optional }}
+	if protoReq.{{ camel .GetName }} == nil {
+		if locale := routes.Locale(ctx); locale != "" {
+			protoReq.{{ camel .GetName }} = &locale
+		}
+	}
+{{- else }}
+	if protoReq.{{ camel .GetName }} == "" {
+		protoReq.{{ camel .GetName }} = routes.Locale(ctx)
+	}
+{{- end }}
+{{- end }}
+{{- if .Method.IdentityFields }}
+	// bound to the identity of the principal, never provided by the client
+	identity, err := routes.Identity(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+{{- range .Method.IdentityFields }}
+	protoReq.{{ camel .Field.GetName }} = {{ if .Field.GetProto3Optional }}&{{ end }}identity.{{ identityField .Identity }}
+{{- end }}
+{{- end }}
+{{- if .Method.EncryptedFields }}
+	if err := routes.OpenFields(ctx, kms, &protoReq{{ range .Method.EncryptedFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
+{{- if .Method.RequiredFields }}
+	if err := routes.CheckRequired(&protoReq{{ range .Method.RequiredFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
+{{- if and .Method.Immutable .Body (or (eq .HTTPMethod "PUT") (eq .HTTPMethod "PATCH")) }}
+{{- $resource := "&protoReq" }}
+{{- with .Method.Immutable.BodyField }}
+{{- $resource = printf "protoReq.%s" (camel .GetName) }}
+{{- end }}
+	// immutable fields of {{ .Method.Immutable.Resource.GetName }} are never changed by the update
+	if getter, ok := server.({{ .Method.Service.GetName }}{{ .Method.GetName }}Current); ok {
+		current, err := getter.Current{{ .Method.GetName }}(ctx, &protoReq)
+		switch {
+		case status.Code(err) == codes.NotFound:
+			// nothing to compare against, left to the server
+		case err != nil:
+			return nil, metadata, err
+		default:
+			if err := routes.CheckImmutable({{ $resource }}, current{{ range .Method.Immutable.Fields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+				return nil, metadata, err
+			}
+		}
+	} else {
+		routes.StripImmutable({{ $resource }}{{ range .Method.Immutable.Fields }}, {{ .GetName | printf "%q" }}{{ end }})
+	}
+{{- end }}
+	msg, err := server.{{ .Method.GetName }}(ctx, &protoReq)
+	return msg, metadata, err
+}
+`))
+
+	registerTemplate = template.Must(template.New("register").Funcs(funcMap).Parse(`
+{{ $UseRequestContext := .UseRequestContext }}
+{{ range $svc := .Services }}
+// {{ $svc.GetName }}RouteServer is the server API for {{ $svc.GetName }} service
+// served by the generated routes, this is satisfied by the
+// {{ $svc.GetName }}Server generated for grpc
+type {{ $svc.GetName }}RouteServer interface {
+	{{- range $m := $svc.Methods }}
+	{{- if and $m.Bindings (isUnary $m) }}
+	{{ $m.GetName }}(context.Context, *{{ $m.RequestType.GoType $m.Service.File.GoPkg.Path }}) (*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path }}, error)
+	{{- end }}
+	{{- end }}
+}
+{{- range $m := $svc.Methods }}
+{{- with $m.Immutable }}
+
+// {{ $svc.GetName }}{{ $m.GetName }}Current is optionally implemented by the
+// {{ $svc.GetName }}RouteServer to fetch the current {{ .Resource.GetName }} updated by
+// {{ $m.GetName }}, against which the generated routes reject the changes to
+// its immutable fields, stripped from the update otherwise. NotFound
+// error skips the check, like for the upserts creating the resource
+type {{ $svc.GetName }}{{ $m.GetName }}Current interface {
+	Current{{ $m.GetName }}(context.Context, *{{ $m.RequestType.GoType $m.Service.File.GoPkg.Path }}) (*{{ .Resource.GoType $m.Service.File.GoPkg.Path }}, error)
+}
+{{- end }}
+{{- end }}
+
+// Register{{ $svc.GetName }}Routes registers the http handlers for service
+// {{ $svc.GetName }} to "mux", calling the server directly. Request bodies
+// encoded using the codings registered with the compression package are
+// decompressed transparently, while the responses are compressed once
+// enabled using routes.WithCompression and the calls are logged once
+// enabled using routes.WithLogging. Streaming methods are currently
+// unsupported.
+func Register{{ $svc.GetName }}Routes(ctx context.Context, mux routes.Mux, server {{ $svc.GetName }}RouteServer) error {
+	serveMux := routes.ServeMux(mux)
+	{{- if hasCompressedMethods $svc }}
+	compress := routes.Compression(mux)
+	{{- end }}
+	{{- if hasEncryptedFields $svc }}
+	kms := routes.KMS(mux)
+	if kms == nil {
+		return errors.New("KMS is required to open the encrypted fields of {{ $svc.GetName }} service, see routes.WithKMS")
+	}
+	{{- end }}
+	{{- if hasSignedMethods $svc }}
+	signer := routes.Signer(mux)
+	if signer == nil {
+		return errors.New("signer is required to sign the responses of {{ $svc.GetName }} service, see routes.WithSigner")
+	}
+	{{- end }}
+	{{- if hasInvalidatingMethods $svc }}
+	purge := routes.Purge(mux)
+	{{- end }}
+	{{- if hasFlaggedMethods $svc }}
+	// methods guarded by the feature flags are disabled unless the flags
+	// are provided using routes.WithFlags
+	flags := routes.Flags(mux)
+	{{- end }}
+	{{- if hasShadowedMethods $svc }}
+	shadow := routes.Shadow(mux)
+	{{- end }}
+	{{- if hasDebugMethods $svc }}
+	// debug only methods are served once enabled using routes.WithDebug
+	debug := routes.Debug(mux)
+	{{- end }}
+	{{- if hasLoggedMethods $svc }}
+	logCall := routes.Logging(mux)
+	{{- end }}
+	{{- range $m := $svc.Methods }}
+	{{- if and (isUnary $m) $m.MaxConcurrency $m.Bindings }}
+	// concurrent calls of {{ $m.GetName }} are limited across the bindings
+	limit{{ $m.GetName }} := routes.NewLimiter("/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", {{ $m.MaxConcurrency }})
+	{{- end }}
+	{{- end }}
+	{{- range $m := $svc.Methods }}
+	{{- range $b := $m.Bindings }}
+	{{- if $m.DebugOnly }}
+	if debug != nil {
+	{{- end }}
+	{{- if not (isUnary $m) }}
+	if err := mux.HandlePath({{ $b.HTTPMethod | toHTTPMethod }}, "{{ $b.PathTmpl.Template }}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported by the generated routes")
+		_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+	}); err != nil {
+		return err
+	}
+	{{- else }}
+	if err := mux.HandlePath({{ $b.HTTPMethod | toHTTPMethod }}, "{{ $b.PathTmpl.Template }}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	{{- if $UseRequestContext }}
+		ctx, cancel := context.WithCancel(req.Context())
+	{{- else }}
+		ctx, cancel := context.WithCancel(ctx)
+	{{- end }}
+		defer cancel()
+		{{- if $m.DebugOnly }}
+		if err := debug(ctx, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}"); err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		{{- end }}
+		{{- if $m.FeatureFlag }}
+		if err := routes.CheckFlag(ctx, flags, {{ $m.FeatureFlag | printf "%q" }}); err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		{{- end }}
+		{{- if $m.MaxConcurrency }}
+		release, err := limit{{ $m.GetName }}.Acquire()
+		if err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		defer release()
+		{{- end }}
+		{{- if $m.ShadowRate }}
+		// copied to the shadow target, once enabled using routes.WithShadow
+		shadow(req, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", {{ $m.ShadowRate }})
+		{{- end }}
+		{{- if $m.LongPoll }}
+		w = routes.LongPoll(w)
+		{{- else }}
+		w, compressed := compress(w, req)
+		defer compressed()
+		{{- end }}
+		{{- if $m.Signed }}
+		w, signed := routes.SignResponse(ctx, w, signer)
+		defer signed()
+		{{- end }}
+		{{- if and $m.SurrogateKey (eq $b.HTTPMethod "GET") }}
+		// tagged for the caches to purge once invalidated
+		routes.SetSurrogateKey(w, {{ $m.SurrogateKey | printf "%q" }})
+		{{- end }}
+		w, logged := logCall(w, req, route_log_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }})
+		defer logged()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(serveMux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, serveMux, req, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", runtime.WithHTTPPathPattern("{{ $b.PathTmpl.Template }}"))
+		if err != nil {
+			runtime.HTTPError(ctx, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := route_request_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}(annotatedContext, inboundMarshaler, server, req, pathParams{{ if $m.EncryptedFields }}, kms{{ end }})
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		{{- if $m.Upsert }}
+		created := routes.Created(&md)
+		{{- end }}
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, serveMux, outboundMarshaler, w, req, err)
+			return
+		}
+		{{- if $m.Invalidates }}
+		purge(annotatedContext{{ range $m.Invalidates }}, {{ printf "%q" . }}{{ end }})
+		{{- end }}
+		{{- if $m.Upsert }}
+		if created {
+			w = routes.WithStatus(w, http.StatusCreated)
+		}
+		{{- end }}
+		{{- if and $b.ResponseBody $b.IsHTTPBodyResponse }}
+		// raw bytes of the body written as is with its declared content type
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, {{ $b.ResponseBody.AssignableExpr (printf "resp.(*%s)" ($m.ResponseType.GoType $m.Service.File.GoPkg.Path)) $m.Service.File.GoPkg.Path }}, serveMux.GetForwardResponseOptions()...)
+		{{- else if $b.ResponseBody }}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, route_response_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}{resp.(*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path }})}, serveMux.GetForwardResponseOptions()...)
+		{{- else }}
+		runtime.ForwardResponseMessage(annotatedContext, serveMux, outboundMarshaler, w, req, resp, serveMux.GetForwardResponseOptions()...)
+		{{- end }}
+	}); err != nil {
+		return err
+	}
+	{{- end }}
+	{{- if $m.DebugOnly }}
+	}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	return nil
+}
+
+{{ range $m := $svc.Methods }}
+{{ range $b := $m.Bindings }}
+{{ if and $b.ResponseBody (isUnary $m) (not $b.IsHTTPBodyResponse) }}
+type route_response_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }} struct {
+	*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path }}
+}
+
+func (m route_response_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}) XXX_ResponseBody() interface{} {
+	return {{ $b.ResponseBody.AssignableExpr "m" $m.Service.File.GoPkg.Path }}
+}
+{{ end }}
+{{ end }}
+{{ end }}
+{{ end }}`))
+
+	wiretemplate = template.Must(template.New("wire").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{ .File.GetName }}
+
+package {{ .File.GoPkg.Name }}
+
+import (
+	"context"
+
+	"github.com/google/wire"
+
+	"github.com/go-core-stack/grpc-core/routes"
+)
+{{ range $svc := .Services }}
+// {{ $svc.GetName }}Routes marks the routes of {{ $svc.GetName }} service
+// registered to the mux, for the wire injectors to depend upon
+type {{ $svc.GetName }}Routes struct{}
+
+// Provide{{ $svc.GetName }}Routes registers the routes of {{ $svc.GetName }}
+// service to the mux, calling the server directly
+func Provide{{ $svc.GetName }}Routes(ctx context.Context, mux routes.Mux, server {{ $svc.GetName }}RouteServer) ({{ $svc.GetName }}Routes, error) {
+	return {{ $svc.GetName }}Routes{}, Register{{ $svc.GetName }}Routes(ctx, mux, server)
+}
+
+// {{ $svc.GetName }}RoutesProviderSet is the wire provider set registering
+// the routes of {{ $svc.GetName }} service, expecting context.Context,
+// routes.Mux and {{ $svc.GetName }}RouteServer to be provided by the injector
+var {{ $svc.GetName }}RoutesProviderSet = wire.NewSet(Provide{{ $svc.GetName }}Routes)
+{{ end }}`))
+
+	gintemplate = template.Must(template.New("gin").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{ .File.GetName }}
+
+package {{ .File.GoPkg.Name }}
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/go-core-stack/grpc-core/routes"
+)
+{{ range $svc := .Services }}
+// Register{{ $svc.GetName }}GinRoutes registers the http handlers for service
+// {{ $svc.GetName }} to the gin router, calling the server directly, the same
+// as Register{{ $svc.GetName }}Routes with the path templates converted to the
+// gin syntax using routes.GinPath, decoding the requests into the request
+// messages and responding the errors as mapped by routes.ErrorHandler. The
+// routes are configured by wrapping the mux using wrap, like
+// routes.WithFlags, in the order provided
+func Register{{ $svc.GetName }}GinRoutes(ctx context.Context, router gin.IRoutes, server {{ $svc.GetName }}RouteServer, wrap ...func(routes.Mux) routes.Mux) error {
+	var mux routes.Mux = routes.NewGinMux(func(meth, path string, h runtime.HandlerFunc) {
+		router.Handle(meth, path, func(c *gin.Context) {
+			params := make(map[string]string, len(c.Params))
+			for _, p := range c.Params {
+				params[p.Key] = p.Value
+			}
+			h(c.Writer, c.Request, params)
+		})
+	})
+	for _, w := range wrap {
+		mux = w(mux)
+	}
+	return Register{{ $svc.GetName }}Routes(ctx, mux, server)
+}
+{{ end }}`))
+
+	echotemplate = template.Must(template.New("echo").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{ .File.GetName }}
+
+package {{ .File.GoPkg.Name }}
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+
+	"github.com/go-core-stack/grpc-core/routes"
+)
+{{ range $svc := .Services }}
+// Register{{ $svc.GetName }}EchoRoutes registers the http handlers for service
+// {{ $svc.GetName }} to the echo group, like e.Group("") for the root of the
+// router, calling the server directly, the same as
+// Register{{ $svc.GetName }}Routes with the path templates converted to the echo
+// syntax using routes.EchoPath, decoding the requests into the request
+// messages and responding the errors as mapped by routes.ErrorHandler.
+// The middleware returned by role, unless nil, is applied to the calls of
+// the methods annotated using (api.role), like authorizing the callers for
+// the role. The routes are configured by wrapping the mux using wrap, like
+// routes.WithFlags, in the order provided
+func Register{{ $svc.GetName }}EchoRoutes(ctx context.Context, group *echo.Group, server {{ $svc.GetName }}RouteServer, role func(routes.RouteRole) echo.MiddlewareFunc, wrap ...func(routes.Mux) routes.Mux) error {
+	roles := map[routes.Route]echo.MiddlewareFunc{}
+	if role != nil {
+		for _, info := range Describe{{ $svc.GetName }}Routes() {
+			if info.Role != nil {
+				roles[info.Route] = role(*info.Role)
+			}
+		}
+	}
+	var router *routes.RouterMux
+	router = routes.NewEchoMux(func(meth, path string, h runtime.HandlerFunc) {
+		group.Add(meth, path, func(c echo.Context) error {
+			params := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				params[name] = c.ParamValues()[i]
+			}
+			next := func(c echo.Context) error {
+				h(c.Response(), c.Request(), params)
+				return nil
+			}
+			// the role of the binding the call is dispatched to
+			if route, ok := router.Route(meth, path, params); ok && roles[route] != nil {
+				return roles[route](next)(c)
+			}
+			return next(c)
+		})
+	})
+	var mux routes.Mux = router
+	for _, w := range wrap {
+		mux = w(mux)
+	}
+	return Register{{ $svc.GetName }}Routes(ctx, mux, server)
+}
+{{ end }}`))
+
+	httptemplate = template.Must(template.New("http").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{ .File.GetName }}
+
+package {{ .File.GoPkg.Name }}
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-core-stack/grpc-core/routes"
+)
+{{ range $svc := .Services }}
+// Register{{ $svc.GetName }}HTTPRoutes registers the http handlers for service
+// {{ $svc.GetName }} to the http.ServeMux, calling the server directly, the
+// same as Register{{ $svc.GetName }}Routes with the path templates converted to
+// the method qualified patterns using routes.HTTPPath, decoding the requests
+// into the request messages and responding the errors as mapped by
+// routes.ErrorHandler. The routes are configured by wrapping the mux
+// using wrap, like routes.WithFlags, in the order provided
+func Register{{ $svc.GetName }}HTTPRoutes(ctx context.Context, serveMux *http.ServeMux, server {{ $svc.GetName }}RouteServer, wrap ...func(routes.Mux) routes.Mux) error {
+	var mux routes.Mux = routes.NewHTTPMux(serveMux)
+	for _, w := range wrap {
+		mux = w(mux)
+	}
+	return Register{{ $svc.GetName }}Routes(ctx, mux, server)
+}
+{{ end }}`))
+
+	fxtemplate = template.Must(template.New("fx").Parse(`
+// Code generated by protoc-gen-routes. DO NOT EDIT.
+// source: {{ .File.GetName }}
+
+package {{ .File.GoPkg.Name }}
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/go-core-stack/grpc-core/routes"
+)
+{{ range $svc := .Services }}
+// {{ $svc.GetName }}RoutesModule registers the routes of {{ $svc.GetName }}
+// service to the mux of the Fx application, calling the server directly,
+// expecting routes.Mux and {{ $svc.GetName }}RouteServer to be provided
+var {{ $svc.GetName }}RoutesModule = fx.Module("{{ with $.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}.routes",
+	fx.Invoke(func(mux routes.Mux, server {{ $svc.GetName }}RouteServer) error {
+		return Register{{ $svc.GetName }}Routes(context.Background(), mux, server)
+	}),
+)
+{{ end }}`))
 )
@@ -35,6 +35,7 @@ var (
 	useRequestContext          = flag.Bool("request_context", true, "determine whether to use http.Request's context or not")
 	allowDeleteBody            = flag.Bool("allow_delete_body", false, "unless set, HTTP DELETE methods may not have a body")
 	grpcAPIConfiguration       = flag.String("grpc_api_configuration", "", "path to gRPC API Configuration in YAML format")
+	openAPIImport              = flag.String("openapi_import", "", "path to OpenAPI document in YAML or JSON format whose operations are bound to the methods by operationId")
 	_                          = flag.Bool("allow_repeated_fields_in_body", true, "allows to use repeated field in `body` and `response_body` field of `google.api.http` annotation option. DEPRECATED: the value is ignored and always behaves as `true`.")
 	repeatedPathParamSeparator = flag.String("repeated_path_param_separator", "csv", "configures how repeated fields should be split. Allowed values are `csv`, `pipes`, `ssv` and `tsv`.")
 	allowPatchFeature          = flag.Bool("allow_patch_feature", true, "determines whether to use PATCH feature involving update masks (using google.protobuf.FieldMask).")
@@ -43,6 +44,15 @@ var (
 	versionFlag                = flag.Bool("version", false, "print the current version")
 	warnOnUnboundMethods       = flag.Bool("warn_on_unbound_methods", false, "emit a warning message if an RPC method has no HttpRule annotation")
 	generateUnboundMethods     = flag.Bool("generate_unbound_methods", false, "generate proxy methods even for RPC methods that have no HttpRule annotation")
+	includeExperimental        = flag.Bool("include_experimental", false, "include the services from the files marked with (api.experimental) option")
+	bestEffort                 = flag.Bool("best_effort", false, "skip the services failing the validation, reporting them along with a go:generate note in the generated files, instead of failing the generation of the entire package")
+	acceptLanguage             = flag.Bool("accept_language", false, "parse the Accept-Language header into the request context of the generated handlers")
+	wireProviders              = flag.Bool("wire", false, "generate the google/wire provider sets registering the routes of the services")
+	fxModules                  = flag.Bool("fx", false, "generate the Fx modules registering the routes of the services")
+	router                     = flag.String("router", "", "router the routes are additionally registered on, along with the routes.Mux, `gin` generates the Register*GinRoutes functions registering the routes on the gin router, `echo` the Register*EchoRoutes functions registering them on the echo group and `http` the Register*HTTPRoutes functions registering them on the net/http ServeMux")
+	strictQuery                = flag.Bool("strict_query", false, "reject the requests carrying unknown query parameters with 400, suggesting the closest known ones")
+	registrySnapshot           = flag.String("registry_snapshot", "", "if set, writes the JSON snapshot of the resolved registry to the given output file for the external tooling")
+	conflictReport             = flag.String("conflict_report", "", "if set, writes the report of the duplicate HTTP annotations to the given file, as HTML for .html files and as markdown otherwise")
 
 	_ = flag.Bool("logtostderr", false, "Legacy glog compatibility. This flag is a no-op, you can safely remove it")
 )
@@ -87,7 +97,23 @@ func main() {
 
 		codegenerator.SetSupportedFeaturesOnPluginGen(gen)
 
-		generator := genroute.New(reg, *useRequestContext, *registerFuncSuffix, *allowPatchFeature, *standalone)
+		switch *router {
+		case "", "gin", "echo", "http":
+		default:
+			return fmt.Errorf("unknown router: %s", *router)
+		}
+
+		generator := genroute.New(reg, genroute.Options{
+			UseRequestContext:  *useRequestContext,
+			RegisterFuncSuffix: *registerFuncSuffix,
+			AllowPatchFeature:  *allowPatchFeature,
+			Standalone:         *standalone,
+			AcceptLanguage:     *acceptLanguage,
+			Wire:               *wireProviders,
+			Fx:                 *fxModules,
+			StrictQuery:        *strictQuery,
+			Router:             *router,
+		})
 
 		if grpclog.V(1) {
 			grpclog.Infof("Parsing code generator request")
@@ -97,6 +123,20 @@ func main() {
 			return err
 		}
 
+		if report := reg.SkippedServicesReport(); report != "" {
+			fmt.Fprintf(os.Stderr, "protoc-gen-routes: %s\n", report)
+		}
+
+		if *registrySnapshot != "" {
+			data, err := reg.Export()
+			if err != nil {
+				return err
+			}
+			if _, err := gen.NewGeneratedFile(*registrySnapshot, "").Write(data); err != nil {
+				return err
+			}
+		}
+
 		unboundHTTPRules := reg.UnboundExternalHTTPRules()
 		if len(unboundHTTPRules) != 0 {
 			return fmt.Errorf("HTTP rules without a matching selector: %s", strings.Join(unboundHTTPRules, ", "))
@@ -112,6 +152,7 @@ func main() {
 		}
 
 		files, err := generator.Generate(targets)
+		err = descriptor.WriteConflictReport(*conflictReport, err)
 		for _, f := range files {
 			if grpclog.V(1) {
 				grpclog.Infof("NewGeneratedFile %q in %s", f.GetName(), f.GoPkg)
@@ -137,6 +178,11 @@ func applyFlags(reg *descriptor.Registry) error {
 			return err
 		}
 	}
+	if *openAPIImport != "" {
+		if err := reg.LoadOpenAPIRoutes(*openAPIImport); err != nil {
+			return err
+		}
+	}
 	if *warnOnUnboundMethods && *generateUnboundMethods {
 		grpclog.Warningf("Option warn_on_unbound_methods has no effect when generate_unbound_methods is used.")
 	}
@@ -152,5 +198,7 @@ func applyFlags(reg *descriptor.Registry) error {
 	reg.SetOmitPackageDoc(*omitPackageDoc)
 	reg.SetWarnOnUnboundMethods(*warnOnUnboundMethods)
 	reg.SetGenerateUnboundMethods(*generateUnboundMethods)
+	reg.SetIncludeExperimental(*includeExperimental)
+	reg.SetBestEffort(*bestEffort)
 	return reg.SetRepeatedPathParamSeparator(*repeatedPathParamSeparator)
 }
@@ -0,0 +1,61 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-core-stack/grpc-core/protoc-gen-sdk/internal/scaffold"
+)
+
+// runInit scaffolds the proto file for a new service as per the
+// arguments of the init subcommand, returning the exit code
+func runInit(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: protoc-gen-sdk init -package <pkg> -resource <resource> [flags]\n\n")
+		fmt.Fprintf(stderr, "Scaffolds the proto file for a service managing the resource, annotated\n")
+		fmt.Fprintf(stderr, "with the HttpRule and Role options following the enforced conventions.\n\n")
+		fs.PrintDefaults()
+	}
+	cfg := &scaffold.Config{}
+	fs.StringVar(&cfg.Package, "package", "", "proto package of the file")
+	fs.StringVar(&cfg.GoPackage, "go_package", "", "go_package option of the file")
+	fs.StringVar(&cfg.Product, "product", "", "kebab-case product the service belongs to")
+	fs.StringVar(&cfg.Service, "service", "", "name of the service, defaults to <Resource>Service")
+	fs.StringVar(&cfg.Resource, "resource", "", "kebab-case resource managed by the service")
+	fs.StringVar(&cfg.Collection, "collection", "", "kebab-case collection of the resources used in the paths, defaults to the plural of resource")
+	scopes := fs.String("scopes", "", "comma separated kebab-case scopes of the role")
+	out := fs.String("out", "", "output file, written to stdout if empty")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *scopes != "" {
+		cfg.Scopes = strings.Split(*scopes, ",")
+	}
+
+	data, err := scaffold.Generate(cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "protoc-gen-sdk init: %v\n", err)
+		return 1
+	}
+	if *out == "" {
+		_, _ = stdout.Write(data)
+		return 0
+	}
+	if _, err := os.Stat(*out); err == nil {
+		fmt.Fprintf(stderr, "protoc-gen-sdk init: %s already exists\n", *out)
+		return 1
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintf(stderr, "protoc-gen-sdk init: %v\n", err)
+		return 1
+	}
+	return 0
+}
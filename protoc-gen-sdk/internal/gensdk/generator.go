@@ -1,10 +1,13 @@
 package gensdk
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"go/format"
 	"path"
+	"strings"
+	"text/template"
 
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/proto"
@@ -23,6 +26,22 @@ type generator struct {
 	registerFuncSuffix string
 	allowPatchFeature  bool
 	standalone         bool
+	batchedListDecode  bool
+	bidiWebSocket      bool
+	enumsAsInts        bool
+	longPollFallback   bool
+	raceTests          bool
+	wire               bool
+	fx                 bool
+	requestTracing     bool
+	withOtel           bool
+	mocks              bool
+	fakeServer         bool
+	fakeServerCmd      bool
+	examples           bool
+	grpcFallback       bool
+	wireCompatTests    bool
+	pathPrefix         string
 }
 
 func UpdateReserveGoImports(reg *descriptor.Registry, packages []string) []descriptor.GoPackage {
@@ -47,33 +66,123 @@ func UpdateReserveGoImports(reg *descriptor.Registry, packages []string) []descr
 	return imports
 }
 
-// New returns a new generator which generates grpc gateway files.
-func New(reg *descriptor.Registry, useRequestContext bool, registerFuncSuffix string,
-	allowPatchFeature, standalone bool) gen.Generator {
+// Options configures the code generated along with the SDK wrappers
+type Options struct {
+	// UseRequestContext uses the context of the http requests
+	UseRequestContext bool
+	// RegisterFuncSuffix is used to construct the names of the generated
+	// Register*<Suffix> functions
+	RegisterFuncSuffix string
+	// AllowPatchFeature uses the update masks for the PATCH methods
+	AllowPatchFeature bool
+	// Standalone generates the package importing the target service
+	// package
+	Standalone bool
+	// BatchedListDecoding decodes the items of the list responses into
+	// a batch allocated slice
+	BatchedListDecoding bool
+	// BidiWebSocket generates the bidirectional streaming methods using
+	// the websocket transport, skipped otherwise
+	BidiWebSocket bool
+	// EnumsAsInts sends the enums in the path and the query params as
+	// the numeric values
+	EnumsAsInts bool
+	// LongPollFallback generates the Subscribe wrappers of the server
+	// streaming methods, falling back to long polling
+	LongPollFallback bool
+	// RaceTests generates the tests calling the methods concurrently
+	RaceTests bool
+	// Wire and Fx generate the google/wire provider sets and the Fx
+	// modules providing the SDK wrappers
+	Wire bool
+	Fx   bool
+	// RequestTracing attaches the sdk.RequestTrace to the requests
+	RequestTracing bool
+	// WithOtel generates the OpenTelemetry client spans around the calls
+	WithOtel bool
+	// Mocks generates the mocks of the SDK wrappers for the unit tests
+	Mocks bool
+	// FakeServer generates the in-memory fakes serving the routes, while
+	// FakeServerCmd additionally generates the cmd/fakeserver main
+	// serving them for the package, implying FakeServer
+	FakeServer    bool
+	FakeServerCmd bool
+	// Examples generates the Go examples calling the methods for go doc
+	Examples bool
+	// GRPCFallback sends the unary calls natively over the grpc
+	// connection of the services configured using sdk.WithConn
+	GRPCFallback bool
+	// WireCompatTests generates the tests pinning the requests sent for
+	// every binding
+	WireCompatTests bool
+	// PathPrefix is prepended to the URIs of all the methods, normalized
+	// to begin with and to end without the slash
+	PathPrefix string
+}
+
+// New returns a new generator which generates the SDK wrappers of the
+// services as configured using opts
+func New(reg *descriptor.Registry, opts Options) gen.Generator {
 	imports := UpdateReserveGoImports(reg, []string{
 		"io",
 		"net/http",
-		"github.com/go-core-stack/auth/client",
 		"github.com/grpc-ecosystem/grpc-gateway/v2/runtime",
+		"github.com/go-core-stack/grpc-core/sdk",
 	})
 	return &generator{
 		reg:                reg,
 		imports:            imports,
-		useRequestContext:  useRequestContext,
-		registerFuncSuffix: registerFuncSuffix,
-		allowPatchFeature:  allowPatchFeature,
-		standalone:         standalone,
+		useRequestContext:  opts.UseRequestContext,
+		registerFuncSuffix: opts.RegisterFuncSuffix,
+		allowPatchFeature:  opts.AllowPatchFeature,
+		standalone:         opts.Standalone,
+		batchedListDecode:  opts.BatchedListDecoding,
+		bidiWebSocket:      opts.BidiWebSocket,
+		enumsAsInts:        opts.EnumsAsInts,
+		longPollFallback:   opts.LongPollFallback,
+		raceTests:          opts.RaceTests,
+		wire:               opts.Wire,
+		fx:                 opts.Fx,
+		requestTracing:     opts.RequestTracing,
+		withOtel:           opts.WithOtel,
+		mocks:              opts.Mocks,
+		fakeServer:         opts.FakeServer || opts.FakeServerCmd,
+		fakeServerCmd:      opts.FakeServerCmd,
+		examples:           opts.Examples,
+		grpcFallback:       opts.GRPCFallback,
+		wireCompatTests:    opts.WireCompatTests,
+		pathPrefix:         normalizePathPrefix(opts.PathPrefix),
+	}
+}
+
+// normalizePathPrefix returns the prefix beginning with a single slash
+// and without the trailing slashes, empty for the root
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
 	}
+	return "/" + prefix
 }
 
 func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
 	var files []*descriptor.ResponseFile
+	var products []*productParams
+	var fakeServers []*fakeServerParams
 	for _, file := range targets {
 		if grpclog.V(1) {
 			grpclog.Infof("Processing %s", file.GetName())
 		}
 
 		code, err := g.generate(file)
+		skipped := err == errNoTargetService && len(file.Skipped) != 0
+		if skipped {
+			// all the services of the file are skipped using best_effort,
+			// the file carries the notes reporting them alone
+			w := bytes.NewBuffer(nil)
+			err = skippedtemplate.Execute(w, file)
+			code = w.String()
+		}
 		if err == errNoTargetService {
 			if grpclog.V(1) {
 				grpclog.Infof("%s: %v", file.GetName(), err)
@@ -95,10 +204,167 @@ func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.Response
 				Content: proto.String(string(formatted)),
 			},
 		})
+		if skipped {
+			continue
+		}
+		for _, c := range []struct {
+			enabled bool
+			tmpl    *template.Template
+			suffix  string
+		}{
+			{enabled: g.raceTests, tmpl: racetemplate, suffix: ".sdk_race_test.go"},
+			{enabled: g.wireCompatTests, tmpl: wirecompattemplate, suffix: ".sdk_wire_test.go"},
+			{enabled: g.wire, tmpl: wiretemplate, suffix: ".sdk.wire.go"},
+			{enabled: g.fx, tmpl: fxtemplate, suffix: ".sdk.fx.go"},
+			{enabled: g.mocks, tmpl: mocktemplate, suffix: ".sdk.mock.go"},
+			{enabled: g.fakeServer && hasFakeMethods(file), tmpl: faketemplate, suffix: ".sdk.fake.go"},
+			{enabled: g.examples, tmpl: exampletemplate, suffix: ".sdk_example_test.go"},
+		} {
+			if !c.enabled {
+				continue
+			}
+			code, err := applyFileTemplate(c.tmpl, &fileParams{
+				Registry:         g.reg,
+				File:             file,
+				BidiWebSocket:    g.bidiWebSocket,
+				LongPollFallback: g.longPollFallback,
+				PathPrefix:       g.pathPrefix,
+			})
+			if err != nil {
+				return nil, err
+			}
+			formatted, err := format.Source([]byte(code))
+			if err != nil {
+				grpclog.Errorf("%v: %s", err, code)
+				return nil, err
+			}
+			files = append(files, &descriptor.ResponseFile{
+				GoPkg: file.GoPkg,
+				CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+					Name:    proto.String(file.GeneratedFilenamePrefix + c.suffix),
+					Content: proto.String(string(formatted)),
+				},
+			})
+		}
+		products = collectProducts(products, file)
+		if g.fakeServerCmd {
+			fakeServers = collectFakeServers(fakeServers, file)
+		}
+	}
+
+	for _, p := range products {
+		code, err := applyProductTemplate(p)
+		if err != nil {
+			return nil, err
+		}
+		formatted, err := format.Source([]byte(code))
+		if err != nil {
+			grpclog.Errorf("%v: %s", err, code)
+			return nil, err
+		}
+		files = append(files, &descriptor.ResponseFile{
+			GoPkg: p.GoPkg,
+			CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+				Name:    proto.String(path.Join(p.Dir, p.Product+".product.sdk.go")),
+				Content: proto.String(string(formatted)),
+			},
+		})
+	}
+	for _, p := range fakeServers {
+		code, err := applyFakeServerTemplate(p)
+		if err != nil {
+			return nil, err
+		}
+		formatted, err := format.Source([]byte(code))
+		if err != nil {
+			grpclog.Errorf("%v: %s", err, code)
+			return nil, err
+		}
+		files = append(files, &descriptor.ResponseFile{
+			GoPkg: descriptor.GoPackage{Path: path.Join(p.GoPkg.Path, "cmd", "fakeserver"), Name: "main"},
+			CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+				Name:    proto.String(path.Join(p.Dir, "cmd", "fakeserver", "main.go")),
+				Content: proto.String(string(formatted)),
+			},
+		})
 	}
 	return files, nil
 }
 
+// collectProducts groups the services of the file, having SDK methods
+// generated, with the products they are part of, products are scoped
+// to the go package since the umbrella client is generated per package
+func collectProducts(products []*productParams, file *descriptor.File) []*productParams {
+	for _, svc := range file.Services {
+		if svc.Product == "" || !hasBindings(svc) {
+			continue
+		}
+		var p *productParams
+		for _, known := range products {
+			if known.Product == svc.Product && known.GoPkg.Path == file.GoPkg.Path {
+				p = known
+				break
+			}
+		}
+		if p == nil {
+			p = &productParams{
+				GoPkg:   file.GoPkg,
+				Dir:     path.Dir(file.GeneratedFilenamePrefix),
+				Product: svc.Product,
+			}
+			products = append(products, p)
+		}
+		p.Services = append(p.Services, svc)
+	}
+	return products
+}
+
+// collectFakeServers adds the services of the file having the methods
+// served by the fakes to the fake server of the go package of the file
+func collectFakeServers(servers []*fakeServerParams, file *descriptor.File) []*fakeServerParams {
+	for _, svc := range file.Services {
+		if len(fakeMethods(svc)) == 0 {
+			continue
+		}
+		var p *fakeServerParams
+		for _, known := range servers {
+			if known.GoPkg.Path == file.GoPkg.Path {
+				p = known
+				break
+			}
+		}
+		if p == nil {
+			p = &fakeServerParams{
+				GoPkg: file.GoPkg,
+				Dir:   path.Dir(file.GeneratedFilenamePrefix),
+			}
+			servers = append(servers, p)
+		}
+		p.Services = append(p.Services, svc)
+	}
+	return servers
+}
+
+// hasFakeMethods reports whether the file has the unary methods with
+// bindings, served by the fake servers
+func hasFakeMethods(file *descriptor.File) bool {
+	for _, svc := range file.Services {
+		if len(fakeMethods(svc)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBindings(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *generator) generate(file *descriptor.File) (string, error) {
 	pkgSeen := make(map[string]bool)
 	var imports []descriptor.GoPackage
@@ -138,7 +404,7 @@ func (g *generator) generate(file *descriptor.File) (string, error) {
 		}
 	}
 	if includeHeader4Body {
-		newImports := UpdateReserveGoImports(g.reg, []string{"bytes"})
+		newImports := UpdateReserveGoImports(g.reg, []string{"github.com/go-core-stack/grpc-core/sdk"})
 		imports = append(imports, newImports...)
 	}
 	if hasQueryParams || hasPathParams {
@@ -161,9 +427,19 @@ func (g *generator) generate(file *descriptor.File) (string, error) {
 		UseRequestContext:  g.useRequestContext,
 		RegisterFuncSuffix: g.registerFuncSuffix,
 		AllowPatchFeature:  g.allowPatchFeature,
+		BatchedListDecode:  g.batchedListDecode,
+		BidiWebSocket:      g.bidiWebSocket,
+		EnumsAsInts:        g.enumsAsInts,
+		LongPollFallback:   g.longPollFallback,
+		RequestTracing:     g.requestTracing,
+		WithOtel:           g.withOtel,
+		GRPCFallback:       g.grpcFallback,
+		PathPrefix:         g.pathPrefix,
 	}
 	if g.reg != nil {
 		params.OmitPackageDoc = g.reg.GetOmitPackageDoc()
+		params.ProtoNames = g.reg.UseProtoNames(file)
+		params.CamelQueryParams = g.reg.UseCamelQueryParams(file)
 	}
 	return applyTemplate(params, g.reg)
 }
@@ -0,0 +1,535 @@
+package gensdk
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+)
+
+func TestNormalizePathPrefix(t *testing.T) {
+	for _, spec := range []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "", want: ""},
+		{prefix: "/", want: ""},
+		{prefix: "//", want: ""},
+		{prefix: "api", want: "/api"},
+		{prefix: "/api", want: "/api"},
+		{prefix: "/api/", want: "/api"},
+		{prefix: "//api//", want: "/api"},
+		{prefix: " /api/v2/ ", want: "/api/v2"},
+	} {
+		if got := normalizePathPrefix(spec.prefix); got != spec.want {
+			t.Errorf("normalizePathPrefix(%q) = %q; want %q", spec.prefix, got, spec.want)
+		}
+	}
+}
+
+// exampleFile is the file with a service having a single method bound
+// to GET, used to verify the generated files
+const exampleFile = `
+	name: "example.proto"
+	package: "example"
+	options < go_package: "example.com/example;example" >
+	message_type <
+		name: "StringMessage"
+		field <
+			name: "string"
+			number: 1
+			label: LABEL_OPTIONAL
+			type: TYPE_STRING
+		>
+	>
+	service <
+		name: "ExampleService"
+		method <
+			name: "Echo"
+			input_type: "StringMessage"
+			output_type: "StringMessage"
+			options <
+				[google.api.http] <
+					get: "/v1/example/{string}"
+				>
+			>
+		>
+	>
+`
+
+// loadExample returns the registry loaded with the example file
+func loadExample(t *testing.T) (*descriptor.Registry, *descriptor.File) {
+	t.Helper()
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(exampleFile), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", exampleFile, err)
+	}
+	reg := descriptor.NewRegistry()
+	if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+		Parameter:      proto.String(""),
+	}); err != nil {
+		t.Fatalf("reg.Load() failed with %v; want success", err)
+	}
+	file, err := reg.LookupFile(fd.GetName())
+	if err != nil {
+		t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+	}
+	return reg, file
+}
+
+func TestGenerateWithPathPrefix(t *testing.T) {
+	for _, spec := range []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "", want: `uri := "/v1/example/{string}"`},
+		{prefix: "/api/", want: `uri := "/api/v1/example/{string}"`},
+		{prefix: "api", want: `uri := "/api/v1/example/{string}"`},
+	} {
+		reg, file := loadExample(t)
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, PathPrefix: spec.prefix})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with prefix %q failed with %v; want success", spec.prefix, err)
+		}
+		if got := files[0].GetContent(); !strings.Contains(got, spec.want) {
+			t.Errorf("Generate() with prefix %q missing %s in\n%s", spec.prefix, spec.want, got)
+		}
+	}
+}
+
+func TestGenerateDependencyInjection(t *testing.T) {
+	for _, spec := range []struct {
+		wire bool
+		fx   bool
+		want map[string][]string
+	}{
+		{
+			want: map[string][]string{"example.sdk.go": nil},
+		},
+		{
+			wire: true,
+			want: map[string][]string{
+				"example.sdk.go": nil,
+				"example.sdk.wire.go": {
+					`"github.com/google/wire"`,
+					"func ProvideExampleServiceService(client sdk.Doer, opts sdk.ServiceOptions) ExampleServiceService",
+					"var ExampleServiceServiceProviderSet = wire.NewSet(ProvideExampleServiceService)",
+				},
+			},
+		},
+		{
+			wire: true,
+			fx:   true,
+			want: map[string][]string{
+				"example.sdk.go":      nil,
+				"example.sdk.wire.go": {"wire.NewSet(ProvideExampleServiceService)"},
+				"example.sdk.fx.go": {
+					`"go.uber.org/fx"`,
+					"Options sdk.ServiceOptions `optional:\"true\"`",
+					`var ExampleServiceServiceModule = fx.Module("example.ExampleService.sdk",`,
+					"return NewExampleServiceService(p.Client, p.Options...)",
+				},
+			},
+		},
+	} {
+		reg, file := loadExample(t)
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Wire: spec.wire, Fx: spec.fx})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with wire=%v, fx=%v failed with %v; want success", spec.wire, spec.fx, err)
+		}
+		if len(files) != len(spec.want) {
+			t.Errorf("Generate() with wire=%v, fx=%v returned %d files; want %d", spec.wire, spec.fx, len(files), len(spec.want))
+		}
+		for _, f := range files {
+			want, ok := spec.want[path.Base(f.GetName())]
+			if !ok {
+				t.Errorf("Generate() with wire=%v, fx=%v returned unexpected file %s", spec.wire, spec.fx, f.GetName())
+				continue
+			}
+			for _, w := range want {
+				if !strings.Contains(f.GetContent(), w) {
+					t.Errorf("%s missing %s in\n%s", f.GetName(), w, f.GetContent())
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateWithOtel(t *testing.T) {
+	for _, withOtel := range []bool{false, true} {
+		reg, file := loadExample(t)
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, WithOtel: withOtel})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with withOtel=%v failed with %v; want success", withOtel, err)
+		}
+		if len(files) != 1 {
+			t.Fatalf("Generate() with withOtel=%v returned %d files; want 1", withOtel, len(files))
+		}
+		for _, w := range []string{
+			`"go.opentelemetry.io/otel"`,
+			`otel.Tracer("example.com/example").Start(ctx, "ExampleService/Echo",`,
+			`attribute.String("http.request.method", "GET")`,
+			`attribute.String("http.route", "/v1/example/{string}")`,
+			`span.SetAttributes(attribute.Int("http.response.status_code", status))`,
+			"otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))",
+			"status, body, err := s.sendEcho(ctx, req, out, opts)",
+		} {
+			if got := strings.Contains(files[0].GetContent(), w); got != withOtel {
+				t.Errorf("Generate() with withOtel=%v contains %s = %v; want %v", withOtel, w, got, withOtel)
+			}
+		}
+	}
+}
+
+func TestGenerateGRPCFallback(t *testing.T) {
+	for _, grpcFallback := range []bool{false, true} {
+		reg, file := loadExample(t)
+		g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, GRPCFallback: grpcFallback})
+		files, err := g.Generate([]*descriptor.File{file})
+		if err != nil {
+			t.Fatalf("Generate() with grpcFallback=%v failed with %v; want success", grpcFallback, err)
+		}
+		for _, w := range []string{
+			`"google.golang.org/grpc"`,
+			"func NewExampleServiceServiceFromConn(conn grpc.ClientConnInterface, opts ...sdk.ServiceOption) ExampleServiceService {",
+			"return NewExampleServiceService(sdk.NoClient, append(opts, sdk.WithConn(conn))...)",
+			"if s.config.Native() {",
+			`return s.config.Invoke(ctx, "/example.ExampleService/Echo", req, out, opts)`,
+		} {
+			if got := strings.Contains(files[0].GetContent(), w); got != grpcFallback {
+				t.Errorf("Generate() with grpcFallback=%v contains %s = %v; want %v", grpcFallback, w, got, grpcFallback)
+			}
+		}
+	}
+}
+
+func TestGenerateMocks(t *testing.T) {
+	reg, file := loadExample(t)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Mocks: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with mocks failed with %v; want success", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Generate() with mocks returned %d files; want 2", len(files))
+	}
+	if got := path.Base(files[1].GetName()); got != "example.sdk.mock.go" {
+		t.Fatalf("Generate() with mocks returned %s; want example.sdk.mock.go", got)
+	}
+	for _, w := range []string{
+		"type MockExampleServiceService struct {",
+		"EchoFunc     func(ctx context.Context, req *StringMessage, opts ...sdk.CallOption) (*StringMessage, error)",
+		"EchoIntoFunc func(ctx context.Context, req *StringMessage, out *StringMessage, opts ...sdk.CallOption) error",
+		"var _ ExampleServiceService = (*MockExampleServiceService)(nil)",
+		`panic("MockExampleServiceService.EchoFunc is not set")`,
+		"return m.EchoIntoFunc(ctx, req, out, opts...)",
+	} {
+		if !strings.Contains(files[1].GetContent(), w) {
+			t.Errorf("%s missing %s in\n%s", files[1].GetName(), w, files[1].GetContent())
+		}
+	}
+}
+
+func TestGenerateFakeServer(t *testing.T) {
+	reg, file := loadExample(t)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, FakeServer: true, PathPrefix: "/api"})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with fakeServer failed with %v; want success", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Generate() with fakeServer returned %d files; want 2", len(files))
+	}
+	if got := path.Base(files[1].GetName()); got != "example.sdk.fake.go" {
+		t.Fatalf("Generate() with fakeServer returned %s; want example.sdk.fake.go", got)
+	}
+	for _, w := range []string{
+		"type FakeExampleServiceServer struct {",
+		"EchoFunc func(ctx context.Context, req *StringMessage) (*StringMessage, error)",
+		`Method:     "example.ExampleService.Echo",`,
+		`Pattern:    "/api/v1/example/{string}",`,
+		"}, &f.EchoFunc)",
+	} {
+		if !strings.Contains(files[1].GetContent(), w) {
+			t.Errorf("%s missing %s in\n%s", files[1].GetName(), w, files[1].GetContent())
+		}
+	}
+}
+
+func TestGenerateExamples(t *testing.T) {
+	reg, file := loadExample(t)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, Examples: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with examples failed with %v; want success", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Generate() with examples returned %d files; want 2", len(files))
+	}
+	if got := path.Base(files[1].GetName()); got != "example.sdk_example_test.go" {
+		t.Fatalf("Generate() with examples returned %s; want example.sdk_example_test.go", got)
+	}
+	for _, w := range []string{
+		"package example_test",
+		`"example.com/example"`,
+		"func ExampleExampleServiceService_Echo() {",
+		"svc := example.NewExampleServiceService(client)",
+		"resp, err := svc.Echo(ctx, &example.StringMessage{",
+		`String_: "string",`,
+	} {
+		if !strings.Contains(files[1].GetContent(), w) {
+			t.Errorf("%s missing %s in\n%s", files[1].GetName(), w, files[1].GetContent())
+		}
+	}
+}
+
+func TestGenerateFakeServerCmd(t *testing.T) {
+	reg, file := loadExample(t)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, FakeServerCmd: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with fakeServerCmd failed with %v; want success", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Generate() with fakeServerCmd returned %d files; want 3", len(files))
+	}
+	if got := path.Base(files[1].GetName()); got != "example.sdk.fake.go" {
+		t.Errorf("Generate() with fakeServerCmd returned %s; want example.sdk.fake.go implied", got)
+	}
+	if got := files[2].GetName(); got != "example.com/example/cmd/fakeserver/main.go" {
+		t.Fatalf("Generate() with fakeServerCmd returned %s; want example.com/example/cmd/fakeserver/main.go", got)
+	}
+	content := files[2].GetContent()
+	for _, w := range []string{
+		"package main",
+		`"example.com/example"`,
+		"(&example.FakeExampleServiceServer{}).Register(mux)",
+		"log.Fatal(http.ListenAndServe(*addr, mux))",
+	} {
+		if !strings.Contains(content, w) {
+			t.Errorf("%s missing %s in\n%s", files[2].GetName(), w, content)
+		}
+	}
+	// the methods without (api.role) are not served using the store
+	if strings.Contains(content, "sdk.NewFakeStore()") || strings.Contains(files[1].GetContent(), "WithStore") {
+		t.Errorf("Generate() without (api.role) seeds the store; want the fakes only")
+	}
+}
+
+// nestedFile is the file with a service acting on the tags nested
+// within the projects, used to verify the resources of the fakes
+const nestedFile = `
+	name: "nested.proto"
+	package: "nested"
+	options < go_package: "example.com/nested;nested" >
+	message_type <
+		name: "Tag"
+		field < name: "project" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING >
+		field < name: "id" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 >
+		field < name: "color" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING >
+	>
+	message_type <
+		name: "ListTagsRequest"
+		field < name: "project" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING >
+		field < name: "color" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING >
+		field < name: "page_size" number: 3 label: LABEL_OPTIONAL type: TYPE_INT32 >
+	>
+	message_type <
+		name: "ListTagsResponse"
+		field < name: "tags" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".nested.Tag" >
+	>
+	service <
+		name: "TagService"
+		method <
+			name: "CreateTag"
+			input_type: ".nested.Tag"
+			output_type: ".nested.Tag"
+			options <
+				[google.api.http] < post: "/v1/projects/{project}/tags" body: "*" >
+				[api.role] < resource: "tag" verb: "create" >
+			>
+		>
+		method <
+			name: "GetTag"
+			input_type: ".nested.Tag"
+			output_type: ".nested.Tag"
+			options <
+				[google.api.http] < get: "/v1/projects/{project}/tags/{id}" >
+				[api.role] < resource: "tag" verb: "get" >
+			>
+		>
+		method <
+			name: "ListTags"
+			input_type: ".nested.ListTagsRequest"
+			output_type: ".nested.ListTagsResponse"
+			options <
+				[google.api.http] < get: "/v1/projects/{project}/tags" >
+				[api.role] < resource: "tag" verb: "list" >
+			>
+		>
+	>
+`
+
+func TestGenerateFakeStoreResources(t *testing.T) {
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(nestedFile), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", nestedFile, err)
+	}
+	reg := descriptor.NewRegistry()
+	if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+		Parameter:      proto.String(""),
+	}); err != nil {
+		t.Fatalf("reg.Load() failed with %v; want success", err)
+	}
+	file, err := reg.LookupFile(fd.GetName())
+	if err != nil {
+		t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+	}
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, FakeServer: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with fakeServer failed with %v; want success", err)
+	}
+	content := files[1].GetContent()
+	for _, w := range []string{
+		// the params followed by the collection identify the parent,
+		// while the trailing one identifies the tag
+		"Verb:     \"create\",\n\t\t\tParent:   []string{\"project\"},\n\t\t\tBody:     \"*\",\n\t\t\tID:       \"id\",\n",
+		"Verb:     \"get\",\n\t\t\tParent:   []string{\"project\"},\n\t\t\tKeys:     []string{\"id\"},\n",
+		// the paging fields are not the filters
+		"Verb:     \"list\",\n\t\t\tParent:   []string{\"project\"},\n\t\t\tFilters:  []string{\"color\"},\n",
+	} {
+		if !strings.Contains(content, w) {
+			t.Errorf("%s missing %s in\n%s", files[1].GetName(), w, content)
+		}
+	}
+}
+
+func TestGenerateBestEffort(t *testing.T) {
+	reg, file := loadBestEffort(t, exampleFile+brokenService)
+	g := New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with best effort failed with %v; want success", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Generate() with best effort returned %d files; want 1", len(files))
+	}
+	content := files[0].GetContent()
+	for _, want := range []string{
+		`//go:generate echo "protoc-gen-sdk: skipped example.BrokenService: `,
+		"type ExampleServiceService interface",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing %s in\n%s", files[0].GetName(), want, content)
+		}
+	}
+	if strings.Contains(content, "BrokenServiceService") {
+		t.Errorf("%s generates the SDK of the skipped BrokenService", files[0].GetName())
+	}
+}
+
+// brokenService is the service failing the validation, skipped using
+// best_effort
+const brokenService = `
+	service <
+		name: "BrokenService"
+		method <
+			name: "Echo"
+			input_type: "StringMessage"
+			output_type: "StringMessage"
+			server_streaming: true
+			options <
+				[google.api.http] <
+					get: "/v1/broken/{string}"
+				>
+				[api.debug_only]: true
+			>
+		>
+	>
+`
+
+// loadBestEffort loads the file from the source in prototext into the
+// registry skipping the services failing the validation
+func loadBestEffort(t *testing.T, src string) (*descriptor.Registry, *descriptor.File) {
+	t.Helper()
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", src, err)
+	}
+	reg := descriptor.NewRegistry()
+	reg.SetBestEffort(true)
+	if err := reg.Load(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{&fd},
+		Parameter:      proto.String(""),
+	}); err != nil {
+		t.Fatalf("reg.Load() with best effort failed with %v; want success", err)
+	}
+	file, err := reg.LookupFile(fd.GetName())
+	if err != nil {
+		t.Fatalf("reg.LookupFile(%q) failed with %v; want success", fd.GetName(), err)
+	}
+	return reg, file
+}
+
+func TestGenerateAllServicesSkipped(t *testing.T) {
+	src := `
+	name: "broken.proto"
+	package: "example"
+	options < go_package: "example.com/example;example" >
+	message_type <
+		name: "StringMessage"
+		field <
+			name: "string"
+			number: 1
+			label: LABEL_OPTIONAL
+			type: TYPE_STRING
+		>
+	>
+` + brokenService
+	reg, file := loadBestEffort(t, src)
+	g := New(reg, Options{
+		UseRequestContext:  true,
+		RegisterFuncSuffix: "Handler",
+		AllowPatchFeature:  true,
+		RaceTests:          true,
+		Wire:               true,
+		Fx:                 true,
+		Mocks:              true,
+		Examples:           true,
+	})
+	files, err := g.Generate([]*descriptor.File{file})
+	if err != nil {
+		t.Fatalf("Generate() with all the services skipped failed with %v; want success", err)
+	}
+	// the file carries the notes alone, without the companion files
+	if len(files) != 1 {
+		t.Fatalf("Generate() with all the services skipped returned %d files; want 1", len(files))
+	}
+	content := files[0].GetContent()
+	for _, want := range []string{
+		"package example",
+		`//go:generate echo "protoc-gen-sdk: skipped example.BrokenService: `,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing %s in\n%s", files[0].GetName(), want, content)
+		}
+	}
+	if strings.Contains(content, "import") || strings.Contains(content, "func ") {
+		t.Errorf("%s generates more than the notes of the skipped services:\n%s", files[0].GetName(), content)
+	}
+}
@@ -0,0 +1,89 @@
+package gensdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+	"github.com/go-core-stack/grpc-core/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Run(t,
+		golden.Case{
+			Name:          "default",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true}).Generate(targets)
+			},
+		},
+		golden.Case{
+			Name:          "all_features",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				if err := reg.SetJSONNames("camel"); err != nil {
+					return nil, err
+				}
+				return New(reg, Options{
+					UseRequestContext:   true,
+					RegisterFuncSuffix:  "Handler",
+					AllowPatchFeature:   true,
+					BatchedListDecoding: true,
+					BidiWebSocket:       true,
+					EnumsAsInts:         true,
+					LongPollFallback:    true,
+					RaceTests:           true,
+					Wire:                true,
+					Fx:                  true,
+					RequestTracing:      true,
+					WithOtel:            true,
+					Mocks:               true,
+					FakeServer:          true,
+					FakeServerCmd:       true,
+					Examples:            true,
+					GRPCFallback:        true,
+					WireCompatTests:     true,
+					PathPrefix:          "/api",
+				}).Generate(targets)
+			},
+		},
+		golden.Case{
+			Name:          "grpc_fallback",
+			DescriptorSet: golden.Fixture("example"),
+			Generate: func(reg *descriptor.Registry, targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+				return New(reg, Options{UseRequestContext: true, RegisterFuncSuffix: "Handler", AllowPatchFeature: true, GRPCFallback: true}).Generate(targets)
+			},
+			Check: func(t *testing.T, files map[string]string) {
+				// the routes open the sealed fields and populate the ones
+				// of the identity, never sent natively
+				content := files["example.sdk.go"]
+				for _, method := range []string{"GetObject", "SetCredentials"} {
+					fn := funcBody(content, "func (s *implHelloWorldService) do"+method+"(")
+					if fn == "" {
+						t.Fatalf("example.sdk.go missing do%s", method)
+					}
+					if strings.Contains(fn, "s.config.Native()") {
+						t.Errorf("do%s sent natively over the grpc connection:\n%s", method, fn)
+					}
+				}
+				if fn := funcBody(content, "func (s *implHelloWorldService) doPostObject("); !strings.Contains(fn, "s.config.Native()") {
+					t.Errorf("doPostObject not sent natively over the grpc connection:\n%s", fn)
+				}
+			},
+		},
+	)
+}
+
+// funcBody returns the declaration of the function starting with decl,
+// empty if missing
+func funcBody(content, decl string) string {
+	start := strings.Index(content, decl)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(content[start:], "\n}\n")
+	if end < 0 {
+		return content[start:]
+	}
+	return content[start : start+end+3]
+}
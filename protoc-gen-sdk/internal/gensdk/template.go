@@ -2,10 +2,15 @@ package gensdk
 
 import (
 	"bytes"
+	"fmt"
+	"path"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/go-core-stack/grpc-core/internal/casing"
 	"github.com/go-core-stack/grpc-core/internal/descriptor"
@@ -19,6 +24,18 @@ type param struct {
 	AllowPatchFeature  bool
 	OmitPackageDoc     bool
 	PathPrefix         string
+	BatchedListDecode  bool
+	BidiWebSocket      bool
+	EnumsAsInts        bool
+	LongPollFallback   bool
+	RequestTracing     bool
+	WithOtel           bool
+	GRPCFallback       bool
+	ProtoNames         bool
+	CamelQueryParams   bool
+	ListFields         map[*descriptor.Method]*listField
+	Informers          map[*descriptor.Service][]*informer
+	Pagers             map[*descriptor.Method]*pager
 }
 
 type trailerParams struct {
@@ -29,6 +46,18 @@ type trailerParams struct {
 	PathPrefix         string
 }
 
+type productParams struct {
+	GoPkg    descriptor.GoPackage
+	Dir      string
+	Product  string
+	Services []*descriptor.Service
+}
+
+// Name returns the go identifier used for the product
+func (p *productParams) Name() string {
+	return casing.Camel(strings.ReplaceAll(p.Product, "-", "_"))
+}
+
 // getMethodComment retrieves leading comments for a given service/method
 func getMethodComment(p param, serviceIndex, methodIndex int) []string {
 	file := p.File
@@ -69,29 +98,189 @@ func equalPath(a, b []int32) bool {
 	return true
 }
 
+// listField describes the repeated field holding the items of a list
+// response, decoded in batch when batched_list_decoding is enabled
+type listField struct {
+	Name     string
+	GoName   string
+	ItemType string
+}
+
+// getListFields returns the list fields of the methods responding with
+// a list, identified as a response message with exactly one repeated
+// message field, of a message defined in the same go package
+func getListFields(file *descriptor.File, reg *descriptor.Registry) map[*descriptor.Method]*listField {
+	fields := map[*descriptor.Method]*listField{}
+	for _, svc := range file.Services {
+		for _, m := range svc.Methods {
+			if m.GetServerStreaming() || m.GetClientStreaming() {
+				continue
+			}
+			var lf *listField
+			count := 0
+			for _, f := range m.ResponseType.Fields {
+				if f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+					continue
+				}
+				count++
+				if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					continue
+				}
+				item, err := reg.LookupMsg("", f.GetTypeName())
+				if err != nil || item.GetOptions().GetMapEntry() || item.File.GoPkg.Path != file.GoPkg.Path {
+					continue
+				}
+				lf = &listField{
+					Name:     f.GetName(),
+					GoName:   casing.Camel(f.GetName()),
+					ItemType: item.GoType(file.GoPkg.Path),
+				}
+			}
+			if count == 1 && lf != nil {
+				fields[m] = lf
+			}
+		}
+	}
+	return fields
+}
+
+// informer describes the informer generated for the resource having
+// both the list and the watch methods
+type informer struct {
+	Name      string
+	Resource  string
+	List      *descriptor.Method
+	Watch     *descriptor.Method
+	ListField *listField
+	KeyField  string
+}
+
+// getInformers returns the informers for the resources of the services
+// having a list method, responding with a list of the objects streamed
+// by the watch method of the resource, keyed by the name or the id
+// field of the objects
+func getInformers(file *descriptor.File, reg *descriptor.Registry) map[*descriptor.Service][]*informer {
+	listFields := getListFields(file, reg)
+	informers := map[*descriptor.Service][]*informer{}
+	for _, svc := range file.Services {
+		seen := map[string]bool{}
+		for _, w := range svc.Methods {
+			if w.Watch == nil || len(w.Bindings) == 0 || seen[w.Role.Resource] {
+				continue
+			}
+			var key string
+			for _, f := range w.Watch.Object.Fields {
+				if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING || f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+					continue
+				}
+				if f.GetName() == "name" || (f.GetName() == "id" && key == "") {
+					key = f.GetName()
+				}
+			}
+			if key == "" {
+				continue
+			}
+			for _, l := range svc.Methods {
+				lf := listFields[l]
+				if lf == nil || len(l.Bindings) == 0 || l.Role == nil || l.Role.Verb != "list" || l.Role.Resource != w.Role.Resource {
+					continue
+				}
+				if lf.ItemType != w.Watch.Object.GoType(file.GoPkg.Path) {
+					continue
+				}
+				seen[w.Role.Resource] = true
+				informers[svc] = append(informers[svc], &informer{
+					Name:      casing.Camel(strings.ReplaceAll(w.Role.Resource, "-", "_")),
+					Resource:  w.Role.Resource,
+					List:      l,
+					Watch:     w,
+					ListField: lf,
+					KeyField:  key,
+				})
+				break
+			}
+		}
+	}
+	return informers
+}
+
+// pager describes the iterators generated for the list method
+// following the pagination convention
+type pager struct {
+	*descriptor.Method
+	// ListField of the response holding the items of the pages, nil if
+	// the items are not known, iterating the pages alone
+	ListField *listField
+}
+
+// getPagers returns the pagers of the unary methods following the
+// AIP-158 pagination convention, see isPaginated
+func getPagers(file *descriptor.File, reg *descriptor.Registry) map[*descriptor.Method]*pager {
+	listFields := getListFields(file, reg)
+	pagers := map[*descriptor.Method]*pager{}
+	for _, svc := range file.Services {
+		for _, m := range svc.Methods {
+			if isPaginated(m) {
+				pagers[m] = &pager{Method: m, ListField: listFields[m]}
+			}
+		}
+	}
+	return pagers
+}
+
+// isPaginated reports whether the method is the unary method having the
+// bindings, with the page_token string field in the request and the
+// next_page_token string field in the response
+func isPaginated(m *descriptor.Method) bool {
+	if len(m.Bindings) == 0 || m.GetClientStreaming() || m.GetServerStreaming() {
+		return false
+	}
+	hasString := func(msg *descriptor.Message, name string) bool {
+		for _, f := range msg.Fields {
+			if f.GetName() == name {
+				return f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING &&
+					f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+			}
+		}
+		return false
+	}
+	return hasString(m.RequestType, "page_token") && hasString(m.ResponseType, "next_page_token")
+}
+
 func getImports(services []*descriptor.Service) []string {
 	imports := []string{"context", "fmt", "io", "net/http"}
 	importMap := map[string]bool{}
 	for _, s := range services {
 		for _, m := range s.Methods {
-			if len(m.Bindings) == 0 {
+			// client and bidirectional streaming methods send the
+			// request messages in the body alone
+			if len(m.Bindings) == 0 || m.GetClientStreaming() {
 				continue
 			}
-			b := m.Bindings[0]
-			if len(b.PathParams) != 0 {
-				importMap["strings"] = true
-				importMap["net/url"] = true
+			if m.RetryPolicy != nil || m.Timeout != 0 || m.Objective != nil && m.Objective.Latency != 0 {
+				importMap["time"] = true
 			}
-			if hasQueryParams(m) {
-				importMap["net/url"] = true
+			if isPaginated(m) {
+				importMap["iter"] = true
 			}
-			if b.Body != nil {
-				importMap["bytes"] = true
+			for _, b := range m.Bindings {
+				if len(b.PathParams) != 0 {
+					importMap["strings"] = true
+					importMap["net/url"] = true
+				}
+				if hasQueryParams(b) {
+					importMap["net/url"] = true
+				}
 			}
 		}
 	}
 
-	_, ok := importMap["strings"]
+	_, ok := importMap["iter"]
+	if ok {
+		imports = append(imports, "iter")
+	}
+
+	_, ok = importMap["strings"]
 	if ok {
 		imports = append(imports, "strings")
 	}
@@ -101,24 +290,130 @@ func getImports(services []*descriptor.Service) []string {
 		imports = append(imports, "net/url")
 	}
 
-	_, ok = importMap["bytes"]
+	_, ok = importMap["time"]
 	if ok {
-		imports = append(imports, "bytes")
+		imports = append(imports, "time")
 	}
 
 	return imports
 }
 
+// usesOtel reports whether the generated code creates the OpenTelemetry
+// spans, which are created for the unary and server streaming methods
+func usesOtel(p param, services []*descriptor.Service) bool {
+	if !p.WithOtel {
+		return false
+	}
+	for _, s := range services {
+		for _, m := range s.Methods {
+			if len(m.Bindings) != 0 && !m.GetClientStreaming() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isClientStreaming reports whether the method streams the request
+// messages while responding with a single message
+func isClientStreaming(m *descriptor.Method) bool {
+	return m.GetClientStreaming() && !m.GetServerStreaming()
+}
+
+// isDownload reports whether the unary method responds with the
+// google.api.HttpBody, getting the Download variant streaming the raw
+// bytes of the response body
+func isDownload(m *descriptor.Method) bool {
+	if m.GetClientStreaming() || m.GetServerStreaming() || len(m.Bindings) == 0 {
+		return false
+	}
+	return m.Bindings[0].IsHTTPBodyResponse()
+}
+
+// isNative reports whether the unary method is sent natively over the
+// grpc connection configured using sdk.WithConn, which is not the case
+// for the methods relying on the routes for the semantics of the
+// request fields and the response, like the sealed fields opened, the
+// fields populated from the identity of the principal and the signed
+// responses
+func isNative(m *descriptor.Method) bool {
+	return !m.Signed && len(m.EncryptedFields) == 0 && len(m.IdentityFields) == 0
+}
+
+// isBidiStreaming reports whether the method streams both the request
+// and the response messages
+func isBidiStreaming(m *descriptor.Method) bool {
+	return m.GetClientStreaming() && m.GetServerStreaming()
+}
+
+// durationExpr returns the go expression of the duration using the
+// largest unit dividing it evenly, like 100 * time.Millisecond
+func durationExpr(d time.Duration) string {
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	} {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
+// hasObjectives reports whether any of the methods of the service bound
+// to the routes is annotated using (api.slo)
+func hasObjectives(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && m.Objective != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getterExpr returns the go expression reading the field at the path
+// of the request, like req.GetObject().GetEtag()
+func getterExpr(path string) string {
+	expr := "req"
+	for _, name := range strings.Split(path, ".") {
+		expr += ".Get" + casing.Camel(name) + "()"
+	}
+	return expr
+}
+
+// conflictStatuses returns the status codes of the version conflicts of
+// the conditional writes, excluding the ones allowed for the method
+func conflictStatuses(m *descriptor.Method) []int32 {
+	var codes []int32
+	for _, code := range []int32{409, 412} {
+		if !slices.Contains(m.AllowedStatus, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
 func getCamelCasing(val string) string {
 	return casing.Camel(val)
 }
 
-func hasQueryParams(m *descriptor.Method) bool {
-	if len(m.Bindings) == 0 {
-		return false
+// getLowerCamelCasing returns camel cased value with first letter
+// in lower case to be used for unexported identifiers
+func getLowerCamelCasing(val string) string {
+	val = casing.Camel(val)
+	if val == "" {
+		return val
 	}
+	return strings.ToLower(val[:1]) + val[1:]
+}
 
-	b := m.Bindings[0]
+func hasQueryParams(b *descriptor.Binding) bool {
 	// if body is expected with *, then skip going through
 	// query params
 	if b.Body != nil && len(b.Body.FieldPath) == 0 {
@@ -143,6 +438,17 @@ func hasQueryParams(m *descriptor.Method) bool {
 		delete(fields, p.FieldPath.String())
 	}
 
+	// skip the fields populated by the routes from the identity of
+	// the principal
+	for _, f := range b.Method.IdentityFields {
+		delete(fields, f.Field.GetName())
+	}
+
+	// skip the fields set by the server alone
+	for _, path := range b.Method.OutputOnlyFields {
+		delete(fields, path)
+	}
+
 	// include remaining fields in the query params list
 	for _, f := range b.Method.RequestType.Fields {
 		// iterate through the list instead of map
@@ -162,15 +468,12 @@ func hasQueryParams(m *descriptor.Method) bool {
 type queryParam struct {
 	Name     string
 	Optional bool
+	Field    *descriptor.Field
 }
 
-func getQueryParams(m descriptor.Method) []queryParam {
+func getQueryParams(mb *methodBinding) []queryParam {
+	b := mb.Binding
 	list := []queryParam{}
-	if len(m.Bindings) == 0 {
-		return list
-	}
-
-	b := m.Bindings[0]
 	// if body is expected with *, then skip going through
 	// query params
 	if b.Body != nil && len(b.Body.FieldPath) == 0 {
@@ -195,6 +498,17 @@ func getQueryParams(m descriptor.Method) []queryParam {
 		delete(fields, p.FieldPath.String())
 	}
 
+	// skip the fields populated by the routes from the identity of
+	// the principal
+	for _, f := range b.Method.IdentityFields {
+		delete(fields, f.Field.GetName())
+	}
+
+	// skip the fields set by the server alone
+	for _, path := range b.Method.OutputOnlyFields {
+		delete(fields, path)
+	}
+
 	// include remaining fields in the query params list
 	for _, f := range b.Method.RequestType.Fields {
 		// iterate through the list instead of map
@@ -204,9 +518,16 @@ func getQueryParams(m descriptor.Method) []queryParam {
 		val := f.GetName()
 		_, ok := fields[val]
 		if ok {
+			_, wkt := wellKnownFormatters[f.GetTypeName()]
+			if mb.CamelQueryParams {
+				val = descriptor.JSONName(f)
+			}
 			list = append(list, queryParam{
-				Name:     val,
-				Optional: f.GetProto3Optional(),
+				Name: val,
+				// unset well known types are skipped similar to
+				// optional fields
+				Optional: f.GetProto3Optional() || wkt,
+				Field:    f,
 			})
 		}
 	}
@@ -214,6 +535,137 @@ func getQueryParams(m descriptor.Method) []queryParam {
 	return list
 }
 
+// methodBinding is the binding of a method along with the name of the
+// SDK method generated for it, the first binding is used by the method
+// itself while every additional binding gets a method of its own
+type methodBinding struct {
+	*descriptor.Binding
+	// Name of the SDK method using the binding
+	Name string
+	// ListField of the response decoded in batch, nil if not a list
+	ListField *listField
+	// EnumsAsInts sends the enums as their numeric values
+	EnumsAsInts bool
+	// PathPrefix is prepended to the path template of the binding
+	PathPrefix string
+	// CamelQueryParams sends the query params in lowerCamelCase
+	CamelQueryParams bool
+	// WithOtel propagates the trace context of the client span
+	WithOtel bool
+	// RequestTracing attaches the trace of the request for debugging
+	RequestTracing bool
+}
+
+// BodyExpr returns the expression of the value sent as the request
+// body, the request itself for wildcard body, otherwise the field of
+// the request the body is mapped to
+func (b *methodBinding) BodyExpr() string {
+	if len(b.Body.FieldPath) == 0 {
+		return "req"
+	}
+	return fmt.Sprintf("req.Get%s()", casing.Camel(b.Body.FieldPath.String()))
+}
+
+// ResponseBodyExpr returns the expression of the field of the response
+// the payload is mapped to
+func (b *methodBinding) ResponseBodyExpr() string {
+	return fmt.Sprintf("out.%s", casing.Camel(b.ResponseBody.FieldPath.String()))
+}
+
+// BodyIsMessage reports whether the value sent as the request body is a
+// message, as opposed to a scalar, repeated or map field
+func (b *methodBinding) BodyIsMessage() bool {
+	if len(b.Body.FieldPath) == 0 {
+		return true
+	}
+	f := b.Body.FieldPath[len(b.Body.FieldPath)-1].Target
+	return f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE &&
+		f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+}
+
+// Protobuf reports whether the binding can be sent and received as
+// protobuf binary when configured using sdk.WithProtobuf, which needs
+// the body and the payload of the response to be the messages
+// themselves, the signed and the streaming methods continue to use JSON
+func (b *methodBinding) Protobuf() bool {
+	m := b.Method
+	if m.GetClientStreaming() || m.GetServerStreaming() || m.Signed {
+		return false
+	}
+	if b.ListField != nil || b.ResponseBody != nil {
+		return false
+	}
+	return b.Body == nil || len(b.Body.FieldPath) == 0
+}
+
+// RawResponse reports whether the payload of the response is mapped to
+// a google.api.HttpBody field, carrying the raw bytes of the response
+// instead of being decoded
+func (b *methodBinding) RawResponse() bool {
+	m := b.Method
+	if m.GetClientStreaming() || m.GetServerStreaming() {
+		return false
+	}
+	return b.ResponseBody != nil && b.IsHTTPBodyResponse()
+}
+
+// Marshals reports whether the marshaller is needed to encode the body
+// of the request or to decode the response
+func (b *methodBinding) Marshals() bool {
+	return !b.RawResponse() || (b.Body != nil && !b.IsHTTPBody())
+}
+
+// FormatValue returns the expression formatting the value of the field,
+// given by expr, as a path or query param
+func (b *methodBinding) FormatValue(f *descriptor.Field, expr string) string {
+	if fn, ok := wellKnownFormatters[f.GetTypeName()]; ok {
+		return fmt.Sprintf("sdk.%s(%s)", fn, expr)
+	}
+	if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		return fmt.Sprintf("fmt.Sprintf(\"%%v\", %s)", expr)
+	}
+	if b.EnumsAsInts {
+		return fmt.Sprintf("fmt.Sprintf(\"%%d\", %s)", expr)
+	}
+	return expr + ".String()"
+}
+
+// wellKnownFormatters are the SDK functions formatting the well known
+// types as parsed by grpc-gateway for the path and query params
+var wellKnownFormatters = map[string]string{
+	".google.protobuf.Timestamp": "FormatTimestamp",
+	".google.protobuf.Duration":  "FormatDuration",
+	".google.protobuf.FieldMask": "FormatFieldMask",
+}
+
+// getBindings returns the bindings of the method for which the SDK
+// methods are generated
+func getBindings(p param, m *descriptor.Method) []*methodBinding {
+	var bindings []*methodBinding
+	for i, b := range m.Bindings {
+		name := m.GetName()
+		if i != 0 {
+			name = fmt.Sprintf("%sBinding%d", m.GetName(), i)
+		}
+		mb := &methodBinding{
+			Binding:          b,
+			Name:             name,
+			EnumsAsInts:      p.EnumsAsInts,
+			PathPrefix:       p.PathPrefix,
+			CamelQueryParams: p.CamelQueryParams,
+			WithOtel:         p.WithOtel,
+			RequestTracing:   p.RequestTracing,
+		}
+		// payload mapped to a field of the response is not the list
+		// response, decoded as is
+		if b.ResponseBody == nil {
+			mb.ListField = p.ListFields[m]
+		}
+		bindings = append(bindings, mb)
+	}
+	return bindings
+}
+
 func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	var targetServices []*descriptor.Service
 
@@ -222,6 +674,10 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 		msg.Name = &msgName
 	}
 
+	if err := reg.CheckDuplicateBindings(p.Services); err != nil {
+		return "", err
+	}
+
 	for _, svc := range p.Services {
 		var methodWithBindingsSeen bool
 		svcName := casing.Camel(*svc.Name)
@@ -233,11 +689,7 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 			}
 			methName := casing.Camel(*meth.Name)
 			meth.Name = &methName
-			for _, b := range meth.Bindings {
-				if err := reg.CheckDuplicateAnnotation(b.HTTPMethod, b.PathTmpl.Template, svc); err != nil {
-					return "", err
-				}
-
+			if len(meth.Bindings) != 0 {
 				methodWithBindingsSeen = true
 			}
 		}
@@ -248,6 +700,11 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	if len(targetServices) == 0 {
 		return "", errNoTargetService
 	}
+	if p.BatchedListDecode {
+		p.ListFields = getListFields(p.File, reg)
+	}
+	p.Informers = getInformers(p.File, reg)
+	p.Pagers = getPagers(p.File, reg)
 
 	tp := trailerParams{
 		P:                  p,
@@ -265,129 +722,1927 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	return w.String(), nil
 }
 
-var (
-	rtemplate = template.Must(template.New("header").Funcs(
-		template.FuncMap{
-			"GetCamelCasing":   getCamelCasing,
-			"GetQueryParams":   getQueryParams,
-			"GetImports":       getImports,
-			"GetMethodComment": getMethodComment,
-		},
-	).Parse(`
-// Code generated by protoc-gen-sdk. DO NOT EDIT.
-// source: {{.P.GetName}}
-
-package {{.P.GoPkg.Name}}
-
-{{- $param := .P }}
-{{- $imp := GetImports .Services }}
-{{- if $imp }}
-import (
-	{{- range $i := $imp }}
-	"{{ $i }}"
-	{{- end }}
-
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-
-	auth "github.com/go-core-stack/auth/client"
-)
-{{- end }}
+// fileParams describes the services of the file having the SDK
+// wrappers, for the companion files generated along with the SDK
+type fileParams struct {
+	Registry         *descriptor.Registry
+	File             *descriptor.File
+	Services         []*descriptor.Service
+	BidiWebSocket    bool
+	LongPollFallback bool
+	PathPrefix       string
+}
 
-{{range $sid, $svc := .Services}}
-// {{$svc.GetName}}Service
-// provides SDK wrapper methods for {{$svc.GetName}} service
-type {{$svc.GetName}}Service interface {
-	{{- range $mid, $m := $svc.Methods }}
-	{{- range $comment := GetMethodComment $param $sid $mid }}
-	// {{ $comment }}
-	{{- end }}
-	{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (*{{$m.ResponseType.GetName}}, error)
+// applyFileTemplate returns the companion file generated using the
+// template for the services of the file having the SDK wrappers, like
+// the race test exercising all the unary and server streaming methods
+// concurrently, the dependency injection glue or the mocks
+func applyFileTemplate(tmpl *template.Template, p *fileParams) (string, error) {
+	file := p.File
+	for _, svc := range file.Services {
+		if hasBindings(svc) {
+			p.Services = append(p.Services, svc)
+		}
+	}
+	w := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(w, p); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
 
-	{{- end }}
+// WireFixture returns the path of the fixture pinning the requests sent
+// by the SDK wrapper for the service, relative to the package
+func (p *fileParams) WireFixture(svc *descriptor.Service) string {
+	return "testdata/" + path.Base(p.File.GeneratedFilenamePrefix) + "." + svc.GetName() + ".wire.json"
 }
 
-type impl{{$svc.GetName}}Service struct {
-	client auth.Client
+// mockMethod describes a method of the SDK interface of a service, as
+// implemented by the generated mock calling the function field
+type mockMethod struct {
+	Name    string
+	Params  string
+	Args    string
+	Results string
 }
 
-// New{{$svc.GetName}}Service
-// creates a new SDK wrapper for {{$svc.GetName}} service
-// function expects to be provided with an auth client to
-// trigger request to service
-func New{{$svc.GetName}}Service(client auth.Client) {{$svc.GetName}}Service {
-	return &impl{{$svc.GetName}}Service{
-		client: client,
+// MockMethods returns the methods of the SDK interface of the service,
+// the same as declared by the interface in the SDK
+func (p *fileParams) MockMethods(svc *descriptor.Service) []*mockMethod {
+	var methods []*mockMethod
+	for _, m := range svc.Methods {
+		req := "*" + m.RequestType.GetName()
+		resp := m.ResponseType.GetName()
+		switch {
+		case isBidiStreaming(m):
+			if !p.BidiWebSocket {
+				continue
+			}
+			methods = append(methods, &mockMethod{
+				Name:    m.GetName(),
+				Params:  "ctx context.Context",
+				Args:    "ctx",
+				Results: fmt.Sprintf("(*sdk.BidiStream[%s, %s, *%s], error)", req, resp, resp),
+			})
+		case isClientStreaming(m):
+			methods = append(methods, &mockMethod{
+				Name:    m.GetName(),
+				Params:  "ctx context.Context",
+				Args:    "ctx",
+				Results: fmt.Sprintf("(*sdk.ClientStream[%s, %s, *%s], error)", req, resp, resp),
+			})
+		case m.GetServerStreaming():
+			results := fmt.Sprintf("(*sdk.Stream[%s, *%s], error)", resp, resp)
+			if m.Watch != nil {
+				results = fmt.Sprintf("(sdk.Watcher[*%s], error)", m.Watch.Object.GoType(svc.File.GoPkg.Path))
+			}
+			methods = append(methods, &mockMethod{
+				Name:    m.GetName(),
+				Params:  "ctx context.Context, req " + req,
+				Args:    "ctx, req",
+				Results: results,
+			})
+			if p.LongPollFallback {
+				methods = append(methods, &mockMethod{
+					Name:    "Subscribe" + m.GetName(),
+					Params:  "ctx context.Context, req " + req + ", opts ...sdk.SubscribeOption",
+					Args:    "ctx, req, opts...",
+					Results: fmt.Sprintf("(*sdk.Subscription[%s, *%s], error)", resp, resp),
+				})
+			}
+		default:
+			call := &mockMethod{
+				Name:    m.GetName(),
+				Params:  "ctx context.Context, req " + req + ", opts ...sdk.CallOption",
+				Args:    "ctx, req, opts...",
+				Results: fmt.Sprintf("(*%s, error)", resp),
+			}
+			methods = append(methods, call, &mockMethod{
+				Name:    m.GetName() + "Into",
+				Params:  "ctx context.Context, req " + req + ", out *" + resp + ", opts ...sdk.CallOption",
+				Args:    "ctx, req, out, opts...",
+				Results: "error",
+			})
+			if len(m.AllowedStatus) != 0 || m.Upsert {
+				methods = append(methods, &mockMethod{
+					Name:    m.GetName() + "Result",
+					Params:  call.Params,
+					Args:    call.Args,
+					Results: fmt.Sprintf("(*sdk.Result[%s], error)", resp),
+				})
+			}
+			if isDownload(m) {
+				methods = append(methods, &mockMethod{
+					Name:    m.GetName() + "Download",
+					Params:  call.Params,
+					Args:    call.Args,
+					Results: "(*sdk.Download, error)",
+				})
+			}
+			for i := 1; i < len(m.Bindings); i++ {
+				methods = append(methods, &mockMethod{
+					Name:    fmt.Sprintf("%sBinding%d", m.GetName(), i),
+					Params:  call.Params,
+					Args:    call.Args,
+					Results: call.Results,
+				})
+			}
+		}
 	}
+	return methods
 }
 
-{{range $m := $svc.Methods}}
-func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (*{{$m.ResponseType.GetName}}, error) {
-	{{- $b := (index $m.Bindings 0) }}
-	uri := "{{ $b.PathTmpl.Template }}"
-
-	{{- if gt (len $b.PathParams) 0 }}
-	// ensure replacing the variables in the uri before triggering client
-	{{- end }}
-	{{- range $p := $b.PathParams }}
-	uri = strings.Replace(uri, "{"+"{{ $p.Target.Name }}"+"}", url.PathEscape(fmt.Sprintf("%v", req.{{GetCamelCasing $p.Target.Name }})), -1)
-	{{- end }}
+// FakeMethods returns the methods of the service served by the fake
+// server, the unary methods having the bindings
+func (p *fileParams) FakeMethods(svc *descriptor.Service) []*descriptor.Method {
+	return fakeMethods(svc)
+}
 
-	// use marshaller for grpc Gateway since we are working protobuf files
-	marshaller := &runtime.JSONPb{}
-	{{ if $b.Body }}
-	inData, _ := marshaller.Marshal(req)
-	r, err := http.NewRequestWithContext(ctx, {{ $b.HTTPMethod | printf "%q" }}, uri, bytes.NewBuffer(inData))
-	{{- else }}
-	r, err := http.NewRequestWithContext(ctx, {{ $b.HTTPMethod | printf "%q" }}, uri, nil)
-	{{- end }}
-	if err != nil {
-		return nil, fmt.Errorf("failed create request: %s", err) 
-	}
+// fakeStoreMethod describes the method of the fake served using the
+// sdk.FakeStore, as per the (api.role) option of the method
+type fakeStoreMethod struct {
+	*descriptor.Method
+	Resource string
+	Verb     string
+	// Parent are the path params of the first binding of the method
+	// preceding the collection of the resource
+	Parent []string
+	// Keys are the trailing path param of the first binding of the
+	// method, identifying the resource within the parent
+	Keys []string
+	// Body is the body of the first binding of the method
+	Body string
+	// ID is the field of the resource identifying the resource, set
+	// for the create and the upsert methods
+	ID string
+	// Filters are the simple fields of the list request having the
+	// fields of the same names and types in the resource, set for the
+	// list methods
+	Filters []string
+}
 
-	{{- $qList := GetQueryParams $m }}
-	{{- if $qList }}
-	q := url.Values{}
-	{{- range $q := $qList }}
-	{{- if $q.Optional }}
-	if req.{{GetCamelCasing $q.Name }} != nil {
-		q.Add("{{ $q.Name }}", fmt.Sprintf("%v", req.Get{{GetCamelCasing $q.Name }}()))
-	}
-	{{- else }}
-	q.Add("{{ $q.Name }}", fmt.Sprintf("%v", req.Get{{GetCamelCasing $q.Name }}()))
-	{{- end }}
-	{{- end }}
-	r.URL.RawQuery = q.Encode()
-	{{- end }}
+// FakeStoreMethods returns the methods of the service served by the fake
+// using the sdk.FakeStore, the ones having the (api.role) option
+func (p *fileParams) FakeStoreMethods(svc *descriptor.Service) []*fakeStoreMethod {
+	return fakeStoreMethods(p.Registry, svc)
+}
 
-	r.Header.Set("Content-Type", "application/json")
-	resp, err := s.client.Do(r)
-	if err != nil {
-		return nil, err
+// fakeStoreMethods returns the methods of the service having the
+// (api.role) option, along with the model of their resources inferred
+// from the methods acting on the resource, the parent and the resource
+// keys from the path templates, the message of the resource from the
+// get, create or list methods, the field identifying the resource from
+// the keys and the filters from the list requests
+func fakeStoreMethods(reg *descriptor.Registry, svc *descriptor.Service) []*fakeStoreMethod {
+	var methods []*fakeStoreMethod
+	for _, m := range fakeMethods(svc) {
+		if m.Role == nil || m.Role.Resource == "" {
+			continue
+		}
+		b := m.Bindings[0]
+		fm := &fakeStoreMethod{
+			Method:   m,
+			Resource: m.Role.Resource,
+			Verb:     strings.ToLower(m.Role.Verb),
+		}
+		fm.Parent, fm.Keys = fakeKeys(b)
+		if b.Body != nil {
+			fm.Body = "*"
+			if len(b.Body.FieldPath) != 0 {
+				fm.Body = b.Body.FieldPath.String()
+			}
+		}
+		methods = append(methods, fm)
 	}
-
-	defer func() {
-		if resp.Body != nil {
-			_ = resp.Body.Close()
+	for _, fm := range methods {
+		switch {
+		case fm.Verb == "create" || fm.Upsert:
+			fm.ID = fakeIDField(fakeResourceMessage(reg, methods, fm.Resource), methods, fm.Resource)
+		case fm.Verb == "list":
+			fm.Filters = fakeFilterFields(fakeResourceMessage(reg, methods, fm.Resource), fm)
 		}
-	}()
-	outBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
 	}
+	return methods
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// fakeKeys splits the path params of the binding into the ones
+// identifying the parent and the trailing one identifying the resource,
+// the params followed by the collection of the resource in the path
+// template identifying the parent, like project in
+// /v1/projects/{project}/objects
+func fakeKeys(b *descriptor.Binding) (parent, keys []string) {
+	tmpl := b.PathTmpl.Template
+	if b.PathTmpl.Verb != "" {
+		tmpl = strings.TrimSuffix(tmpl, ":"+b.PathTmpl.Verb)
 	}
-
-	out := &{{ $m.ResponseType.GetName }}{}
-	err = marshaller.Unmarshal(outBytes, out)
-	if err != nil {
-		return nil, err
+	for i, param := range b.PathParams {
+		if i == len(b.PathParams)-1 && strings.HasSuffix(tmpl, "}") {
+			keys = append(keys, param.FieldPath.String())
+			continue
+		}
+		parent = append(parent, param.FieldPath.String())
 	}
-
-	return out, nil
+	return parent, keys
 }
-{{end}}
 
-{{end}}`))
+// fakeResourceMessage returns the message of the resource, as responded
+// by the get method, sent by the create method or listed by the list
+// method of the resource, nil if none of them is known
+func fakeResourceMessage(reg *descriptor.Registry, methods []*fakeStoreMethod, resource string) *descriptor.Message {
+	for _, verb := range []string{"get", "create", "list"} {
+		for _, fm := range methods {
+			if fm.Resource != resource || fm.Verb != verb {
+				continue
+			}
+			switch verb {
+			case "get":
+				return fm.ResponseType
+			case "create":
+				if fm.Body == "*" {
+					return fm.RequestType
+				}
+				body := fm.Bindings[0].Body
+				if body == nil {
+					continue
+				}
+				target := body.FieldPath[len(body.FieldPath)-1].Target
+				if msg, err := reg.LookupMsg("", target.GetTypeName()); err == nil {
+					return msg
+				}
+			case "list":
+				for _, f := range fm.ResponseType.Fields {
+					if f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED || f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+						continue
+					}
+					if msg, err := reg.LookupMsg("", f.GetTypeName()); err == nil && !msg.GetOptions().GetMapEntry() {
+						return msg
+					}
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fakeIDField returns the field of the resource message identifying the
+// resource, the string or the integer field named after the key of the
+// methods of the resource, falling back to the id or the name fields
+func fakeIDField(msg *descriptor.Message, methods []*fakeStoreMethod, resource string) string {
+	if msg == nil {
+		return ""
+	}
+	var candidates []string
+	for _, fm := range methods {
+		if fm.Resource == resource && len(fm.Keys) != 0 {
+			key := fm.Keys[len(fm.Keys)-1]
+			candidates = append(candidates, key[strings.LastIndex(key, ".")+1:])
+		}
+	}
+	for _, name := range append(candidates, "id", "name") {
+		for _, f := range msg.Fields {
+			if f.GetName() != name || f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				continue
+			}
+			switch f.GetType() {
+			case descriptorpb.FieldDescriptorProto_TYPE_STRING,
+				descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64,
+				descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+				descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+				descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+				descriptorpb.FieldDescriptorProto_TYPE_SFIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// fakePageFields are the fields of the list requests controlling the
+// listing, never used as the filters
+var fakePageFields = map[string]bool{
+	"page_size":  true,
+	"page_token": true,
+	"order_by":   true,
+	"filter":     true,
+}
+
+// fakeFilterFields returns the simple fields of the list request, not
+// bound to the path, having the fields of the same names and types in
+// the resource message
+func fakeFilterFields(msg *descriptor.Message, fm *fakeStoreMethod) []string {
+	if msg == nil {
+		return nil
+	}
+	bound := map[string]bool{}
+	for _, k := range append(slices.Clone(fm.Parent), fm.Keys...) {
+		bound[k] = true
+	}
+	var filters []string
+	for _, f := range fm.RequestType.Fields {
+		if bound[f.GetName()] || fakePageFields[f.GetName()] ||
+			f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED ||
+			f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP ||
+			f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+			continue
+		}
+		for _, rf := range msg.Fields {
+			if rf.GetName() == f.GetName() && rf.GetType() == f.GetType() && rf.GetTypeName() == f.GetTypeName() &&
+				rf.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				filters = append(filters, f.GetName())
+				break
+			}
+		}
+	}
+	return filters
+}
+
+func fakeMethods(svc *descriptor.Service) []*descriptor.Method {
+	var methods []*descriptor.Method
+	for _, m := range svc.Methods {
+		if len(m.Bindings) != 0 && !m.GetClientStreaming() && !m.GetServerStreaming() {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// exampleMethod describes the example calling a method of the SDK
+// wrapper, documented using the comments of the method
+type exampleMethod struct {
+	Name    string
+	Comment []string
+	// Kind of the call, one of unary, server_streaming, watch and
+	// client_streaming
+	Kind string
+	// Pkg is the name of the package of the request message
+	Pkg     string
+	Request string
+	Fields  []*exampleField
+}
+
+// exampleField is a field of the request set by the example
+type exampleField struct {
+	Name    string
+	Value   string
+	Comment []string
+}
+
+// PackageImport returns the import spec of the package of the file, as
+// imported by the examples from the external test package
+func (p *fileParams) PackageImport() string {
+	if path.Base(p.File.GoPkg.Path) == p.File.GoPkg.Name {
+		return fmt.Sprintf("%q", p.File.GoPkg.Path)
+	}
+	return fmt.Sprintf("%s %q", p.File.GoPkg.Name, p.File.GoPkg.Path)
+}
+
+// ExampleMethods returns the examples of the methods of the service
+// having the bindings, skipping the bidirectional streaming methods
+func (p *fileParams) ExampleMethods(svc *descriptor.Service) []*exampleMethod {
+	var methods []*exampleMethod
+	for _, m := range svc.Methods {
+		if len(m.Bindings) == 0 || isBidiStreaming(m) {
+			continue
+		}
+		example := &exampleMethod{
+			Name:    m.GetName(),
+			Comment: methodComment(svc, m),
+			Kind:    "unary",
+			Pkg:     p.File.GoPkg.Name,
+			Request: m.RequestType.GetName(),
+			Fields:  exampleFields(m),
+		}
+		switch {
+		case m.Watch != nil:
+			example.Kind = "watch"
+		case isClientStreaming(m):
+			example.Kind = "client_streaming"
+		case m.GetServerStreaming():
+			example.Kind = "server_streaming"
+		}
+		methods = append(methods, example)
+	}
+	return methods
+}
+
+// methodComment returns the leading comments of the method, looked up
+// by the name as the services without the bindings are dropped
+func methodComment(svc *descriptor.Service, m *descriptor.Method) []string {
+	for i, sd := range svc.File.GetService() {
+		if sd.GetName() != svc.GetName() {
+			continue
+		}
+		for j, md := range sd.GetMethod() {
+			if md.GetName() == m.GetName() {
+				return leadingComments(svc.File, []int32{6, int32(i), 2, int32(j)})
+			}
+		}
+	}
+	return nil
+}
+
+// leadingComments returns the lines of the leading comments of the
+// element at the path of the file, nil if none
+func leadingComments(file *descriptor.File, path []int32) []string {
+	for _, loc := range file.GetSourceCodeInfo().GetLocation() {
+		if !equalPath(loc.GetPath(), path) {
+			continue
+		}
+		str := strings.TrimSpace(loc.GetLeadingComments())
+		if str == "" {
+			return nil
+		}
+		lines := strings.Split(str, "\n")
+		for i, s := range lines {
+			lines[i] = strings.TrimSpace(s)
+		}
+		return lines
+	}
+	return nil
+}
+
+// exampleFields returns the singular scalar fields of the request set
+// by the example, using the defaults of the fields if annotated and the
+// placeholder values otherwise, along with the comments of the fields
+// of the top level messages
+func exampleFields(m *descriptor.Method) []*exampleField {
+	defaults := map[*descriptor.Field]string{}
+	for _, d := range m.Defaults {
+		defaults[d.Field] = d.Value
+	}
+	msg := m.RequestType
+	var fields []*exampleField
+	for i, f := range msg.Fields {
+		if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED || f.OneofIndex != nil {
+			continue
+		}
+		var value string
+		def, hasDefault := defaults[f]
+		switch f.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+			value = fmt.Sprintf("%q", f.GetName())
+			if hasDefault {
+				value = fmt.Sprintf("%q", def)
+			}
+		case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+			value = fmt.Sprintf("[]byte(%q)", f.GetName())
+		case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+			value = "true"
+			if hasDefault {
+				value = def
+			}
+		case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+			value = "1.5"
+			if hasDefault {
+				value = def
+			}
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_ENUM, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+			continue
+		default:
+			value = "1"
+			if hasDefault {
+				value = def
+			}
+		}
+		field := &exampleField{Name: goFieldName(f.GetName()), Value: value}
+		if len(msg.Outers) == 0 {
+			field.Comment = leadingComments(msg.File, []int32{4, int32(msg.Index), 2, int32(i)})
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// goFieldName returns the name of the Go struct field generated for the
+// proto field, suffixed with an underscore by protoc-gen-go when it
+// collides with the methods of the generated messages
+func goFieldName(name string) string {
+	name = casing.Camel(name)
+	switch name {
+	case "Reset", "String", "ProtoMessage", "Marshal", "Unmarshal", "ExtensionRangeArray", "ExtensionMap", "Descriptor":
+		return name + "_"
+	}
+	return name
+}
+
+// fakeServerParams describes the services of a go package served by
+// the generated cmd/fakeserver main
+type fakeServerParams struct {
+	GoPkg    descriptor.GoPackage
+	Dir      string
+	Services []*descriptor.Service
+}
+
+// HasStore reports whether the service has the methods served using the
+// sdk.FakeStore
+func (p *fakeServerParams) HasStore(svc *descriptor.Service) bool {
+	for _, m := range fakeMethods(svc) {
+		if m.Role != nil && m.Role.Resource != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Pkg returns the name the package is imported as by the main
+func (p *fakeServerParams) Pkg() string {
+	if p.GoPkg.Alias != "" {
+		return p.GoPkg.Alias
+	}
+	return p.GoPkg.Name
+}
+
+// Seeded reports whether any of the services has the methods served
+// using the sdk.FakeStore, seeded from the fixtures
+func (p *fakeServerParams) Seeded() bool {
+	for _, svc := range p.Services {
+		if p.HasStore(svc) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyFakeServerTemplate(p *fakeServerParams) (string, error) {
+	w := bytes.NewBuffer(nil)
+	if err := fakeservertemplate.Execute(w, p); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func applyProductTemplate(p *productParams) (string, error) {
+	w := bytes.NewBuffer(nil)
+	if err := ptemplate.Execute(w, p); err != nil {
+		return "", err
+	}
+
+	return w.String(), nil
+}
+
+var (
+	rtemplate = template.Must(template.New("header").Funcs(
+		template.FuncMap{
+			"GetCamelCasing":    getCamelCasing,
+			"GetQueryParams":    getQueryParams,
+			"GetBindings":       getBindings,
+			"GetImports":        getImports,
+			"UsesOtel":          usesOtel,
+			"IsClientStreaming": isClientStreaming,
+			"IsDownload":        isDownload,
+			"IsBidiStreaming":   isBidiStreaming,
+			"IsNative":          isNative,
+			"GetMethodComment":  getMethodComment,
+			"DurationExpr":      durationExpr,
+			"GetterExpr":        getterExpr,
+			"ConflictStatuses":  conflictStatuses,
+			"HasObjectives":     hasObjectives,
+		},
+	).Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.P.GetName}}
+
+package {{.P.GoPkg.Name}}
+{{- template "skipped-notes" .P }}
+
+{{- $param := .P }}
+{{- $imp := GetImports .Services }}
+{{- if $imp }}
+import (
+	{{- range $i := $imp }}
+	"{{ $i }}"
+	{{- end }}
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	{{- if UsesOtel $param .Services }}
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	{{- end }}
+	{{- if $param.GRPCFallback }}
+	"google.golang.org/grpc"
+	{{- end }}
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{- end }}
+
+{{range $sid, $svc := .Services}}
+// {{$svc.GetName}}Service
+// provides SDK wrapper methods for {{$svc.GetName}} service
+type {{$svc.GetName}}Service interface {
+	{{- range $mid, $m := $svc.Methods }}
+	{{- if and (IsBidiStreaming $m) (not $param.BidiWebSocket) }}
+	{{- continue }}
+	{{- end }}
+	{{- range $comment := GetMethodComment $param $sid $mid }}
+	// {{ $comment }}
+	{{- end }}
+	{{- if IsBidiStreaming $m }}
+	{{$m.GetName}}(ctx context.Context) (*sdk.BidiStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error)
+	{{- else if IsClientStreaming $m }}
+	{{$m.GetName}}(ctx context.Context) (*sdk.ClientStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error)
+	{{- else if $m.GetServerStreaming }}
+	{{- if $m.Watch }}
+	{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (sdk.Watcher[*{{$m.Watch.Object.GoType $svc.File.GoPkg.Path}}], error)
+	{{- else }}
+	{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (*sdk.Stream[{{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error)
+	{{- end }}
+	{{- if $param.LongPollFallback }}
+	// Subscribe{{$m.GetName}} is same as {{$m.GetName}}, receiving the messages
+	// as server sent events with fallback to long polling when streaming is
+	// not supported by the network, resuming transparently across reconnects
+	Subscribe{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.SubscribeOption) (*sdk.Subscription[{{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error)
+	{{- end }}
+	{{- else }}
+	{{- if and $param.GRPCFallback (not (IsNative $m)) }}
+	// Always sent using the http client, even if configured using
+	// sdk.WithConn, relying on the routes to handle the fields of the
+	// request or to sign the response
+	{{- end }}
+	{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*{{$m.ResponseType.GetName}}, error)
+	// {{$m.GetName}}Into is same as {{$m.GetName}}, decoding the response
+	// into the provided message to allow reusing the allocations
+	{{$m.GetName}}Into(ctx context.Context, req *{{$m.RequestType.GetName}}, out *{{$m.ResponseType.GetName}}, opts ...sdk.CallOption) error
+	{{- if or $m.AllowedStatus $m.Upsert }}
+	// {{$m.GetName}}Result is same as {{$m.GetName}}, additionally providing the
+	{{- if $m.AllowedStatus }}
+	// outcome for the expected status codes {{ range $i, $c := $m.AllowedStatus }}{{ if $i }}, {{ end }}{{ $c }}{{ end }} instead of an error
+	{{- else }}
+	// status code, reporting whether the upsert created the resource
+	{{- end }}
+	{{$m.GetName}}Result(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*sdk.Result[{{$m.ResponseType.GetName}}], error)
+	{{- end }}
+	{{- if IsDownload $m }}
+	// {{$m.GetName}}Download is same as {{$m.GetName}}, streaming the raw bytes
+	// of the response body instead of reading them in memory, like the
+	// large file downloads, the returned download must be closed once done
+	{{- if $param.GRPCFallback }}.
+	// Always sent using the http client, even if configured using
+	// sdk.WithConn, failing with sdk.ErrNoClient when constructed using
+	// only the grpc connection
+	{{- end }}
+	{{$m.GetName}}Download(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*sdk.Download, error)
+	{{- end }}
+	{{- range $i, $mb := GetBindings $param $m }}
+	{{- if $i }}
+	// {{$mb.Name}} is same as {{$m.GetName}}, using the additional binding
+	// {{$mb.HTTPMethod}} {{$mb.PathPrefix}}{{$mb.PathTmpl.Template}}
+	{{$mb.Name}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*{{$m.ResponseType.GetName}}, error)
+	{{- end }}
+	{{- end }}
+	{{- end }}
+
+	{{- end }}
+}
+
+type impl{{$svc.GetName}}Service struct {
+	client sdk.Doer
+	config *sdk.ServiceConfig
+}
+
+// New{{$svc.GetName}}Service
+// creates a new SDK wrapper for {{$svc.GetName}} service, safe for
+// concurrent use, triggering the requests using the client, see
+// package sdk for the ServiceOptions
+func New{{$svc.GetName}}Service(client sdk.Doer, opts ...sdk.ServiceOption) {{$svc.GetName}}Service {
+	{{- if HasObjectives $svc }}
+	// service level objectives of the methods as per (api.slo), declared
+	// to the metrics implementing sdk.ObjectiveMetrics
+	opts = append([]sdk.ServiceOption{sdk.WithObjectives(map[string]sdk.Objective{
+		{{- range $m := $svc.Methods }}
+		{{- if $m.Bindings }}
+		{{- with $m.Objective }}
+		{{ $m.GetName | printf "%q" }}: {
+			{{- if .Latency }}Latency: {{ DurationExpr .Latency }}, LatencyPercentile: {{ .LatencyPercentile }}{{ end }}
+			{{- if and .Latency .Availability }}, {{ end }}
+			{{- if .Availability }}Availability: {{ .Availability }}{{ end -}}
+		},
+		{{- end }}
+		{{- end }}
+		{{- end }}
+	})}, opts...)
+	{{- end }}
+	{{- if $param.ProtoNames }}
+	// JSON bodies named the same as the query params
+	config := sdk.NewServiceConfig("{{ with $svc.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}", append([]sdk.ServiceOption{sdk.WithProtoNames()}, opts...)...)
+	{{- else }}
+	config := sdk.NewServiceConfig("{{ with $svc.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}", opts...)
+	{{- end }}
+	return &impl{{$svc.GetName}}Service{
+		client: config.Client(client),
+		config: config,
+	}
+}
+{{- if $param.GRPCFallback }}
+
+// New{{$svc.GetName}}ServiceFromConn
+// creates a new SDK wrapper for {{$svc.GetName}} service sending the
+// unary calls natively over the grpc connection, skipping the gateway
+// hop for the internal services, the calls requiring the http client,
+// like the streaming methods, fail with sdk.ErrNoClient. Use
+// New{{$svc.GetName}}Service along with sdk.WithConn to fall back to the
+// http client for them instead
+func New{{$svc.GetName}}ServiceFromConn(conn grpc.ClientConnInterface, opts ...sdk.ServiceOption) {{$svc.GetName}}Service {
+	return New{{$svc.GetName}}Service(sdk.NoClient, append(opts, sdk.WithConn(conn))...)
+}
+{{- end }}
+
+{{range $m := $svc.Methods}}
+{{- if IsBidiStreaming $m }}
+{{- if $param.BidiWebSocket }}
+{{- $b := (index $m.Bindings 0) }}
+// {{$m.GetName}} opens the websocket stream to exchange messages with
+// the server, the returned stream must be closed once done
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context) (*sdk.BidiStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error) {
+	uri := "{{ $param.PathPrefix }}{{ $b.PathTmpl.Template }}"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+	return sdk.NewBidiStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}](ctx, s.client, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), marshaller)
+}
+{{- end }}
+{{- else if IsClientStreaming $m }}
+{{- $b := (index $m.Bindings 0) }}
+// {{$m.GetName}} opens the stream to send messages to the server, the
+// response is received once the stream is closed using CloseAndRecv
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context) (*sdk.ClientStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error) {
+	uri := "{{ $param.PathPrefix }}{{ $b.PathTmpl.Template }}"
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+	return sdk.NewClientStream[*{{$m.RequestType.GetName}}, {{$m.ResponseType.GetName}}](ctx, s.client, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), marshaller)
+}
+{{- else if $m.GetServerStreaming }}
+{{- if $m.Watch }}
+// {{$m.GetName}} watches the changes sent by the server as typed events,
+// the returned watcher must be stopped once done
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (sdk.Watcher[*{{$m.Watch.Object.GoType $svc.File.GoPkg.Path}}], error) {
+{{- else }}
+// {{$m.GetName}} opens the stream of messages sent by the server, the
+// returned stream must be closed once done
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}) (*sdk.Stream[{{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error) {
+{{- end }}
+	{{- if $param.WithOtel }}
+	{{- template "otel-span" (index (GetBindings $param $m) 0) }}
+	{{- end }}
+	{{- with $m.IdentityFields }}
+	// populated from the identity of the principal by the server instead
+	req = sdk.OmitFields(req{{ range . }}, {{ .Field.GetName | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- with $m.OutputOnlyFields }}
+	// set by the server alone, never sent
+	req = sdk.OmitFields(req{{ range . }}, {{ . | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- if $m.RequiredFields }}
+	if err := sdk.CheckRequired(req{{ range $m.RequiredFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	r, marshaller, err := s.new{{$m.GetName}}Request(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	observed := s.config.Observe({{ $m.GetName | printf "%q" }})
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		{{- if $param.WithOtel }}
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		{{- end }}
+		return nil, err
+	}
+	{{- if $param.WithOtel }}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	{{- end }}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		{{- if $param.WithOtel }}
+		span.SetStatus(otelcodes.Error, http.StatusText(resp.StatusCode))
+		{{- end }}
+		return nil, sdk.DecodeError(resp.StatusCode, body)
+	}
+	{{- if $m.Watch }}
+	return sdk.NewWatcher(sdk.NewStream[{{$m.ResponseType.GetName}}](marshaller, resp), func(e *{{$m.ResponseType.GetName}}) sdk.Event[*{{$m.Watch.Object.GoType $svc.File.GoPkg.Path}}] {
+		return sdk.Event[*{{$m.Watch.Object.GoType $svc.File.GoPkg.Path}}]{
+			Type:   sdk.ParseEventType(e.Get{{ GetCamelCasing $m.Watch.TypeField.GetName }}(){{ if eq $m.Watch.TypeField.GetType.String "TYPE_ENUM" }}.String(){{ end }}),
+			Object: e.Get{{ GetCamelCasing $m.Watch.ObjectField.GetName }}(),
+		}
+	}), nil
+	{{- else }}
+	return sdk.NewStream[{{$m.ResponseType.GetName}}](marshaller, resp), nil
+	{{- end }}
+}
+{{- if $param.LongPollFallback }}
+
+func (s *impl{{$svc.GetName}}Service) Subscribe{{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.SubscribeOption) (*sdk.Subscription[{{$m.ResponseType.GetName}}, *{{$m.ResponseType.GetName}}], error) {
+	{{- with $m.IdentityFields }}
+	// populated from the identity of the principal by the server instead
+	req = sdk.OmitFields(req{{ range . }}, {{ .Field.GetName | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- with $m.OutputOnlyFields }}
+	// set by the server alone, never sent
+	req = sdk.OmitFields(req{{ range . }}, {{ . | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- if $m.RequiredFields }}
+	if err := sdk.CheckRequired(req{{ range $m.RequiredFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		r, _, err := s.new{{$m.GetName}}Request(ctx, req)
+		return r, err
+	}
+	return sdk.NewSubscription[{{$m.ResponseType.GetName}}](ctx, s.client, newRequest, s.config.JSONMarshaler(), opts...)
+}
+{{- end }}
+{{- else }}
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*{{$m.ResponseType.GetName}}, error) {
+	out := &{{ $m.ResponseType.GetName }}{}
+	if err := s.{{$m.GetName}}Into(ctx, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}Into(ctx context.Context, req *{{$m.RequestType.GetName}}, out *{{$m.ResponseType.GetName}}, opts ...sdk.CallOption) error {
+	status, body, err := s.do{{$m.GetName}}(ctx, req, out, opts)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return sdk.DecodeError(status, body)
+	}
+	return nil
+}
+{{- if or $m.AllowedStatus $m.Upsert }}
+
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}Result(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*sdk.Result[{{$m.ResponseType.GetName}}], error) {
+	out := &{{ $m.ResponseType.GetName }}{}
+	status, body, err := s.do{{$m.GetName}}(ctx, req, out, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case status >= 200 && status < 300:
+		return &sdk.Result[{{$m.ResponseType.GetName}}]{StatusCode: status, Response: out}, nil
+	{{- if $m.AllowedStatus }}
+	case {{ range $i, $c := $m.AllowedStatus }}{{ if $i }} || {{ end }}status == {{ $c }}{{ end }}:
+		return &sdk.Result[{{$m.ResponseType.GetName}}]{StatusCode: status, Body: body}, nil
+	{{- end }}
+	}
+	return nil, sdk.DecodeError(status, body)
+}
+{{- end }}
+{{- if IsDownload $m }}
+
+func (s *impl{{$svc.GetName}}Service) {{$m.GetName}}Download(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*sdk.Download, error) {
+	{{- with $m.IdentityFields }}
+	// populated from the identity of the principal by the server instead
+	req = sdk.OmitFields(req{{ range . }}, {{ .Field.GetName | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- with $m.OutputOnlyFields }}
+	// set by the server alone, never sent
+	req = sdk.OmitFields(req{{ range . }}, {{ . | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- if $m.RequiredFields }}
+	if err := sdk.CheckRequired(req{{ range $m.RequiredFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	{{- if $m.EncryptedFields }}
+	req, err := sdk.SealFields(ctx, s.config, req{{ range $m.EncryptedFields }}, {{ .GetName | printf "%q" }}{{ end }})
+	if err != nil {
+		return nil, err
+	}
+	{{- end }}
+	r, opts, err := s.new{{$m.GetName}}Request(ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	observed := s.config.Observe({{ $m.GetName | printf "%q" }})
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// body is streamed until the download is closed
+	return sdk.NewDownload(resp, cancel)
+}
+{{- end }}
+{{- range $i, $mb := GetBindings $param $m }}
+{{- if $i }}
+
+func (s *impl{{$svc.GetName}}Service) {{$mb.Name}}(ctx context.Context, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) (*{{$m.ResponseType.GetName}}, error) {
+	out := &{{ $m.ResponseType.GetName }}{}
+	status, body, err := s.do{{$mb.Name}}(ctx, req, out, opts)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, sdk.DecodeError(status, body)
+	}
+	return out, nil
+}
+{{- end }}
+{{- if $param.WithOtel }}
+
+// do{{$mb.Name}} triggers the request within the client span of the call
+func (s *impl{{$svc.GetName}}Service) do{{$mb.Name}}(ctx context.Context, req *{{$m.RequestType.GetName}}, out *{{$m.ResponseType.GetName}}, opts []sdk.CallOption) (int, []byte, error) {
+	{{- template "otel-span" $mb }}
+	status, body, err := s.send{{$mb.Name}}(ctx, req, out, opts)
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	case status >= 400:
+		span.SetStatus(otelcodes.Error, http.StatusText(status))
+	}
+	return status, body, err
+}
+
+// send{{$mb.Name}} triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *impl{{$svc.GetName}}Service) send{{$mb.Name}}(ctx context.Context, req *{{$m.RequestType.GetName}}, out *{{$m.ResponseType.GetName}}, opts []sdk.CallOption) (int, []byte, error) {
+{{- else }}
+
+// do{{$mb.Name}} triggers the request, decoding the response into out
+// on success, returns the status code along with the body otherwise
+func (s *impl{{$svc.GetName}}Service) do{{$mb.Name}}(ctx context.Context, req *{{$m.RequestType.GetName}}, out *{{$m.ResponseType.GetName}}, opts []sdk.CallOption) (int, []byte, error) {
+{{- end }}
+	{{- with $m.IdentityFields }}
+	// populated from the identity of the principal by the server instead
+	req = sdk.OmitFields(req{{ range . }}, {{ .Field.GetName | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- with $m.OutputOnlyFields }}
+	// set by the server alone, never sent
+	req = sdk.OmitFields(req{{ range . }}, {{ . | printf "%q" }}{{ end }})
+	{{- end }}
+	{{- if $m.RequiredFields }}
+	if err := sdk.CheckRequired(req{{ range $m.RequiredFields }}, {{ .GetName | printf "%q" }}{{ end }}); err != nil {
+		return 0, nil, err
+	}
+	{{- end }}
+	{{- if $m.EncryptedFields }}
+	req, err := sdk.SealFields(ctx, s.config, req{{ range $m.EncryptedFields }}, {{ .GetName | printf "%q" }}{{ end }})
+	if err != nil {
+		return 0, nil, err
+	}
+	{{- end }}
+	{{- if and $param.GRPCFallback (IsNative $m) }}
+	{{- template "native-call" $mb }}
+	{{- end }}
+	{{- if IsDownload $m }}
+	r, opts, err := s.new{{$mb.Name}}Request(ctx, req, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	{{- else }}
+	{{- template "new-request" $mb }}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed create request: %s", err) 
+	}
+	{{- template "prepare-request" $mb }}
+	{{- end }}
+	r, cancel := sdk.ApplyCallOptions(r, opts...)
+	defer cancel()
+	observed := s.config.Observe({{ $m.GetName | printf "%q" }})
+	resp, err := s.config.Do(s.client, r)
+	observed(resp, err)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	outBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	{{- if and $m.VersionField (ne $mb.HTTPMethod "GET") }}
+	{{- with ConflictStatuses $m }}
+
+	if {{ range $i, $c := . }}{{ if $i }} || {{ end }}resp.StatusCode == {{ $c }}{{ end }} {
+		return 0, nil, sdk.DecodeConflict(resp.StatusCode, resp.Header, outBytes)
+	}
+	{{- end }}
+	{{- end }}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, outBytes, nil
+	}
+	{{- if $m.Signed }}
+
+	if err := sdk.VerifySignature(s.config, resp.Header, outBytes); err != nil {
+		return 0, nil, err
+	}
+	{{- end }}
+
+	{{- $lf := $mb.ListField }}
+	{{- if $lf }}
+
+	// decode the items of the list in batch to reduce allocations
+	items, err := sdk.UnmarshalList[{{ $lf.ItemType }}](marshaller, outBytes, out, "{{ $lf.Name }}")
+	if err != nil {
+		return 0, nil, err
+	}
+	out.{{ $lf.GoName }} = items
+	return resp.StatusCode, nil, nil
+	{{- else if $mb.RawResponse }}
+
+	// payload carries the raw bytes of the {{ $mb.ResponseBody.FieldPath }} field of the response
+	{{ $mb.ResponseBodyExpr }} = sdk.HTTPBodyOf(resp, outBytes)
+	return resp.StatusCode, nil, nil
+	{{- else if $mb.ResponseBody }}
+
+	// payload carries only the {{ $mb.ResponseBody.FieldPath }} field of the response
+	if err := marshaller.Unmarshal(outBytes, &{{ $mb.ResponseBodyExpr }}); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+	{{- else if $mb.Protobuf }}
+
+	// decoded as per the content type of the response
+	if err := sdk.ResponseMarshaler(resp, marshaller).Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+	{{- else }}
+
+	if err := marshaller.Unmarshal(outBytes, out); err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, nil, nil
+	{{- end }}
+}
+{{- if IsDownload $m }}
+
+// new{{$mb.Name}}Request creates the request of the call along with its
+// options, the defaults of the method followed by the given ones
+func (s *impl{{$svc.GetName}}Service) new{{$mb.Name}}Request(ctx context.Context, req *{{$m.RequestType.GetName}}, opts []sdk.CallOption) (*http.Request, []sdk.CallOption, error) {
+	{{- template "new-request" $mb }}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	{{- template "prepare-request" $mb }}
+	return r, opts, nil
+}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if and $m.GetServerStreaming (not $m.GetClientStreaming) }}
+
+// new{{$m.GetName}}Request creates the request for {{$m.GetName}} along with
+// the marshaller to decode the messages of the stream
+func (s *impl{{$svc.GetName}}Service) new{{$m.GetName}}Request(ctx context.Context, req *{{$m.RequestType.GetName}}) (*http.Request, runtime.Marshaler, error) {
+	{{- if $m.EncryptedFields }}
+	req, err := sdk.SealFields(ctx, s.config, req{{ range $m.EncryptedFields }}, {{ .GetName | printf "%q" }}{{ end }})
+	if err != nil {
+		return nil, nil, err
+	}
+	{{- end }}
+	{{- $mb := index (GetBindings $param $m) 0 }}
+	{{- template "new-request" $mb }}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed create request: %s", err)
+	}
+	{{- template "request-query" $mb }}
+	{{- if not $mb.IsHTTPBody }}
+
+	r.Header.Set("Content-Type", "application/json")
+	{{- end }}
+	sdk.SetMetadataHeaders(r)
+	{{- if $param.WithOtel }}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	{{- end }}
+	{{- if $param.RequestTracing }}
+	{{- template "request-trace" $mb }}
+	{{- end }}
+	return r, {{ if $mb.Marshals }}marshaller{{ else }}nil{{ end }}, nil
+}
+{{- end }}
+{{end}}
+{{- range $inf := index $param.Informers $svc }}
+{{- $obj := $inf.ListField.ItemType }}
+
+// New{{$svc.GetName}}{{$inf.Name}}Informer creates the informer caching the
+// objects of {{$inf.Resource}} resource of {{$svc.GetName}} service keyed by the {{$inf.KeyField}},
+// listed using {{$inf.List.GetName}} and watched using {{$inf.Watch.GetName}}
+func New{{$svc.GetName}}{{$inf.Name}}Informer(svc {{$svc.GetName}}Service, listReq *{{$inf.List.RequestType.GetName}}, watchReq *{{$inf.Watch.RequestType.GetName}}, opts ...sdk.InformerOption) *sdk.Informer[*{{$obj}}] {
+	return sdk.NewInformer(
+		func(ctx context.Context) ([]*{{$obj}}, error) {
+			resp, err := svc.{{$inf.List.GetName}}(ctx, listReq)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Get{{$inf.ListField.GoName}}(), nil
+		},
+		func(ctx context.Context) (sdk.Watcher[*{{$obj}}], error) {
+			return svc.{{$inf.Watch.GetName}}(ctx, watchReq)
+		},
+		func(obj *{{$obj}}) string {
+			return obj.Get{{ GetCamelCasing $inf.KeyField }}()
+		},
+		opts...,
+	)
+}
+{{- end }}
+{{- range $m := $svc.Methods }}
+{{- with index $param.Pagers $m }}
+{{- with .ListField }}
+
+// Iterate{{$svc.GetName}}{{$m.GetName}} returns an iterator over the {{.Name}} of all the pages
+// of {{$m.GetName}}, following the next_page_token until exhausted, stopping
+// after yielding the error of any page
+func Iterate{{$svc.GetName}}{{$m.GetName}}(ctx context.Context, svc {{$svc.GetName}}Service, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) iter.Seq2[*{{.ItemType}}, error] {
+	return sdk.Items(Iterate{{$svc.GetName}}{{$m.GetName}}Pages(ctx, svc, req, opts...), (*{{$m.ResponseType.GetName}}).Get{{.GoName}})
+}
+{{- end }}
+
+// Iterate{{$svc.GetName}}{{$m.GetName}}Pages returns an iterator over the pages of
+// {{$m.GetName}}, starting at the page_token of the request and following the
+// next_page_token until exhausted, see sdk.Pages
+func Iterate{{$svc.GetName}}{{$m.GetName}}Pages(ctx context.Context, svc {{$svc.GetName}}Service, req *{{$m.RequestType.GetName}}, opts ...sdk.CallOption) iter.Seq2[*{{$m.ResponseType.GetName}}, error] {
+	return sdk.Pages(ctx, req, svc.{{$m.GetName}}, opts...)
+}
+{{- end }}
+{{- end }}
+
+{{end}}
+
+{{- define "new-request" }}
+{{- $b := . }}
+	uri := "{{ $b.PathPrefix }}{{ $b.PathTmpl.Template }}"
+
+	{{- if gt (len $b.PathParams) 0 }}
+	// ensure replacing the variables in the uri before triggering client
+	{{- end }}
+	{{- range $p := $b.PathParams }}
+	{{- $expr := printf "req.%s" (GetCamelCasing $p.Target.Name) }}
+	{{- if $p.IsEnum }}
+	{{- $expr = printf "req.Get%s()" (GetCamelCasing $p.Target.Name) }}
+	{{- end }}
+	uri = strings.Replace(uri, "{"+"{{ $p.Target.Name }}"+"}", url.PathEscape({{ $b.FormatValue $p.Target $expr }}), -1)
+	{{- end }}
+
+	{{- if not $b.Marshals }}
+	{{- else if $b.Protobuf }}
+
+	// encoded as protobuf if configured using sdk.WithProtobuf, JSON otherwise
+	marshaller := s.config.Marshaler()
+	{{- else }}
+
+	// use marshaller for grpc Gateway since we are working protobuf files
+	marshaller := s.config.JSONMarshaler()
+	{{- end }}
+	{{ if $b.Body }}
+	{{- if $b.IsHTTPBody }}
+	// raw bytes of the body sent as is with its declared content type
+	r, err := sdk.NewHTTPBodyRequest(ctx, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), {{ $b.BodyExpr }})
+	{{- else if $b.BodyIsMessage }}
+	// large request messages are streamed instead of encoding in memory
+	r, err := sdk.NewRequest(ctx, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), marshaller, {{ $b.BodyExpr }})
+	{{- else }}
+	r, err := sdk.NewValueRequest(ctx, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), marshaller, {{ $b.BodyExpr }})
+	{{- end }}
+	{{- else }}
+	r, err := http.NewRequestWithContext(ctx, {{ $b.HTTPMethod | printf "%q" }}, s.config.URL(uri), nil)
+	{{- end }}
+{{- end }}
+
+{{- define "prepare-request" }}
+{{- $mb := . }}
+{{- $m := $mb.Method }}
+	{{- template "request-query" $mb }}
+	{{- if $mb.Protobuf }}
+
+	r.Header.Set("Content-Type", marshaller.ContentType(nil))
+	r.Header.Set("Accept", marshaller.ContentType(nil))
+	{{- else if not $mb.IsHTTPBody }}
+
+	r.Header.Set("Content-Type", "application/json")
+	{{- end }}
+	sdk.SetMetadataHeaders(r)
+	{{- if $mb.WithOtel }}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	{{- end }}
+	{{- if $mb.RequestTracing }}
+	{{- template "request-trace" $mb }}
+	{{- end }}
+	{{- with $m.RetryPolicy }}
+	// retry policy of the method, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetry(sdk.RetryConfig{
+		MaxAttempts: {{ .MaxAttempts }},
+		{{- if .InitialBackoff }}
+		Backoff: {{ DurationExpr .InitialBackoff }},
+		{{- end }}
+		{{- if .MaxBackoff }}
+		MaxBackoff: {{ DurationExpr .MaxBackoff }},
+		{{- end }}
+		{{- if .StatusCodes }}
+		StatusCodes: []int{ {{- range $i, $c := .StatusCodes }}{{ if $i }}, {{ end }}{{ $c }}{{ end -}} },
+		{{- end }}
+	})}, opts...)
+	{{- if or (eq $mb.HTTPMethod "POST") (eq $mb.HTTPMethod "PATCH") }}
+	// retried {{ $mb.HTTPMethod }} carries the idempotency key generated per call,
+	// allowing the server to deduplicate the attempts
+	opts = append([]sdk.CallOption{sdk.WithIdempotencyKey("")}, opts...)
+	{{- end }}
+	{{- end }}
+	{{- if $m.RetrySafety }}
+	// classified {{ $m.RetrySafety }} to be retried, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithRetrySafe({{ $m.RetrySafety.Safe }})}, opts...)
+	{{- end }}
+	{{- if $m.Bulkhead }}
+	// isolated in the {{ $m.Bulkhead }} bulkhead, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithBulkhead({{ $m.Bulkhead | printf "%q" }})}, opts...)
+	{{- end }}
+	{{- if and $m.SurrogateKey (eq $mb.HTTPMethod "GET") }}
+	// cached until invalidated, when the service is configured using sdk.WithCache
+	opts = append([]sdk.CallOption{sdk.WithCacheTag({{ $m.SurrogateKey | printf "%q" }})}, opts...)
+	{{- end }}
+	{{- if or $m.SensitiveRequestFields $m.SensitiveResponseFields }}
+	// sensitive fields redacted by sdk.LoggingInterceptor
+	opts = append([]sdk.CallOption{sdk.WithRedaction(&sdk.Redaction{
+		{{- with $m.SensitiveRequestFields }}
+		RequestFields: []string{ {{- range $i, $f := . }}{{ if $i }}, {{ end }}{{ $f | printf "%q" }}{{ end -}} },
+		{{- end }}
+		{{- with $mb.Body }}
+		RequestBody: "{{ with .FieldPath.String }}{{ . }}{{ else }}*{{ end }}",
+		{{- end }}
+		{{- with $m.SensitiveResponseFields }}
+		ResponseFields: []string{ {{- range $i, $f := . }}{{ if $i }}, {{ end }}{{ $f | printf "%q" }}{{ end -}} },
+		{{- end }}
+		{{- with $mb.ResponseBody }}
+		ResponseBody: {{ .FieldPath.String | printf "%q" }},
+		{{- end }}
+	})}, opts...)
+	{{- end }}
+	{{- if and $m.VersionField (ne $mb.HTTPMethod "GET") }}
+	// conditional on the version of the resource, unless overridden by the caller
+	opts = append([]sdk.CallOption{sdk.WithIfMatch({{ GetterExpr $m.VersionField }})}, opts...)
+	{{- end }}
+	{{- with $m.Invalidates }}
+	// invalidates the cached responses of {{ range $i, $id := . }}{{ if $i }}, {{ end }}{{ $id }}{{ end }}
+	opts = append([]sdk.CallOption{sdk.WithInvalidation({{ range $i, $id := . }}{{ if $i }}, {{ end }}{{ $id | printf "%q" }}{{ end }})}, opts...)
+	{{- end }}
+	{{- if $m.LongPoll }}
+	// long polling, the options of the caller override the timeout
+	opts = append([]sdk.CallOption{sdk.WithLongPoll({{ if $m.Timeout }}{{ DurationExpr $m.Timeout }}{{ else }}sdk.DefaultLongPollTimeout{{ end }})}, opts...)
+	{{- else if $m.Timeout }}
+	// bounded by the default timeout of the method unless the context has
+	// a deadline, the options of the caller override the timeout
+	opts = append([]sdk.CallOption{sdk.WithDefaultTimeout({{ DurationExpr $m.Timeout }})}, opts...)
+	{{- end }}
+{{- end }}
+
+{{- define "native-call" }}
+{{- $b := . }}
+{{- $m := $b.Method }}
+{{- $svc := $m.Service }}
+	// sent natively over the grpc connection if configured using
+	// sdk.WithConn, skipping the gateway hop
+	if s.config.Native() {
+		{{- if $m.LongPoll }}
+		opts = append([]sdk.CallOption{sdk.WithLongPoll({{ if $m.Timeout }}{{ DurationExpr $m.Timeout }}{{ else }}sdk.DefaultLongPollTimeout{{ end }})}, opts...)
+		{{- else if $m.Timeout }}
+		opts = append([]sdk.CallOption{sdk.WithDefaultTimeout({{ DurationExpr $m.Timeout }})}, opts...)
+		{{- end }}
+		return s.config.Invoke(ctx, "/{{ with $svc.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}/{{ $m.GetName }}", req, out, opts)
+	}
+{{- end }}
+
+{{- define "request-trace" }}
+{{- $b := . }}
+{{- $svc := $b.Method.Service }}
+	// trace of the request constructed from the message, for debugging
+	r = sdk.WithRequestTrace(r, &sdk.RequestTrace{
+		Method:       "/{{ with $svc.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}/{{ $b.Method.GetName }}",
+		HTTPMethod:   {{ $b.HTTPMethod | printf "%q" }},
+		PathTemplate: {{ $b.PathTmpl.Template | printf "%q" }},
+		{{- if $b.PathParams }}
+		PathParams: map[string]string{
+			{{- range $p := $b.PathParams }}
+			{{- $expr := printf "req.%s" (GetCamelCasing $p.Target.Name) }}
+			{{- if $p.IsEnum }}
+			{{- $expr = printf "req.Get%s()" (GetCamelCasing $p.Target.Name) }}
+			{{- end }}
+			"{{ $p.Target.Name }}": {{ $b.FormatValue $p.Target $expr }},
+			{{- end }}
+		},
+		{{- end }}
+		{{- with GetQueryParams $b }}
+		QueryParams: []string{ {{- range $i, $q := . }}{{ if $i }}, {{ end }}"{{ $q.Name }}"{{ end -}} },
+		{{- end }}
+		{{- with $b.Body }}
+		Body: "{{ with .FieldPath.String }}{{ . }}{{ else }}*{{ end }}",
+		{{- end }}
+	})
+{{- end }}
+
+{{- define "otel-span" }}
+{{- $b := . }}
+	// client span of the call, the trace context is propagated to the server
+	ctx, span := otel.Tracer("{{ $b.Method.Service.File.GoPkg.Path }}").Start(ctx, "{{ $b.Method.Service.GetName }}/{{ $b.Method.GetName }}",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", {{ $b.HTTPMethod | printf "%q" }}),
+			attribute.String("http.route", "{{ $b.PathPrefix }}{{ $b.PathTmpl.Template }}"),
+		),
+	)
+	defer span.End()
+{{- end }}
+
+{{- define "request-query" }}
+{{- $b := . }}
+	{{- $qList := GetQueryParams $b }}
+	{{- if $qList }}
+	q := url.Values{}
+	{{- range $q := $qList }}
+	{{- $expr := printf "req.Get%s()" (GetCamelCasing $q.Field.GetName) }}
+	{{- if $q.Optional }}
+	if req.{{GetCamelCasing $q.Field.GetName }} != nil {
+		q.Add("{{ $q.Name }}", {{ $b.FormatValue $q.Field $expr }})
+	}
+	{{- else }}
+	q.Add("{{ $q.Name }}", {{ $b.FormatValue $q.Field $expr }})
+	{{- end }}
+	{{- end }}
+	r.URL.RawQuery = q.Encode()
+	{{- end }}
+{{- end }}
+{{- define "skipped-notes" }}
+{{- if .Skipped }}
+
+// The services skipped using best_effort, as their options failed the
+// validation, are reported by go generate until fixed
+{{- range $s := .Skipped }}
+//go:generate echo {{ printf "protoc-gen-sdk: skipped %s: %v" $s.Name $s.Err | printf "%q" }}
+{{- end }}
+{{ end }}
+{{- end }}`))
+
+	// skippedtemplate generates the file of the proto file all the
+	// services of which are skipped using best_effort, carrying the
+	// notes reporting them alone
+	skippedtemplate = template.Must(rtemplate.New("skipped").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.GetName}}
+
+package {{.GoPkg.Name}}
+{{- template "skipped-notes" . }}
+`))
+
+	ptemplate = template.Must(template.New("product").Funcs(
+		template.FuncMap{
+			"GetLowerCamelCasing": getLowerCamelCasing,
+		},
+	).Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// product: {{.Product}}
+
+package {{.GoPkg.Name}}
+
+import (
+	"sync"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+
+// {{.Name}}Client
+// provides umbrella client for all the services grouped
+// under {{.Product}} product, sharing the same client
+// and underlying connections across all the services
+type {{.Name}}Client struct {
+	client sdk.Doer
+	opts   []sdk.ServiceOption
+	{{- range $svc := .Services}}
+
+	{{GetLowerCamelCasing $svc.GetName}}Once sync.Once
+	{{GetLowerCamelCasing $svc.GetName}} {{$svc.GetName}}Service
+	{{- end}}
+}
+
+// New{{.Name}}Client
+// creates a new umbrella client for {{.Product}} product
+// function expects to be provided with the client to
+// trigger request to all the services of the product,
+// SDK wrappers for services are created only on first use,
+// configured using the provided options, like sdk.WithCanary
+// steering the calls of all the services to the canary, while
+// the defaults of the calls, like the tenant header, the locale
+// and the timeout, are set once on the context using
+// sdk.WithDefaults
+func New{{.Name}}Client(client sdk.Doer, opts ...sdk.ServiceOption) *{{.Name}}Client {
+	return &{{.Name}}Client{
+		client: client,
+		// services are created lazily, retain a copy of the options
+		// as the caller may reuse the slice
+		opts: append([]sdk.ServiceOption(nil), opts...),
+	}
+}
+
+// New{{.Name}}ClientFromConfig
+// creates a new umbrella client for {{.Product}} product using
+// the config loaded from the given YAML or JSON file, when path
+// is empty the file referred by SDK_CONFIG environment is used,
+// options like sdk.WithProfile or sdk.WithResolver select the
+// environment and the endpoints to use
+func New{{.Name}}ClientFromConfig(path string, opts ...sdk.Option) (*{{.Name}}Client, error) {
+	cfg, err := sdk.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	client, err := sdk.NewClient(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return New{{.Name}}Client(client), nil
+}
+
+{{- $name := .Name }}
+{{range $svc := .Services}}
+// {{$svc.GetName}} returns the SDK wrapper for {{$svc.GetName}} service
+// initializing it on first use, safe for concurrent use
+func (c *{{$name}}Client) {{$svc.GetName}}() {{$svc.GetName}}Service {
+	c.{{GetLowerCamelCasing $svc.GetName}}Once.Do(func() {
+		c.{{GetLowerCamelCasing $svc.GetName}} = New{{$svc.GetName}}Service(c.client, c.opts...)
+	})
+	return c.{{GetLowerCamelCasing $svc.GetName}}
+}
+{{end}}`))
+
+	racetemplate = template.Must(template.New("race").Funcs(template.FuncMap{
+		"IsDownload": isDownload,
+	}).Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+// Test{{$svc.GetName}}ServiceConcurrentCalls calls all the methods of the
+// SDK wrapper for {{$svc.GetName}} service concurrently, sharing the client
+// and the wrapper, for the race detector to catch the shared mutable
+// state. Outcome of the calls is not verified
+func Test{{$svc.GetName}}ServiceConcurrentCalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client, err := sdk.NewClient(&sdk.Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	svc := New{{$svc.GetName}}Service(client)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			{{- range $m := $svc.Methods }}
+			{{- if and $m.Bindings (not $m.GetClientStreaming) }}
+			{{- if $m.Watch }}
+			if w, err := svc.{{$m.GetName}}(ctx, &{{$m.RequestType.GetName}}{}); err == nil {
+				w.Stop()
+			}
+			{{- else if $m.GetServerStreaming }}
+			if stream, err := svc.{{$m.GetName}}(ctx, &{{$m.RequestType.GetName}}{}); err == nil {
+				_ = stream.Close()
+			}
+			{{- else }}
+			_, _ = svc.{{$m.GetName}}(ctx, &{{$m.RequestType.GetName}}{})
+			_ = svc.{{$m.GetName}}Into(ctx, &{{$m.RequestType.GetName}}{}, &{{$m.ResponseType.GetName}}{})
+			{{- if IsDownload $m }}
+			if d, err := svc.{{$m.GetName}}Download(ctx, &{{$m.RequestType.GetName}}{}); err == nil {
+				_ = d.Close()
+			}
+			{{- end }}
+			{{- if or $m.AllowedStatus $m.Upsert }}
+			_, _ = svc.{{$m.GetName}}Result(ctx, &{{$m.RequestType.GetName}}{})
+			{{- end }}
+			{{- range $i, $b := $m.Bindings }}
+			{{- if $i }}
+			_, _ = svc.{{$m.GetName}}Binding{{$i}}(ctx, &{{$m.RequestType.GetName}}{})
+			{{- end }}
+			{{- end }}
+			{{- end }}
+			{{- end }}
+			{{- end }}
+		}()
+	}
+	wg.Wait()
+}
+{{end}}`))
+
+	wirecompattemplate = template.Must(template.New("wirecompat").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+// Test{{$svc.GetName}}ServiceWireCompat pins the method, the URL along
+// with the query string and the body of the requests sent by the SDK
+// wrapper for {{$svc.GetName}} service, for the canonical request of every
+// binding of the unary methods, against {{ $.WireFixture $svc }},
+// such that the changes breaking the deployed servers show up as the
+// test failures. The fixture is written when missing, set
+// SDK_UPDATE_WIRE_FIXTURES=true to update it once the change is intended
+func Test{{$svc.GetName}}ServiceWireCompat(t *testing.T) {
+	rec := sdk.NewWireRecorder()
+	svc := New{{$svc.GetName}}Service(rec)
+	ctx := context.Background()
+	{{- range $m := $svc.Methods }}
+	{{- if and $m.Bindings (not $m.GetClientStreaming) (not $m.GetServerStreaming) (not $m.EncryptedFields) }}
+	{{- range $i, $b := $m.Bindings }}
+	{{- if $i }}
+	rec.Record("{{$m.GetName}}Binding{{$i}}", func() { _, _ = svc.{{$m.GetName}}Binding{{$i}}(ctx, sdk.CanonicalMessage(&{{$m.RequestType.GetName}}{})) })
+	{{- else }}
+	rec.Record("{{$m.GetName}}", func() { _, _ = svc.{{$m.GetName}}(ctx, sdk.CanonicalMessage(&{{$m.RequestType.GetName}}{})) })
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	if err := rec.Compare({{ $.WireFixture $svc | printf "%q" }}); err != nil {
+		t.Error(err)
+	}
+}
+{{end}}`))
+
+	wiretemplate = template.Must(template.New("wire").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"github.com/google/wire"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+// Provide{{$svc.GetName}}Service provides the SDK wrapper for {{$svc.GetName}}
+// service to the wire injectors, configured using the injected options
+func Provide{{$svc.GetName}}Service(client sdk.Doer, opts sdk.ServiceOptions) {{$svc.GetName}}Service {
+	return New{{$svc.GetName}}Service(client, opts...)
+}
+
+// {{$svc.GetName}}ServiceProviderSet is the wire provider set of the SDK
+// wrapper for {{$svc.GetName}} service, expecting sdk.Doer and
+// sdk.ServiceOptions to be provided by the injector
+var {{$svc.GetName}}ServiceProviderSet = wire.NewSet(Provide{{$svc.GetName}}Service)
+{{end}}`))
+
+	fxtemplate = template.Must(template.New("fx").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+// fx{{$svc.GetName}}ServiceParams are the dependencies of the SDK wrapper
+// for {{$svc.GetName}} service injected by Fx
+type fx{{$svc.GetName}}ServiceParams struct {
+	fx.In
+
+	Client  sdk.Doer
+	Options sdk.ServiceOptions ` + "`" + `optional:"true"` + "`" + `
+}
+
+// {{$svc.GetName}}ServiceModule provides the SDK wrapper for {{$svc.GetName}}
+// service to the Fx application, expecting sdk.Doer along with the
+// optional sdk.ServiceOptions to be provided
+var {{$svc.GetName}}ServiceModule = fx.Module("{{ with $.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}.sdk",
+	fx.Provide(func(p fx{{$svc.GetName}}ServiceParams) {{$svc.GetName}}Service {
+		return New{{$svc.GetName}}Service(p.Client, p.Options...)
+	}),
+)
+{{end}}`))
+
+	mocktemplate = template.Must(template.New("mock").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"context"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+{{- $methods := $.MockMethods $svc }}
+// Mock{{$svc.GetName}}Service is the mock of the SDK wrapper for {{$svc.GetName}}
+// service for the unit tests, every method calls the function field of
+// the same name suffixed with Func, panicking if the field is not set
+type Mock{{$svc.GetName}}Service struct {
+	{{- range $mm := $methods }}
+	{{$mm.Name}}Func func({{$mm.Params}}) {{$mm.Results}}
+	{{- end }}
+}
+
+var _ {{$svc.GetName}}Service = (*Mock{{$svc.GetName}}Service)(nil)
+{{- range $mm := $methods }}
+
+func (m *Mock{{$svc.GetName}}Service) {{$mm.Name}}({{$mm.Params}}) {{$mm.Results}} {
+	if m.{{$mm.Name}}Func == nil {
+		panic("Mock{{$svc.GetName}}Service.{{$mm.Name}}Func is not set")
+	}
+	return m.{{$mm.Name}}Func({{$mm.Args}})
+}
+{{- end }}
+{{end}}`))
+
+	faketemplate = template.Must(template.New("fake").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{range $svc := .Services}}
+{{- $methods := $.FakeMethods $svc }}
+{{- if $methods }}
+// Fake{{$svc.GetName}}Server is the in-memory fake of {{$svc.GetName}} service
+// for the integration style tests of the SDK consumers, serving the
+// routes of the unary methods by calling the function field of the same
+// name suffixed with Func, responding with Unimplemented if not set.
+// Fields are read without synchronization, set them before sending the
+// requests and never while any of them is in flight
+//
+//	fake := &Fake{{$svc.GetName}}Server{}
+//	srv := httptest.NewServer(fake.Handler())
+//	defer srv.Close()
+type Fake{{$svc.GetName}}Server struct {
+	{{- range $m := $methods }}
+	{{$m.GetName}}Func func(ctx context.Context, req *{{$m.RequestType.GetName}}) (*{{$m.ResponseType.GetName}}, error)
+	{{- end }}
+}
+
+// Handler returns the handler serving the routes of the fake
+func (f *Fake{{$svc.GetName}}Server) Handler() http.Handler {
+	mux := sdk.NewFakeMux()
+	f.Register(mux)
+	return mux
+}
+
+// Register registers the routes of the fake on the mux, such that the
+// fakes of several services are served together
+func (f *Fake{{$svc.GetName}}Server) Register(mux *sdk.FakeMux) {
+	{{- range $m := $methods }}
+	{{- range $b := $m.Bindings }}
+	sdk.HandleFake(mux, sdk.FakeRoute{
+		Method:     "{{ with $.File.GetPackage }}{{ . }}.{{ end }}{{ $svc.GetName }}.{{ $m.GetName }}",
+		HTTPMethod: {{ $b.HTTPMethod | printf "%q" }},
+		Pattern:    "{{ $.PathPrefix }}{{ $b.PathTmpl.Template }}",
+		{{- if $b.Body }}
+		Body:       "{{ if $b.Body.FieldPath }}{{ $b.Body.FieldPath.String }}{{ else }}*{{ end }}",
+		{{- end }}
+		{{- if $b.ResponseBody }}
+		ResponseBody: "{{ $b.ResponseBody.FieldPath.String }}",
+		{{- end }}
+	}, &f.{{$m.GetName}}Func)
+	{{- end }}
+	{{- end }}
+}
+{{- with $.FakeStoreMethods $svc }}
+
+// WithStore serves the methods having the (api.role) option using the
+// store, with the CRUD semantics as per their verbs and the resources
+// inferred from the methods, see sdk.FakeCRUD, leaving the functions
+// already set as is
+func (f *Fake{{$svc.GetName}}Server) WithStore(store *sdk.FakeStore) *Fake{{$svc.GetName}}Server {
+	{{- range $m := . }}
+	if f.{{$m.GetName}}Func == nil {
+		f.{{$m.GetName}}Func = sdk.FakeCRUD[*{{$m.RequestType.GetName}}, *{{$m.ResponseType.GetName}}](store, sdk.FakeResource{
+			Resource: {{ $m.Resource | printf "%q" }},
+			Verb:     {{ $m.Verb | printf "%q" }},
+			{{- with $m.Parent }}
+			Parent:   []string{ {{- range $i, $k := . }}{{ if $i }}, {{ end }}{{ $k | printf "%q" }}{{ end -}} },
+			{{- end }}
+			{{- with $m.Keys }}
+			Keys:     []string{ {{- range $i, $k := . }}{{ if $i }}, {{ end }}{{ $k | printf "%q" }}{{ end -}} },
+			{{- end }}
+			{{- with $m.Body }}
+			Body:     {{ . | printf "%q" }},
+			{{- end }}
+			{{- with $m.ID }}
+			ID:       {{ . | printf "%q" }},
+			{{- end }}
+			{{- with $m.Filters }}
+			Filters:  []string{ {{- range $i, $k := . }}{{ if $i }}, {{ end }}{{ $k | printf "%q" }}{{ end -}} },
+			{{- end }}
+			{{- if $m.Upsert }}
+			Upsert:   true,
+			{{- end }}
+		})
+	}
+	{{- end }}
+	return f
+}
+{{- end }}
+{{- end }}
+{{end}}`))
+
+	fakeservertemplate = template.Must(template.New("fakeserver").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+
+// Command fakeserver serves the routes of the services of package {{.GoPkg.Name}}
+// using the in-memory fakes, with the CRUD semantics as per the
+// (api.role) options of the methods, for the development environments
+// of the frontends and the SDK consumers. The resources are seeded from
+// the JSON fixtures, see sdk.FakeStore.Seed
+//
+//	go run ./cmd/fakeserver -addr :8080 -fixtures fixtures.json
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	{{- if .Seeded }}
+	"os"
+	{{- end }}
+
+	{{.GoPkg}}
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to serve the routes on")
+	{{- if .Seeded }}
+	fixtures := flag.String("fixtures", "", "JSON file seeding the resources, keyed by the names and the keys of the resources")
+	{{- end }}
+	flag.Parse()
+	{{- if .Seeded }}
+
+	store := sdk.NewFakeStore()
+	if *fixtures != "" {
+		f, err := os.Open(*fixtures)
+		if err != nil {
+			log.Fatalf("failed to open fixtures: %v", err)
+		}
+		err = store.Seed(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatalf("failed to seed %s: %v", *fixtures, err)
+		}
+	}
+	{{- end }}
+
+	mux := sdk.NewFakeMux()
+	{{- range $svc := .Services }}
+	{{- if $.HasStore $svc }}
+	(&{{$.Pkg}}.Fake{{$svc.GetName}}Server{}).WithStore(store).Register(mux)
+	{{- else }}
+	(&{{$.Pkg}}.Fake{{$svc.GetName}}Server{}).Register(mux)
+	{{- end }}
+	{{- end }}
+	log.Printf("serving the fakes on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+`))
+
+	exampletemplate = template.Must(template.New("example").Parse(`
+// Code generated by protoc-gen-sdk. DO NOT EDIT.
+// source: {{.File.GetName}}
+
+package {{.File.GoPkg.Name}}_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	{{ .PackageImport }}
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+{{- $pkg := .File.GoPkg.Name }}
+{{- range $svc := .Services }}
+{{- range $m := $.ExampleMethods $svc }}
+
+// Example{{$svc.GetName}}Service_{{$m.Name}} calls {{$m.Name}} of {{$svc.GetName}} service
+{{- with $m.Comment }}
+//
+{{- range . }}
+// {{ . }}
+{{- end }}
+{{- end }}
+func Example{{$svc.GetName}}Service_{{$m.Name}}() {
+	client, err := sdk.NewClient(&sdk.Config{Endpoint: "https://api.example.com"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	svc := {{$pkg}}.New{{$svc.GetName}}Service(client)
+	ctx := context.Background()
+	{{- if eq $m.Kind "client_streaming" }}
+
+	stream, err := svc.{{$m.Name}}(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := stream.Send({{ template "example-request" $m }}); err != nil {
+		log.Fatal(err)
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp)
+	{{- else if eq $m.Kind "watch" }}
+
+	w, err := svc.{{$m.Name}}(ctx, {{ template "example-request" $m }})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer w.Stop()
+	for event := range w.ResultChan() {
+		fmt.Println(event.Type, event.Object)
+	}
+	if err := w.Err(); err != nil {
+		log.Fatal(err)
+	}
+	{{- else if eq $m.Kind "server_streaming" }}
+
+	stream, err := svc.{{$m.Name}}(ctx, {{ template "example-request" $m }})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stream.Close()
+	for msg, err := range stream.All() {
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(msg)
+	}
+	{{- else }}
+
+	resp, err := svc.{{$m.Name}}(ctx, {{ template "example-request" $m }})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp)
+	{{- end }}
+}
+{{- end }}
+{{- end }}
+
+{{- define "example-request" -}}
+&{{ .Pkg }}.{{ .Request }}{
+	{{- range $f := .Fields }}
+	{{- range $f.Comment }}
+	// {{ . }}
+	{{- end }}
+	{{ $f.Name }}: {{ $f.Value }},
+	{{- end }}
+	{{- if .Fields }}
+{{ end -}}
+}
+{{- end }}
+`))
 )
@@ -0,0 +1,241 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package scaffold generates the proto file for a service managing a
+// resource, annotated with the HttpRule and Role options following the
+// conventions enforced by the code generators, to be used as the
+// starting point for new services.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-core-stack/grpc-core/internal/casing"
+	"github.com/go-core-stack/grpc-core/internal/descriptor"
+)
+
+// Config describes the service to be scaffolded
+type Config struct {
+	// Package is the proto package of the file
+	Package string
+
+	// GoPackage is the go_package option of the file, skipped if empty
+	GoPackage string
+
+	// Product is the kebab-case product the service belongs to,
+	// skipped if empty
+	Product string
+
+	// Service is the name of the service, defaults to the camel cased
+	// resource followed by "Service"
+	Service string
+
+	// Resource is the kebab-case name of the resource managed by the
+	// service, used as the role resource and in the http paths
+	Resource string
+
+	// Collection is the kebab-case name of the collection of resources
+	// used in the http paths, defaults to the plural of the resource
+	Collection string
+
+	// Scopes are the kebab-case scopes of the role
+	Scopes []string
+}
+
+var (
+	packageRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+	serviceRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// validate checks the config, applying the defaults
+func (c *Config) validate() error {
+	if c.Resource == "" {
+		return errors.New("resource is required")
+	}
+	if !packageRegex.MatchString(c.Package) {
+		return fmt.Errorf("invalid package %q", c.Package)
+	}
+	if c.Collection == "" {
+		c.Collection = plural(c.Resource)
+	}
+	if c.Service == "" {
+		c.Service = casing.Camel(strings.ReplaceAll(c.Resource, "-", "_")) + "Service"
+	}
+	if !serviceRegex.MatchString(c.Service) {
+		return fmt.Errorf("invalid service %q, must be in PascalCase", c.Service)
+	}
+	for field, value := range map[string]string{
+		"product":    c.Product,
+		"resource":   c.Resource,
+		"collection": c.Collection,
+	} {
+		if err := descriptor.ValidateKebabCase(field, value); err != nil {
+			return err
+		}
+	}
+	for i, scope := range c.Scopes {
+		if err := descriptor.ValidateKebabCase(fmt.Sprintf("scope[%d]", i), scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plural returns the naive english plural of the kebab-case name
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case len(name) > 1 && strings.HasSuffix(name, "y") && !strings.ContainsAny(name[len(name)-2:len(name)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+// names are the identifiers used in the scaffolded file
+type names struct {
+	*Config
+	// Message is the message describing the resource
+	Message string
+	// Plural is the camel cased collection used in the list method
+	Plural string
+}
+
+// role is the role annotated for a method
+type role struct {
+	Resource string
+	Scopes   []string
+	Verb     string
+}
+
+// Generate returns the proto file scaffolded as per the config
+func Generate(cfg *Config) ([]byte, error) {
+	c := *cfg
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	n := &names{
+		Config:  &c,
+		Message: casing.Camel(strings.ReplaceAll(c.Resource, "-", "_")),
+		Plural:  casing.Camel(strings.ReplaceAll(c.Collection, "-", "_")),
+	}
+	var buf bytes.Buffer
+	if err := protoTemplate.Execute(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var protoTemplate = template.Must(template.New("proto").Funcs(template.FuncMap{
+	"roleOf": func(n *names, verb string) *role {
+		return &role{Resource: n.Resource, Scopes: n.Scopes, Verb: verb}
+	},
+}).Parse(`syntax = "proto3";
+
+package {{ .Package }};
+
+import "coreapis/api/options.proto";
+import "coreapis/api/role.proto";
+import "google/api/annotations.proto";
+{{ if .GoPackage }}
+option go_package = "{{ .GoPackage }}";
+{{- end }}
+{{- if .Product }}
+option (api.product) = "{{ .Product }}";
+{{- end }}
+
+// {{ .Service }} manages the {{ .Collection }}
+service {{ .Service }} {
+  // creates the {{ .Resource }}
+  rpc Create{{ .Message }}({{ .Message }}) returns ({{ .Message }}) {
+    option (google.api.http) = {
+      post: "/v1/{{ .Collection }}"
+      body: "*"
+    };
+{{- template "role" (roleOf . "create") }}
+  }
+
+  // returns the {{ .Resource }}
+  rpc Get{{ .Message }}(Get{{ .Message }}Request) returns ({{ .Message }}) {
+    option (google.api.http) = {
+      get: "/v1/{{ .Resource }}/{name}"
+    };
+{{- template "role" (roleOf . "get") }}
+  }
+
+  // lists the {{ .Collection }}
+  rpc List{{ .Plural }}(List{{ .Plural }}Request) returns (List{{ .Plural }}Response) {
+    option (google.api.http) = {
+      get: "/v1/{{ .Collection }}"
+    };
+{{- template "role" (roleOf . "list") }}
+  }
+
+  // updates the {{ .Resource }}
+  rpc Update{{ .Message }}({{ .Message }}) returns ({{ .Message }}) {
+    option (google.api.http) = {
+      put: "/v1/{{ .Resource }}/{name}"
+      body: "*"
+    };
+{{- template "role" (roleOf . "update") }}
+  }
+
+  // deletes the {{ .Resource }}
+  rpc Delete{{ .Message }}(Delete{{ .Message }}Request) returns (Delete{{ .Message }}Response) {
+    option (google.api.http) = {
+      delete: "/v1/{{ .Resource }}/{name}"
+    };
+{{- template "role" (roleOf . "delete") }}
+  }
+}
+
+message {{ .Message }} {
+  // name of the {{ .Resource }}
+  string name = 1 [(api.required) = true];
+
+  // description of the {{ .Resource }}
+  string desc = 2;
+}
+
+message Get{{ .Message }}Request {
+  // name of the {{ .Resource }}
+  string name = 1 [(api.required) = true];
+}
+
+message List{{ .Plural }}Request {
+  // maximum number of {{ .Collection }} to return
+  int32 limit = 1 [(api.default) = "50"];
+
+  // number of {{ .Collection }} to skip
+  int32 offset = 2;
+}
+
+message List{{ .Plural }}Response {
+  // list of {{ .Collection }}
+  repeated {{ .Message }} items = 1;
+
+  // total number of {{ .Collection }} available
+  int32 count = 2;
+}
+
+message Delete{{ .Message }}Request {
+  // name of the {{ .Resource }}
+  string name = 1 [(api.required) = true];
+}
+
+message Delete{{ .Message }}Response {}
+{{- define "role" }}
+    option (api.role) = {
+      resource: "{{ .Resource }}"
+      {{- range .Scopes }}
+      scope: "{{ . }}"
+      {{- end }}
+      verb: "{{ .Verb }}"
+    };
+{{- end }}
+`))
@@ -0,0 +1,54 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package scaffold
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	data, err := Generate(&Config{
+		Package:  "inventory.v1",
+		Product:  "demo",
+		Resource: "storage-policy",
+		Scopes:   []string{"tenant"},
+	})
+	if err != nil {
+		t.Fatalf("Generate() failed with %v; want success", err)
+	}
+	for _, want := range []string{
+		"package inventory.v1;",
+		`option (api.product) = "demo";`,
+		"service StoragePolicyService {",
+		"rpc ListStoragePolicies(ListStoragePoliciesRequest) returns (ListStoragePoliciesResponse)",
+		`get: "/v1/storage-policies"`,
+		`delete: "/v1/storage-policy/{name}"`,
+		`resource: "storage-policy"`,
+		`scope: "tenant"`,
+		`verb: "update"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Generate() = %s; want containing %q", data, want)
+		}
+	}
+	if strings.Contains(string(data), "go_package") {
+		t.Errorf("Generate() = %s; want go_package skipped", data)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	for _, cfg := range []*Config{
+		{Package: "inventory", Resource: ""},
+		{Package: "Inventory", Resource: "volume"},
+		{Package: "inventory", Resource: "storageVolume"},
+		{Package: "inventory", Resource: "volume", Scopes: []string{"Tenant"}},
+		{Package: "inventory", Resource: "volume", Product: "demo_product"},
+		{Package: "inventory", Resource: "volume", Service: "volume_service"},
+	} {
+		if _, err := Generate(cfg); err == nil {
+			t.Errorf("Generate(%+v) succeeded; want error", cfg)
+		}
+	}
+}
@@ -12,6 +12,10 @@
 //
 //	protoc --sdk_out=output_directory path/to/input.proto
 //
+// The proto file for a new service can be scaffolded using
+//
+//	protoc-gen-sdk init -package <pkg> -resource <resource> -out path/to/input.proto
+//
 // See README.md for more details.
 package main
 
@@ -36,6 +40,7 @@ var (
 	useRequestContext          = flag.Bool("request_context", true, "determine whether to use http.Request's context or not")
 	allowDeleteBody            = flag.Bool("allow_delete_body", false, "unless set, HTTP DELETE methods may not have a body")
 	grpcAPIConfiguration       = flag.String("grpc_api_configuration", "", "path to gRPC API Configuration in YAML format")
+	openAPIImport              = flag.String("openapi_import", "", "path to OpenAPI document in YAML or JSON format whose operations are bound to the methods by operationId")
 	_                          = flag.Bool("allow_repeated_fields_in_body", true, "allows to use repeated field in `body` and `response_body` field of `google.api.http` annotation option. DEPRECATED: the value is ignored and always behaves as `true`.")
 	repeatedPathParamSeparator = flag.String("repeated_path_param_separator", "csv", "configures how repeated fields should be split. Allowed values are `csv`, `pipes`, `ssv` and `tsv`.")
 	allowPatchFeature          = flag.Bool("allow_patch_feature", true, "determines whether to use PATCH feature involving update masks (using google.protobuf.FieldMask).")
@@ -44,6 +49,27 @@ var (
 	versionFlag                = flag.Bool("version", false, "print the current version")
 	warnOnUnboundMethods       = flag.Bool("warn_on_unbound_methods", false, "emit a warning message if an RPC method has no HttpRule annotation")
 	generateUnboundMethods     = flag.Bool("generate_unbound_methods", false, "generate proxy methods even for RPC methods that have no HttpRule annotation")
+	includeExperimental        = flag.Bool("include_experimental", false, "include the services from the files marked with (api.experimental) option")
+	bestEffort                 = flag.Bool("best_effort", false, "skip the services failing the validation, reporting them along with a go:generate note in the generated files, instead of failing the generation of the entire package")
+	registrySnapshot           = flag.String("registry_snapshot", "", "if set, writes the JSON snapshot of the resolved registry to the given output file for the external tooling")
+	conflictReport             = flag.String("conflict_report", "", "if set, writes the report of the duplicate HTTP annotations to the given file, as HTML for .html files and as markdown otherwise")
+	batchedListDecoding        = flag.Bool("batched_list_decoding", false, "decode the items of list responses into a batch allocated slice, reducing allocations for large lists")
+	bidiWebSocket              = flag.Bool("bidi_websocket", false, "generate wrappers for the bidirectional streaming methods using websocket transport, such methods are skipped otherwise")
+	enumsAsInts                = flag.Bool("enums_as_ints", false, "send the enums in path and query params as their numeric values instead of the names")
+	longPollFallback           = flag.Bool("long_poll_fallback", false, "generate Subscribe wrappers for the server streaming methods, consuming server sent events with fallback to long polling")
+	raceTests                  = flag.Bool("race_tests", false, "generate the tests calling all the SDK methods concurrently using the shared client, to be run with the race detector")
+	wireProviders              = flag.Bool("wire", false, "generate the google/wire provider sets for the SDK wrappers of the services")
+	fxModules                  = flag.Bool("fx", false, "generate the Fx modules providing the SDK wrappers of the services")
+	requestTracing             = flag.Bool("request_tracing", false, "attach the path template, the resolved path params and the query classification to the context of the requests as sdk.RequestTrace, for debugging")
+	withOtel                   = flag.Bool("with_otel", false, "generate the OpenTelemetry client spans around the calls, propagating the trace context to the server")
+	mocks                      = flag.Bool("mocks", false, "generate the mocks of the SDK wrappers, implementing the methods using the function fields, for the unit tests of the consumers")
+	fakeServer                 = flag.Bool("fake_server", false, "generate the in-memory fake servers, serving the routes of the unary methods using the function fields, for the integration tests of the consumers")
+	fakeServerCmd              = flag.Bool("fake_server_cmd", false, "generate the cmd/fakeserver main serving the fake servers of the package, with the in-memory CRUD semantics as per the (api.role) options of the methods and seeded from the JSON fixtures, implies fake_server")
+	examples                   = flag.Bool("examples", false, "generate the Go examples calling each method of the SDK wrappers, shown by go doc along with the SDK")
+	grpcFallback               = flag.Bool("grpc_fallback", false, "generate the SDK sending the unary calls natively over the grpc connection of the services configured using sdk.WithConn, along with the constructors accepting the connection, skipping the gateway hop for the internal services")
+	wireCompatTests            = flag.Bool("wire_compat_tests", false, "generate the tests pinning the method, the URL and the body of the requests sent for the canonical request of every binding against the fixtures in testdata, catching the changes breaking the deployed servers")
+	pathPrefix                 = flag.String("path_prefix", "", "prefix prepended to the URIs of all the generated methods, e.g. /api")
+	jsonNames                  = flag.String("json_names", "", "naming of the fields in the JSON bodies and the query params sent by the SDK, `camel` for lowerCamelCase and `proto` for the original names, unless overridden using (api.json_names) file option. Unless set, the bodies use lowerCamelCase while the query params use the original names")
 
 	_ = flag.Bool("logtostderr", false, "Legacy glog compatibility. This flag is a no-op, you can safely remove it")
 )
@@ -56,6 +82,10 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		os.Exit(runInit(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	flag.Parse()
 
 	if *versionFlag {
@@ -88,7 +118,28 @@ func main() {
 
 		codegenerator.SetSupportedFeaturesOnPluginGen(gen)
 
-		generator := gensdk.New(reg, *useRequestContext, *registerFuncSuffix, *allowPatchFeature, *standalone)
+		generator := gensdk.New(reg, gensdk.Options{
+			UseRequestContext:   *useRequestContext,
+			RegisterFuncSuffix:  *registerFuncSuffix,
+			AllowPatchFeature:   *allowPatchFeature,
+			Standalone:          *standalone,
+			BatchedListDecoding: *batchedListDecoding,
+			BidiWebSocket:       *bidiWebSocket,
+			EnumsAsInts:         *enumsAsInts,
+			LongPollFallback:    *longPollFallback,
+			RaceTests:           *raceTests,
+			Wire:                *wireProviders,
+			Fx:                  *fxModules,
+			RequestTracing:      *requestTracing,
+			WithOtel:            *withOtel,
+			Mocks:               *mocks,
+			FakeServer:          *fakeServer,
+			FakeServerCmd:       *fakeServerCmd,
+			Examples:            *examples,
+			GRPCFallback:        *grpcFallback,
+			WireCompatTests:     *wireCompatTests,
+			PathPrefix:          *pathPrefix,
+		})
 
 		if grpclog.V(1) {
 			grpclog.Infof("Parsing code generator request")
@@ -98,6 +149,20 @@ func main() {
 			return err
 		}
 
+		if report := reg.SkippedServicesReport(); report != "" {
+			fmt.Fprintf(os.Stderr, "protoc-gen-sdk: %s\n", report)
+		}
+
+		if *registrySnapshot != "" {
+			data, err := reg.Export()
+			if err != nil {
+				return err
+			}
+			if _, err := gen.NewGeneratedFile(*registrySnapshot, "").Write(data); err != nil {
+				return err
+			}
+		}
+
 		unboundHTTPRules := reg.UnboundExternalHTTPRules()
 		if len(unboundHTTPRules) != 0 {
 			return fmt.Errorf("HTTP rules without a matching selector: %s", strings.Join(unboundHTTPRules, ", "))
@@ -114,7 +179,7 @@ func main() {
 
 		files, err := generator.Generate(targets)
 		if err != nil {
-			return err
+			return descriptor.WriteConflictReport(*conflictReport, err)
 		}
 		for _, f := range files {
 			if grpclog.V(1) {
@@ -141,6 +206,11 @@ func applyFlags(reg *descriptor.Registry) error {
 			return err
 		}
 	}
+	if *openAPIImport != "" {
+		if err := reg.LoadOpenAPIRoutes(*openAPIImport); err != nil {
+			return err
+		}
+	}
 	if *warnOnUnboundMethods && *generateUnboundMethods {
 		grpclog.Warningf("Option warn_on_unbound_methods has no effect when generate_unbound_methods is used.")
 	}
@@ -156,5 +226,10 @@ func applyFlags(reg *descriptor.Registry) error {
 	reg.SetOmitPackageDoc(*omitPackageDoc)
 	reg.SetWarnOnUnboundMethods(*warnOnUnboundMethods)
 	reg.SetGenerateUnboundMethods(*generateUnboundMethods)
+	reg.SetIncludeExperimental(*includeExperimental)
+	reg.SetBestEffort(*bestEffort)
+	if err := reg.SetJSONNames(*jsonNames); err != nil {
+		return err
+	}
 	return reg.SetRepeatedPathParamSeparator(*repeatedPathParamSeparator)
 }
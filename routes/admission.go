@@ -0,0 +1,168 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/grpclog"
+)
+
+// AdmissionPolicy determines the outcome of the admission check when
+// the registered routes drift from the expected ones
+type AdmissionPolicy int
+
+const (
+	// AdmissionLog logs the drift and lets the server start
+	AdmissionLog AdmissionPolicy = iota
+
+	// AdmissionFail fails the admission check on drift
+	AdmissionFail
+)
+
+// Route identifies a route by http method and path template
+type Route struct {
+	// http method of the route
+	Method string
+
+	// path template of the route, as provided in the proto
+	Path string
+}
+
+// String returns the route in "METHOD path" format
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// DriftError reports the drift between the routes registered on the
+// mux and the routes expected as per the generated manifests
+type DriftError struct {
+	// Missing lists the expected routes with no handler registered,
+	// keyed by the fully qualified name of the rpc method
+	Missing map[Route]string
+
+	// Extra lists the routes registered on the mux without being
+	// described by any of the expected manifests
+	Extra []Route
+}
+
+// Error returns the description of the drift
+func (e *DriftError) Error() string {
+	var parts []string
+	for _, r := range sortRoutes(e.Missing) {
+		parts = append(parts, fmt.Sprintf("missing handler for %s (%s)", r, e.Missing[r]))
+	}
+	for _, r := range e.Extra {
+		parts = append(parts, fmt.Sprintf("undocumented route %s", r))
+	}
+	return "route registration drift: " + strings.Join(parts, "; ")
+}
+
+// Tracker records the routes registered on the mux, so that those can
+// be verified against the generated manifests once the server has
+// completed the registration of all the handlers
+type Tracker struct {
+	mux        Mux
+	mu         sync.Mutex
+	registered map[Route]bool
+	expected   map[Route]string
+}
+
+// NewTracker returns a tracker registering the handlers on given mux
+func NewTracker(mux Mux) *Tracker {
+	return &Tracker{
+		mux:        mux,
+		registered: map[Route]bool{},
+		expected:   map[Route]string{},
+	}
+}
+
+// HandlePath registers the handler on the underlying mux, recording
+// the route for the admission check
+func (t *Tracker) HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error {
+	if err := t.mux.HandlePath(meth, pathPattern, h); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.registered[Route{Method: meth, Path: pathPattern}] = true
+	return nil
+}
+
+// Unwrap returns the underlying mux
+func (t *Tracker) Unwrap() Mux {
+	return t.mux
+}
+
+// Expect records the route as expected to be registered, serving the
+// rpc method with given fully qualified name, typically invoked by
+// the generated Admit<Service>Routes functions
+func (t *Tracker) Expect(method, meth, pathPattern string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expected[Route{Method: meth, Path: pathPattern}] = method
+}
+
+// Check compares the registered routes against the expected ones,
+// returning *DriftError if any of the expected routes is missing a
+// handler or any route is registered without being expected
+func (t *Tracker) Check() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	drift := &DriftError{Missing: map[Route]string{}}
+	for r, method := range t.expected {
+		if !t.registered[r] {
+			drift.Missing[r] = method
+		}
+	}
+	for r := range t.registered {
+		if _, ok := t.expected[r]; !ok {
+			drift.Extra = append(drift.Extra, r)
+		}
+	}
+	if len(drift.Missing) == 0 && len(drift.Extra) == 0 {
+		return nil
+	}
+	sort.Slice(drift.Extra, func(i, j int) bool {
+		return lessRoute(drift.Extra[i], drift.Extra[j])
+	})
+	return drift
+}
+
+// Admit runs the admission check as per the given policy, meant to be
+// invoked once the server has registered all the handlers and before
+// it starts serving
+func (t *Tracker) Admit(policy AdmissionPolicy) error {
+	err := t.Check()
+	if err == nil {
+		return nil
+	}
+	if policy == AdmissionFail {
+		return err
+	}
+	grpclog.Warningf("%v", err)
+	return nil
+}
+
+func sortRoutes(m map[Route]string) []Route {
+	routes := make([]Route, 0, len(m))
+	for r := range m {
+		routes = append(routes, r)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return lessRoute(routes[i], routes[j])
+	})
+	return routes
+}
+
+func lessRoute(a, b Route) bool {
+	if a.Path != b.Path {
+		return a.Path < b.Path
+	}
+	return a.Method < b.Method
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestTrackerAdmission(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	mux := runtime.NewServeMux()
+	tracker := NewTracker(mux)
+	tracker.Expect(".example.Svc.Get", http.MethodGet, "/v1/object/{name}")
+	tracker.Expect(".example.Svc.Create", http.MethodPost, "/v1/object/{name}")
+	if err := tracker.HandlePath(http.MethodGet, "/v1/object/{name}", handler); err != nil {
+		t.Fatalf("HandlePath() failed with %v; want success", err)
+	}
+	if err := tracker.HandlePath(http.MethodGet, "/v1/debug", handler); err != nil {
+		t.Fatalf("HandlePath() failed with %v; want success", err)
+	}
+
+	// handlers are expected to be registered on the underlying mux
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/object/abc", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("mux.ServeHTTP() status = %d; want %d", rec.Code, http.StatusNoContent)
+	}
+
+	err := tracker.Check()
+	var drift *DriftError
+	if !errors.As(err, &drift) {
+		t.Fatalf("tracker.Check() = %v; want *DriftError", err)
+	}
+	wantMissing := map[Route]string{
+		{Method: http.MethodPost, Path: "/v1/object/{name}"}: ".example.Svc.Create",
+	}
+	if !reflect.DeepEqual(drift.Missing, wantMissing) {
+		t.Errorf("drift.Missing = %v; want %v", drift.Missing, wantMissing)
+	}
+	wantExtra := []Route{{Method: http.MethodGet, Path: "/v1/debug"}}
+	if !reflect.DeepEqual(drift.Extra, wantExtra) {
+		t.Errorf("drift.Extra = %v; want %v", drift.Extra, wantExtra)
+	}
+	wantMsg := "route registration drift: missing handler for POST /v1/object/{name} (.example.Svc.Create); undocumented route GET /v1/debug"
+	if err.Error() != wantMsg {
+		t.Errorf("err.Error() = %q; want %q", err.Error(), wantMsg)
+	}
+
+	if err := tracker.Admit(AdmissionLog); err != nil {
+		t.Errorf("tracker.Admit(AdmissionLog) = %v; want nil", err)
+	}
+	if err := tracker.Admit(AdmissionFail); !errors.As(err, &drift) {
+		t.Errorf("tracker.Admit(AdmissionFail) = %v; want *DriftError", err)
+	}
+
+	tracker.Expect(".example.Svc.Debug", http.MethodGet, "/v1/debug")
+	if err := tracker.HandlePath(http.MethodPost, "/v1/object/{name}", handler); err != nil {
+		t.Fatalf("HandlePath() failed with %v; want success", err)
+	}
+	if err := tracker.Admit(AdmissionFail); err != nil {
+		t.Errorf("tracker.Admit(AdmissionFail) = %v; want nil", err)
+	}
+}
+
+func TestTrackerInvalidPattern(t *testing.T) {
+	tracker := NewTracker(runtime.NewServeMux())
+	err := tracker.HandlePath(http.MethodGet, "/v1/{name", nil)
+	if err == nil {
+		t.Fatalf("HandlePath() succeeded; want error for invalid pattern")
+	}
+	if err := tracker.Check(); err != nil {
+		t.Errorf("tracker.Check() = %v; want nil for failed registration", err)
+	}
+}
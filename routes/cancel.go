@@ -0,0 +1,128 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+const (
+	// CallIDHeader carries the random id of the call, set by the SDK
+	// configured using sdk.WithCancelNotifications
+	CallIDHeader = "X-Call-Id"
+	// CancelReasonHeader carries the reason of the cancel notification
+	CancelReasonHeader = "X-Cancel-Reason"
+	// CancelPath is the path the cancel notifications are received on
+	CancelPath = "/.well-known/cancel"
+)
+
+// reasons of the cancellation notified by the SDK, as per sdk.CancelReason
+const (
+	// CancelUserAbort is the call aborted by the user
+	CancelUserAbort = "user_abort"
+	// CancelDeadline is the call exceeding the deadline of the client
+	CancelDeadline = "deadline"
+	// CancelShutdown is the call canceled while the client is shutting
+	// down
+	CancelShutdown = "shutdown"
+	// CancelUnknown is the call canceled by the client for any other
+	// reason
+	CancelUnknown = "canceled"
+)
+
+// canceledError is the cause of the contexts of the handlers canceled
+// by the cancel notification
+type canceledError struct {
+	reason string
+}
+
+func (e *canceledError) Error() string {
+	return "canceled by the client: " + e.reason
+}
+
+// CancelReason returns the reason of the cancellation notified by the
+// client for the call, empty if the context is not canceled or canceled
+// without the notification, like on the network failures, allowing the
+// servers to distinguish the aborts by the clients in the metrics
+func CancelReason(ctx context.Context) string {
+	var ce *canceledError
+	if errors.As(context.Cause(ctx), &ce) {
+		return ce.reason
+	}
+	return ""
+}
+
+// cancelMux wraps the mux canceling the handlers of the calls as per the
+// cancel notifications received
+type cancelMux struct {
+	Mux
+	// calls are the cancel functions of the calls in flight keyed by
+	// the call id
+	calls sync.Map
+}
+
+// Unwrap returns the wrapped mux
+func (m *cancelMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithCancellation wraps the mux serving the cancel notifications sent
+// by the SDK configured using sdk.WithCancelNotifications on CancelPath,
+// canceling the context of the handler of the call with the reason
+// notified, as returned by CancelReason. Handlers of the calls without
+// the call id are left untouched
+func WithCancellation(mux Mux) (Mux, error) {
+	m := &cancelMux{Mux: mux}
+	if err := mux.HandlePath(http.MethodPost, CancelPath, m.cancel); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HandlePath registers the handler tracking the calls by the call id
+func (m *cancelMux) HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error {
+	return m.Mux.HandlePath(meth, pathPattern, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		id := r.Header.Get(CallIDHeader)
+		if id == "" {
+			h(w, r, params)
+			return
+		}
+		ctx, cancel := context.WithCancelCause(r.Context())
+		if _, loaded := m.calls.LoadOrStore(id, cancel); loaded {
+			// reused call id is not tracked, not to cancel the other call
+			cancel(nil)
+			h(w, r, params)
+			return
+		}
+		defer func() {
+			m.calls.Delete(id)
+			cancel(nil)
+		}()
+		h(w, r.WithContext(ctx), params)
+	})
+}
+
+// cancel serves the cancel notification, responding with no content
+// irrespective of the call being in flight
+func (m *cancelMux) cancel(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if v, ok := m.calls.LoadAndDelete(r.Header.Get(CallIDHeader)); ok {
+		v.(context.CancelCauseFunc)(&canceledError{reason: cancelReason(r.Header.Get(CancelReasonHeader))})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// cancelReason returns the known reason, bounding the values reported
+// in the metrics
+func cancelReason(reason string) string {
+	switch reason {
+	case CancelUserAbort, CancelDeadline, CancelShutdown:
+		return reason
+	}
+	return CancelUnknown
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestWithCancellation(t *testing.T) {
+	serveMux := runtime.NewServeMux()
+	mux, err := WithCancellation(serveMux)
+	if err != nil {
+		t.Fatalf("WithCancellation() failed with %v; want success", err)
+	}
+	started := make(chan struct{})
+	reasons := make(chan string, 1)
+	if err := mux.HandlePath(http.MethodGet, "/v1/objects", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		close(started)
+		select {
+		case <-r.Context().Done():
+			reasons <- CancelReason(r.Context())
+		case <-time.After(5 * time.Second):
+			reasons <- "not canceled"
+		}
+	}); err != nil {
+		t.Fatalf("HandlePath() failed with %v; want success", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+	r.Header.Set(CallIDHeader, "c1")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveMux.ServeHTTP(httptest.NewRecorder(), r)
+	}()
+	<-started
+
+	// notification of an unknown call is ignored
+	for _, id := range []string{"c2", "c1"} {
+		n := httptest.NewRequest(http.MethodPost, CancelPath, nil)
+		n.Header.Set(CallIDHeader, id)
+		n.Header.Set(CancelReasonHeader, CancelUserAbort)
+		rec := httptest.NewRecorder()
+		serveMux.ServeHTTP(rec, n)
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("cancel notification of %s responded %d; want 204", id, rec.Code)
+		}
+	}
+	<-done
+	if got := <-reasons; got != CancelUserAbort {
+		t.Errorf("CancelReason() = %q; want %q", got, CancelUserAbort)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := CancelReason(ctx); got != "" {
+		t.Errorf("CancelReason() without the notification = %q; want empty", got)
+	}
+	if got := cancelReason("bogus"); got != CancelUnknown {
+		t.Errorf("cancelReason(bogus) = %q; want %q", got, CancelUnknown)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Catalog provides the localized messages for the errors, keyed by the
+// reason of the error as conveyed using errdetails.ErrorInfo
+type Catalog interface {
+	// Message returns the message for the reason in the language best
+	// matching the languages preferred by the client, along with the
+	// language of the message, false if there is no message
+	Message(reason string, prefs []language.Tag) (string, language.Tag, bool)
+}
+
+// catalog is the in memory catalog of messages
+type catalog struct {
+	tags     []language.Tag
+	matcher  language.Matcher
+	messages map[language.Tag]map[string]string
+}
+
+// NewCatalog returns the catalog holding the messages per language,
+// keyed by the error reason, the fallback language is used when none
+// of the preferred languages are available. Messages may refer to the
+// metadata of the error as {key}
+func NewCatalog(fallback language.Tag, messages map[language.Tag]map[string]string) Catalog {
+	tags := []language.Tag{fallback}
+	for tag := range messages {
+		if tag != fallback {
+			tags = append(tags, tag)
+		}
+	}
+	// keep the matching stable irrespective of the map ordering
+	sort.Slice(tags[1:], func(i, j int) bool {
+		return tags[i+1].String() < tags[j+1].String()
+	})
+	return &catalog{
+		tags:     tags,
+		matcher:  language.NewMatcher(tags),
+		messages: messages,
+	}
+}
+
+func (c *catalog) Message(reason string, prefs []language.Tag) (string, language.Tag, bool) {
+	_, index, _ := c.matcher.Match(prefs...)
+	for _, tag := range []language.Tag{c.tags[index], c.tags[0]} {
+		if msg, ok := c.messages[tag][reason]; ok {
+			return msg, tag, true
+		}
+	}
+	return "", language.Und, false
+}
+
+// expandMessage replaces the references to the metadata of the error
+// in the message
+func expandMessage(msg string, metadata map[string]string) string {
+	if len(metadata) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	pairs := make([]string, 0, 2*len(metadata))
+	for k, v := range metadata {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
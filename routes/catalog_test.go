@@ -0,0 +1,89 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCatalogMessage(t *testing.T) {
+	c := NewCatalog(language.English, map[language.Tag]map[string]string{
+		language.English: {"IN_USE": "object is in use", "LOCKED": "object is locked"},
+		language.French:  {"IN_USE": "l'objet est utilisé"},
+		language.German:  {"IN_USE": "Objekt wird verwendet"},
+	})
+	for _, spec := range []struct {
+		reason string
+		prefs  string
+		want   string
+		tag    language.Tag
+		ok     bool
+	}{
+		{reason: "IN_USE", prefs: "fr-CA, en;q=0.5", want: "l'objet est utilisé", tag: language.French, ok: true},
+		{reason: "IN_USE", prefs: "de", want: "Objekt wird verwendet", tag: language.German, ok: true},
+		{reason: "IN_USE", prefs: "ja", want: "object is in use", tag: language.English, ok: true},
+		{reason: "IN_USE", prefs: "", want: "object is in use", tag: language.English, ok: true},
+		{reason: "LOCKED", prefs: "fr", want: "object is locked", tag: language.English, ok: true},
+		{reason: "UNKNOWN", prefs: "fr"},
+	} {
+		prefs, _, _ := language.ParseAcceptLanguage(spec.prefs)
+		msg, tag, ok := c.Message(spec.reason, prefs)
+		if msg != spec.want || tag != spec.tag || ok != spec.ok {
+			t.Errorf("Message(%q, %q) = %q, %v, %v; want %q, %v, %v", spec.reason, spec.prefs, msg, tag, ok, spec.want, spec.tag, spec.ok)
+		}
+	}
+}
+
+func TestErrorHandlerWithCatalog(t *testing.T) {
+	c := NewCatalog(language.English, map[language.Tag]map[string]string{
+		language.English: {"IN_USE": "object {name} is in use"},
+		language.French:  {"IN_USE": "l'objet {name} est utilisé"},
+	})
+	mux := runtime.NewServeMux(runtime.WithErrorHandler(ErrorHandler(nil, WithCatalog(c))))
+
+	st, _ := status.New(codes.FailedPrecondition, "object is in use").WithDetails(&errdetails.ErrorInfo{
+		Reason:   "IN_USE",
+		Domain:   "example.com",
+		Metadata: map[string]string{"name": "obj-1"},
+	})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/v1/object/obj-1", nil)
+	r.Header.Set("Accept-Language", "fr-FR")
+	runtime.HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, st.Err())
+
+	var body struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Type    string `json:"@type"`
+			Locale  string `json:"locale"`
+			Message string `json:"message"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode error %s: %v", w.Body.String(), err)
+	}
+	if w.Code != http.StatusBadRequest || body.Code != int32(codes.FailedPrecondition) || body.Message != "object is in use" {
+		t.Errorf("error = %d %s; want 400 with the original message", w.Code, w.Body.String())
+	}
+	var localized string
+	for _, d := range body.Details {
+		if d.Type == "type.googleapis.com/google.rpc.LocalizedMessage" {
+			localized = d.Locale + ": " + d.Message
+		}
+	}
+	if want := "fr: l'objet obj-1 est utilisé"; localized != want {
+		t.Errorf("localized message = %q; want %q", localized, want)
+	}
+}
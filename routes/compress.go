@@ -0,0 +1,170 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+// CompressionConfig configures the compression of the responses of the
+// generated routes
+type CompressionConfig struct {
+	// Codecs are the names of the codings offered to the clients, the
+	// order breaking the ties among the ones accepted with the equal
+	// weights, compression.DefaultCodecs if empty. Codings not
+	// registered with the compression package are ignored
+	Codecs []string
+
+	// Threshold is the size of the response body beyond which the body
+	// is compressed, compression.DefaultThreshold if not positive
+	Threshold int
+}
+
+// CompressFunc returns the writer compressing the response as
+// negotiated using the Accept-Encoding header of the request, along
+// with the function to be called once the response is complete
+type CompressFunc func(w http.ResponseWriter, req *http.Request) (http.ResponseWriter, func())
+
+// compressionMux wraps the mux providing the compression config to the
+// generated routes
+type compressionMux struct {
+	Mux
+	cfg CompressionConfig
+}
+
+// Unwrap returns the wrapped mux
+func (m *compressionMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithCompression wraps the mux enabling the compression of the
+// responses of the unary methods by the generated routes
+//
+//	mux := routes.WithCompression(runtime.NewServeMux(), routes.CompressionConfig{
+//		Codecs: compression.InternalCodecs,
+//	})
+func WithCompression(mux Mux, cfg CompressionConfig) Mux {
+	if len(cfg.Codecs) == 0 {
+		cfg.Codecs = compression.DefaultCodecs
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = compression.DefaultThreshold
+	}
+	return &compressionMux{Mux: mux, cfg: cfg}
+}
+
+// Compression returns the function compressing the responses as per
+// the config provided for the mux using WithCompression, looking
+// through the wrappers exposing Unwrap() Mux, leaving the responses
+// uncompressed if not provided
+func Compression(mux Mux) CompressFunc {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *compressionMux:
+			return m.compress
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			mux = nil
+		}
+	}
+	return func(w http.ResponseWriter, _ *http.Request) (http.ResponseWriter, func()) {
+		return w, func() {}
+	}
+}
+
+func (m *compressionMux) compress(w http.ResponseWriter, req *http.Request) (http.ResponseWriter, func()) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	name := compression.Negotiate(req.Header.Get("Accept-Encoding"), m.cfg.Codecs)
+	if name == "" {
+		return w, func() {}
+	}
+	codec, _ := compression.Lookup(name)
+	cw := &compressWriter{ResponseWriter: w, codec: codec, threshold: m.cfg.Threshold}
+	return cw, cw.finish
+}
+
+// compressWriter holds the response until the body reaches the
+// threshold, compressing the ones reaching it while writing out the
+// smaller ones as is
+type compressWriter struct {
+	http.ResponseWriter
+	codec     compression.Codec
+	threshold int
+	status    int
+	buf       []byte
+	started   bool
+	zw        io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.started {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.started {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.threshold {
+			return len(data), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.zw != nil {
+		return w.zw.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// start writes out the header along with the body held so far,
+// compressing the body unless already encoded by the handler
+func (w *compressWriter) start(compress bool) error {
+	w.started = true
+	h := w.Header()
+	if compress && h.Get("Content-Encoding") == "" {
+		zw, err := w.codec.NewWriter(w.ResponseWriter)
+		if err == nil {
+			h.Set("Content-Encoding", w.codec.Name())
+			h.Del("Content-Length")
+			w.zw = zw
+		}
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.zw != nil {
+		_, err = w.zw.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// finish completes the response, writing out the responses smaller
+// than the threshold as is
+func (w *compressWriter) finish() {
+	if !w.started {
+		_ = w.start(false)
+	}
+	if w.zw != nil {
+		_ = w.zw.Close()
+	}
+}
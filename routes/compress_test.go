@@ -0,0 +1,83 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+func TestCompression(t *testing.T) {
+	large := strings.Repeat("compressible ", 200)
+	serve := func(compress CompressFunc, accept, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+		if accept != "" {
+			req.Header.Set("Accept-Encoding", accept)
+		}
+		rec := httptest.NewRecorder()
+		w, done := compress(rec, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, body)
+		done()
+		return rec
+	}
+
+	// responses are left as is without WithCompression
+	rec := serve(Compression(runtime.NewServeMux()), "gzip", large)
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != large {
+		t.Errorf("response without WithCompression encoded as %q; want as is", rec.Header().Get("Content-Encoding"))
+	}
+
+	compress := Compression(WithPurge(WithCompression(runtime.NewServeMux(), CompressionConfig{
+		Codecs: compression.InternalCodecs,
+	}), nil))
+	for _, spec := range []struct {
+		accept string
+		body   string
+		want   string
+	}{
+		{accept: "gzip, zstd", body: large, want: compression.Zstd},
+		{accept: "gzip, zstd;q=0.5", body: large, want: compression.Gzip},
+		{accept: "*", body: large, want: compression.Zstd},
+		{accept: "br", body: large},
+		{accept: "", body: large},
+		{accept: "gzip", body: "small"},
+	} {
+		rec := serve(compress, spec.accept, spec.body)
+		if rec.Code != http.StatusCreated {
+			t.Errorf("Accept-Encoding %q responded %d; want %d", spec.accept, rec.Code, http.StatusCreated)
+		}
+		if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("Accept-Encoding %q responded Vary %q; want Accept-Encoding", spec.accept, got)
+		}
+		got := rec.Header().Get("Content-Encoding")
+		if got != spec.want {
+			t.Errorf("Accept-Encoding %q encoded the %d bytes as %q; want %q", spec.accept, len(spec.body), got, spec.want)
+			continue
+		}
+		body := io.Reader(rec.Body)
+		if got != "" {
+			codec, _ := compression.Lookup(got)
+			r, err := codec.NewReader(rec.Body)
+			if err != nil {
+				t.Fatalf("%s.NewReader() failed with %v; want success", got, err)
+			}
+			defer r.Close()
+			body = r
+		}
+		if data, _ := io.ReadAll(body); !bytes.Equal(data, []byte(spec.body)) {
+			t.Errorf("Accept-Encoding %q responded %d bytes; want %d", spec.accept, len(data), len(spec.body))
+		}
+	}
+}
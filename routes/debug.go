@@ -0,0 +1,63 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"os"
+)
+
+// DebugEnv is the environment variable, set to true on the deployments
+// serving the methods marked using (api.debug_only), required along with
+// the mux being wrapped using WithDebug, such that a production build
+// accidentally wrapping the mux does not expose them
+const DebugEnv = "ROUTES_DEBUG_ENDPOINTS"
+
+// DebugAuthorizer authorizes the call of the debug only method, given
+// the full name of the method, like checking the admin role of the
+// caller. The error is responded as is, like codes.PermissionDenied
+// responded with 403 Forbidden
+type DebugAuthorizer func(ctx context.Context, method string) error
+
+// debugMux wraps the mux enabling the debug only methods for the
+// generated routes
+type debugMux struct {
+	Mux
+	authorize DebugAuthorizer
+}
+
+// Unwrap returns the wrapped mux
+func (m *debugMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithDebug wraps the mux enabling the routes of the methods marked
+// using (api.debug_only), registered by the generated routes only while
+// DebugEnv is set to true, with every call authorized using the
+// authorizer. The routes of such methods are not registered otherwise,
+// keeping them out of the production surface by construction
+func WithDebug(mux Mux, authorize DebugAuthorizer) Mux {
+	return &debugMux{Mux: mux, authorize: authorize}
+}
+
+// Debug returns the authorizer of the debug only methods provided for
+// the mux using WithDebug, looking through the wrappers exposing
+// Unwrap() Mux, nil if the debug only methods are not to be served, as
+// the authorizer is not provided or DebugEnv is not set to true
+func Debug(mux Mux) DebugAuthorizer {
+	if os.Getenv(DebugEnv) != "true" {
+		return nil
+	}
+	for mux != nil {
+		switch m := mux.(type) {
+		case *debugMux:
+			return m.authorize
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
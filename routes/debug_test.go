@@ -0,0 +1,38 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDebug(t *testing.T) {
+	authorize := func(_ context.Context, method string) error {
+		return status.Errorf(codes.PermissionDenied, "%s requires the admin role", method)
+	}
+	mux := NewTracker(WithDebug(runtime.NewServeMux(), authorize))
+
+	// debug only methods are not served without the environment
+	t.Setenv(DebugEnv, "")
+	if Debug(mux) != nil {
+		t.Errorf("Debug() without %s returned the authorizer; want nil", DebugEnv)
+	}
+
+	t.Setenv(DebugEnv, "true")
+	debug := Debug(mux)
+	if debug == nil {
+		t.Fatalf("Debug() did not return the authorizer provided using WithDebug")
+	}
+	if err := debug(context.Background(), "/example.Debug/Profile"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("authorizer failed with %v; want PermissionDenied", err)
+	}
+	if Debug(runtime.NewServeMux()) != nil {
+		t.Errorf("Debug() without WithDebug returned the authorizer; want nil")
+	}
+}
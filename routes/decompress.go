@@ -0,0 +1,128 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+var (
+	// MaxDecompressionRatio is the maximum allowed ratio of the
+	// decompressed size of the request body against its compressed
+	// size, protecting the handlers against decompression bombs
+	MaxDecompressionRatio int64 = 100
+
+	// DecompressionRatioThreshold is the decompressed size of the
+	// request body up to which the ratio is not enforced, allowing
+	// small but highly compressible payloads
+	DecompressionRatioThreshold int64 = 1 << 20
+)
+
+// ErrDecompressionRatio is returned while reading the request body
+// once it expands beyond MaxDecompressionRatio
+var ErrDecompressionRatio = errors.New("request body exceeds the allowed decompression ratio")
+
+// Decompress transparently decompresses the request body as per the
+// Content-Encoding header, supporting the codings registered with the
+// compression package, gzip, deflate and zstd unless registered more.
+// Returns error with 415 status for unsupported encodings
+func Decompress(req *http.Request) error {
+	encodings := req.Header.Values("Content-Encoding")
+	if len(encodings) == 0 || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	var codings []string
+	for _, v := range encodings {
+		for _, coding := range strings.Split(v, ",") {
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding != "" && coding != "identity" {
+				codings = append(codings, coding)
+			}
+		}
+	}
+
+	raw := &countingReader{r: req.Body}
+	var r io.Reader = raw
+	// codings are listed in the order applied, decode in reverse
+	for i := len(codings) - 1; i >= 0; i-- {
+		codec, ok := compression.Lookup(codings[i])
+		if !ok {
+			return &runtime.HTTPStatusError{
+				HTTPStatus: http.StatusUnsupportedMediaType,
+				Err:        status.Errorf(codes.InvalidArgument, "unsupported content encoding %q", codings[i]),
+			}
+		}
+		var err error
+		r, err = codec.NewReader(r)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s request body: %v", codings[i], err)
+		}
+	}
+
+	req.Body = &decompressReader{r: r, raw: raw, body: req.Body}
+	req.Header.Del("Content-Encoding")
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	return nil
+}
+
+// DecodeError converts the error observed while decoding the request
+// body to the error reported to the client
+func DecodeError(err error) error {
+	if errors.Is(err, ErrDecompressionRatio) {
+		return &runtime.HTTPStatusError{
+			HTTPStatus: http.StatusRequestEntityTooLarge,
+			Err:        status.Error(codes.ResourceExhausted, err.Error()),
+		}
+	}
+	return status.Errorf(codes.InvalidArgument, "%v", err)
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
+
+// decompressReader provides the decompressed body enforcing the
+// decompression ratio, closing the original body on close
+type decompressReader struct {
+	r    io.Reader
+	raw  *countingReader
+	body io.Closer
+	n    int64
+}
+
+func (d *decompressReader) Read(b []byte) (int, error) {
+	n, err := d.r.Read(b)
+	d.n += int64(n)
+	if d.n > DecompressionRatioThreshold && d.n > d.raw.n*MaxDecompressionRatio {
+		return n, fmt.Errorf("%w of %d", ErrDecompressionRatio, MaxDecompressionRatio)
+	}
+	return n, err
+}
+
+func (d *decompressReader) Close() error {
+	if c, ok := d.r.(io.Closer); ok {
+		_ = c.Close()
+	}
+	return d.body.Close()
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func compress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	payload := []byte(`{"name":"abc","desc":"sample object"}`)
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{name: "none", body: payload},
+		{name: "identity", encoding: "identity", body: payload},
+		{name: "gzip", encoding: "gzip", body: compress(t, "gzip", payload)},
+		{name: "x-gzip", encoding: "x-gzip", body: compress(t, "gzip", payload)},
+		{name: "deflate", encoding: "deflate", body: compress(t, "deflate", payload)},
+		{name: "raw deflate", encoding: "deflate", body: compress(t, "raw-deflate", payload)},
+		{name: "chained", encoding: "deflate, GZIP", body: compress(t, "gzip", compress(t, "deflate", payload))},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/object/abc", bytes.NewReader(tc.body))
+			if tc.encoding != "" {
+				req.Header.Set("Content-Encoding", tc.encoding)
+			}
+			if err := Decompress(req); err != nil {
+				t.Fatalf("Decompress() failed with %v; want success", err)
+			}
+			got, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Errorf("Decompress() body = %q; want %q", got, payload)
+			}
+			if enc := req.Header.Get("Content-Encoding"); enc != "" && enc != tc.encoding {
+				t.Errorf("Content-Encoding = %q; want removed", enc)
+			}
+			if err := req.Body.Close(); err != nil {
+				t.Errorf("req.Body.Close() failed with %v", err)
+			}
+		})
+	}
+}
+
+func TestDecompressUnsupported(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/object/abc", strings.NewReader("data"))
+	req.Header.Set("Content-Encoding", "br")
+	err := Decompress(req)
+	var statusErr *runtime.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.HTTPStatus != http.StatusUnsupportedMediaType {
+		t.Fatalf("Decompress() = %v; want error with status %d", err, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/object/abc", strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	if err := Decompress(req); err == nil {
+		t.Fatalf("Decompress() succeeded; want error for invalid gzip body")
+	}
+}
+
+func TestDecompressRatio(t *testing.T) {
+	bomb := compress(t, "gzip", make([]byte, 4*DecompressionRatioThreshold))
+	req := httptest.NewRequest(http.MethodPost, "/v1/object/abc", bytes.NewReader(bomb))
+	req.Header.Set("Content-Encoding", "gzip")
+	if err := Decompress(req); err != nil {
+		t.Fatalf("Decompress() failed with %v; want success", err)
+	}
+	_, err := io.ReadAll(req.Body)
+	if !errors.Is(err, ErrDecompressionRatio) {
+		t.Fatalf("io.ReadAll() = %v; want %v", err, ErrDecompressionRatio)
+	}
+	var statusErr *runtime.HTTPStatusError
+	if !errors.As(DecodeError(err), &statusErr) || statusErr.HTTPStatus != http.StatusRequestEntityTooLarge {
+		t.Errorf("DecodeError() = %v; want error with status %d", DecodeError(err), http.StatusRequestEntityTooLarge)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Default is the value of the request field, as per the (api.default)
+// option, applied when the field is not set by the client
+type Default struct {
+	// Field is the name of the field of the request message
+	Field string
+
+	// Value in the same format as when provided as a query param
+	Value string
+}
+
+// PopulateDefaults sets the default values for the fields of the
+// message which are not set, fields without explicit presence are
+// treated as not set when holding the zero value
+func PopulateDefaults(msg proto.Message, defaults []Default) error {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for _, d := range defaults {
+		fd := fields.ByName(protoreflect.Name(d.Field))
+		if fd == nil {
+			return fmt.Errorf("no field %s in %s", d.Field, m.Descriptor().FullName())
+		}
+		if m.Has(fd) {
+			continue
+		}
+		if err := runtime.PopulateFieldFromPath(msg, d.Field, d.Value); err != nil {
+			return fmt.Errorf("invalid default of %s: %w", d.Field, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestPopulateDefaults(t *testing.T) {
+	defaults := []Default{
+		{Field: "name", Value: "unnamed"},
+		{Field: "number", Value: "50"},
+		{Field: "label", Value: "LABEL_REPEATED"},
+		{Field: "proto3_optional", Value: "true"},
+	}
+	for _, spec := range []struct {
+		name string
+		msg  *descriptorpb.FieldDescriptorProto
+		want *descriptorpb.FieldDescriptorProto
+	}{
+		{
+			name: "empty",
+			msg:  &descriptorpb.FieldDescriptorProto{},
+			want: &descriptorpb.FieldDescriptorProto{
+				Name:           proto.String("unnamed"),
+				Number:         proto.Int32(50),
+				Label:          descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Proto3Optional: proto.Bool(true),
+			},
+		},
+		{
+			name: "provided",
+			msg: &descriptorpb.FieldDescriptorProto{
+				Name:           proto.String("id"),
+				Number:         proto.Int32(0),
+				Proto3Optional: proto.Bool(false),
+			},
+			want: &descriptorpb.FieldDescriptorProto{
+				Name:           proto.String("id"),
+				Number:         proto.Int32(0),
+				Label:          descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Proto3Optional: proto.Bool(false),
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			if err := PopulateDefaults(spec.msg, defaults); err != nil {
+				t.Fatalf("PopulateDefaults() failed with %v; want success", err)
+			}
+			if !proto.Equal(spec.msg, spec.want) {
+				t.Errorf("PopulateDefaults() = %v; want %v", spec.msg, spec.want)
+			}
+		})
+	}
+
+	if err := PopulateDefaults(&descriptorpb.FieldDescriptorProto{}, []Default{{Field: "unknown", Value: "1"}}); err == nil {
+		t.Errorf("PopulateDefaults() with unknown field succeeded; want error")
+	}
+}
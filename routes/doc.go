@@ -0,0 +1,7 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package routes provides the runtime used by the code generated by
+// protoc-gen-routes, allowing servers to verify the handlers they
+// register against the routes described by the protos.
+package routes
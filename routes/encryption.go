@@ -0,0 +1,62 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-core-stack/grpc-core/envelope"
+)
+
+// kmsMux wraps the mux providing the KMS to the generated routes
+type kmsMux struct {
+	Mux
+	kms envelope.KMS
+}
+
+// Unwrap returns the wrapped mux
+func (m *kmsMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithKMS wraps the mux providing the KMS used by the generated routes
+// to open the request fields marked using (api.encrypted), registering
+// the routes of such methods fails without a KMS
+func WithKMS(mux Mux, kms envelope.KMS) Mux {
+	return &kmsMux{Mux: mux, kms: kms}
+}
+
+// KMS returns the KMS provided for the mux using WithKMS, looking
+// through the wrappers exposing Unwrap() Mux, nil if not provided
+func KMS(mux Mux) envelope.KMS {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *kmsMux:
+			return m.kms
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// OpenFields opens the sealed fields of the request message in place,
+// the requests with the fields not sealed or failing to open are
+// rejected with InvalidArgument
+func OpenFields(ctx context.Context, kms envelope.KMS, msg proto.Message, fields ...string) error {
+	if err := envelope.OpenFields(ctx, kms, msg, fields...); err != nil {
+		if errors.Is(err, envelope.ErrNotSealed) {
+			return status.Errorf(codes.InvalidArgument, "encrypted field must be sealed: %v", err)
+		}
+		return status.Errorf(codes.InvalidArgument, "invalid encrypted field: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-core-stack/grpc-core/errcode"
+)
+
+// ErrorOption customizes the error handler
+type ErrorOption func(*errorHandler)
+
+// WithCatalog localizes the errors carrying errdetails.ErrorInfo using
+// the catalog, as per the Accept-Language header of the request. The
+// localized message is added as errdetails.LocalizedMessage detail,
+// retaining the message of the error as is
+func WithCatalog(c Catalog) ErrorOption {
+	return func(h *errorHandler) {
+		h.catalog = c
+	}
+}
+
+type errorHandler struct {
+	mapping *errcode.Mapping
+	catalog Catalog
+}
+
+// ErrorHandler returns the error handler, to be configured on the mux
+// using runtime.WithErrorHandler, writing the errors with the http
+// status code as per the mapping, errcode.Default is used when nil.
+// The grpc code is always conveyed as part of the body allowing the
+// SDK to recover it even when multiple codes share the status code
+func ErrorHandler(m *errcode.Mapping, opts ...ErrorOption) runtime.ErrorHandlerFunc {
+	h := &errorHandler{mapping: m}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h.handle
+}
+
+func (h *errorHandler) handle(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var serr *runtime.HTTPStatusError
+	if errors.As(err, &serr) {
+		err = &runtime.HTTPStatusError{
+			HTTPStatus: serr.HTTPStatus,
+			Err:        h.localize(r, serr.Err),
+		}
+	} else {
+		mapping := h.mapping
+		if mapping == nil {
+			mapping = errcode.Default
+		}
+		err = &runtime.HTTPStatusError{
+			HTTPStatus: mapping.HTTPStatus(status.Code(err)),
+			Err:        h.localize(r, err),
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// localize adds the localized message to the error if available
+func (h *errorHandler) localize(r *http.Request, err error) error {
+	if h.catalog == nil {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		prefs := Languages(NewLocaleContext(r.Context(), r))
+		msg, tag, ok := h.catalog.Message(info.GetReason(), prefs)
+		if !ok {
+			return err
+		}
+		localized, derr := st.WithDetails(&errdetails.LocalizedMessage{
+			Locale:  tag.String(),
+			Message: expandMessage(msg, info.GetMetadata()),
+		})
+		if derr != nil {
+			return err
+		}
+		return localized.Err()
+	}
+	return err
+}
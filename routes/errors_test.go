@@ -0,0 +1,59 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-core-stack/grpc-core/errcode"
+	"github.com/go-core-stack/grpc-core/sdk"
+)
+
+func TestErrorHandlerRoundTrip(t *testing.T) {
+	mapping := errcode.New(errcode.WithStatus(codes.AlreadyExists, http.StatusConflict))
+	mux := runtime.NewServeMux(runtime.WithErrorHandler(ErrorHandler(mapping)))
+	for _, spec := range []struct {
+		code   codes.Code
+		status int
+	}{
+		{code: codes.FailedPrecondition, status: http.StatusBadRequest},
+		{code: codes.AlreadyExists, status: http.StatusConflict},
+		{code: codes.Aborted, status: http.StatusConflict},
+		{code: codes.Unauthenticated, status: http.StatusUnauthorized},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+		runtime.HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, status.Error(spec.code, "failed"))
+
+		resp := w.Result()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != spec.status {
+			t.Errorf("%v: status code = %d; want %d", spec.code, resp.StatusCode, spec.status)
+		}
+		if got := status.Code(sdk.DecodeError(resp.StatusCode, body)); got != spec.code {
+			t.Errorf("%v: decoded code = %v; want %v", spec.code, got, spec.code)
+		}
+	}
+}
+
+func TestErrorHandlerHTTPStatusError(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/v1/objects", nil)
+	err := &runtime.HTTPStatusError{
+		HTTPStatus: http.StatusUnsupportedMediaType,
+		Err:        status.Error(codes.InvalidArgument, "unsupported content encoding"),
+	}
+	runtime.HTTPError(context.Background(), ServeMux(NewTracker(nil)), &runtime.JSONPb{}, w, r, err)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status code = %d; want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+}
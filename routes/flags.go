@@ -0,0 +1,114 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"maps"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FlagProvider reports whether the feature flags guarding the methods
+// marked using (api.feature_flag) are enabled, checked by the generated
+// routes on every call, such that the flags are flipped at runtime
+type FlagProvider interface {
+	// Enabled reports whether the flag is enabled for the call, the
+	// error is responded as is, like codes.PermissionDenied responded
+	// with 403 Forbidden for the callers not part of the rollout
+	Enabled(ctx context.Context, flag string) (bool, error)
+}
+
+// FlagProviderFunc adapts the function to FlagProvider
+type FlagProviderFunc func(ctx context.Context, flag string) (bool, error)
+
+// Enabled calls the function
+func (f FlagProviderFunc) Enabled(ctx context.Context, flag string) (bool, error) {
+	return f(ctx, flag)
+}
+
+// FlagSet is the FlagProvider serving the flags from the set, replaced
+// as a whole using Update, like when the config carrying them is
+// reloaded. Flags not part of the set are disabled
+type FlagSet struct {
+	flags atomic.Pointer[map[string]bool]
+}
+
+// NewFlagSet creates the set with the flags
+func NewFlagSet(flags map[string]bool) *FlagSet {
+	s := &FlagSet{}
+	s.Update(flags)
+	return s
+}
+
+// Update replaces the flags of the set, safe to be called while the
+// flags are being checked
+func (s *FlagSet) Update(flags map[string]bool) {
+	flags = maps.Clone(flags)
+	s.flags.Store(&flags)
+}
+
+// Enabled reports whether the flag is enabled in the set
+func (s *FlagSet) Enabled(_ context.Context, flag string) (bool, error) {
+	flags := s.flags.Load()
+	return flags != nil && (*flags)[flag], nil
+}
+
+// flagsMux wraps the mux providing the feature flags to the generated
+// routes
+type flagsMux struct {
+	Mux
+	flags FlagProvider
+}
+
+// Unwrap returns the wrapped mux
+func (m *flagsMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithFlags wraps the mux providing the feature flags checked by the
+// generated routes for the methods marked using (api.feature_flag),
+// such methods are disabled on every call without a provider, while
+// the other routes are served as is
+func WithFlags(mux Mux, flags FlagProvider) Mux {
+	return &flagsMux{Mux: mux, flags: flags}
+}
+
+// Flags returns the provider of the feature flags provided for the mux
+// using WithFlags, looking through the wrappers exposing Unwrap() Mux,
+// nil if not provided
+func Flags(mux Mux) FlagProvider {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *flagsMux:
+			return m.flags
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// CheckFlag returns the error of the call of the method guarded by the
+// feature flag, codes.NotFound while the flag is disabled, such that the
+// endpoint is indistinguishable from the one not yet served, along with
+// the error of the provider if any. Flags are disabled when no provider
+// is configured, failing closed
+func CheckFlag(ctx context.Context, flags FlagProvider, flag string) error {
+	if flags == nil {
+		return status.Error(codes.NotFound, "Not Found")
+	}
+	enabled, err := flags.Enabled(ctx, flag)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return status.Error(codes.NotFound, "Not Found")
+	}
+	return nil
+}
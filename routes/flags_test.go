@@ -0,0 +1,56 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFlags(t *testing.T) {
+	flags := NewFlagSet(map[string]bool{"downloads": true})
+
+	// provider is found through the wrappers of the mux
+	mux := WithKMS(WithFlags(runtime.NewServeMux(), flags), nil)
+	if Flags(mux) != flags {
+		t.Fatalf("Flags() did not return the provider provided using WithFlags")
+	}
+	if Flags(runtime.NewServeMux()) != nil {
+		t.Errorf("Flags() without WithFlags returned a provider; want nil")
+	}
+
+	ctx := context.Background()
+	if err := CheckFlag(ctx, flags, "downloads"); err != nil {
+		t.Errorf("CheckFlag(downloads) enabled failed with %v; want success", err)
+	}
+	if err := CheckFlag(ctx, flags, "uploads"); status.Code(err) != codes.NotFound {
+		t.Errorf("CheckFlag(uploads) unknown failed with %v; want NotFound", err)
+	}
+
+	// flags are reloaded at runtime
+	flags.Update(map[string]bool{"downloads": false, "uploads": true})
+	if err := CheckFlag(ctx, flags, "downloads"); status.Code(err) != codes.NotFound {
+		t.Errorf("CheckFlag(downloads) disabled failed with %v; want NotFound", err)
+	}
+	if err := CheckFlag(ctx, flags, "uploads"); err != nil {
+		t.Errorf("CheckFlag(uploads) enabled failed with %v; want success", err)
+	}
+
+	// flags are disabled without a provider
+	if err := CheckFlag(ctx, nil, "downloads"); status.Code(err) != codes.NotFound {
+		t.Errorf("CheckFlag() without provider failed with %v; want NotFound", err)
+	}
+
+	// errors of the provider are responded as is
+	denied := FlagProviderFunc(func(context.Context, string) (bool, error) {
+		return false, status.Error(codes.PermissionDenied, "not part of the rollout")
+	})
+	if err := CheckFlag(ctx, denied, "downloads"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CheckFlag() denied failed with %v; want PermissionDenied", err)
+	}
+}
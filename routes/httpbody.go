@@ -0,0 +1,26 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"io"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+// ReadHTTPBody reads the raw body of the request as google.api.HttpBody
+// along with its declared content type, used by the generated routes
+// for the request bodies mapped to HttpBody, like the file uploads,
+// instead of decoding them as JSON
+func ReadHTTPBody(req *http.Request) (*httpbody.HttpBody, error) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+	return &httpbody.HttpBody{
+		ContentType: req.Header.Get("Content-Type"),
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,22 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadHTTPBody(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/v1/objects/a/attachment", strings.NewReader("png"))
+	req.Header.Set("Content-Type", "image/png")
+	body, err := ReadHTTPBody(req)
+	if err != nil {
+		t.Fatalf("ReadHTTPBody() failed with %v; want success", err)
+	}
+	if body.GetContentType() != "image/png" || string(body.GetData()) != "png" {
+		t.Errorf("ReadHTTPBody() = %q, %q; want image/png, png", body.GetContentType(), body.GetData())
+	}
+}
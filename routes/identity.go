@@ -0,0 +1,25 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/http"
+
+	authctx "github.com/go-core-stack/auth/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Identity returns the auth info of the principal authenticated for the
+// request, as set by the authenticating proxy in front of the server,
+// used by the generated routes to populate the request fields marked
+// using (api.from_identity). The requests without the auth info are
+// rejected with codes.Unauthenticated
+func Identity(req *http.Request) (*authctx.AuthInfo, error) {
+	info, err := authctx.GetAuthInfoHeader(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "identity of the principal is not available: %v", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	authctx "github.com/go-core-stack/auth/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIdentity(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/objects", nil)
+	if _, err := Identity(req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Identity() without the auth info failed with %v; want Unauthenticated", err)
+	}
+
+	if err := authctx.SetAuthInfoHeader(req, &authctx.AuthInfo{Realm: "acme", UserName: "alice", Email: "alice@acme.io"}); err != nil {
+		t.Fatalf("SetAuthInfoHeader() failed with %v; want success", err)
+	}
+	info, err := Identity(req)
+	if err != nil {
+		t.Fatalf("Identity() failed with %v; want success", err)
+	}
+	if info.UserName != "alice" || info.Realm != "acme" || info.Email != "alice@acme.io" {
+		t.Errorf("Identity() = %+v; want the auth info set by the proxy", info)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckImmutable ensures the update of the resource carries no changes
+// to the fields marked using the (api.immutable) option, as compared to
+// the current resource fetched by the server. Fields not set in the
+// update are left as is, while setting a field the current resource
+// does not have is a change too. Returns InvalidArgument error listing
+// all the changed fields, carrying errdetails.BadRequest as the detail
+func CheckImmutable(update, current proto.Message, fields ...string) error {
+	u, c := update.ProtoReflect(), current.ProtoReflect()
+	if !u.IsValid() {
+		return nil
+	}
+	var changed []string
+	for _, name := range fields {
+		fd := u.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !u.Has(fd) {
+			continue
+		}
+		if !c.IsValid() || !c.Has(fd) || !u.Get(fd).Equal(c.Get(fd)) {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("immutable parameters can not be changed: %s", strings.Join(changed, ", ")))
+	br := &errdetails.BadRequest{}
+	for _, name := range changed {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: "immutable parameter can not be changed",
+		})
+	}
+	if ds, err := st.WithDetails(br); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// StripImmutable clears the fields of the update of the resource marked
+// using the (api.immutable) option, used by the generated routes when
+// the server does not provide the current resource to compare against,
+// such that the immutable fields are never changed by the update
+func StripImmutable(update proto.Message, fields ...string) {
+	u := update.ProtoReflect()
+	if !u.IsValid() {
+		return
+	}
+	for _, name := range fields {
+		if fd := u.Descriptor().Fields().ByName(protoreflect.Name(name)); fd != nil {
+			u.Clear(fd)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckImmutable(t *testing.T) {
+	current := &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), Number: proto.Int32(1)}
+	for _, update := range []*descriptorpb.FieldDescriptorProto{
+		{},
+		{Name: proto.String("id"), JsonName: proto.String("ID")},
+		{Number: proto.Int32(1)},
+		nil,
+	} {
+		if err := CheckImmutable(update, current, "name", "number", "type_name"); err != nil {
+			t.Errorf("CheckImmutable(%v) failed with %v; want success", update, err)
+		}
+	}
+
+	update := &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), Number: proto.Int32(2), TypeName: proto.String("Object")}
+	err := CheckImmutable(update, current, "name", "number", "type_name")
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("CheckImmutable() = %v; want InvalidArgument", err)
+	}
+	if want := "immutable parameters can not be changed: number, type_name"; st.Message() != want {
+		t.Errorf("CheckImmutable() = %q; want %q", st.Message(), want)
+	}
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	if len(fields) != 2 || fields[0] != "number" || fields[1] != "type_name" {
+		t.Errorf("CheckImmutable() field violations = %v; want [number type_name]", fields)
+	}
+}
+
+func TestStripImmutable(t *testing.T) {
+	update := &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), Number: proto.Int32(2), JsonName: proto.String("ID")}
+	StripImmutable(update, "name", "number")
+	if want := (&descriptorpb.FieldDescriptorProto{JsonName: proto.String("ID")}); !proto.Equal(update, want) {
+		t.Errorf("StripImmutable() = %v; want %v", update, want)
+	}
+	// nil update is left as is
+	StripImmutable((*descriptorpb.FieldDescriptorProto)(nil), "name")
+}
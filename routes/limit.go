@@ -0,0 +1,59 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"expvar"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// inflightCalls publishes the number of the calls being served by
+	// the methods with the concurrency limit, keyed by the method
+	inflightCalls = expvar.NewMap("routes_inflight_calls")
+
+	// rejectedCalls publishes the number of the calls rejected on the
+	// saturation of the concurrency limit, keyed by the method
+	rejectedCalls = expvar.NewMap("routes_rejected_calls")
+)
+
+// Limiter bounds the number of the concurrent calls served by a method,
+// rejecting the calls beyond the limit instead of queueing them, used
+// by the generated routes for the methods with (api.max_concurrency)
+type Limiter struct {
+	method string
+	slots  chan struct{}
+}
+
+// NewLimiter returns the limiter allowing up to limit concurrent calls
+// of the method with given fully qualified name
+func NewLimiter(method string, limit int) *Limiter {
+	// publish the method even before the first call
+	inflightCalls.Add(method, 0)
+	rejectedCalls.Add(method, 0)
+	return &Limiter{
+		method: method,
+		slots:  make(chan struct{}, limit),
+	}
+}
+
+// Acquire reserves a slot for the call, returning the function to be
+// called once the call is complete. Returns Unavailable error, served
+// as 503 to allow the clients to retry with backoff, if all the slots
+// are in use
+func (l *Limiter) Acquire() (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		rejectedCalls.Add(l.method, 1)
+		return nil, status.Errorf(codes.Unavailable, "too many concurrent calls of %s, limit %d", l.method, cap(l.slots))
+	}
+	inflightCalls.Add(l.method, 1)
+	return func() {
+		inflightCalls.Add(l.method, -1)
+		<-l.slots
+	}, nil
+}
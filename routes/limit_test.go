@@ -0,0 +1,46 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiter(t *testing.T) {
+	const method = "/example.ExampleService/Export"
+	l := NewLimiter(method, 2)
+	var releases []func()
+	for i := 0; i < 2; i++ {
+		release, err := l.Acquire()
+		if err != nil {
+			t.Fatalf("Acquire() within limit failed with %v; want success", err)
+		}
+		releases = append(releases, release)
+	}
+	if got := inflightCalls.Get(method).String(); got != "2" {
+		t.Errorf("inflight calls = %s; want 2", got)
+	}
+
+	if _, err := l.Acquire(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Acquire() beyond limit = %v; want Unavailable", err)
+	}
+	if got := rejectedCalls.Get(method).String(); got != "1" {
+		t.Errorf("rejected calls = %s; want 1", got)
+	}
+
+	// released slot is available to the next call
+	releases[0]()
+	release, err := l.Acquire()
+	if err != nil {
+		t.Fatalf("Acquire() after release failed with %v; want success", err)
+	}
+	release()
+	releases[1]()
+	if got := inflightCalls.Get(method).String(); got != "0" {
+		t.Errorf("inflight calls = %s; want 0", got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+type localeKey struct{}
+
+// wildcard is the tag the Accept-Language wildcard is parsed as
+var wildcard = language.MustParse("mul")
+
+// NewLocaleContext returns the context carrying the languages preferred
+// by the client as per the Accept-Language header of the request, an
+// invalid header is treated same as absent
+func NewLocaleContext(ctx context.Context, req *http.Request) context.Context {
+	var tags []language.Tag
+	for _, v := range req.Header.Values("Accept-Language") {
+		parsed, _, err := language.ParseAcceptLanguage(v)
+		if err != nil {
+			continue
+		}
+		for _, tag := range parsed {
+			// wildcard conveys no preference
+			if tag != wildcard {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return context.WithValue(ctx, localeKey{}, tags)
+}
+
+// Languages returns the languages preferred by the client, ordered by
+// the preference, available when the generated routes are configured to
+// parse the Accept-Language header
+func Languages(ctx context.Context) []language.Tag {
+	tags, _ := ctx.Value(localeKey{}).([]language.Tag)
+	return tags
+}
+
+// Locale returns the language most preferred by the client, returns
+// empty string when the client has no preference
+func Locale(ctx context.Context) string {
+	tags := Languages(ctx)
+	if len(tags) == 0 {
+		return ""
+	}
+	return tags[0].String()
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocaleContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		locale string
+		count  int
+	}{
+		{name: "absent"},
+		{name: "single", header: []string{"fr-CH"}, locale: "fr-CH", count: 1},
+		{name: "weighted", header: []string{"de;q=0.7, fr-CH, fr;q=0.9, *;q=0.5"}, locale: "fr-CH", count: 3},
+		{name: "multiple headers", header: []string{"en-US", "de;q=0.5"}, locale: "en-US", count: 2},
+		{name: "wildcard", header: []string{"*"}},
+		{name: "invalid", header: []string{"en;q=abc;q"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+			for _, v := range tc.header {
+				req.Header.Add("Accept-Language", v)
+			}
+			ctx := NewLocaleContext(context.Background(), req)
+			if got := Locale(ctx); got != tc.locale {
+				t.Errorf("Locale() = %q; want %q", got, tc.locale)
+			}
+			if got := len(Languages(ctx)); got != tc.count {
+				t.Errorf("len(Languages()) = %d; want %d", got, tc.count)
+			}
+		})
+	}
+
+	if got := Locale(context.Background()); got != "" {
+		t.Errorf("Locale() without locale context = %q; want empty", got)
+	}
+}
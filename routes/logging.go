@@ -0,0 +1,233 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/go-core-stack/grpc-core/internal/redact"
+)
+
+// DefaultMaxLoggedBodySize is the size of the bodies logged by the
+// generated routes unless configured otherwise
+const DefaultMaxLoggedBodySize = 4 << 10
+
+// LoggingConfig configures the logging of the calls served by the
+// generated routes
+type LoggingConfig struct {
+	// Logger the calls are logged to, slog.Default() if nil
+	Logger *slog.Logger
+
+	// Level of the successful calls, the failed calls are logged at
+	// slog.LevelWarn
+	Level slog.Level
+
+	// SampleRate is the fraction of the successful calls logged, all of
+	// them if zero and none if negative, overridden for the methods
+	// using (api.log_sample_rate). Failed calls are always logged
+	SampleRate float64
+
+	// Bodies enables logging the request and the response bodies of the
+	// successful calls sampled, the bodies of the failed calls are
+	// logged irrespective, with the sensitive fields redacted
+	Bodies bool
+
+	// MaxBodySize is the size of the bodies logged, larger bodies are
+	// logged truncated unless they carry sensitive fields, in which
+	// case they are omitted, DefaultMaxLoggedBodySize if zero
+	MaxBodySize int
+}
+
+// LogRoute describes the route of a method for the logging, declared
+// by the generated routes for every binding
+type LogRoute struct {
+	// Method is the full name of the method, like /example.Svc/Method
+	Method string
+
+	// SampleRate overrides the sample rate of the config for the method
+	// as per the (api.log_sample_rate) option, nil if not annotated
+	SampleRate *float64
+
+	// RequestFields are the paths of the fields of the request marked
+	// using (api.sensitive), redacted from the query params and the body
+	RequestFields []string
+
+	// RequestBody is the path of the field of the request received as
+	// the body, * for the whole message and empty if none
+	RequestBody string
+
+	// ResponseFields are the paths of the sensitive fields of the
+	// response, redacted from the response body
+	ResponseFields []string
+
+	// ResponseBody is the path of the field of the response sent as the
+	// body, empty for the whole message
+	ResponseBody string
+}
+
+// LogFunc returns the writer capturing the response to be logged along
+// with the function to be called once the response is complete
+type LogFunc func(w http.ResponseWriter, req *http.Request, route *LogRoute) (http.ResponseWriter, func())
+
+// loggingMux wraps the mux providing the logging config to the
+// generated routes
+type loggingMux struct {
+	Mux
+	cfg LoggingConfig
+}
+
+// Unwrap returns the wrapped mux
+func (m *loggingMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithLogging wraps the mux enabling the logging of the calls of the
+// unary methods by the generated routes, logging the failed calls in
+// full along with the bodies while sampling the successful ones, such
+// that the services get the observability without the cost of logging
+// every call
+//
+//	mux := routes.WithLogging(runtime.NewServeMux(), routes.LoggingConfig{
+//		SampleRate: 0.01,
+//	})
+func WithLogging(mux Mux, cfg LoggingConfig) Mux {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = DefaultMaxLoggedBodySize
+	}
+	return &loggingMux{Mux: mux, cfg: cfg}
+}
+
+// Logging returns the function logging the calls as per the config
+// provided for the mux using WithLogging, looking through the wrappers
+// exposing Unwrap() Mux, leaving the calls unlogged if not provided
+func Logging(mux Mux) LogFunc {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *loggingMux:
+			return m.log
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			mux = nil
+		}
+	}
+	return func(w http.ResponseWriter, _ *http.Request, _ *LogRoute) (http.ResponseWriter, func()) {
+		return w, func() {}
+	}
+}
+
+func (m *loggingMux) log(w http.ResponseWriter, req *http.Request, route *LogRoute) (http.ResponseWriter, func()) {
+	rate := m.cfg.SampleRate
+	if rate == 0 {
+		rate = 1
+	}
+	if route.SampleRate != nil {
+		rate = *route.SampleRate
+	}
+	sampled := rate >= 1 || rand.Float64() < rate
+
+	// the request body is captured as read by the handler, bodies
+	// encoded using Content-Encoding are not logged
+	var reqBody *capturedBody
+	if req.Body != nil && req.Body != http.NoBody && req.Header.Get("Content-Encoding") == "" {
+		reqBody = &capturedBody{ReadCloser: req.Body, max: m.cfg.MaxBodySize}
+		req.Body = reqBody
+	}
+	lw := &loggingWriter{ResponseWriter: w, max: m.cfg.MaxBodySize}
+	start := time.Now()
+	return lw, func() {
+		status := lw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		failed := status >= 400
+		if !failed && !sampled {
+			return
+		}
+		attrs := []any{
+			"method", route.Method,
+			"http_method", req.Method,
+			"url", redact.URL(req.URL, route.RequestFields),
+			"status", status,
+			"duration", time.Since(start),
+		}
+		level := m.cfg.Level
+		if failed {
+			level = slog.LevelWarn
+		}
+		if failed || m.cfg.Bodies {
+			if reqBody != nil && reqBody.buf.Len() != 0 {
+				attrs = append(attrs, "request_body", redact.Body(reqBody.buf.Bytes(), m.cfg.MaxBodySize, redact.RelativePaths(route.RequestFields, route.RequestBody)))
+			}
+			// error responses carry the status, not the response message
+			var paths []string
+			if !failed {
+				paths = redact.RelativePaths(route.ResponseFields, route.ResponseBody)
+			}
+			if lw.buf.Len() != 0 {
+				attrs = append(attrs, "response_body", redact.Body(lw.buf.Bytes(), m.cfg.MaxBodySize, paths))
+			}
+		}
+		msg := "call served"
+		if failed {
+			msg = "call failed"
+		}
+		m.cfg.Logger.Log(req.Context(), level, msg, attrs...)
+	}
+}
+
+// capturedBody captures the body up to one byte beyond max as it is
+// read, such that the larger ones are detected
+type capturedBody struct {
+	io.ReadCloser
+	max int
+	buf bytes.Buffer
+}
+
+func (b *capturedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := b.max + 1 - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+// loggingWriter captures the status and the response body up to one
+// byte beyond max as it is written
+type loggingWriter struct {
+	http.ResponseWriter
+	status int
+	max    int
+	buf    bytes.Buffer
+}
+
+func (w *loggingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *loggingWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if room := w.max + 1 - w.buf.Len(); room > 0 {
+		w.buf.Write(data[:min(len(data), room)])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the wrapped writer, used by http.ResponseController
+func (w *loggingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLogging(t *testing.T) {
+	var logs bytes.Buffer
+	serve := func(log LogFunc, route *LogRoute, status int, reqBody, respBody string) map[string]any {
+		t.Helper()
+		logs.Reset()
+		req := httptest.NewRequest(http.MethodPost, "/v1/objects?name=abc&password=secret", strings.NewReader(reqBody))
+		w, done := log(httptest.NewRecorder(), req, route)
+		if _, err := io.ReadAll(req.Body); err != nil {
+			t.Fatalf("reading the request body failed with %v; want success", err)
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, respBody)
+		done()
+		if logs.Len() == 0 {
+			return nil
+		}
+		var entry map[string]any
+		if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+			t.Fatalf("log entry %q is not JSON: %v", logs.String(), err)
+		}
+		return entry
+	}
+	route := &LogRoute{
+		Method:         "/example.Objects/Create",
+		RequestFields:  []string{"password"},
+		RequestBody:    "*",
+		ResponseFields: []string{"token"},
+	}
+	reqBody := `{"name":"abc","password":"secret"}`
+	respBody := `{"name":"abc","token":"t0k3n"}`
+
+	// calls are not logged without WithLogging
+	if entry := serve(Logging(runtime.NewServeMux()), route, http.StatusOK, reqBody, respBody); entry != nil {
+		t.Errorf("call without WithLogging logged %v; want none", entry)
+	}
+
+	log := Logging(WithPurge(WithLogging(runtime.NewServeMux(), LoggingConfig{
+		Logger: slog.New(slog.NewJSONHandler(&logs, nil)),
+		Bodies: true,
+	}), nil))
+	entry := serve(log, route, http.StatusOK, reqBody, respBody)
+	if entry == nil {
+		t.Fatalf("successful call not logged; want logged")
+	}
+	if entry["level"] != "INFO" || entry["method"] != route.Method || entry["status"] != float64(http.StatusOK) {
+		t.Errorf("successful call logged %v; want INFO for %s with 200", entry, route.Method)
+	}
+	if url := entry["url"].(string); strings.Contains(url, "secret") || !strings.Contains(url, "name=abc") {
+		t.Errorf("successful call logged url %q; want password redacted", url)
+	}
+	if body := entry["request_body"].(string); strings.Contains(body, "secret") || !strings.Contains(body, `"name":"abc"`) {
+		t.Errorf("successful call logged request body %q; want password redacted", body)
+	}
+	if body := entry["response_body"].(string); strings.Contains(body, "t0k3n") || !strings.Contains(body, `"name":"abc"`) {
+		t.Errorf("successful call logged response body %q; want token redacted", body)
+	}
+
+	// successful calls are skipped as per the sample rate of the route,
+	// while the failed ones are logged in full irrespective
+	log = Logging(WithLogging(runtime.NewServeMux(), LoggingConfig{
+		Logger:     slog.New(slog.NewJSONHandler(&logs, nil)),
+		SampleRate: 1,
+	}))
+	sampled := *route
+	sampled.SampleRate = proto.Float64(0)
+	if entry := serve(log, &sampled, http.StatusOK, reqBody, respBody); entry != nil {
+		t.Errorf("successful call not sampled logged %v; want none", entry)
+	}
+	errBody := `{"code":3,"message":"invalid name"}`
+	entry = serve(log, &sampled, http.StatusBadRequest, reqBody, errBody)
+	if entry == nil {
+		t.Fatalf("failed call not logged; want logged")
+	}
+	if entry["level"] != "WARN" || entry["status"] != float64(http.StatusBadRequest) {
+		t.Errorf("failed call logged %v; want WARN with 400", entry)
+	}
+	if body := entry["request_body"].(string); strings.Contains(body, "secret") {
+		t.Errorf("failed call logged request body %q; want password redacted", body)
+	}
+	if entry["response_body"] != errBody {
+		t.Errorf("failed call logged response body %v; want %s", entry["response_body"], errBody)
+	}
+
+	// bodies of the successful calls are logged only once enabled
+	sampled.SampleRate = nil
+	entry = serve(log, &sampled, http.StatusOK, reqBody, respBody)
+	if entry == nil {
+		t.Fatalf("successful call not logged; want logged")
+	}
+	if _, ok := entry["response_body"]; ok {
+		t.Errorf("successful call logged response body %v without Bodies; want none", entry["response_body"])
+	}
+}
+
+func TestLoggingTruncated(t *testing.T) {
+	var logs bytes.Buffer
+	log := Logging(WithLogging(runtime.NewServeMux(), LoggingConfig{
+		Logger:      slog.New(slog.NewJSONHandler(&logs, nil)),
+		Bodies:      true,
+		MaxBodySize: 8,
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+	w, done := log(httptest.NewRecorder(), req, &LogRoute{Method: "/example.Objects/List"})
+	_, _ = io.WriteString(w, strings.Repeat("0123456789", 10))
+	done()
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("log entry %q is not JSON: %v", logs.String(), err)
+	}
+	if want := "01234567...(truncated)"; entry["response_body"] != want {
+		t.Errorf("large response logged %v; want %s", entry["response_body"], want)
+	}
+}
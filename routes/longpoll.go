@@ -0,0 +1,51 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// LongPollWriteTimeout is the write deadline of the responses of the
+// methods marked using (api.long_poll), overriding the WriteTimeout of
+// the server
+var LongPollWriteTimeout = 5 * time.Minute
+
+// flushWriter flushes the response as soon as it is written
+type flushWriter struct {
+	http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (w *flushWriter) Write(data []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(data)
+	if err == nil {
+		_ = w.rc.Flush()
+	}
+	return n, err
+}
+
+// Unwrap returns the wrapped writer, used by http.ResponseController
+func (w *flushWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// LongPoll prepares the response of the long polling method, extending
+// the write deadline to LongPollWriteTimeout and disabling the
+// buffering by the intermediate proxies, returns the writer flushing
+// the response as soon as it is written
+func LongPoll(w http.ResponseWriter) http.ResponseWriter {
+	rc := http.NewResponseController(w)
+	err := rc.SetWriteDeadline(time.Now().Add(LongPollWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		grpclog.Warningf("Failed to extend write deadline for long polling: %v", err)
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	return &flushWriter{ResponseWriter: w, rc: rc}
+}
@@ -0,0 +1,37 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// Mux is the mux on which the route handlers are registered, this
+// is satisfied by the grpc-gateway runtime.ServeMux
+type Mux interface {
+	HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error
+}
+
+// defaultServeMux provides the default marshalers and error handlers
+// when the mux in use does not wrap a runtime.ServeMux
+var defaultServeMux = runtime.NewServeMux(runtime.WithErrorHandler(ErrorHandler(nil)))
+
+// ServeMux returns the runtime.ServeMux backing the given mux, used by
+// the generated handlers for marshaler selection, error handling and
+// response forwarding as configured on the mux. Wrappers expose the
+// mux they wrap using Unwrap() Mux, falls back to the defaults when
+// no runtime.ServeMux is found
+func ServeMux(mux Mux) *runtime.ServeMux {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *runtime.ServeMux:
+			return m
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			return defaultServeMux
+		}
+	}
+	return defaultServeMux
+}
@@ -0,0 +1,29 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+type customMux struct{}
+
+func (customMux) HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error {
+	return nil
+}
+
+func TestServeMux(t *testing.T) {
+	mux := runtime.NewServeMux()
+	if got := ServeMux(mux); got != mux {
+		t.Errorf("ServeMux(mux) = %p; want %p", got, mux)
+	}
+	if got := ServeMux(NewTracker(mux)); got != mux {
+		t.Errorf("ServeMux(tracker) = %p; want %p", got, mux)
+	}
+	if got := ServeMux(NewTracker(customMux{})); got != defaultServeMux {
+		t.Errorf("ServeMux(custom) = %p; want default mux", got)
+	}
+}
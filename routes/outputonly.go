@@ -0,0 +1,23 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-core-stack/grpc-core/internal/protopath"
+)
+
+// ClearFields clears the fields of the request message, given as their
+// paths like object.create_time, in all of the elements of the repeated
+// and the map fields on the way. The generated routes silently clear
+// the fields marked using (api.output_only), set by the server alone,
+// such that the clients echoing back the full resources are not failed
+// by the validations of the server
+func ClearFields(msg proto.Message, fields ...string) {
+	m := msg.ProtoReflect()
+	for _, path := range fields {
+		protopath.Clear(m, path)
+	}
+}
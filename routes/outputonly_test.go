@@ -0,0 +1,33 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestClearFields(t *testing.T) {
+	msg := &descriptorpb.DescriptorProto{
+		Name: proto.String("Object"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("id"), JsonName: proto.String("ID")},
+			{Name: proto.String("size")},
+		},
+		Options: &descriptorpb.MessageOptions{Deprecated: proto.Bool(true)},
+	}
+	ClearFields(msg, "field.json_name", "options", "unknown")
+	want := &descriptorpb.DescriptorProto{
+		Name: proto.String("Object"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("id")},
+			{Name: proto.String("size")},
+		},
+	}
+	if !proto.Equal(msg, want) {
+		t.Errorf("ClearFields() = %v; want %v", msg, want)
+	}
+}
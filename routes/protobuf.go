@@ -0,0 +1,33 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ProtobufContentType is the content type of the bodies encoded as
+// protobuf binary, as sent by the SDK configured using sdk.WithProtobuf
+const ProtobufContentType = "application/x-protobuf"
+
+// protoMarshaler encodes the messages as protobuf binary
+type protoMarshaler struct {
+	runtime.ProtoMarshaller
+}
+
+// ContentType returns the content type of the protobuf bodies
+func (*protoMarshaler) ContentType(_ any) string {
+	return ProtobufContentType
+}
+
+// WithProtobuf returns the option registering the protobuf binary
+// marshaler on the runtime.ServeMux for ProtobufContentType, allowing
+// the clients to send the requests and ask for the responses as
+// protobuf using the Content-Type and Accept headers, while the others
+// continue to be served as JSON
+//
+//	mux := runtime.NewServeMux(routes.WithProtobuf())
+func WithProtobuf() runtime.ServeMuxOption {
+	return runtime.WithMarshalerOption(ProtobufContentType, &protoMarshaler{})
+}
@@ -0,0 +1,28 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestWithProtobuf(t *testing.T) {
+	mux := runtime.NewServeMux(WithProtobuf())
+	r := httptest.NewRequest("POST", "/v1/objects", nil)
+	r.Header.Set("Content-Type", ProtobufContentType)
+	r.Header.Set("Accept", ProtobufContentType)
+	in, out := runtime.MarshalerForRequest(mux, r)
+	if in.ContentType(nil) != ProtobufContentType || out.ContentType(nil) != ProtobufContentType {
+		t.Errorf("MarshalerForRequest() = %q, %q; want %q", in.ContentType(nil), out.ContentType(nil), ProtobufContentType)
+	}
+
+	r = httptest.NewRequest("POST", "/v1/objects", nil)
+	r.Header.Set("Content-Type", "application/json")
+	if in, _ := runtime.MarshalerForRequest(mux, r); in.ContentType(nil) != "application/json" {
+		t.Errorf("MarshalerForRequest() json = %q; want application/json", in.ContentType(nil))
+	}
+}
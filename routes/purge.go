@@ -0,0 +1,69 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+)
+
+// SurrogateKeyHeader carries the keys the caches in front of the server,
+// like the CDNs, tag the response with, purging it along with the
+// other responses tagged with the same key
+const SurrogateKeyHeader = "Surrogate-Key"
+
+// PurgeFunc purges the cached responses tagged with the surrogate keys,
+// called by the generated routes once the call of a method marked using
+// (api.invalidates) succeeds, with the operation ids of the invalidated
+// methods as the keys. Expected to be non blocking, the purge of the
+// remote caches is best left to be done asynchronously
+type PurgeFunc func(ctx context.Context, keys ...string)
+
+// purgeMux wraps the mux providing the purge callback to the generated
+// routes
+type purgeMux struct {
+	Mux
+	purge PurgeFunc
+}
+
+// Unwrap returns the wrapped mux
+func (m *purgeMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithPurge wraps the mux providing the callback purging the cached
+// responses invalidated by the methods marked using (api.invalidates)
+func WithPurge(mux Mux, purge PurgeFunc) Mux {
+	return &purgeMux{Mux: mux, purge: purge}
+}
+
+// Purge returns the purge callback provided for the mux using
+// WithPurge, looking through the wrappers exposing Unwrap() Mux, no-op
+// if not provided
+func Purge(mux Mux) PurgeFunc {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *purgeMux:
+			if m.purge != nil {
+				return m.purge
+			}
+			mux = m.Mux
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			mux = nil
+		}
+	}
+	return func(context.Context, ...string) {}
+}
+
+// SetSurrogateKey tags the response with the surrogate key, in addition
+// to the keys already set, called by the generated routes for the GET
+// methods invalidated by the other methods
+func SetSurrogateKey(w http.ResponseWriter, key string) {
+	if keys := w.Header().Get(SurrogateKeyHeader); keys != "" {
+		key = keys + " " + key
+	}
+	w.Header().Set(SurrogateKeyHeader, key)
+}
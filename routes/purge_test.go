@@ -0,0 +1,36 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestPurge(t *testing.T) {
+	// purge without WithPurge is a no-op
+	Purge(runtime.NewServeMux())(context.Background(), "HelloWorld_GetObject")
+
+	var purged []string
+	mux := WithSigner(WithPurge(runtime.NewServeMux(), func(ctx context.Context, keys ...string) {
+		purged = append(purged, keys...)
+	}), nil)
+	Purge(mux)(context.Background(), "HelloWorld_GetObject", "HelloWorld_ListObjects")
+	if want := []string{"HelloWorld_GetObject", "HelloWorld_ListObjects"}; !reflect.DeepEqual(purged, want) {
+		t.Errorf("purged %v; want %v", purged, want)
+	}
+}
+
+func TestSetSurrogateKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetSurrogateKey(w, "HelloWorld_GetObject")
+	SetSurrogateKey(w, "tenant-1")
+	if got := w.Header().Get(SurrogateKeyHeader); got != "HelloWorld_GetObject tenant-1" {
+		t.Errorf("%s = %q; want both the keys", SurrogateKeyHeader, got)
+	}
+}
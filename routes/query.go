@@ -0,0 +1,192 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxSuggestionDepth limits the nesting of the field paths suggested
+// for the unknown query parameters
+const maxSuggestionDepth = 3
+
+// CheckQueryParams ensures all the query parameters map to the fields of
+// the message, not already bound from the path or the body as per the
+// filter. Returns InvalidArgument error listing the unknown parameters
+// along with the closest known ones, catching the typos otherwise
+// ignored silently. Nil message rejects all the query parameters, used
+// for the routes taking none
+func CheckQueryParams(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	var unknown []string
+	for key := range values {
+		if !isKnownQueryParam(msg, key, filter) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	var known []string
+	if msg != nil {
+		known = queryParamPaths(msg.ProtoReflect().Descriptor(), "", filter, 0)
+	}
+	br := &errdetails.BadRequest{}
+	params := make([]string, 0, len(unknown))
+	for _, key := range unknown {
+		desc := "unknown query parameter"
+		param := fmt.Sprintf("%q", key)
+		if s := suggest(key, known); s != "" {
+			desc = fmt.Sprintf("unknown query parameter, did you mean %q?", s)
+			param = fmt.Sprintf("%q (did you mean %q?)", key, s)
+		}
+		params = append(params, param)
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       key,
+			Description: desc,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("unknown query parameters: %s", strings.Join(params, ", ")))
+	if ds, err := st.WithDetails(br); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// isKnownQueryParam reports whether the query parameter resolves to the
+// field of the message, accepting both proto and JSON names along with
+// the map entries as field[key]
+func isKnownQueryParam(msg proto.Message, key string, filter *utilities.DoubleArray) bool {
+	if msg == nil {
+		return false
+	}
+	md := msg.ProtoReflect().Descriptor()
+	parts := strings.Split(key, ".")
+	path := make([]string, 0, len(parts))
+	for i, part := range parts {
+		if md == nil {
+			// traversing into the scalar field
+			return false
+		}
+		if j := strings.IndexByte(part, '['); j > 0 && i == len(parts)-1 && strings.HasSuffix(part, "]") {
+			part = part[:j]
+			fd := lookupField(md, part)
+			if fd == nil || !fd.IsMap() {
+				return false
+			}
+			path = append(path, string(fd.Name()))
+			break
+		}
+		fd := lookupField(md, part)
+		if fd == nil {
+			return false
+		}
+		path = append(path, string(fd.Name()))
+		md = fd.Message()
+		if fd.IsMap() || isWellKnownType(md) {
+			// values of the map and well known types are parsed as is
+			md = nil
+		}
+	}
+	return filter == nil || !filter.HasCommonPrefix(path)
+}
+
+func lookupField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByJSONName(name); fd != nil {
+		return fd
+	}
+	return md.Fields().ByName(protoreflect.Name(name))
+}
+
+// wellKnownTypes are parsed from the single query parameter value
+var wellKnownTypes = map[protoreflect.FullName]bool{
+	"google.protobuf.Timestamp":   true,
+	"google.protobuf.Duration":    true,
+	"google.protobuf.FieldMask":   true,
+	"google.protobuf.Struct":      true,
+	"google.protobuf.Value":       true,
+	"google.protobuf.ListValue":   true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+func isWellKnownType(md protoreflect.MessageDescriptor) bool {
+	return md != nil && wellKnownTypes[md.FullName()]
+}
+
+// queryParamPaths collects the field paths accepted as the query
+// parameters, skipping the ones bound from the path or the body
+func queryParamPaths(md protoreflect.MessageDescriptor, prefix string, filter *utilities.DoubleArray, depth int) []string {
+	var paths []string
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if filter != nil && filter.HasCommonPrefix(strings.Split(path, ".")) {
+			continue
+		}
+		paths = append(paths, path)
+		if fd.Message() != nil && !fd.IsMap() && !fd.IsList() && !isWellKnownType(fd.Message()) && depth+1 < maxSuggestionDepth {
+			paths = append(paths, queryParamPaths(fd.Message(), path+".", filter, depth+1)...)
+		}
+	}
+	return paths
+}
+
+// suggest returns the known parameter closest to the key, if close
+// enough to be a typo
+func suggest(key string, known []string) string {
+	best, bestDist := "", 0
+	for _, k := range known {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(k))
+		if best == "" || d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	// allow roughly one edit for every three characters
+	if best == "" || bestDist > max(1, len(key)/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
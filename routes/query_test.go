@@ -0,0 +1,98 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckQueryParams(t *testing.T) {
+	// name is bound from the path
+	filter := utilities.NewDoubleArray([][]string{{"name"}})
+	for _, tc := range []struct {
+		name    string
+		query   string
+		nilMsg  bool
+		want    string
+		details []string
+	}{
+		{name: "no params"},
+		{name: "known params", query: "number=1&type_name=x&jsonName=y&options.packed=true&options.ctype=CORD"},
+		{
+			name:    "path param",
+			query:   "name=x",
+			want:    `unknown query parameters: "name"`,
+			details: []string{"name"},
+		},
+		{
+			name:    "typos",
+			query:   "numbr=1&type_nam=x&options.pakced=true",
+			want:    `unknown query parameters: "numbr" (did you mean "number"?), "options.pakced" (did you mean "options.packed"?), "type_nam" (did you mean "type_name"?)`,
+			details: []string{"numbr", "options.pakced", "type_nam"},
+		},
+		{
+			name:    "no suggestion",
+			query:   "limit=10&number.value=1",
+			want:    `unknown query parameters: "limit", "number.value"`,
+			details: []string{"limit", "number.value"},
+		},
+		{
+			name:    "no params allowed",
+			query:   "number=1",
+			nilMsg:  true,
+			want:    `unknown query parameters: "number"`,
+			details: []string{"number"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery(%q) failed with %v", tc.query, err)
+			}
+			msg := &descriptorpb.FieldDescriptorProto{}
+			if tc.nilMsg {
+				err = CheckQueryParams(nil, values, nil)
+			} else {
+				err = CheckQueryParams(msg, values, filter)
+			}
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("CheckQueryParams() failed with %v; want success", err)
+				}
+				return
+			}
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != codes.InvalidArgument {
+				t.Fatalf("CheckQueryParams() = %v; want InvalidArgument", err)
+			}
+			if st.Message() != tc.want {
+				t.Errorf("CheckQueryParams() = %q; want %q", st.Message(), tc.want)
+			}
+			var fields []string
+			for _, d := range st.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					for _, v := range br.GetFieldViolations() {
+						fields = append(fields, v.GetField())
+					}
+				}
+			}
+			if len(fields) != len(tc.details) {
+				t.Fatalf("CheckQueryParams() field violations = %v; want %v", fields, tc.details)
+			}
+			for i := range fields {
+				if fields[i] != tc.details[i] {
+					t.Errorf("CheckQueryParams() field violations = %v; want %v", fields, tc.details)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckRequired ensures the fields of the message, marked using the
+// (api.required) option, are set. Returns InvalidArgument error listing
+// all the missing fields, carrying errdetails.BadRequest as the detail.
+// Fields without explicit presence are treated as missing when holding
+// the zero value
+func CheckRequired(msg proto.Message, fields ...string) error {
+	m := msg.ProtoReflect()
+	var missing []string
+	for _, name := range fields {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !m.Has(fd) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("missing required parameters: %s", strings.Join(missing, ", ")))
+	br := &errdetails.BadRequest{}
+	for _, name := range missing {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: "required parameter is missing",
+		})
+	}
+	if ds, err := st.WithDetails(br); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckRequired(t *testing.T) {
+	msg := &descriptorpb.FieldDescriptorProto{Name: proto.String("id")}
+	if err := CheckRequired(msg, "name"); err != nil {
+		t.Errorf("CheckRequired() failed with %v; want success", err)
+	}
+
+	err := CheckRequired(msg, "name", "number", "type_name")
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("CheckRequired() = %v; want InvalidArgument", err)
+	}
+	if want := "missing required parameters: number, type_name"; st.Message() != want {
+		t.Errorf("CheckRequired() = %q; want %q", st.Message(), want)
+	}
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	if len(fields) != 2 || fields[0] != "number" || fields[1] != "type_name" {
+		t.Errorf("CheckRequired() field violations = %v; want [number type_name]", fields)
+	}
+}
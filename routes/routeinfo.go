@@ -0,0 +1,61 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// RouteRole is the role required for the route as per the (api.role)
+// option of the method
+type RouteRole struct {
+	// Resource the route acts on
+	Resource string
+
+	// Scopes the resource is accessible in
+	Scopes []string
+
+	// Verb of the action on the resource
+	Verb string
+}
+
+// RouteInfo describes the route of a binding of the method, as returned
+// by the generated Describe<Service>Routes functions, for the servers to
+// register the routes dynamically, feed the API gateways or print the
+// route table
+type RouteInfo struct {
+	Route
+
+	// FQMN is the fully qualified name of the rpc method serving the
+	// route, like .example.HelloWorld.GetObject
+	FQMN string
+
+	// Role is the role required for the route, nil if not annotated
+	Role *RouteRole
+
+	// DebugOnly marks the route registered only when the debug only
+	// methods are enabled using WithDebug
+	DebugOnly bool
+}
+
+// WriteRouteTable writes the table of the routes, one route per line
+// along with the rpc method serving it and its role, like at the startup
+// of the server
+//
+//	routes.WriteRouteTable(os.Stderr, example.DescribeHelloWorldRoutes())
+func WriteRouteTable(w io.Writer, routes []RouteInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tRPC\tROLE")
+	for _, r := range routes {
+		role := "-"
+		if r.Role != nil {
+			role = fmt.Sprintf("%s:%s [%s]", r.Role.Resource, r.Role.Verb, strings.Join(r.Role.Scopes, ","))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Method, r.Path, r.FQMN, role)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,34 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteRouteTable(t *testing.T) {
+	var b strings.Builder
+	err := WriteRouteTable(&b, []RouteInfo{
+		{
+			Route: Route{Method: "GET", Path: "/v1/object/{name}"},
+			FQMN:  ".example.HelloWorld.GetObject",
+			Role:  &RouteRole{Resource: "object", Scopes: []string{"abc", "def"}, Verb: "get"},
+		},
+		{
+			Route: Route{Method: "POST", Path: "/v1/ping"},
+			FQMN:  ".example.HelloWorld.Ping",
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteRouteTable() failed with %v; want success", err)
+	}
+	want := `METHOD  PATH               RPC                            ROLE
+GET     /v1/object/{name}  .example.HelloWorld.GetObject  object:get [abc,def]
+POST    /v1/ping           .example.HelloWorld.Ping       -
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteRouteTable() =\n%s\nwant\n%s", got, want)
+	}
+}
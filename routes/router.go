@@ -0,0 +1,426 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RouterPath is the path template of the http rule converted to the
+// syntax of the router, along with the custom verb following the path
+// param in the last segment, which the router can not match on its own
+type RouterPath struct {
+	// Path is the path in the syntax of the router
+	Path string
+
+	// Verb is the custom verb following the param, empty if none
+	Verb string
+
+	// Param is the name of the param in the last segment, followed by
+	// the verb if any
+	Param string
+
+	// CatchAll is the name of the param matching the rest of the path,
+	// empty if none
+	CatchAll string
+
+	// Names maps the names of the params of the router to the names of
+	// the variables of the path template they differ from, like * to the
+	// variable matching the rest of the path for echo
+	Names map[string]string
+}
+
+// pathSyntax is the syntax of the paths of the router
+type pathSyntax struct {
+	// param and catchAll return the segment of the param matching a
+	// segment and the rest of the path, along with its name for the
+	// router
+	param    func(name string) (segment, key string)
+	catchAll func(name string) (segment, key string)
+
+	// literal escapes the literal segment
+	literal func(segment string) string
+}
+
+var (
+	ginSyntax = pathSyntax{
+		param:    func(name string) (string, string) { return ":" + name, name },
+		catchAll: func(name string) (string, string) { return "*" + name, name },
+		literal:  escapeColons,
+	}
+	echoSyntax = pathSyntax{
+		param:    func(name string) (string, string) { return ":" + name, name },
+		catchAll: func(string) (string, string) { return "*", "*" },
+		literal:  escapeColons,
+	}
+	httpSyntax = pathSyntax{
+		param: func(name string) (string, string) {
+			key := identifier(name)
+			return "{" + key + "}", key
+		},
+		catchAll: func(name string) (string, string) {
+			key := identifier(name)
+			return "{" + key + "...}", key
+		},
+		literal: func(segment string) string { return segment },
+	}
+)
+
+// GinPath converts the path template of the http rule to the path of the
+// gin router, like /v1/objects/{name}/attachment to
+// /v1/objects/:name/attachment and /v1/{path=**} to /v1/*path, escaping
+// the colons of the custom verbs following the literals. The variables
+// matching multiple segments are supported only at the end of the path
+func GinPath(pattern string) (RouterPath, error) {
+	return routerPath(pattern, ginSyntax)
+}
+
+// EchoPath converts the path template of the http rule to the path of the
+// echo router, like /v1/objects/{name}/attachment to
+// /v1/objects/:name/attachment and /v1/{path=**} to /v1/*, escaping the
+// colons of the custom verbs following the literals. The variables
+// matching multiple segments are supported only at the end of the path
+func EchoPath(pattern string) (RouterPath, error) {
+	return routerPath(pattern, echoSyntax)
+}
+
+// HTTPPath converts the path template of the http rule to the pattern of
+// http.ServeMux, without the method, like /v1/objects/{object.name} to
+// /v1/objects/{object_name} and /v1/{path=**} to /v1/{path...}, naming
+// the params after the variables with the characters other than the
+// letters, the digits and the underscores replaced by the underscores.
+// The variables matching multiple segments are supported only at the end
+// of the path
+func HTTPPath(pattern string) (RouterPath, error) {
+	rp, err := routerPath(pattern, httpSyntax)
+	if err == nil && strings.HasSuffix(rp.Path, "/") {
+		// the patterns ending with the slash match the prefix otherwise
+		rp.Path += "{$}"
+	}
+	return rp, err
+}
+
+// routerPath converts the path template to the path of the router as per
+// the syntax
+func routerPath(pattern string, syntax pathSyntax) (RouterPath, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return RouterPath{}, fmt.Errorf("invalid path template %q: expected to start with /", pattern)
+	}
+	var rp RouterPath
+	segments := splitTemplate(pattern[1:])
+	last := segments[len(segments)-1]
+	// custom verb follows the last segment, outside the braces
+	if i := strings.LastIndex(last, ":"); i > strings.LastIndex(last, "}") && strings.HasPrefix(last, "{") {
+		segments[len(segments)-1], rp.Verb = last[:i], last[i+1:]
+	}
+	parts := make([]string, 0, len(segments))
+	keys := map[string]bool{}
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, "{") {
+			// including the custom verb following the literal, matched
+			// by the router as is
+			parts = append(parts, syntax.literal(seg))
+			continue
+		}
+		name, match, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}"), "=")
+		var part, key string
+		switch {
+		case match == "" || match == "*":
+			part, key = syntax.param(name)
+		case match == "**" && i == len(segments)-1:
+			part, key = syntax.catchAll(name)
+			rp.CatchAll = name
+		default:
+			return RouterPath{}, fmt.Errorf("unsupported path template %q: variable %s matching %s", pattern, name, match)
+		}
+		if keys[key] {
+			return RouterPath{}, fmt.Errorf("unsupported path template %q: variables named %s by the router", pattern, key)
+		}
+		keys[key] = true
+		if key != name {
+			if rp.Names == nil {
+				rp.Names = map[string]string{}
+			}
+			rp.Names[key] = name
+		}
+		parts = append(parts, part)
+		if i == len(segments)-1 {
+			rp.Param = name
+		}
+	}
+	rp.Path = "/" + strings.Join(parts, "/")
+	return rp, nil
+}
+
+// escapeColons escapes the colons of the literal segment, which are the
+// params otherwise for gin and echo
+func escapeColons(segment string) string {
+	return strings.ReplaceAll(segment, ":", `\:`)
+}
+
+// identifier returns the name as the identifier, replacing the characters
+// other than the letters, the digits and the underscores by underscores
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// splitTemplate splits the path template into the segments, keeping the
+// variables matching multiple segments as one
+func splitTemplate(pattern string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, c := range pattern {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, pattern[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, pattern[start:])
+}
+
+// RouterMux adapts the routers other than runtime.ServeMux, like gin,
+// echo and http.ServeMux, to Mux, registering the handlers using the
+// paths converted to the syntax of the router. The bindings differing
+// only by the custom verb following the path param, like
+// /v1/objects/{name} and /v1/objects/{name}:watch, are registered as a
+// single route dispatching by the suffix of the param
+type RouterMux struct {
+	convert func(pattern string) (RouterPath, error)
+	handle  func(meth, path string, h runtime.HandlerFunc) error
+
+	mu     sync.Mutex
+	routes map[string]*verbRoutes
+}
+
+// verbRoutes are the bindings sharing the path of the router, keyed by
+// the custom verb, empty for the one without
+type verbRoutes struct {
+	mu       sync.RWMutex
+	param    string
+	catchAll string
+	names    map[string]string
+	bindings map[string]boundRoute
+}
+
+// boundRoute is the handler of the binding along with its path template
+type boundRoute struct {
+	pattern string
+	h       runtime.HandlerFunc
+}
+
+// NewGinMux returns the mux registering the handlers on the gin router
+// using handle, called once per http method and path converted using
+// GinPath, with the handler expecting the params of the gin context
+//
+//	mux := routes.NewGinMux(func(meth, path string, h runtime.HandlerFunc) {
+//		router.Handle(meth, path, func(c *gin.Context) {
+//			params := make(map[string]string, len(c.Params))
+//			for _, p := range c.Params {
+//				params[p.Key] = p.Value
+//			}
+//			h(c.Writer, c.Request, params)
+//		})
+//	})
+func NewGinMux(handle func(meth, path string, h runtime.HandlerFunc)) *RouterMux {
+	return &RouterMux{
+		convert: GinPath,
+		handle: func(meth, path string, h runtime.HandlerFunc) error {
+			handle(meth, path, h)
+			return nil
+		},
+		routes: map[string]*verbRoutes{},
+	}
+}
+
+// NewEchoMux returns the mux registering the handlers on the echo router
+// using handle, called once per http method and path converted using
+// EchoPath, with the handler expecting the params of the echo context
+//
+//	mux := routes.NewEchoMux(func(meth, path string, h runtime.HandlerFunc) {
+//		e.Add(meth, path, func(c echo.Context) error {
+//			params := make(map[string]string, len(c.ParamNames()))
+//			for i, name := range c.ParamNames() {
+//				params[name] = c.ParamValues()[i]
+//			}
+//			h(c.Response(), c.Request(), params)
+//			return nil
+//		})
+//	})
+func NewEchoMux(handle func(meth, path string, h runtime.HandlerFunc)) *RouterMux {
+	return &RouterMux{
+		convert: EchoPath,
+		handle: func(meth, path string, h runtime.HandlerFunc) error {
+			handle(meth, path, h)
+			return nil
+		},
+		routes: map[string]*verbRoutes{},
+	}
+}
+
+// NewHTTPMux returns the mux registering the handlers on the http.ServeMux
+// using the method qualified patterns, like GET /v1/objects/{name},
+// converted using HTTPPath, removing the need of any third party router.
+// The registration of the patterns conflicting with the ones registered
+// on the mux already fails
+//
+//	mux := http.NewServeMux()
+//	if err := example.RegisterHelloWorldRoutes(ctx, routes.NewHTTPMux(mux), server); err != nil {
+//		return err
+//	}
+func NewHTTPMux(mux *http.ServeMux) *RouterMux {
+	return &RouterMux{
+		convert: HTTPPath,
+		handle: func(meth, path string, h runtime.HandlerFunc) (err error) {
+			var keys []string
+			for _, seg := range strings.Split(path, "/") {
+				if key, ok := strings.CutPrefix(seg, "{"); ok && seg != "{$}" {
+					keys = append(keys, strings.TrimSuffix(strings.TrimSuffix(key, "}"), "..."))
+				}
+			}
+			defer func() {
+				// the mux panics on the conflicting patterns
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%v", r)
+				}
+			}()
+			mux.HandleFunc(meth+" "+path, func(w http.ResponseWriter, req *http.Request) {
+				params := make(map[string]string, len(keys))
+				for _, key := range keys {
+					params[key] = req.PathValue(key)
+				}
+				h(w, req, params)
+			})
+			return nil
+		},
+		routes: map[string]*verbRoutes{},
+	}
+}
+
+// HandlePath registers the handler for the path template on the router
+func (m *RouterMux) HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error {
+	rp, err := m.convert(pathPattern)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := meth + " " + rp.Path
+	routes, ok := m.routes[key]
+	if !ok {
+		routes = &verbRoutes{
+			param:    rp.Param,
+			catchAll: rp.CatchAll,
+			names:    rp.Names,
+			bindings: map[string]boundRoute{},
+		}
+		m.routes[key] = routes
+	}
+	routes.mu.Lock()
+	_, dup := routes.bindings[rp.Verb]
+	if !dup {
+		routes.bindings[rp.Verb] = boundRoute{pattern: pathPattern, h: h}
+	}
+	routes.mu.Unlock()
+	if dup {
+		return fmt.Errorf("duplicate route %s %s", meth, pathPattern)
+	}
+	if !ok {
+		if err := m.handle(meth, rp.Path, routes.serve); err != nil {
+			delete(m.routes, key)
+			return err
+		}
+	}
+	return nil
+}
+
+// Route returns the route of the binding the request matched by the
+// router to the http method and the path, along with the params, is
+// dispatched to, like for the middlewares of the router to act as per
+// the binding
+func (m *RouterMux) Route(meth, path string, params map[string]string) (Route, bool) {
+	m.mu.Lock()
+	routes, ok := m.routes[meth+" "+path]
+	m.mu.Unlock()
+	if !ok {
+		return Route{}, false
+	}
+	params = maps.Clone(params)
+	routes.normalize(params)
+	routes.mu.RLock()
+	defer routes.mu.RUnlock()
+	b, _, ok := routes.match(params)
+	if !ok {
+		return Route{}, false
+	}
+	return Route{Method: meth, Path: b.pattern}, true
+}
+
+// normalize names the params as per the variables of the path template
+func (r *verbRoutes) normalize(params map[string]string) {
+	for key, name := range r.names {
+		if value, ok := params[key]; ok {
+			delete(params, key)
+			params[name] = value
+		}
+	}
+	if value, ok := params[r.catchAll]; ok {
+		// the routers match the rest of the path along with the slash
+		params[r.catchAll] = strings.TrimPrefix(value, "/")
+	}
+}
+
+// match returns the binding of the verb the param is suffixed with, the
+// one without the verb otherwise, along with the value of the param
+// without the verb
+func (r *verbRoutes) match(params map[string]string) (boundRoute, string, bool) {
+	value, ok := params[r.param]
+	if ok {
+		for verb, b := range r.bindings {
+			if v, found := strings.CutSuffix(value, ":"+verb); found && verb != "" && v != "" {
+				return b, v, true
+			}
+		}
+	}
+	b, found := r.bindings[""]
+	return b, value, found
+}
+
+// serve dispatches the request to the handler of the binding matching
+// the params, responding not found if none
+func (r *verbRoutes) serve(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	r.normalize(params)
+	r.mu.RLock()
+	b, value, ok := r.match(params)
+	r.mu.RUnlock()
+	if !ok {
+		_, outboundMarshaler := runtime.MarshalerForRequest(defaultServeMux, req)
+		runtime.HTTPError(req.Context(), defaultServeMux, outboundMarshaler, w, req, status.Error(codes.NotFound, "Not Found"))
+		return
+	}
+	if _, ok := params[r.param]; ok {
+		params[r.param] = value
+	}
+	b.h(w, req, params)
+}
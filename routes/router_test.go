@@ -0,0 +1,286 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestGinPath(t *testing.T) {
+	for _, spec := range []struct {
+		pattern string
+		want    RouterPath
+		wantErr bool
+	}{
+		{
+			pattern: "/v1/objects",
+			want:    RouterPath{Path: "/v1/objects"},
+		},
+		{
+			pattern: "/v1/object/{name}/attachment",
+			want:    RouterPath{Path: "/v1/object/:name/attachment"},
+		},
+		{
+			pattern: "/v1/object/{name}",
+			want:    RouterPath{Path: "/v1/object/:name", Param: "name"},
+		},
+		{
+			pattern: "/v1/object/{object.name=*}:watch",
+			want:    RouterPath{Path: "/v1/object/:object.name", Verb: "watch", Param: "object.name"},
+		},
+		{
+			pattern: "/v1/objects:batchCreate",
+			want:    RouterPath{Path: `/v1/objects\:batchCreate`},
+		},
+		{
+			pattern: "/v1/files/{path=**}",
+			want:    RouterPath{Path: "/v1/files/*path", Param: "path", CatchAll: "path"},
+		},
+		{
+			pattern: "/v1/{name=shelves/*}/books",
+			wantErr: true,
+		},
+		{
+			pattern: "/v1/{path=**}/meta",
+			wantErr: true,
+		},
+		{
+			pattern: "v1/objects",
+			wantErr: true,
+		},
+	} {
+		got, err := GinPath(spec.pattern)
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("GinPath(%q) = %+v; want error", spec.pattern, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("GinPath(%q) = %+v, %v; want %+v", spec.pattern, got, err, spec.want)
+		}
+	}
+}
+
+func TestEchoPath(t *testing.T) {
+	for _, spec := range []struct {
+		pattern string
+		want    RouterPath
+		wantErr bool
+	}{
+		{
+			pattern: "/v1/object/{name}:watch",
+			want:    RouterPath{Path: "/v1/object/:name", Verb: "watch", Param: "name"},
+		},
+		{
+			pattern: "/v1/objects:batchCreate",
+			want:    RouterPath{Path: `/v1/objects\:batchCreate`},
+		},
+		{
+			pattern: "/v1/files/{path=**}",
+			want:    RouterPath{Path: "/v1/files/*", Param: "path", CatchAll: "path", Names: map[string]string{"*": "path"}},
+		},
+		{
+			pattern: "/v1/{path=**}/meta",
+			wantErr: true,
+		},
+	} {
+		got, err := EchoPath(spec.pattern)
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("EchoPath(%q) = %+v; want error", spec.pattern, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("EchoPath(%q) = %+v, %v; want %+v", spec.pattern, got, err, spec.want)
+		}
+	}
+}
+
+func TestHTTPPath(t *testing.T) {
+	for _, spec := range []struct {
+		pattern string
+		want    RouterPath
+		wantErr bool
+	}{
+		{
+			pattern: "/orgs/{org_id}/users/{id}",
+			want:    RouterPath{Path: "/orgs/{org_id}/users/{id}", Param: "id"},
+		},
+		{
+			pattern: "/v1/object/{object.name=*}:watch",
+			want:    RouterPath{Path: "/v1/object/{object_name}", Verb: "watch", Param: "object.name", Names: map[string]string{"object_name": "object.name"}},
+		},
+		{
+			pattern: "/v1/objects:batchCreate",
+			want:    RouterPath{Path: "/v1/objects:batchCreate"},
+		},
+		{
+			pattern: "/v1/files/{path=**}",
+			want:    RouterPath{Path: "/v1/files/{path...}", Param: "path", CatchAll: "path"},
+		},
+		{
+			pattern: "/",
+			want:    RouterPath{Path: "/{$}"},
+		},
+		{
+			pattern: "/v1/{a.b}/{a_b}",
+			wantErr: true,
+		},
+		{
+			pattern: "/v1/{name=shelves/*}/books",
+			wantErr: true,
+		},
+	} {
+		got, err := HTTPPath(spec.pattern)
+		if spec.wantErr {
+			if err == nil {
+				t.Errorf("HTTPPath(%q) = %+v; want error", spec.pattern, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, spec.want) {
+			t.Errorf("HTTPPath(%q) = %+v, %v; want %+v", spec.pattern, got, err, spec.want)
+		}
+	}
+}
+
+func TestGinMux(t *testing.T) {
+	// router keyed by the method and the path, as registered on gin
+	router := map[string]runtime.HandlerFunc{}
+	mux := NewGinMux(func(meth, path string, h runtime.HandlerFunc) {
+		if _, ok := router[meth+" "+path]; ok {
+			t.Fatalf("route %s %s registered twice on the router", meth, path)
+		}
+		router[meth+" "+path] = h
+	})
+	var called string
+	var got map[string]string
+	handler := func(name string) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			called, got = name, params
+		}
+	}
+	for _, r := range []struct{ meth, pattern, name string }{
+		{"GET", "/v1/object/{name}", "get"},
+		{"GET", "/v1/object/{name}:watch", "watch"},
+		{"POST", "/v1/object/{name}:setCredentials", "credentials"},
+		{"GET", "/v1/files/{path=**}", "files"},
+	} {
+		if err := mux.HandlePath(r.meth, r.pattern, handler(r.name)); err != nil {
+			t.Fatalf("HandlePath(%s, %s) failed with %v; want success", r.meth, r.pattern, err)
+		}
+	}
+	if err := mux.HandlePath("GET", "/v1/object/{name}", handler("dup")); err == nil {
+		t.Errorf("HandlePath() of the duplicate route succeeded; want error")
+	}
+
+	for _, spec := range []struct {
+		route  string
+		params map[string]string
+		want   string
+		wanted map[string]string
+	}{
+		{"GET /v1/object/:name", map[string]string{"name": "abc"}, "get", map[string]string{"name": "abc"}},
+		{"GET /v1/object/:name", map[string]string{"name": "abc:watch"}, "watch", map[string]string{"name": "abc"}},
+		{"POST /v1/object/:name", map[string]string{"name": "abc:setCredentials"}, "credentials", map[string]string{"name": "abc"}},
+		{"GET /v1/files/*path", map[string]string{"path": "/a/b.txt"}, "files", map[string]string{"path": "a/b.txt"}},
+	} {
+		called, got = "", nil
+		h, ok := router[spec.route]
+		if !ok {
+			t.Fatalf("route %s not registered on the router", spec.route)
+		}
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), spec.params)
+		if called != spec.want || !reflect.DeepEqual(got, spec.wanted) {
+			t.Errorf("%s with %v called %s with %v; want %s with %v", spec.route, spec.params, called, got, spec.want, spec.wanted)
+		}
+	}
+
+	// the verbs not served are not found
+	w := httptest.NewRecorder()
+	router["POST /v1/object/:name"](w, httptest.NewRequest(http.MethodPost, "/", nil), map[string]string{"name": "abc"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("call without the verb responded %d; want 404", w.Code)
+	}
+}
+
+func TestEchoMux(t *testing.T) {
+	router := map[string]runtime.HandlerFunc{}
+	mux := NewEchoMux(func(meth, path string, h runtime.HandlerFunc) {
+		router[meth+" "+path] = h
+	})
+	var got map[string]string
+	for _, pattern := range []string{"/v1/files/{path=**}", "/v1/files/{path=**}:stat"} {
+		if err := mux.HandlePath("GET", pattern, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			got = params
+		}); err != nil {
+			t.Fatalf("HandlePath(GET, %s) failed with %v; want success", pattern, err)
+		}
+	}
+	params := map[string]string{"*": "a/b.txt:stat"}
+	route, ok := mux.Route("GET", "/v1/files/*", params)
+	if want := (Route{Method: "GET", Path: "/v1/files/{path=**}:stat"}); !ok || route != want {
+		t.Errorf("Route(GET, /v1/files/*) = %v, %v; want %v", route, ok, want)
+	}
+	if _, ok := mux.Route("GET", "/v1/objects", nil); ok {
+		t.Errorf("Route(GET, /v1/objects) of the route not registered succeeded; want failure")
+	}
+	router["GET /v1/files/*"](httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), params)
+	if want := map[string]string{"path": "a/b.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("handler called with %v; want %v", got, want)
+	}
+}
+
+func TestHTTPMux(t *testing.T) {
+	serveMux := http.NewServeMux()
+	mux := NewHTTPMux(serveMux)
+	var called string
+	var got map[string]string
+	for _, r := range []struct{ meth, pattern, name string }{
+		{"GET", "/orgs/{org_id}/users/{user.id}", "get"},
+		{"GET", "/orgs/{org_id}/users/{user.id}:watch", "watch"},
+		{"GET", "/orgs/{org_id}/users:search", "search"},
+		{"GET", "/files/{path=**}", "files"},
+	} {
+		if err := mux.HandlePath(r.meth, r.pattern, func(w http.ResponseWriter, req *http.Request, params map[string]string) {
+			called, got = r.name, params
+		}); err != nil {
+			t.Fatalf("HandlePath(%s, %s) failed with %v; want success", r.meth, r.pattern, err)
+		}
+	}
+	// conflicting with the route of the users, neither is more specific
+	if err := mux.HandlePath("GET", "/orgs/default/{kind}/{id}", func(http.ResponseWriter, *http.Request, map[string]string) {}); err == nil {
+		t.Errorf("HandlePath() of the conflicting route succeeded; want error")
+	}
+
+	for _, spec := range []struct {
+		url    string
+		want   string
+		wanted map[string]string
+	}{
+		{"/orgs/acme/users/bob", "get", map[string]string{"org_id": "acme", "user.id": "bob"}},
+		{"/orgs/acme/users/bob:watch", "watch", map[string]string{"org_id": "acme", "user.id": "bob"}},
+		{"/orgs/acme/users:search", "search", map[string]string{"org_id": "acme"}},
+		{"/files/a/b.txt", "files", map[string]string{"path": "a/b.txt"}},
+	} {
+		called, got = "", nil
+		w := httptest.NewRecorder()
+		serveMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, spec.url, nil))
+		if called != spec.want || !reflect.DeepEqual(got, spec.wanted) {
+			t.Errorf("GET %s called %q with %v; want %s with %v", spec.url, called, got, spec.want, spec.wanted)
+		}
+	}
+	w := httptest.NewRecorder()
+	serveMux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orgs/acme/users/bob", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST of the route served for GET responded %d; want 405", w.Code)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+const (
+	// ShadowHeader marks the copies of the requests sent to the shadow
+	// target, such that the target may skip the side effects, like
+	// notifying the users, not meant to be repeated
+	ShadowHeader = "X-Shadow-Request"
+
+	// DefaultShadowTimeout bounds the copies of the requests sent to the
+	// shadow target unless configured otherwise
+	DefaultShadowTimeout = 10 * time.Second
+
+	// DefaultMaxShadowBodySize is the size of the largest request body
+	// copied to the shadow target unless configured otherwise
+	DefaultMaxShadowBodySize = 1 << 20
+
+	// DefaultMaxShadowInflight is the number of the copies in flight to
+	// the shadow target unless configured otherwise
+	DefaultMaxShadowInflight = 64
+)
+
+var (
+	// shadowedCalls publishes the number of the calls copied to the
+	// shadow target, keyed by the method
+	shadowedCalls = expvar.NewMap("routes_shadowed_calls")
+
+	// droppedShadowCalls publishes the number of the calls sampled but
+	// not copied to the shadow target, as the copies in flight are
+	// saturated or the body is too large, keyed by the method
+	droppedShadowCalls = expvar.NewMap("routes_dropped_shadow_calls")
+)
+
+// ShadowConfig configures the copying of the calls of the methods marked
+// using (api.shadow_rate) to the shadow target, like the rewrite of the
+// service serving the same proto contract, validated against the live
+// traffic without affecting the callers
+type ShadowConfig struct {
+	// Target is the base URL of the server the copies are sent to,
+	// along with the scheme, host, port and an optional path prefix
+	Target string
+
+	// Client sends the copies, http.DefaultClient if nil
+	Client *http.Client
+
+	// Timeout bounds the copies, DefaultShadowTimeout if zero
+	Timeout time.Duration
+
+	// MaxBodySize is the size of the largest request body copied,
+	// DefaultMaxShadowBodySize if zero
+	MaxBodySize int
+
+	// MaxInflight is the number of the copies in flight, the calls
+	// sampled beyond which are not copied, DefaultMaxShadowInflight if
+	// zero
+	MaxInflight int
+
+	// ForwardHeaders are the credential headers, like Authorization,
+	// forwarded with the copies for the shadow target authenticating
+	// the callers on its own, none of them is forwarded if empty
+	ForwardHeaders []string
+}
+
+// ShadowFunc copies the request of the call of the method to the shadow
+// target, for the fraction of the calls as per the rate
+type ShadowFunc func(req *http.Request, method string, rate float64)
+
+// shadowMux wraps the mux providing the shadow target to the generated
+// routes
+type shadowMux struct {
+	Mux
+	target  string
+	client  *http.Client
+	timeout time.Duration
+	max     int
+	slots   chan struct{}
+	// strip are the headers not forwarded with the copies
+	strip []string
+}
+
+// Unwrap returns the wrapped mux
+func (m *shadowMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithShadow wraps the mux enabling the copying of the calls of the
+// methods marked using (api.shadow_rate) by the generated routes to the
+// shadow target. The copies are sent asynchronously, marked using
+// ShadowHeader, while their responses are discarded, such that the
+// callers are served irrespective of the shadow target. The headers
+// carrying the credentials of the callers are not forwarded unless
+// allowed using ShadowConfig.ForwardHeaders. The calls are not copied
+// without the shadow target
+func WithShadow(mux Mux, cfg ShadowConfig) (Mux, error) {
+	wrap, err := NewShadow(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return wrap(mux), nil
+}
+
+// NewShadow validates the config returning the wrapper of the mux same
+// as WithShadow, usable as the wrap of the generated registrations
+//
+//	shadow, err := routes.NewShadow(routes.ShadowConfig{Target: "http://objects-v2:8080"})
+//	if err != nil {
+//		return err
+//	}
+//	err = example.RegisterHelloWorldHTTPRoutes(ctx, serveMux, server, shadow)
+func NewShadow(cfg ShadowConfig) (func(Mux) Mux, error) {
+	target, err := url.Parse(cfg.Target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shadow target %q: %w", cfg.Target, err)
+	}
+	if target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("invalid shadow target %q: scheme and host are required", cfg.Target)
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultShadowTimeout
+	}
+	maxBody := cfg.MaxBodySize
+	if maxBody <= 0 {
+		maxBody = DefaultMaxShadowBodySize
+	}
+	inflight := cfg.MaxInflight
+	if inflight <= 0 {
+		inflight = DefaultMaxShadowInflight
+	}
+	strip := slices.Clone(hopHeaders)
+	for _, h := range credentialHeaders {
+		if !slices.ContainsFunc(cfg.ForwardHeaders, func(f string) bool { return strings.EqualFold(f, h) }) {
+			strip = append(strip, h)
+		}
+	}
+	// copies in flight are bounded across the routes wrapped
+	slots := make(chan struct{}, inflight)
+	return func(mux Mux) Mux {
+		return &shadowMux{
+			Mux:     mux,
+			target:  strings.TrimSuffix(target.String(), "/"),
+			client:  client,
+			timeout: timeout,
+			max:     maxBody,
+			slots:   slots,
+			strip:   strip,
+		}
+	}, nil
+}
+
+// Shadow returns the function copying the calls to the shadow target
+// provided for the mux using WithShadow, looking through the wrappers
+// exposing Unwrap() Mux, leaving the calls uncopied if not provided
+func Shadow(mux Mux) ShadowFunc {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *shadowMux:
+			return m.shadow
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			mux = nil
+		}
+	}
+	return func(*http.Request, string, float64) {}
+}
+
+func (m *shadowMux) shadow(req *http.Request, method string, rate float64) {
+	if rate < 1 && rand.Float64() >= rate {
+		return
+	}
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		droppedShadowCalls.Add(method, 1)
+		return
+	}
+	// the body is read upfront, to be replayed for the call as well as
+	// the copy, left for the call as is when too large
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(io.LimitReader(req.Body, int64(m.max)+1))
+		req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), req.Body), Closer: req.Body}
+		if err != nil || len(data) > m.max {
+			<-m.slots
+			droppedShadowCalls.Add(method, 1)
+			return
+		}
+		body = data
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), m.timeout)
+	r, err := http.NewRequestWithContext(ctx, req.Method, m.target+req.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		<-m.slots
+		droppedShadowCalls.Add(method, 1)
+		return
+	}
+	r.Header = req.Header.Clone()
+	for _, h := range m.strip {
+		r.Header.Del(h)
+	}
+	r.Header.Set(ShadowHeader, "true")
+	shadowedCalls.Add(method, 1)
+	go func() {
+		defer func() { <-m.slots }()
+		defer cancel()
+		resp, err := m.client.Do(r)
+		if err != nil {
+			grpclog.Infof("Failed to send the shadow copy of %s: %v", method, err)
+			return
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+}
+
+// hopHeaders are the hop-by-hop headers not forwarded with the copies
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// credentialHeaders are the headers carrying the credentials of the
+// callers, not forwarded with the copies unless allowed
+var credentialHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"X-Api-Key",
+	"X-Api-Key-Id",
+	"X-Signature",
+	"X-Timestamp",
+}
+
+// readCloser replays the body read upfront followed by the rest of it
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestShadow(t *testing.T) {
+	type copied struct {
+		method, uri, body, marker, auth, cookie string
+	}
+	copies := make(chan copied, 4)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		copies <- copied{r.Method, r.URL.RequestURI(), string(body), r.Header.Get(ShadowHeader), r.Header.Get("Authorization"), r.Header.Get("Cookie")}
+	}))
+	defer target.Close()
+
+	if _, err := WithShadow(runtime.NewServeMux(), ShadowConfig{Target: "/relative"}); err == nil {
+		t.Errorf("WithShadow() with relative target succeeded; want error")
+	}
+	// calls are not copied without WithShadow
+	req := httptest.NewRequest(http.MethodPost, "/v1/objects", strings.NewReader("body"))
+	Shadow(runtime.NewServeMux())(req, "/example.Objects/Create", 1)
+
+	mux, err := WithShadow(runtime.NewServeMux(), ShadowConfig{Target: target.URL + "/shadow/", MaxBodySize: 16})
+	if err != nil {
+		t.Fatalf("WithShadow() failed with %v; want success", err)
+	}
+	shadow := Shadow(WithPurge(mux, nil))
+	req = httptest.NewRequest(http.MethodPost, "/v1/objects?dry=true", strings.NewReader(`{"name":"abc"}`))
+	req.Header.Set("Authorization", "Bearer t")
+	req.Header.Set("Cookie", "session=s")
+	shadow(req, "/example.Objects/Create", 1)
+	// body is retained for the call
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"name":"abc"}` {
+		t.Errorf("request body after the copy = %q; want retained", body)
+	}
+	select {
+	case c := <-copies:
+		// credentials are not forwarded by default
+		want := copied{http.MethodPost, "/shadow/v1/objects?dry=true", `{"name":"abc"}`, "true", "", ""}
+		if c != want {
+			t.Errorf("shadow copy = %+v; want %+v", c, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("shadow copy not received")
+	}
+
+	// calls not sampled and the ones with the large bodies are not copied
+	large := strings.Repeat("x", 32)
+	req = httptest.NewRequest(http.MethodPost, "/v1/objects", strings.NewReader(large))
+	shadow(req, "/example.Objects/Create", 1)
+	if body, _ := io.ReadAll(req.Body); string(body) != large {
+		t.Errorf("large request body after the copy = %q; want retained", body)
+	}
+	shadow(httptest.NewRequest(http.MethodGet, "/v1/objects", nil), "/example.Objects/List", 0)
+	select {
+	case c := <-copies:
+		t.Errorf("shadow copy %+v received; want none", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// credential headers allowed are forwarded, with the wrapper created
+	// using NewShadow
+	wrap, err := NewShadow(ShadowConfig{Target: target.URL, ForwardHeaders: []string{"authorization"}})
+	if err != nil {
+		t.Fatalf("NewShadow() failed with %v; want success", err)
+	}
+	req = httptest.NewRequest(http.MethodDelete, "/v1/objects/abc", nil)
+	req.Header.Set("Authorization", "Bearer t")
+	req.Header.Set("Cookie", "session=s")
+	Shadow(wrap(runtime.NewServeMux()))(req, "/example.Objects/Delete", 1)
+	select {
+	case c := <-copies:
+		want := copied{http.MethodDelete, "/v1/objects/abc", "", "true", "Bearer t", ""}
+		if c != want {
+			t.Errorf("shadow copy = %+v; want %+v", c, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("shadow copy not received")
+	}
+}
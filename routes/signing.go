@@ -0,0 +1,94 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/go-core-stack/grpc-core/jws"
+)
+
+// signerMux wraps the mux providing the signer to the generated routes
+type signerMux struct {
+	Mux
+	signer jws.Signer
+}
+
+// Unwrap returns the wrapped mux
+func (m *signerMux) Unwrap() Mux {
+	return m.Mux
+}
+
+// WithSigner wraps the mux providing the signer used by the generated
+// routes to sign the responses of the methods marked using
+// (api.signed), registering the routes of such methods fails without a
+// signer
+func WithSigner(mux Mux, signer jws.Signer) Mux {
+	return &signerMux{Mux: mux, signer: signer}
+}
+
+// Signer returns the signer provided for the mux using WithSigner,
+// looking through the wrappers exposing Unwrap() Mux, nil if not
+// provided
+func Signer(mux Mux) jws.Signer {
+	for mux != nil {
+		switch m := mux.(type) {
+		case *signerMux:
+			return m.signer
+		case interface{ Unwrap() Mux }:
+			mux = m.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// signingWriter buffers the response to sign the body once complete
+type signingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *signingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *signingWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+// SignResponse returns the writer buffering the response, along with
+// the function to be called once the response is complete, writing it
+// out with the detached signature of the body set in jws.Header.
+// Responses failing to sign are replaced by an internal error, such
+// that the clients never receive a response without the signature
+func SignResponse(ctx context.Context, w http.ResponseWriter, signer jws.Signer) (http.ResponseWriter, func()) {
+	sw := &signingWriter{ResponseWriter: w}
+	return sw, func() {
+		sig, err := jws.Sign(ctx, signer, sw.body.Bytes())
+		if err != nil {
+			grpclog.Errorf("Failed to sign response: %v", err)
+			w.Header().Del("Content-Length")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(jws.Header, sig)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		w.WriteHeader(sw.status)
+		_, _ = w.Write(sw.body.Bytes())
+	}
+}
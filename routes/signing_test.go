@@ -0,0 +1,52 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/go-core-stack/grpc-core/jws"
+)
+
+func TestSignResponse(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := jws.NewSigner("k1", key)
+	if err != nil {
+		t.Fatalf("NewSigner() failed with %v; want success", err)
+	}
+
+	// signer is found through the wrappers of the mux
+	mux := WithKMS(WithSigner(runtime.NewServeMux(), signer), nil)
+	if Signer(mux) != signer {
+		t.Fatalf("Signer() did not return the signer provided using WithSigner")
+	}
+	if Signer(runtime.NewServeMux()) != nil {
+		t.Errorf("Signer() without WithSigner returned a signer; want nil")
+	}
+
+	rec := httptest.NewRecorder()
+	w, done := SignResponse(context.Background(), rec, Signer(mux))
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte(`{"name":`))
+	_, _ = w.Write([]byte(`"abc"}`))
+	if rec.Body.Len() != 0 {
+		t.Fatalf("SignResponse() wrote the body before completion")
+	}
+	done()
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusAccepted)
+	}
+	sig := rec.Header().Get(jws.Header)
+	if err := jws.Verify(jws.KeySet{"k1": pub}, sig, rec.Body.Bytes()); err != nil {
+		t.Errorf("Verify() of signed response failed with %v; want success", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CreatedHeader is the key of the header metadata set by the servers of
+// the methods marked using (api.upsert) when the call created the
+// resource
+const CreatedHeader = "x-resource-created"
+
+// SetCreated reports the resource as created by the call of the method
+// marked using (api.upsert), responded with 201 Created by the
+// generated routes, while the grpc clients receive it as the header
+// metadata
+//
+//	func (s *server) PutObject(ctx context.Context, req *PutRequest) (*Object, error) {
+//		obj, created := s.store.Upsert(req.GetObject())
+//		if created {
+//			_ = routes.SetCreated(ctx)
+//		}
+//		return obj, nil
+//	}
+func SetCreated(ctx context.Context) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(CreatedHeader, "true"))
+}
+
+// Created reports whether the server reported the resource as created
+// using SetCreated, removing the header metadata as conveyed by the
+// status code instead, called by the generated routes
+func Created(md *runtime.ServerMetadata) bool {
+	values := md.HeaderMD.Get(CreatedHeader)
+	if len(values) == 0 {
+		return false
+	}
+	md.HeaderMD.Delete(CreatedHeader)
+	return values[0] == "true"
+}
+
+// statusWriter responds with the status code unless set explicitly
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(w.status)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the wrapped writer, used by http.ResponseController
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithStatus returns the writer responding with the status code, unless
+// set explicitly by the handler, like 201 Created for the upserts
+// creating the resource
+func WithStatus(w http.ResponseWriter, status int) http.ResponseWriter {
+	return &statusWriter{ResponseWriter: w, status: status}
+}
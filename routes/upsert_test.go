@@ -0,0 +1,53 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCreated(t *testing.T) {
+	var stream runtime.ServerTransportStream
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &stream)
+	md := runtime.ServerMetadata{HeaderMD: metadata.Pairs("x-other", "1")}
+	if Created(&md) {
+		t.Errorf("Created() without SetCreated = true; want false")
+	}
+	if err := SetCreated(ctx); err != nil {
+		t.Fatalf("SetCreated() failed with %v; want success", err)
+	}
+	md.HeaderMD = metadata.Join(md.HeaderMD, stream.Header())
+	if !Created(&md) {
+		t.Errorf("Created() after SetCreated = false; want true")
+	}
+	if _, ok := md.HeaderMD[CreatedHeader]; ok || md.HeaderMD.Get("x-other") == nil {
+		t.Errorf("Created() left %v; want %s alone removed", md.HeaderMD, CreatedHeader)
+	}
+}
+
+func TestWithStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := WithStatus(rec, http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte("{}"))
+	if rec.Code != http.StatusCreated || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("WithStatus() responded %d with %v; want 201 with the headers", rec.Code, rec.Header())
+	}
+
+	// status set explicitly by the handler, like for the errors, wins
+	rec = httptest.NewRecorder()
+	w = WithStatus(rec, http.StatusCreated)
+	w.WriteHeader(http.StatusConflict)
+	_, _ = w.Write([]byte("{}"))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("WithStatus() with WriteHeader(409) responded %d; want 409", rec.Code)
+	}
+}
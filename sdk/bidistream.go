@@ -0,0 +1,115 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+)
+
+// BidiStream exchanges the messages of a bidirectional streaming method
+// over a websocket connection, every message is sent as a text frame
+// encoded using the marshaler, while the messages received are
+// optionally wrapped as {"result": ...} or {"error": ...} similar to
+// the server streaming methods
+//
+//	stream, err := svc.SyncObjects(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	if err := stream.Send(req); err != nil {
+//		return err
+//	}
+//	_ = stream.CloseSend()
+//	for {
+//		msg, err := stream.Recv()
+//		...
+//	}
+type BidiStream[Req proto.Message, T any, P interface {
+	*T
+	proto.Message
+}] struct {
+	conn *wsConn
+	m    runtime.Marshaler
+	stop func() bool
+
+	// mu guards the terminal error of the receiving side
+	mu  sync.Mutex
+	err error
+}
+
+// NewBidiStream upgrades the connection to websocket using the client
+// for the given method and uri, the connection is closed when the
+// context is cancelled
+func NewBidiStream[Req proto.Message, T any, P interface {
+	*T
+	proto.Message
+}](ctx context.Context, client Doer, method, uri string, m runtime.Marshaler) (*BidiStream[Req, T, P], error) {
+	conn, err := dialWebSocket(ctx, client, method, uri)
+	if err != nil {
+		return nil, err
+	}
+	return &BidiStream[Req, T, P]{
+		conn: conn,
+		m:    m,
+		stop: context.AfterFunc(ctx, func() {
+			_ = conn.Close()
+		}),
+	}, nil
+}
+
+// Send encodes and sends the message to the server, safe to be used
+// concurrently with Recv
+func (s *BidiStream[Req, T, P]) Send(msg Req) error {
+	data, err := s.m.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	return s.conn.writeFrame(opText, data)
+}
+
+// CloseSend indicates the server that no more messages are to be sent,
+// messages from the server continue to be received till the server
+// closes the stream
+func (s *BidiStream[Req, T, P]) CloseSend() error {
+	return s.conn.writeClose(closeNormal, "")
+}
+
+// Recv returns the next message from the server, returns io.EOF once
+// the server has completed the stream, *StreamError if the server
+// terminated the stream with an error and *WebSocketCloseError if the
+// connection is closed abnormally
+func (s *BidiStream[Req, T, P]) Recv() (P, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	m, err := s.recv()
+	if err != nil {
+		s.err = err
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *BidiStream[Req, T, P]) recv() (P, error) {
+	data, err := s.conn.readMessage()
+	if err != nil {
+		return nil, err
+	}
+	return decodeFrame[T, P](s.m, data)
+}
+
+// Close terminates the stream releasing the underlying connection
+func (s *BidiStream[Req, T, P]) Close() error {
+	s.stop()
+	_ = s.conn.writeClose(closeNormal, "")
+	return s.conn.Close()
+}
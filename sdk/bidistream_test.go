@@ -0,0 +1,133 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newWebSocketServer returns the server upgrading the requests to
+// websocket, serving the connection using the handler
+func newWebSocketServer(t *testing.T, handler func(r *http.Request, c *wsConn)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() failed with %v", err)
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", acceptKey(r.Header.Get("Sec-WebSocket-Key")))
+		_ = rw.Flush()
+		handler(r, newWSConn(conn, false))
+	}))
+}
+
+func TestBidiStream(t *testing.T) {
+	var method string
+	srv := newWebSocketServer(t, func(r *http.Request, c *wsConn) {
+		method = r.URL.Query().Get("method")
+		for {
+			msg, err := c.readMessage()
+			if err != nil {
+				return
+			}
+			if err := c.writeFrame(opText, fmt.Appendf(nil, `{"result":%s}`, msg)); err != nil {
+				return
+			}
+		}
+	})
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	stream, err := NewBidiStream[*wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, http.MethodPost, "/v1/objects:sync", &runtime.JSONPb{})
+	if err != nil {
+		t.Fatalf("NewBidiStream() failed with %v; want success", err)
+	}
+	defer stream.Close()
+
+	for _, want := range []string{"a", "b"} {
+		if err := stream.Send(wrapperspb.String(want)); err != nil {
+			t.Fatalf("stream.Send(%q) failed with %v; want success", want, err)
+		}
+		m, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream.Recv() failed with %v; want %q", err, want)
+		}
+		if m.GetValue() != want {
+			t.Errorf("stream.Recv() = %q; want %q", m.GetValue(), want)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("stream.CloseSend() failed with %v; want success", err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("stream.Recv() after CloseSend = %v; want io.EOF", err)
+	}
+	if err := stream.Send(wrapperspb.String("c")); err == nil {
+		t.Errorf("stream.Send() after CloseSend succeeded; want error")
+	}
+	if method != http.MethodPost {
+		t.Errorf("method = %q; want %q", method, http.MethodPost)
+	}
+}
+
+func TestBidiStreamAbnormalClose(t *testing.T) {
+	srv := newWebSocketServer(t, func(r *http.Request, c *wsConn) {
+		_ = c.writeFrame(opPing, []byte("ping"))
+		_ = c.writeFrame(opText, []byte(`{"result":"first"}`))
+		_ = c.writeClose(1011, "internal failure")
+		_, _ = c.readMessage()
+	})
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	stream, err := NewBidiStream[*wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, http.MethodGet, "/v1/objects:sync", &runtime.JSONPb{})
+	if err != nil {
+		t.Fatalf("NewBidiStream() failed with %v; want success", err)
+	}
+	defer stream.Close()
+
+	if m, err := stream.Recv(); err != nil || m.GetValue() != "first" {
+		t.Fatalf("stream.Recv() = %v, %v; want first", m, err)
+	}
+	_, err = stream.Recv()
+	var cerr *WebSocketCloseError
+	if !errors.As(err, &cerr) || cerr.Code != 1011 || cerr.Reason != "internal failure" {
+		t.Errorf("stream.Recv() = %v; want *WebSocketCloseError with code 1011", err)
+	}
+}
+
+func TestBidiStreamRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	if _, err := NewBidiStream[*wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, http.MethodPost, "/v1/objects:sync", &runtime.JSONPb{}); err == nil {
+		t.Errorf("NewBidiStream() succeeded; want error")
+	}
+}
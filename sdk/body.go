@@ -0,0 +1,139 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// StreamingThreshold is the size of the request message, as per its
+// binary encoding, beyond which the request body is streamed instead
+// of being encoded in memory, streamed requests are sent using chunked
+// transfer encoding and are not retried since the body is consumed
+var StreamingThreshold = 1 << 20
+
+// NewRequest creates the http request with the message encoded in the
+// body using the marshaler, messages larger than StreamingThreshold
+// are encoded while the body is being sent
+func NewRequest(ctx context.Context, method, uri string, m runtime.Marshaler, msg proto.Message) (*http.Request, error) {
+	pb, ok := m.(*runtime.JSONPb)
+	if !ok || !canStream(pb, msg) || proto.Size(msg) < StreamingThreshold {
+		data, err := m.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		return http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(data))
+	}
+
+	pr, pw := io.Pipe()
+	r, err := http.NewRequestWithContext(ctx, method, uri, pr)
+	if err != nil {
+		_ = pr.Close()
+		return nil, err
+	}
+	go func() {
+		// encoding stops as soon as the transport closes the body
+		_ = pw.CloseWithError(streamJSON(pw, pb.MarshalOptions, msg))
+	}()
+	return r, nil
+}
+
+// NewValueRequest creates the http request with the value encoded in
+// the body using the marshaler, used for the bodies mapped to a scalar,
+// repeated or map field of the request message
+func NewValueRequest(ctx context.Context, method, uri string, m runtime.Marshaler, v any) (*http.Request, error) {
+	data, err := m.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	return http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(data))
+}
+
+// canStream reports whether the message can be encoded field by field
+// while retaining the encoding provided by the marshaler
+func canStream(pb *runtime.JSONPb, msg proto.Message) bool {
+	if pb.EmitUnpopulated || msg == nil {
+		return false
+	}
+	name := msg.ProtoReflect().Descriptor().FullName()
+	return name.Parent() != "google.protobuf" || !wellKnownTypes[name.Name()]
+}
+
+// wellKnownTypes with special JSON representation
+var wellKnownTypes = map[protoreflect.Name]bool{
+	"Any": true, "Duration": true, "Timestamp": true, "FieldMask": true,
+	"Struct": true, "Value": true, "ListValue": true, "Empty": true,
+	"BoolValue": true, "BytesValue": true, "StringValue": true,
+	"DoubleValue": true, "FloatValue": true, "Int32Value": true,
+	"Int64Value": true, "UInt32Value": true, "UInt64Value": true,
+}
+
+// streamJSON writes the JSON encoding of the message, encoding the
+// repeated message fields an item at a time and any other field on
+// its own, such that the whole encoding is never held in memory
+func streamJSON(w io.Writer, opts protojson.MarshalOptions, msg proto.Message) error {
+	bw := bufio.NewWriter(w)
+	m := msg.ProtoReflect()
+	_ = bw.WriteByte('{')
+	first := true
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		if !first {
+			_ = bw.WriteByte(',')
+		}
+		first = false
+
+		if fd.IsList() && fd.Message() != nil {
+			name := fd.JSONName()
+			if opts.UseProtoNames {
+				name = string(fd.Name())
+			}
+			_, _ = fmt.Fprintf(bw, "%q:[", name)
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				if j > 0 {
+					_ = bw.WriteByte(',')
+				}
+				data, err := opts.Marshal(list.Get(j).Message().Interface())
+				if err != nil {
+					return err
+				}
+				if _, err := bw.Write(data); err != nil {
+					return err
+				}
+			}
+			_ = bw.WriteByte(']')
+			continue
+		}
+
+		// encode the field using a message holding only the field,
+		// dropping the enclosing braces
+		single := m.New()
+		single.Set(fd, m.Get(fd))
+		data, err := opts.Marshal(single.Interface())
+		if err != nil {
+			return err
+		}
+		data = bytes.TrimSpace(data)
+		if _, err := bw.Write(bytes.TrimSpace(data[1 : len(data)-1])); err != nil {
+			return err
+		}
+	}
+	_ = bw.WriteByte('}')
+	return bw.Flush()
+}
@@ -0,0 +1,155 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNewRequestStreaming(t *testing.T) {
+	defer func(threshold int) { StreamingThreshold = threshold }(StreamingThreshold)
+	StreamingThreshold = 32
+
+	msg := &descriptorpb.DescriptorProto{
+		Name: proto.String("object"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("name"), Number: proto.Int32(1), JsonName: proto.String("name")},
+			{Name: proto.String("desc"), Number: proto.Int32(2), JsonName: proto.String("desc")},
+		},
+		Options:       &descriptorpb.MessageOptions{Deprecated: proto.Bool(true)},
+		ReservedName:  []string{"old", "older"},
+		ReservedRange: []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(5), End: proto.Int32(6)}},
+	}
+	small := &descriptorpb.DescriptorProto{Name: proto.String("x")}
+
+	for _, spec := range []struct {
+		name      string
+		marshaler runtime.Marshaler
+		msg       proto.Message
+		streamed  bool
+	}{
+		{name: "large message", marshaler: &runtime.JSONPb{}, msg: msg, streamed: true},
+		{
+			name:      "proto names",
+			marshaler: &runtime.JSONPb{MarshalOptions: protojson.MarshalOptions{UseProtoNames: true}},
+			msg:       msg,
+			streamed:  true,
+		},
+		{name: "small message", marshaler: &runtime.JSONPb{}, msg: small},
+		{
+			name:      "emit unpopulated",
+			marshaler: &runtime.JSONPb{MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true}},
+			msg:       msg,
+		},
+		{
+			name:      "well known type",
+			marshaler: &runtime.JSONPb{},
+			msg:       structpb.NewStringValue(string(bytes.Repeat([]byte("a"), 128))),
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r, err := NewRequest(context.Background(), http.MethodPost, "/v1/object", spec.marshaler, spec.msg)
+			if err != nil {
+				t.Fatalf("NewRequest() failed with %v; want success", err)
+			}
+			if streamed := r.ContentLength == 0; streamed != spec.streamed {
+				t.Errorf("NewRequest() streamed = %v; want %v", streamed, spec.streamed)
+			}
+			if streamed := r.GetBody == nil; streamed != spec.streamed {
+				t.Errorf("NewRequest() without GetBody = %v; want %v", streamed, spec.streamed)
+			}
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("reading body failed with %v; want success", err)
+			}
+			got := spec.msg.ProtoReflect().New().Interface()
+			if err := spec.marshaler.Unmarshal(data, got); err != nil {
+				t.Fatalf("decoding %s failed with %v; want success", data, err)
+			}
+			if !proto.Equal(got, spec.msg) {
+				t.Errorf("body = %s; want encoding of %v", data, spec.msg)
+			}
+		})
+	}
+}
+
+func TestNewRequestStreamingAborted(t *testing.T) {
+	defer func(threshold int) { StreamingThreshold = threshold }(StreamingThreshold)
+	StreamingThreshold = 0
+
+	msg := &descriptorpb.FileDescriptorSet{}
+	for range 1000 {
+		msg.File = append(msg.File, &descriptorpb.FileDescriptorProto{Name: proto.String("file.proto")})
+	}
+	r, err := NewRequest(context.Background(), http.MethodPost, "/v1/object", &runtime.JSONPb{}, msg)
+	if err != nil {
+		t.Fatalf("NewRequest() failed with %v; want success", err)
+	}
+	// closing the body, as done by transports, must stop the encoder
+	buf := make([]byte, 16)
+	if _, err := r.Body.Read(buf); err != nil {
+		t.Fatalf("reading body failed with %v; want success", err)
+	}
+	if err := r.Body.Close(); err != nil {
+		t.Fatalf("closing body failed with %v; want success", err)
+	}
+}
+
+func TestNewRequestBodyField(t *testing.T) {
+	ctx := context.Background()
+	m := &runtime.JSONPb{}
+	tests := []struct {
+		name string
+		new  func() (*http.Request, error)
+		want string
+	}{
+		{
+			name: "unset message field",
+			new: func() (*http.Request, error) {
+				var msg *structpb.Struct
+				return NewRequest(ctx, http.MethodPut, "http://localhost/v1", m, msg)
+			},
+			want: "{}",
+		},
+		{
+			name: "scalar field",
+			new: func() (*http.Request, error) {
+				return NewValueRequest(ctx, http.MethodPut, "http://localhost/v1", m, "abc")
+			},
+			want: `"abc"`,
+		},
+		{
+			name: "repeated field",
+			new: func() (*http.Request, error) {
+				return NewValueRequest(ctx, http.MethodPut, "http://localhost/v1", m, []string{"a", "b"})
+			},
+			want: `["a","b"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.new()
+			if err != nil {
+				t.Fatalf("new request failed with %v; want success", err)
+			}
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if got := string(body); got != tt.want {
+				t.Errorf("body = %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrBulkheadFull is returned for the calls rejected while all the
+// slots of the bulkhead are in use
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+// BulkheadConfig describes the limits of a bulkhead
+type BulkheadConfig struct {
+	// MaxConcurrent is the number of the calls of the bulkhead allowed
+	// to be in flight at once, unlimited if not positive
+	MaxConcurrent int `yaml:"max_concurrent"`
+
+	// MaxWait is the duration a call waits for a slot to be released
+	// before failing with ErrBulkheadFull, the calls fail right away
+	// if zero
+	MaxWait time.Duration `yaml:"max_wait"`
+}
+
+// Bulkheads isolates the calls of the methods grouped into the named
+// bulkheads, bounding the concurrent calls of each, such that a slow
+// dependency can not exhaust the connections used by the rest. The
+// same instance is expected to be shared by the services of the client
+// using WithBulkheads, the calls of the bulkheads not configured are
+// not limited
+type Bulkheads struct {
+	groups map[string]*bulkhead
+}
+
+type bulkhead struct {
+	name    string
+	slots   chan struct{}
+	maxWait time.Duration
+}
+
+// NewBulkheads creates the bulkheads with the given limits keyed by the
+// name of the bulkhead, as used in the (api.bulkhead) option
+func NewBulkheads(limits map[string]BulkheadConfig) *Bulkheads {
+	b := &Bulkheads{groups: map[string]*bulkhead{}}
+	for name, cfg := range limits {
+		if cfg.MaxConcurrent <= 0 {
+			continue
+		}
+		b.groups[name] = &bulkhead{
+			name:    name,
+			slots:   make(chan struct{}, cfg.MaxConcurrent),
+			maxWait: cfg.MaxWait,
+		}
+	}
+	return b
+}
+
+// WithBulkheads isolates the calls of the service in the bulkheads, as
+// per the bulkhead of the call set using WithBulkhead
+func WithBulkheads(b *Bulkheads) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.bulkheads = b
+	}
+}
+
+// WithBulkhead sets the name of the bulkhead the call is isolated in,
+// empty name excludes the call from the bulkheads. The generated SDK
+// sets it for the methods marked using (api.bulkhead) option
+func WithBulkhead(name string) CallOption {
+	return func(o *callOptions) {
+		o.bulkhead = &name
+	}
+}
+
+// bulkheadKey is the context key carrying the bulkhead of the call
+type bulkheadKey struct{}
+
+// acquire reserves a slot in the bulkhead of the call, waiting for one
+// to be released up to the max wait, returning the function releasing
+// the slot
+func (b *Bulkheads) acquire(ctx context.Context) (func(), error) {
+	if b == nil {
+		return func() {}, nil
+	}
+	name, _ := ctx.Value(bulkheadKey{}).(string)
+	g, ok := b.groups[name]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case g.slots <- struct{}{}:
+		return g.release, nil
+	default:
+	}
+	if g.maxWait <= 0 {
+		return nil, fmt.Errorf("bulkhead %s: %w", g.name, ErrBulkheadFull)
+	}
+	t := time.NewTimer(g.maxWait)
+	defer t.Stop()
+	select {
+	case g.slots <- struct{}{}:
+		return g.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.C:
+		return nil, fmt.Errorf("bulkhead %s: %w", g.name, ErrBulkheadFull)
+	}
+}
+
+func (g *bulkhead) release() {
+	<-g.slots
+}
+
+// releaseOnClose holds the slot of the bulkhead till the body of the
+// response is closed, as the connection remains in use till then
+func releaseOnClose(resp *http.Response, err error, release func()) (*http.Response, error) {
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBulkheads(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-unblock
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	bulkheads := NewBulkheads(map[string]BulkheadConfig{
+		"reports": {MaxConcurrent: 1},
+		"billing": {MaxConcurrent: 1, MaxWait: 10 * time.Millisecond},
+	})
+	// bulkheads are shared by the services of the client
+	reports := NewServiceConfig("example.Reports", WithBulkheads(bulkheads))
+	other := NewServiceConfig("example.Other", WithBulkheads(bulkheads))
+	call := func(cfg *ServiceConfig, path string, opts ...CallOption) (*http.Response, error) {
+		r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest() failed with %v; want success", err)
+		}
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		return cfg.Do(c, r)
+	}
+
+	done := make(chan error)
+	go func() {
+		resp, err := call(reports, "/slow", WithBulkhead("reports"))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		done <- err
+	}()
+	// wait for the slow call to hold the slot of the bulkhead
+	for len(bulkheads.groups["reports"].slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := call(other, "/fast", WithBulkhead("reports")); !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("Do() in the full bulkhead = %v; want ErrBulkheadFull", err)
+	}
+	for _, opts := range [][]CallOption{nil, {WithBulkhead("billing")}, {WithBulkhead("unknown")}} {
+		resp, err := call(other, "/fast", opts...)
+		if err != nil {
+			t.Fatalf("Do() outside the full bulkhead failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if len(bulkheads.groups["billing"].slots) != 0 {
+		t.Errorf("slot of the billing bulkhead held after the body is closed")
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("Do() of the slow call failed with %v; want success", err)
+	}
+	resp, err := call(other, "/fast", WithBulkhead("reports"))
+	if err != nil {
+		t.Fatalf("Do() after the slot is released failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestBulkheadsMaxWait(t *testing.T) {
+	b := NewBulkheads(map[string]BulkheadConfig{"billing": {MaxConcurrent: 1, MaxWait: time.Minute}})
+	ctx := context.WithValue(context.Background(), bulkheadKey{}, "billing")
+	release, err := b.acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire() failed with %v; want success", err)
+	}
+
+	// waiting call gets the slot once released
+	acquired := make(chan error)
+	go func() {
+		release, err := b.acquire(ctx)
+		if err == nil {
+			release()
+		}
+		acquired <- err
+	}()
+	release()
+	if err := <-acquired; err != nil {
+		t.Errorf("acquire() waiting for the slot failed with %v; want success", err)
+	}
+
+	// waiting is bounded by the context of the call
+	release, _ = b.acquire(ctx)
+	defer release()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := b.acquire(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire() with canceled context = %v; want context.Canceled", err)
+	}
+}
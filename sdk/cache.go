@@ -0,0 +1,223 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache caches the successful responses of the GET methods invalidated
+// by the other methods as per the (api.invalidates) option, keyed by
+// the URL of the request and tagged with the operation id of the
+// method, such that the successful calls of the invalidating methods
+// drop them, see MemoryCache for a ready made implementation. Cached
+// responses are shared by the calls irrespective of the credentials,
+// a cache is expected to be used by the services of a single client
+type Cache interface {
+	// Get returns the response cached for the key
+	Get(key string) (*CachedResponse, bool)
+	// Add caches the response for the key, tagged with the tag
+	Add(key, tag string, resp *CachedResponse)
+	// Invalidate drops the responses tagged with any of the tags
+	Invalidate(tags ...string)
+}
+
+// CachedResponse is the response held by the cache
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// response returns the http response for the request served from the
+// cache
+func (c *CachedResponse) response(r *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.StatusCode, http.StatusText(c.StatusCode)),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       r,
+	}
+}
+
+// WithCache caches the responses of the GET methods of the service
+// invalidated by the other methods, the caller may bypass the cache for
+// a call using WithHeader("Cache-Control", "no-cache")
+func WithCache(c Cache) ServiceOption {
+	return func(cfg *ServiceConfig) {
+		cfg.cache = c
+	}
+}
+
+// WithCacheTag marks the call cacheable, tagging the cached response
+// with the tag. The generated SDK sets it for the GET methods
+// invalidated by the other methods, with their operation ids
+func WithCacheTag(tag string) CallOption {
+	return func(o *callOptions) {
+		o.cacheTag = &tag
+	}
+}
+
+// WithInvalidation invalidates the cached responses tagged with any of
+// the tags once the call succeeds. The generated SDK sets it for the
+// methods marked using (api.invalidates)
+func WithInvalidation(tags ...string) CallOption {
+	return func(o *callOptions) {
+		o.invalidates = append(o.invalidates, tags...)
+	}
+}
+
+// cacheTagKey is the context key carrying the cache tag of the call
+type cacheTagKey struct{}
+
+// invalidatesKey is the context key carrying the tags invalidated by
+// the call
+type invalidatesKey struct{}
+
+// cached serves the cacheable calls from the cache, caching the
+// successful responses, and invalidates the tags of the successful
+// invalidating calls
+func (c *ServiceConfig) cached(client Doer, r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	if tags, _ := ctx.Value(invalidatesKey{}).([]string); len(tags) != 0 {
+		resp, err := c.isolate(client, r)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			c.cache.Invalidate(tags...)
+		}
+		return resp, err
+	}
+	tag, _ := ctx.Value(cacheTagKey{}).(string)
+	if tag == "" || r.Method != http.MethodGet || r.Header.Get("Cache-Control") == "no-cache" {
+		return c.isolate(client, r)
+	}
+	key := r.URL.String()
+	if cached, ok := c.cache.Get(key); ok {
+		return cached.response(r), nil
+	}
+	resp, err := c.isolate(client, r)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(key, tag, &CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// MemoryCache implements Cache in memory, holding the responses up to
+// the TTL while evicting the least recently used ones beyond the max
+// entries, safe for concurrent use
+//
+//	cache := sdk.NewMemoryCache(time.Minute, 1000)
+//	svc := example.NewHelloWorldService(client, sdk.WithCache(cache))
+type MemoryCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	tags    map[string]map[string]struct{}
+}
+
+type cacheEntry struct {
+	key     string
+	tag     string
+	resp    *CachedResponse
+	expires time.Time
+}
+
+// NewMemoryCache creates the cache holding the responses up to the TTL,
+// forever if zero, evicting the least recently used responses beyond
+// the max entries, unbounded if zero
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+		tags:       map[string]map[string]struct{}{},
+	}
+}
+
+// Get returns the response cached for the key unless expired
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*cacheEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return e.resp, true
+}
+
+// Add caches the response for the key, tagged with the tag
+func (c *MemoryCache) Add(key, tag string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	e := &cacheEntry{key: key, tag: tag, resp: resp}
+	if c.ttl > 0 {
+		e.expires = time.Now().Add(c.ttl)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	if c.tags[tag] == nil {
+		c.tags[tag] = map[string]struct{}{}
+	}
+	c.tags[tag][key] = struct{}{}
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Invalidate drops the responses tagged with any of the tags
+func (c *MemoryCache) Invalidate(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			c.remove(c.entries[key])
+		}
+	}
+}
+
+// Len returns the number of the cached responses, including the expired
+// ones not evicted yet
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *MemoryCache) remove(elem *list.Element) {
+	e := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, e.key)
+	delete(c.tags[e.tag], e.key)
+	if len(c.tags[e.tag]) == 0 {
+		delete(c.tags, e.tag)
+	}
+}
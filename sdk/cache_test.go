@@ -0,0 +1,120 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var version, gets atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets.Add(1)
+			if r.URL.Path == "/v1/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, "%s@%d", r.URL.Path, version.Load())
+		case http.MethodPatch:
+			if r.URL.Path == "/v1/fail" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			version.Add(1)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	cache := NewMemoryCache(0, 0)
+	cfg := NewServiceConfig("example.Objects", WithCache(cache))
+	call := func(method, path string, opts ...CallOption) (int, string) {
+		t.Helper()
+		r, err := http.NewRequestWithContext(context.Background(), method, path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest() failed with %v; want success", err)
+		}
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := cfg.Do(c, r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+	get := WithCacheTag("Objects_GetObject")
+
+	for i := 0; i < 2; i++ {
+		if code, body := call(http.MethodGet, "/v1/objects/a", get); code != http.StatusOK || body != "/v1/objects/a@0" {
+			t.Fatalf("Get() = %d %s; want 200 /v1/objects/a@0", code, body)
+		}
+	}
+	if n := gets.Load(); n != 1 {
+		t.Errorf("server got %d GET requests; want 1 served from the cache", n)
+	}
+
+	// untagged calls, errors and the no-cache requests are not cached
+	call(http.MethodGet, "/v1/objects/a")
+	call(http.MethodGet, "/v1/missing", get)
+	call(http.MethodGet, "/v1/missing", get)
+	call(http.MethodGet, "/v1/objects/a", get, WithHeader("Cache-Control", "no-cache"))
+	if n := gets.Load(); n != 5 {
+		t.Errorf("server got %d GET requests; want 5", n)
+	}
+
+	// failing mutations keep the cache, successful ones invalidate it
+	call(http.MethodPatch, "/v1/fail", WithInvalidation("Objects_GetObject"))
+	if _, body := call(http.MethodGet, "/v1/objects/a", get); body != "/v1/objects/a@0" {
+		t.Errorf("Get() after the failed update = %s; want the cached /v1/objects/a@0", body)
+	}
+	call(http.MethodPatch, "/v1/objects/a", WithInvalidation("Objects_ListObjects", "Objects_GetObject"))
+	if cache.Len() != 0 {
+		t.Errorf("cache holds %d responses after the update; want 0", cache.Len())
+	}
+	if _, body := call(http.MethodGet, "/v1/objects/a", get); body != "/v1/objects/a@1" {
+		t.Errorf("Get() after the update = %s; want /v1/objects/a@1", body)
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	resp := func(body string) *CachedResponse {
+		return &CachedResponse{StatusCode: http.StatusOK, Body: []byte(body)}
+	}
+	c := NewMemoryCache(0, 2)
+	c.Add("/a", "get", resp("a"))
+	c.Add("/b", "list", resp("b"))
+	// touch /a so that /b is the least recently used
+	if got, ok := c.Get("/a"); !ok || string(got.Body) != "a" {
+		t.Fatalf("Get(/a) = %v, %t; want a", got, ok)
+	}
+	c.Add("/c", "get", resp("c"))
+	if _, ok := c.Get("/b"); ok {
+		t.Errorf("Get(/b) beyond the max entries found; want evicted")
+	}
+	c.Invalidate("get")
+	if c.Len() != 0 {
+		t.Errorf("Len() after invalidating = %d; want 0", c.Len())
+	}
+
+	c = NewMemoryCache(time.Millisecond, 0)
+	c.Add("/a", "get", resp("a"))
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("/a"); ok {
+		t.Errorf("Get(/a) beyond the ttl found; want expired")
+	}
+}
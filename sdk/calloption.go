@@ -0,0 +1,208 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DefaultLongPollTimeout is the timeout of the long polling calls, used
+// when not provided explicitly
+const DefaultLongPollTimeout = 5 * time.Minute
+
+// CallOption customizes a single call of the unary methods of the
+// generated SDK
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	header         http.Header
+	timeout        time.Duration
+	defaultTimeout time.Duration
+	retry          *RetryConfig
+	retrySafe      *bool
+	longPoll       bool
+	idempotencyKey *string
+	bulkhead       *string
+	cacheTag       *string
+	invalidates    []string
+	redaction      *Redaction
+	progress       ProgressFunc
+}
+
+// WithHeader sets the header on the request of the call, overriding
+// the value set by the SDK if any
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithTimeout bounds the call, including the retries, by the given
+// timeout in addition to the deadline of the context
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithDefaultTimeout bounds the call, including the retries, by the
+// given timeout when the context has no deadline, unless bounded using
+// WithTimeout. The generated SDK sets it for the methods annotated
+// using (api.timeout)
+func WithDefaultTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.defaultTimeout = timeout
+	}
+}
+
+// WithRetry overrides the retry policy configured for the client for
+// the call, zero value disables the retries
+func WithRetry(cfg RetryConfig) CallOption {
+	return func(o *callOptions) {
+		o.retry = &cfg
+	}
+}
+
+// WithRetrySafe classifies the call safe or unsafe to be retried,
+// overriding the classification based on the HTTP method of the
+// request. The generated SDK sets it as per the verb of the role and
+// the idempotency_level option of the method
+func WithRetrySafe(safe bool) CallOption {
+	return func(o *callOptions) {
+		o.retrySafe = &safe
+	}
+}
+
+// WithIdempotencyKey sets the IdempotencyKeyHeader on the request of
+// the call unless already set, using a random UUID if the key is empty,
+// such that all the attempts of the call carry the same key. The call
+// is classified safe to be retried, as the server is expected to
+// deduplicate the attempts using the key. The generated SDK sets it for
+// the POST and PATCH methods having the (api.retry) policy
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		safe := true
+		o.idempotencyKey = &key
+		o.retrySafe = &safe
+	}
+}
+
+// retrySafeKey is the context key carrying the retry classification
+// of the call
+type retrySafeKey struct{}
+
+// isRetrySafe reports whether the request is safe to be retried, as
+// classified for the call if any, based on the HTTP method otherwise
+func isRetrySafe(req *http.Request) bool {
+	if safe, ok := req.Context().Value(retrySafeKey{}).(bool); ok {
+		return safe
+	}
+	return isIdempotent(req.Method)
+}
+
+// WithLongPoll marks the call as long polling, waiting for the server
+// beyond the timeout configured for the client, bounded by the given
+// timeout instead, DefaultLongPollTimeout if not positive. The
+// generated SDK sets it for the methods marked using (api.long_poll)
+func WithLongPoll(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		if timeout <= 0 {
+			timeout = DefaultLongPollTimeout
+		}
+		o.longPoll = true
+		o.timeout = timeout
+	}
+}
+
+// longPollKey is the context key marking the long polling calls
+type longPollKey struct{}
+
+// isLongPoll reports whether the context belongs to a long polling call
+func isLongPoll(ctx context.Context) bool {
+	longPoll, _ := ctx.Value(longPollKey{}).(bool)
+	return longPoll
+}
+
+// retryKey is the context key carrying the retry policy of the call
+type retryKey struct{}
+
+// retryConfig returns the retry policy of the call carried by the
+// context, the configured one otherwise, falling back to
+// DefaultRetryConfig for the calls classified safe to be retried
+func retryConfig(ctx context.Context, cfg RetryConfig) RetryConfig {
+	if retry, ok := ctx.Value(retryKey{}).(*RetryConfig); ok {
+		return *retry
+	}
+	if safe, _ := ctx.Value(retrySafeKey{}).(bool); safe && cfg.MaxAttempts == 0 {
+		return DefaultRetryConfig
+	}
+	return cfg
+}
+
+// ApplyCallOptions applies the call options to the request, preceded by
+// the defaults carried by the context of the request set using
+// WithDefaults, returning the request to be sent along with the function
+// releasing the resources of the call, to be called once the response is
+// consumed
+func ApplyCallOptions(r *http.Request, opts ...CallOption) (*http.Request, context.CancelFunc) {
+	opts = withDefaults(r.Context(), opts)
+	if len(opts) == 0 {
+		return r, func() {}
+	}
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	for key, values := range o.header {
+		r.Header[key] = values
+	}
+	if o.idempotencyKey != nil && r.Header.Get(IdempotencyKeyHeader) == "" {
+		key := *o.idempotencyKey
+		if key == "" {
+			key = newIdempotencyKey()
+		}
+		r.Header.Set(IdempotencyKeyHeader, key)
+	}
+	ctx, cancel := r.Context(), context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	} else if _, ok := ctx.Deadline(); !ok && o.defaultTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.defaultTimeout)
+	}
+	if o.retry != nil {
+		ctx = context.WithValue(ctx, retryKey{}, o.retry)
+	}
+	if o.retrySafe != nil {
+		ctx = context.WithValue(ctx, retrySafeKey{}, *o.retrySafe)
+	}
+	if o.bulkhead != nil {
+		ctx = context.WithValue(ctx, bulkheadKey{}, *o.bulkhead)
+	}
+	if o.cacheTag != nil {
+		ctx = context.WithValue(ctx, cacheTagKey{}, *o.cacheTag)
+	}
+	if len(o.invalidates) != 0 {
+		ctx = context.WithValue(ctx, invalidatesKey{}, o.invalidates)
+	}
+	if o.redaction != nil {
+		ctx = context.WithValue(ctx, redactionKey{}, o.redaction)
+	}
+	if o.progress != nil {
+		trackUpload(r, o.progress)
+		ctx = context.WithValue(ctx, progressKey{}, o.progress)
+	}
+	if o.longPoll {
+		// avoid the intermediate caches holding the response
+		if r.Header.Get("Cache-Control") == "" {
+			r.Header.Set("Cache-Control", "no-cache")
+		}
+		ctx = context.WithValue(ctx, longPollKey{}, true)
+	}
+	return r.WithContext(ctx), cancel
+}
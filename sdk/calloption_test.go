@@ -0,0 +1,218 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestApplyCallOptions(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		case "/flaky":
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Header().Set("X-Echo", r.Header.Get("X-Request-Id"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func(path string, opts ...CallOption) (*http.Response, error) {
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := c.Do(r)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	resp, err := do("/echo", WithHeader("X-Request-Id", "abc"))
+	if err != nil || resp.Header.Get("X-Echo") != "abc" {
+		t.Errorf("call with header = %v, %v; want echoed header", resp, err)
+	}
+
+	if _, err := do("/slow", WithTimeout(50*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call with timeout = %v; want deadline exceeded", err)
+	}
+
+	// retries are disabled for the client, enabled only for the call
+	resp, err = do("/flaky", WithRetry(RetryConfig{MaxAttempts: 2, Backoff: time.Millisecond}))
+	if err != nil || resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("call with retry = %v, %v after %d attempts; want success after 2", resp, err, calls.Load())
+	}
+}
+
+func TestDefaultTimeoutCallOption(t *testing.T) {
+	deadline := func(ctx context.Context, opts ...CallOption) time.Duration {
+		t.Helper()
+		r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/objects", nil)
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		d, ok := r.Context().Deadline()
+		if !ok {
+			return 0
+		}
+		return time.Until(d).Round(time.Second)
+	}
+	if got := deadline(context.Background(), WithDefaultTimeout(time.Minute)); got != time.Minute {
+		t.Errorf("deadline with default timeout = %s; want 1m", got)
+	}
+	// the deadline of the caller and the explicit timeout take precedence
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	if got := deadline(ctx, WithDefaultTimeout(time.Minute)); got != time.Hour {
+		t.Errorf("deadline with default timeout under the deadline of the caller = %s; want 1h", got)
+	}
+	if got := deadline(context.Background(), WithDefaultTimeout(time.Minute), WithTimeout(time.Hour)); got != time.Hour {
+		t.Errorf("deadline with default and explicit timeouts = %s; want 1h", got)
+	}
+}
+
+func TestLongPollCallOption(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("X-Cache-Control", r.Header.Get("Cache-Control"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL, Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func(opts ...CallOption) (*http.Response, error) {
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/watch", nil)
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := c.Do(r)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	if _, err := do(); err == nil {
+		t.Errorf("call exceeding the client timeout succeeded; want error")
+	}
+
+	resp, err := do(WithLongPoll(time.Second))
+	if err != nil {
+		t.Fatalf("long polling call failed with %v; want success", err)
+	}
+	if got := resp.Header.Get("X-Cache-Control"); got != "no-cache" {
+		t.Errorf("long polling call sent Cache-Control %q; want no-cache", got)
+	}
+
+	if _, err := do(WithLongPoll(50 * time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("long polling call exceeding its timeout = %v; want deadline exceeded", err)
+	}
+}
+
+func TestRetrySafeCallOption(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func(method string, opts ...CallOption) int32 {
+		calls.Store(0)
+		r, _ := http.NewRequestWithContext(context.Background(), method, "/object", nil)
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := c.Do(r)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return calls.Load()
+	}
+
+	defaultRetry := DefaultRetryConfig
+	DefaultRetryConfig = RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond}
+	defer func() { DefaultRetryConfig = defaultRetry }()
+
+	// unclassified calls are not retried without the retry policy
+	if got := do(http.MethodGet); got != 1 {
+		t.Errorf("unclassified call made %d attempts; want 1", got)
+	}
+	// calls classified safe fall back to the default retry policy
+	if got := do(http.MethodPost, WithRetrySafe(true)); got != 3 {
+		t.Errorf("safe call made %d attempts; want 3", got)
+	}
+	// classification overrides the idempotency of the HTTP method
+	if got := do(http.MethodPut, WithRetrySafe(false), WithRetry(RetryConfig{MaxAttempts: 3})); got != 1 {
+		t.Errorf("unsafe call made %d attempts; want 1", got)
+	}
+	// retries disabled explicitly for the call
+	if got := do(http.MethodGet, WithRetrySafe(true), WithRetry(RetryConfig{})); got != 1 {
+		t.Errorf("safe call with retries disabled made %d attempts; want 1", got)
+	}
+}
+
+func TestIdempotencyKeyCallOption(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func(opts ...CallOption) []string {
+		keys = nil
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/objects", nil)
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := c.Do(r)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return keys
+	}
+	retry := WithRetry(RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	// unsafe call carrying the key is retried with the same key
+	first := do(WithRetrySafe(false), WithIdempotencyKey(""), retry)
+	if len(first) != 3 || first[0] == "" || first[1] != first[0] || first[2] != first[0] {
+		t.Fatalf("attempts sent keys %q; want 3 attempts with the same key", first)
+	}
+	// every logical call gets a new key
+	if second := do(WithIdempotencyKey(""), retry); len(second) != 3 || second[0] == first[0] {
+		t.Errorf("second call sent keys %q; want a new key", second)
+	}
+	// key provided by the caller is retained
+	if got := do(WithIdempotencyKey(""), WithHeader(IdempotencyKeyHeader, "k1"), retry); len(got) != 3 || got[0] != "k1" {
+		t.Errorf("call sent keys %q; want the key provided", got)
+	}
+	if got := do(WithIdempotencyKey("k2"), WithRetrySafe(false), retry); len(got) != 1 || got[0] != "k2" {
+		t.Errorf("call classified unsafe by the caller sent keys %q; want a single attempt with k2", got)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+)
+
+const (
+	// CanaryHeader is the header steering the requests to the canary of
+	// the backend, as per the canary-by-header convention of the ingress
+	// controllers and the service meshes
+	CanaryHeader = "X-Canary"
+
+	// CanaryValue is the value of the header set for the requests routed
+	// to the canary unless configured otherwise
+	CanaryValue = "always"
+)
+
+// CanaryConfig configures the client side canary of the backend, steering
+// the calls of the cohort to the new version of the backend using the
+// canary header, set for the percentage of the identities, such that an
+// identity consistently reaches the same version across the calls
+type CanaryConfig struct {
+	// Percent is the percentage of the identities in the canary cohort,
+	// from 0 for none to 100 for all
+	Percent float64
+
+	// Identity returns the identity the cohort is chosen by, like the
+	// claim of the token using CanaryClaim, the calls without it are
+	// routed to the canary independently as per the percentage. All
+	// the calls are routed independently if nil
+	Identity func(r *http.Request) string
+
+	// Salt reshuffles the identities across the cohorts, such that a
+	// new rollout starts with a different set of the identities
+	Salt string
+
+	// Header is the header set for the calls of the cohort,
+	// CanaryHeader if empty
+	Header string
+
+	// Value is the value of the header, CanaryValue if empty
+	Value string
+}
+
+// WithCanary routes the calls of the service to the canary of the backend
+// as per the config, set across the services of the product when
+// provided to the umbrella client. Calls carrying the header already,
+// as set by the caller, are left as is
+//
+//	client := example.NewDemoClient(doer, sdk.WithCanary(sdk.CanaryConfig{
+//		Percent:  5,
+//		Identity: sdk.CanaryClaim("sub"),
+//	}))
+func WithCanary(cfg CanaryConfig) ServiceOption {
+	if cfg.Header == "" {
+		cfg.Header = CanaryHeader
+	}
+	if cfg.Value == "" {
+		cfg.Value = CanaryValue
+	}
+	return WithInterceptors(func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+		if r.Header.Get(cfg.Header) == "" && cfg.inCohort(r) {
+			r.Header.Set(cfg.Header, cfg.Value)
+		}
+		return next(ctx, r)
+	})
+}
+
+// inCohort reports whether the call is routed to the canary
+func (c *CanaryConfig) inCohort(r *http.Request) bool {
+	if c.Percent <= 0 {
+		return false
+	}
+	if c.Percent >= 100 {
+		return true
+	}
+	var id string
+	if c.Identity != nil {
+		id = c.Identity(r)
+	}
+	if id == "" {
+		return rand.Float64()*100 < c.Percent
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(c.Salt))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(id))
+	// buckets of a hundredth of a percent
+	return float64(h.Sum64()%10000)/100 < c.Percent
+}
+
+// CanaryClaim returns the identity of the canary cohort as the claim of
+// the bearer token of the request, like sub or tenant. The token is
+// decoded without being verified, which is left to the server, empty
+// if the request carries no such token or the claim
+func CanaryClaim(name string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return ""
+		}
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return ""
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return ""
+		}
+		var claims map[string]any
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return ""
+		}
+		switch v := claims[name].(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWithCanary(t *testing.T) {
+	var header string
+	client := DoerFunc(func(r *http.Request) (*http.Response, error) {
+		header = r.Header.Get(CanaryHeader)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	token := func(sub string) string {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":%q}`, sub)))
+		return "Bearer e30." + payload + ".sig"
+	}
+	send := func(cfg *ServiceConfig, auth string) string {
+		t.Helper()
+		header = ""
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/objects", nil)
+		if auth != "" {
+			r.Header.Set("Authorization", auth)
+		}
+		if _, err := cfg.Do(client, r); err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		return header
+	}
+
+	cfg := NewServiceConfig("example.Objects", WithCanary(CanaryConfig{Percent: 30, Identity: CanaryClaim("sub")}))
+	canaries := 0
+	for i := range 1000 {
+		auth := token(fmt.Sprintf("user-%d", i))
+		got := send(cfg, auth)
+		// identities are routed consistently
+		for range 3 {
+			if again := send(cfg, auth); again != got {
+				t.Fatalf("canary of user-%d = %q then %q; want sticky", i, got, again)
+			}
+		}
+		switch got {
+		case CanaryValue:
+			canaries++
+		case "":
+		default:
+			t.Fatalf("canary header = %q; want %q or none", got, CanaryValue)
+		}
+	}
+	if canaries < 250 || canaries > 350 {
+		t.Errorf("canary cohort has %d of 1000 identities; want around 300", canaries)
+	}
+
+	for _, spec := range []struct {
+		cfg  CanaryConfig
+		want string
+	}{
+		{cfg: CanaryConfig{Percent: 0}},
+		{cfg: CanaryConfig{Percent: 100}, want: CanaryValue},
+		{cfg: CanaryConfig{Percent: 100, Value: "v2"}, want: "v2"},
+	} {
+		if got := send(NewServiceConfig("example.Objects", WithCanary(spec.cfg)), ""); got != spec.want {
+			t.Errorf("canary header with %+v = %q; want %q", spec.cfg, got, spec.want)
+		}
+	}
+
+	if got := CanaryClaim("tenant")(&http.Request{Header: http.Header{"Authorization": {token("abc")}}}); got != "" {
+		t.Errorf("CanaryClaim(tenant) without the claim = %q; want empty", got)
+	}
+}
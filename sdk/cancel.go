@@ -0,0 +1,140 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// CallIDHeader carries the random id of the call, used by the
+	// cancel notification to identify the call being canceled
+	CallIDHeader = "X-Call-Id"
+	// CancelReasonHeader carries the reason of the cancel notification
+	CancelReasonHeader = "X-Cancel-Reason"
+	// CancelPath is the path, relative to the root of the endpoint, the
+	// cancel notifications are sent to, served by routes.WithCancellation
+	CancelPath = "/.well-known/cancel"
+)
+
+// CancelReason is the reason of the cancellation of a call, conveyed to
+// the server by the cancel notification
+type CancelReason string
+
+const (
+	// CancelUserAbort is the call aborted by the user, canceled using
+	// ErrUserAbort as the cause
+	CancelUserAbort CancelReason = "user_abort"
+	// CancelDeadline is the call exceeding the deadline of the context
+	CancelDeadline CancelReason = "deadline"
+	// CancelShutdown is the call canceled since the client is shutting
+	// down, canceled using ErrShutdown as the cause
+	CancelShutdown CancelReason = "shutdown"
+	// CancelUnknown is the call canceled for any other reason
+	CancelUnknown CancelReason = "canceled"
+)
+
+var (
+	// ErrUserAbort is the cause of the contexts canceled on the abort by
+	// the user, like Ctrl+C in a CLI
+	//
+	//	ctx, cancel := context.WithCancelCause(ctx)
+	//	defer cancel(nil)
+	//	go func() { <-interrupted; cancel(sdk.ErrUserAbort) }()
+	ErrUserAbort = errors.New("sdk: aborted by the user")
+	// ErrShutdown is the cause of the contexts canceled while the client
+	// is shutting down
+	ErrShutdown = errors.New("sdk: client shutting down")
+)
+
+// CancelNotifyTimeout bounds the cancel notification, delaying the
+// abort of the call at most by it
+var CancelNotifyTimeout = time.Second
+
+// CancelReasonOf returns the reason of the cancellation of the context,
+// as per the cause it is canceled with, empty if not canceled
+func CancelReasonOf(ctx context.Context) CancelReason {
+	if ctx.Err() == nil {
+		return ""
+	}
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, ErrUserAbort):
+		return CancelUserAbort
+	case errors.Is(cause, ErrShutdown):
+		return CancelShutdown
+	case errors.Is(cause, context.DeadlineExceeded):
+		return CancelDeadline
+	}
+	return CancelUnknown
+}
+
+// WithCancelNotifications sends the best-effort cancel notification to
+// the server for the calls canceled by the context, carrying the reason
+// of the cancellation, before aborting the request. Servers wrapping
+// the mux using routes.WithCancellation observe the reason using
+// routes.CancelReason in the context of the handler, distinguishing the
+// aborts by the clients from the network failures, where no notification
+// is received
+func WithCancelNotifications() ServiceOption {
+	return func(c *ServiceConfig) {
+		c.cancelNotify = true
+	}
+}
+
+// notifyCancel sends the request with the call id, notifying the server
+// with the reason before aborting it once the context of the request is
+// canceled, the abort is delayed till the notification is sent
+func notifyCancel(client Doer, r *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	id := r.Header.Get(CallIDHeader)
+	if id == "" {
+		id = newIdempotencyKey()
+		r.Header.Set(CallIDHeader, id)
+	}
+	parent := r.Context()
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(parent))
+	stop := context.AfterFunc(parent, func() {
+		sendCancelNotification(client, r.URL, id, CancelReasonOf(parent))
+		cancel(context.Cause(parent))
+	})
+	release := func() {
+		stop()
+		cancel(nil)
+	}
+	resp, err := send(r.WithContext(ctx))
+	if err != nil && parent.Err() != nil {
+		// same error as if the call was aborted right away
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.Err = parent.Err()
+		} else {
+			err = parent.Err()
+		}
+	}
+	return releaseOnClose(resp, err, release)
+}
+
+// sendCancelNotification notifies the server about the call canceled for
+// the reason, ignoring the failures
+func sendCancelNotification(client Doer, u *url.URL, id string, reason CancelReason) {
+	ctx, cancel := context.WithTimeout(context.Background(), CancelNotifyTimeout)
+	defer cancel()
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, u.ResolveReference(&url.URL{Path: CancelPath}).String(), nil)
+	if err != nil {
+		return
+	}
+	r.Header.Set(CallIDHeader, id)
+	r.Header.Set(CancelReasonHeader, string(reason))
+	resp, err := client.Do(r)
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
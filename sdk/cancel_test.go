@@ -0,0 +1,94 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCancelReasonOf(t *testing.T) {
+	if got := CancelReasonOf(context.Background()); got != "" {
+		t.Errorf("CancelReasonOf() not canceled = %q; want empty", got)
+	}
+	for cause, want := range map[error]CancelReason{
+		ErrUserAbort:                 CancelUserAbort,
+		ErrShutdown:                  CancelShutdown,
+		context.DeadlineExceeded:     CancelDeadline,
+		errors.New("something else"): CancelUnknown,
+	} {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		if got := CancelReasonOf(ctx); got != want {
+			t.Errorf("CancelReasonOf() canceled with %v = %q; want %q", cause, got, want)
+		}
+	}
+}
+
+func TestWithCancelNotifications(t *testing.T) {
+	type notification struct {
+		id, reason string
+	}
+	started := make(chan string, 1)
+	notified := make(chan notification, 1)
+	// aborted reports whether the call was aborted before the
+	// notification is received
+	aborted := make(chan bool, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == CancelPath {
+			notified <- notification{id: r.Header.Get(CallIDHeader), reason: r.Header.Get(CancelReasonHeader)}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		started <- r.Header.Get(CallIDHeader)
+		select {
+		case <-r.Context().Done():
+			aborted <- true
+		case n := <-notified:
+			notified <- n
+			aborted <- false
+			// aborted once notified
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	cfg := NewServiceConfig("example.Objects", WithCancelNotifications())
+	ctx, cancel := context.WithCancelCause(context.Background())
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v1/objects", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() failed with %v; want success", err)
+	}
+	go func() {
+		<-started
+		cancel(ErrUserAbort)
+	}()
+	if _, err := cfg.Do(srv.Client(), r); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() canceled failed with %v; want context.Canceled", err)
+	}
+	if <-aborted {
+		t.Errorf("Do() canceled aborted the call before the notification")
+	}
+	n := <-notified
+	if n.id == "" || n.id != r.Header.Get(CallIDHeader) || n.reason != string(CancelUserAbort) {
+		t.Errorf("Do() canceled notified %+v; want call id %q with %q", n, r.Header.Get(CallIDHeader), CancelUserAbort)
+	}
+
+	// deadline of the caller is retained
+	ctx, stop := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer stop()
+	r, _ = http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v1/objects", nil)
+	go func() { <-started }()
+	if _, err := cfg.Do(srv.Client(), r); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() past the deadline failed with %v; want context.DeadlineExceeded", err)
+	}
+	if n := <-notified; n.reason != string(CancelDeadline) {
+		t.Errorf("Do() past the deadline notified %q; want %q", n.reason, CancelDeadline)
+	}
+	<-aborted
+}
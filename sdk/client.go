@@ -0,0 +1,313 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-core-stack/auth/hash"
+)
+
+// client triggers the requests generated by the SDK against the
+// configured endpoint, taking care of authentication and retries
+type client struct {
+	endpoint *url.URL
+	set      *endpointSet
+	client   *http.Client
+	longPoll *http.Client
+	signer   hash.Generator
+	retry    RetryConfig
+}
+
+// NewClient creates a client to be used with the generated SDK
+// wrappers based on the provided config, safe for concurrent use and
+// meant to be shared across the wrappers
+func NewClient(cfg *Config, opts ...Option) (Doer, error) {
+	o := newOptions(opts)
+	profile := o.profile
+	// the environment and the config only select among the profiles
+	// configured, leaving the configs without profiles as is
+	if profile == "" && len(cfg.Profiles) != 0 {
+		profile = os.Getenv(ProfileEnv)
+		if profile == "" {
+			profile = cfg.Profile
+		}
+	}
+	if profile != "" {
+		var err error
+		cfg, err = cfg.ForProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c := &client{retry: cfg.Retry}
+	var socket string
+	if o.resolver != nil {
+		if err := cfg.validateSettings(); err != nil {
+			return nil, err
+		}
+		c.set = &endpointSet{resolver: o.resolver, refresh: o.refresh}
+		if c.set.refresh <= 0 {
+			c.set.refresh = DefaultRefreshInterval
+		}
+	} else {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		endpoint, err := url.Parse(cfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+		}
+		c.endpoint = endpoint
+		if endpoint.Scheme == UnixScheme {
+			socket, err = unixSocket(endpoint)
+			if err != nil {
+				return nil, err
+			}
+			c.endpoint = unixEndpoint
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.dial != nil {
+		transport.DialContext = o.dial
+	}
+	if o.dualStack {
+		dialer := newHappyEyeballsDialer(o.dialDelay)
+		if o.dial != nil {
+			dialer.dial = o.dial
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if cfg.Proxy != "" {
+		proxy, err := parseProxy(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if o.proxy != nil {
+		transport.Proxy = nil
+		if *o.proxy != "" {
+			proxy, err := parseProxy(*o.proxy)
+			if err != nil {
+				return nil, err
+			}
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+	if socket != "" {
+		// connected to the socket directly, bypassing the proxies
+		transport.Proxy = nil
+		transport.DialContext = dialUnix(socket)
+	}
+	if o.transport != nil {
+		o.transport.apply(transport)
+	}
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	c.client = &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	}
+	if c.set != nil && o.probePath != "" {
+		c.set.probe = &healthProbe{
+			path:     o.probePath,
+			interval: o.probeEvery,
+			client:   c.client,
+		}
+		if c.set.probe.interval <= 0 {
+			c.set.probe.interval = DefaultProbeInterval
+		}
+	}
+	if cfg.Auth.Mode == AuthModeHMAC {
+		c.signer = hash.NewGenerator(cfg.Auth.APIKey, cfg.Auth.Secret)
+	}
+	redirect := DefaultRedirectPolicy
+	if o.redirect != nil {
+		redirect = *o.redirect
+	}
+	c.client.CheckRedirect = redirect.checkRedirect(c.signer)
+	// long polling calls are bounded by the timeout of the call instead
+	longPoll := *c.client
+	longPoll.Timeout = 0
+	c.longPoll = &longPoll
+	return c, nil
+}
+
+// parseProxy parses the URL of the proxy, supported by the transport
+func parseProxy(rawURL string) (*url.URL, error) {
+	proxy, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", rawURL, err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q: unsupported scheme %q", rawURL, proxy.Scheme)
+	}
+	return proxy, nil
+}
+
+// newTLSConfig builds the TLS config for the transport, returns nil
+// to use the defaults when nothing is customized
+func newTLSConfig(cfg *Config) (*tls.Config, error) {
+	if !cfg.Insecure && cfg.TLS == (TLSConfig{}) {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.Insecure,
+		ServerName:         cfg.TLS.ServerName,
+	}
+	if cfg.TLS.CAFile != "" {
+		data, err := os.ReadFile(cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", cfg.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.TLS.CertFile != "" || cfg.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// resolve joins the request uri, generated by the SDK relative to
+// the server root, with the endpoint
+func resolve(endpoint, u *url.URL) *url.URL {
+	if u.Host != "" {
+		return u
+	}
+	resolved := *endpoint
+	prefix := strings.TrimSuffix(endpoint.Path, "/")
+	resolved.Path = prefix + u.Path
+	if u.RawPath != "" {
+		resolved.RawPath = strings.TrimSuffix(endpoint.EscapedPath(), "/") + u.RawPath
+	} else {
+		resolved.RawPath = ""
+	}
+	resolved.RawQuery = u.RawQuery
+	return &resolved
+}
+
+// Do sends the request to the configured endpoint, retrying the
+// requests safe to be retried, as classified using WithRetrySafe or by
+// the HTTP method otherwise, as per the retry policy of the call if
+// provided using WithRetry, the configured one otherwise
+func (c *client) Do(req *http.Request) (*http.Response, error) {
+	retry := retryConfig(req.Context(), c.retry)
+	attempts := max(retry.MaxAttempts, 1)
+	if !isRetrySafe(req) || (req.Body != nil && req.GetBody == nil) {
+		attempts = 1
+	}
+
+	backoff := retry.Backoff
+	if backoff <= 0 {
+		// never retry back to back, hammering the failing server
+		backoff = DefaultRetryConfig.Backoff
+	}
+	for attempt := 1; ; attempt++ {
+		r := req.Clone(req.Context())
+		endpoint := c.endpoint
+		if c.set != nil {
+			// pick the endpoint for every attempt to fail over
+			// across the discovered endpoints
+			var err error
+			endpoint, err = c.set.pick(req.Context())
+			if err != nil {
+				return nil, err
+			}
+		}
+		r.URL = resolve(endpoint, req.URL)
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+		if c.signer != nil {
+			r = c.signer.AddAuthHeaders(r)
+		}
+		resp, err := c.httpClient(req.Context()).Do(r)
+		if err != nil && c.set != nil && req.Context().Err() == nil {
+			c.set.markUnhealthy(endpoint)
+		}
+		if retry.MaxBackoff > 0 {
+			backoff = min(backoff, retry.MaxBackoff)
+		}
+		// skip the retry that can not complete before the deadline,
+		// returning the outcome of the last attempt instead
+		deadline, ok := req.Context().Deadline()
+		if attempt >= attempts || !isRetryable(resp, err, retry.StatusCodes) || (ok && time.Until(deadline) <= backoff) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isIdempotent reports whether the request with given http method
+// can be safely retried
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryable reports whether the outcome of an attempt is transient,
+// the responses are retried for the given status codes if any
+func isRetryable(resp *http.Response, err error, codes []int) bool {
+	if err != nil {
+		return true
+	}
+	if len(codes) != 0 {
+		return slices.Contains(codes, resp.StatusCode)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// httpClient returns the http client used for the request, the long
+// polling calls skip the timeout configured for the client
+func (c *client) httpClient(ctx context.Context) *http.Client {
+	if isLongPoll(ctx) && c.longPoll != nil {
+		return c.longPoll
+	}
+	return c.client
+}
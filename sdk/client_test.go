@@ -0,0 +1,322 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientResolvesEndpoint(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotQuery = r.URL.RawQuery
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL + "/api/"})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	r, _ := http.NewRequest(http.MethodGet, "/v1/object/a%2Fb?desc=x", nil)
+	resp, err := c.Do(r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+	if want := "/api/v1/object/a%2Fb"; gotPath != want {
+		t.Errorf("path = %q; want %q", gotPath, want)
+	}
+	if want := "desc=x"; gotQuery != want {
+		t.Errorf("query = %q; want %q", gotQuery, want)
+	}
+}
+
+func TestClientProfiles(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(ca, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{
+		Endpoint: "http://127.0.0.1:1",
+		Profile:  "dev",
+		Profiles: map[string]*Profile{
+			"dev":     {},
+			"staging": {Endpoint: srv.URL, TLS: &TLSConfig{CAFile: ca}},
+			"broken":  {Endpoint: srv.URL},
+		},
+	}
+
+	for _, spec := range []struct {
+		name    string
+		env     string
+		opts    []Option
+		wantHit bool
+	}{
+		{name: "default profile"},
+		{name: "env profile", env: "staging", wantHit: true},
+		{name: "option profile", env: "dev", opts: []Option{WithProfile("staging")}, wantHit: true},
+		{name: "profile without ca", opts: []Option{WithProfile("broken")}},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, spec.env)
+			hits.Store(0)
+			c, err := NewClient(cfg, spec.opts...)
+			if err != nil {
+				t.Fatalf("NewClient() failed with %v; want success", err)
+			}
+			r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+			resp, err := c.Do(r)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			if got := hits.Load() == 1; got != spec.wantHit || (err == nil) != spec.wantHit {
+				t.Errorf("Do() = %v, server hit %v; want hit %v", err, got, spec.wantHit)
+			}
+		})
+	}
+
+	if _, err := NewClient(cfg, WithProfile("prod")); err == nil {
+		t.Errorf("NewClient() with unknown profile succeeded; want error")
+	}
+
+	t.Run("env profile, config without profiles", func(t *testing.T) {
+		t.Setenv(ProfileEnv, "staging")
+		hits.Store(0)
+		c, err := NewClient(&Config{Endpoint: srv.URL, TLS: TLSConfig{CAFile: ca}})
+		if err != nil {
+			t.Fatalf("NewClient() failed with %v; want success", err)
+		}
+		r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+		if hits.Load() != 1 {
+			t.Errorf("Do() did not hit the server")
+		}
+	})
+}
+
+func TestClientSignsRequests(t *testing.T) {
+	var keyID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID = r.Header.Get("x-api-key-id")
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint: srv.URL,
+		Auth:     AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+	resp, err := c.Do(r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+	if keyID != "key-1" {
+		t.Errorf("x-api-key-id = %q; want %q", keyID, "key-1")
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	for _, spec := range []struct {
+		method       string
+		wantAttempts int32
+	}{
+		{method: http.MethodGet, wantAttempts: 3},
+		{method: http.MethodPut, wantAttempts: 3},
+		{method: http.MethodPost, wantAttempts: 1},
+	} {
+		t.Run(spec.method, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPut && string(body) != "payload" {
+					t.Errorf("body = %q on attempt %d; want %q", body, attempts.Load(), "payload")
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(&Config{
+				Endpoint: srv.URL,
+				Retry:    RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() failed with %v; want success", err)
+			}
+			r, _ := http.NewRequest(spec.method, "/v1/object", bytes.NewBufferString("payload"))
+			resp, err := c.Do(r)
+			if err != nil {
+				t.Fatalf("Do() failed with %v; want success", err)
+			}
+			_ = resp.Body.Close()
+			if got := attempts.Load(); got != spec.wantAttempts {
+				t.Errorf("attempts = %d; want %d", got, spec.wantAttempts)
+			}
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("StatusCode = %d; want %d", resp.StatusCode, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func(ctx context.Context, cfg RetryConfig) time.Duration {
+		attempts.Store(0)
+		r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/object", nil)
+		r, cancel := ApplyCallOptions(r, WithRetry(cfg))
+		defer cancel()
+		start := time.Now()
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+		return time.Since(start)
+	}
+
+	// 500 is not retried by default
+	do(context.Background(), RetryConfig{MaxAttempts: 3, Backoff: time.Millisecond})
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts without status codes = %d; want 1", got)
+	}
+
+	// backoff of 50ms, 100ms and 200ms is capped to 50ms each
+	elapsed := do(context.Background(), RetryConfig{
+		MaxAttempts: 4,
+		Backoff:     50 * time.Millisecond,
+		MaxBackoff:  50 * time.Millisecond,
+		StatusCodes: []int{http.StatusInternalServerError},
+	})
+	if got := attempts.Load(); got != 4 {
+		t.Errorf("attempts with status codes = %d; want 4", got)
+	}
+	if elapsed >= 300*time.Millisecond {
+		t.Errorf("retries took %s; want backoff capped by max backoff", elapsed)
+	}
+
+	// zero backoff waits as per the default retry policy
+	elapsed = do(context.Background(), RetryConfig{MaxAttempts: 2, StatusCodes: []int{http.StatusInternalServerError}})
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts with zero backoff = %d; want 2", got)
+	}
+	if elapsed < DefaultRetryConfig.Backoff {
+		t.Errorf("retry with zero backoff took %s; want at least %s", elapsed, DefaultRetryConfig.Backoff)
+	}
+
+	// retry not completing before the deadline is skipped
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	elapsed = do(ctx, RetryConfig{MaxAttempts: 3, Backoff: time.Second, StatusCodes: []int{http.StatusInternalServerError}})
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts exceeding the deadline = %d; want 1", got)
+	}
+	if elapsed >= 400*time.Millisecond {
+		t.Errorf("call waited %s for the retry beyond the deadline", elapsed)
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+	var direct atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		direct.Add(1)
+	}))
+	defer srv.Close()
+
+	send := func(endpoint string, opts ...Option) {
+		t.Helper()
+		c, err := NewClient(&Config{Endpoint: endpoint, Proxy: "http://proxy.invalid:3128"}, opts...)
+		if err != nil {
+			t.Fatalf("NewClient() failed with %v; want success", err)
+		}
+		r, _ := http.NewRequest(http.MethodGet, "/v1/objects", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// proxy of the option overrides the one of the config
+	send("http://api.example.com", WithProxy(proxy.URL))
+	if want := "http://api.example.com/v1/objects"; proxied != want {
+		t.Errorf("request proxied for %q; want %q", proxied, want)
+	}
+	send(srv.URL, WithProxy(""))
+	if direct.Load() != 1 {
+		t.Errorf("request without the proxy not sent directly")
+	}
+
+	if _, err := NewClient(&Config{Endpoint: srv.URL}, WithProxy("ftp://proxy.example.com")); err == nil {
+		t.Errorf("NewClient() with ftp proxy succeeded; want error")
+	}
+}
+
+func TestClientDialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	for _, opts := range [][]Option{nil, {WithHappyEyeballs(0)}} {
+		var dialed atomic.Int32
+		dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed.Add(1)
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}
+		c, err := NewClient(&Config{Endpoint: srv.URL}, append(opts, WithDialContext(dial))...)
+		if err != nil {
+			t.Fatalf("NewClient() failed with %v; want success", err)
+		}
+		r, _ := http.NewRequest(http.MethodGet, "/v1/objects", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+		if dialed.Load() != 1 {
+			t.Errorf("Do() with %d options dialed %d times using the dial function; want once", len(opts), dialed.Load())
+		}
+	}
+}
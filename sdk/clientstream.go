@@ -0,0 +1,139 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+)
+
+// errStreamClosed is observed by Send once the stream is closed
+var errStreamClosed = errors.New("stream is closed")
+
+// ClientStream sends the messages of a client streaming method as
+// newline delimited JSON in the body of a single chunked request, as
+// expected by grpc-gateway, receiving the response once the stream is
+// closed
+//
+//	stream, err := svc.CreateObjects(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	for _, obj := range objs {
+//		if err := stream.Send(obj); err != nil {
+//			return err
+//		}
+//	}
+//	resp, err := stream.CloseAndRecv()
+type ClientStream[Req proto.Message, T any, P interface {
+	*T
+	proto.Message
+}] struct {
+	m    runtime.Marshaler
+	pw   *io.PipeWriter
+	done chan struct{}
+
+	// mu serializes the messages written to the request body
+	mu sync.Mutex
+
+	// resp and err are the outcome of the request, available once
+	// done is closed
+	resp *http.Response
+	err  error
+}
+
+// NewClientStream triggers the request using the client, sending the
+// messages encoded using the marshaler as the request body, the request
+// is terminated when the context is cancelled
+func NewClientStream[Req proto.Message, T any, P interface {
+	*T
+	proto.Message
+}](ctx context.Context, client Doer, method, uri string, m runtime.Marshaler) (*ClientStream[Req, T, P], error) {
+	pr, pw := io.Pipe()
+	r, err := http.NewRequestWithContext(ctx, method, uri, pr)
+	if err != nil {
+		_ = pr.Close()
+		return nil, fmt.Errorf("failed create request: %s", err)
+	}
+	r.Header.Set("Content-Type", m.ContentType(nil))
+	SetMetadataHeaders(r)
+
+	s := &ClientStream[Req, T, P]{
+		m:    m,
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		s.resp, s.err = client.Do(r)
+		// unblock the sender if the request completed without
+		// consuming the whole body
+		_ = pr.CloseWithError(errStreamClosed)
+	}()
+	return s, nil
+}
+
+// Send encodes and sends the message to the server, blocks till the
+// message is consumed by the transport. Returns the error terminating
+// the request if the request failed before the stream is closed
+func (s *ClientStream[Req, T, P]) Send(msg Req) error {
+	data, err := s.m.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	delimiter := []byte("\n")
+	if d, ok := s.m.(runtime.Delimited); ok {
+		delimiter = d.Delimiter()
+	}
+	data = append(data, delimiter...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.pw.Write(data); err != nil {
+		if errors.Is(err, errStreamClosed) || errors.Is(err, io.ErrClosedPipe) {
+			<-s.done
+			if s.err != nil {
+				return s.err
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// CloseAndRecv closes the stream indicating the server that no more
+// messages are to be sent, and returns the response of the server
+func (s *ClientStream[Req, T, P]) CloseAndRecv() (P, error) {
+	s.mu.Lock()
+	_ = s.pw.Close()
+	s.mu.Unlock()
+
+	<-s.done
+	if s.err != nil {
+		return nil, s.err
+	}
+	defer func() {
+		_ = s.resp.Body.Close()
+	}()
+	data, err := io.ReadAll(s.resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if s.resp.StatusCode < 200 || s.resp.StatusCode >= 300 {
+		return nil, DecodeError(s.resp.StatusCode, data)
+	}
+
+	out := P(new(T))
+	if err := s.m.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestClientStreamSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var names []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			names = append(names, strings.Trim(scanner.Text(), `"`))
+		}
+		fmt.Fprintf(w, "%q", strings.Join(names, ","))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	stream, err := NewClientStream[*wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, http.MethodPost, "/v1/objects", &runtime.JSONPb{})
+	if err != nil {
+		t.Fatalf("NewClientStream() failed with %v; want success", err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if err := stream.Send(wrapperspb.String(name)); err != nil {
+			t.Fatalf("stream.Send(%q) failed with %v; want success", name, err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("stream.CloseAndRecv() failed with %v; want success", err)
+	}
+	if got, want := resp.GetValue(), "a,b,c"; got != want {
+		t.Errorf("stream.CloseAndRecv() = %q; want %q", got, want)
+	}
+}
+
+func TestClientStreamRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotImplemented)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	stream, err := NewClientStream[*wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, http.MethodPost, "/v1/objects", &runtime.JSONPb{})
+	if err != nil {
+		t.Fatalf("NewClientStream() failed with %v; want success", err)
+	}
+	// server rejecting the stream must not block the sender
+	for i := 0; i < 1000; i++ {
+		if err := stream.Send(wrapperspb.String(strings.Repeat("x", 1024))); err != nil {
+			break
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err == nil || !strings.Contains(err.Error(), "501") {
+		t.Errorf("stream.CloseAndRecv() = %v; want status code error", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+// DefaultCompressionThreshold is the size of the request body beyond
+// which the body is compressed, used when not provided explicitly
+const DefaultCompressionThreshold = compression.DefaultThreshold
+
+// WithCompression compresses the request bodies of the calls of the
+// service of at least threshold bytes, DefaultCompressionThreshold if
+// not positive, while the bodies streamed without a known length are
+// always compressed. The bodies are gzipped unless configured otherwise
+// using WithCompressionCodecs, the calls advertise the codings using
+// Accept-Encoding, decompressing the encoded responses transparently
+func WithCompression(threshold int) ServiceOption {
+	return func(c *ServiceConfig) {
+		if threshold <= 0 {
+			threshold = DefaultCompressionThreshold
+		}
+		c.compressionThreshold = threshold
+	}
+}
+
+// WithCompressionCodecs sets the codings registered with the compression
+// package the calls of the service use, encoding the request bodies
+// using the first one while accepting the responses encoded using any
+// of them, enabling the compression with DefaultCompressionThreshold
+// unless set using WithCompression. compression.InternalCodecs is
+// recommended for the services within the deployment
+//
+//	svc := example.NewHelloWorldService(client, sdk.WithCompressionCodecs(compression.InternalCodecs...))
+func WithCompressionCodecs(codecs ...string) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.compressionCodecs = codecs
+		if c.compressionThreshold == 0 && len(codecs) != 0 {
+			c.compressionThreshold = DefaultCompressionThreshold
+		}
+	}
+}
+
+// compress returns the request with the body compressed using the first
+// of the codecs as per the threshold, advertising the support for the
+// responses compressed using any of them
+func compress(r *http.Request, threshold int, codecs []string) (*http.Request, error) {
+	if len(codecs) == 0 {
+		codecs = compression.DefaultCodecs
+	}
+	codec, ok := compression.Lookup(codecs[0])
+	if !ok {
+		return nil, fmt.Errorf("compression codec %q is not registered", codecs[0])
+	}
+	r = r.Clone(r.Context())
+	r.Header.Set("Accept-Encoding", strings.Join(codecs, ", "))
+	if r.Body == nil || r.Body == http.NoBody || r.Header.Get("Content-Encoding") != "" {
+		return r, nil
+	}
+	if r.GetBody == nil {
+		// streamed body, compressed while being sent
+		body := r.Body
+		pr, pw := io.Pipe()
+		go func() {
+			defer body.Close()
+			zw, err := codec.NewWriter(pw)
+			if err == nil {
+				_, err = io.Copy(zw, body)
+				if cerr := zw.Close(); err == nil {
+					err = cerr
+				}
+			}
+			_ = pw.CloseWithError(err)
+		}()
+		r.Body = pr
+		r.ContentLength = -1
+		r.Header.Set("Content-Encoding", codec.Name())
+		return r, nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	if len(data) >= threshold {
+		var buf bytes.Buffer
+		zw, err := codec.NewWriter(&buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request: %w", err)
+		}
+		_, _ = zw.Write(data)
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress request: %w", err)
+		}
+		data = buf.Bytes()
+		r.Header.Set("Content-Encoding", codec.Name())
+	}
+	r.ContentLength = int64(len(data))
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return r, nil
+}
+
+// decompress replaces the body of the response encoded using a
+// registered coding with the decompressed one
+func decompress(resp *http.Response) (*http.Response, error) {
+	encoding := resp.Header.Get("Content-Encoding")
+	if encoding == "" {
+		return resp, nil
+	}
+	codec, ok := compression.Lookup(encoding)
+	if !ok {
+		return resp, nil
+	}
+	zr, err := codec.NewReader(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	resp.Body = &decodedBody{ReadCloser: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// decodedBody reads the decompressed response, closing the underlying
+// body
+type decodedBody struct {
+	io.ReadCloser
+	body io.ReadCloser
+}
+
+func (b *decodedBody) Close() error {
+	_ = b.ReadCloser.Close()
+	return b.body.Close()
+}
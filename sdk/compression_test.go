@@ -0,0 +1,146 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+func TestServiceConfigCompression(t *testing.T) {
+	type received struct {
+		encoding string
+		accept   string
+		body     string
+	}
+	var got received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = received{encoding: r.Header.Get("Content-Encoding"), accept: r.Header.Get("Accept-Encoding")}
+		body := io.Reader(r.Body)
+		if got.encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("request body is not gzip encoded: %v", err)
+				return
+			}
+			body = zr
+		}
+		data, _ := io.ReadAll(body)
+		got.body = string(data)
+
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = io.WriteString(zw, `{"name":"ok"}`)
+		_ = zw.Close()
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	cfg := NewServiceConfig("example.Service", WithCompression(16))
+	large := strings.Repeat("x", 64)
+	for _, spec := range []struct {
+		name     string
+		body     io.Reader
+		want     string
+		encoding string
+	}{
+		{name: "small body", body: strings.NewReader("tiny"), want: "tiny"},
+		{name: "large body", body: strings.NewReader(large), want: large, encoding: "gzip"},
+		{name: "streamed body", body: io.MultiReader(strings.NewReader("tiny")), want: "tiny", encoding: "gzip"},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/objects", spec.body)
+			resp, err := cfg.Do(c, r)
+			if err != nil {
+				t.Fatalf("Do() failed with %v; want success", err)
+			}
+			data, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil || string(data) != `{"name":"ok"}` {
+				t.Errorf("response body = %q, %v; want decompressed", data, err)
+			}
+			if resp.Header.Get("Content-Encoding") != "" {
+				t.Errorf("response Content-Encoding = %q; want removed once decompressed", resp.Header.Get("Content-Encoding"))
+			}
+			if got.encoding != spec.encoding || got.accept != "gzip" {
+				t.Errorf("request encoding = %q, accept = %q; want %q, gzip", got.encoding, got.accept, spec.encoding)
+			}
+			if got.body != spec.want {
+				t.Errorf("request body = %q; want %q", got.body, spec.want)
+			}
+		})
+	}
+}
+
+func TestDecompressInvalidResponse(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   io.NopCloser(bytes.NewReader([]byte("plain"))),
+	}
+	if _, err := decompress(resp); err == nil {
+		t.Errorf("decompress() of invalid gzip succeeded; want error")
+	}
+}
+
+func TestServiceConfigCompressionCodecs(t *testing.T) {
+	var encoding, accept string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding, accept = r.Header.Get("Content-Encoding"), r.Header.Get("Accept-Encoding")
+		codec, ok := compression.Lookup(encoding)
+		if !ok {
+			t.Errorf("request encoded as %q; want a registered coding", encoding)
+			return
+		}
+		zr, err := codec.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("request body is not %s encoded: %v", encoding, err)
+			return
+		}
+		defer zr.Close()
+		// responds using the other coding accepted
+		gzipCodec, _ := compression.Lookup(compression.Negotiate(accept, []string{compression.Gzip}))
+		w.Header().Set("Content-Encoding", gzipCodec.Name())
+		zw, _ := gzipCodec.NewWriter(w)
+		_, _ = io.Copy(zw, zr)
+		_ = zw.Close()
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	body := strings.Repeat("x", 2*DefaultCompressionThreshold)
+	cfg := NewServiceConfig("example.Service", WithCompressionCodecs(compression.InternalCodecs...))
+	r, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/objects", strings.NewReader(body))
+	resp, err := cfg.Do(c, r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || string(data) != body {
+		t.Errorf("response body of %d bytes, %v; want the %d bytes echoed", len(data), err, len(body))
+	}
+	if encoding != compression.Zstd || accept != "zstd, gzip" {
+		t.Errorf("request encoding = %q, accept = %q; want zstd, \"zstd, gzip\"", encoding, accept)
+	}
+
+	cfg = NewServiceConfig("example.Service", WithCompressionCodecs("unknown"))
+	r, _ = http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/objects", strings.NewReader(body))
+	if _, err := cfg.Do(c, r); err == nil {
+		t.Errorf("Do() with unregistered codec succeeded; want error")
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigEnv is the environment variable referring to the config
+	// file to be used when no explicit path is provided
+	ConfigEnv = "SDK_CONFIG"
+
+	// ProfileEnv is the environment variable selecting the profile
+	// to be used when no explicit profile is provided, ignored for the
+	// configs without profiles
+	ProfileEnv = "SDK_PROFILE"
+)
+
+const (
+	// AuthModeNone triggers requests without any authentication headers
+	AuthModeNone = "none"
+
+	// AuthModeHMAC signs every request using the api key and secret
+	AuthModeHMAC = "hmac"
+)
+
+// AuthConfig describes how the requests are authenticated
+type AuthConfig struct {
+	// Mode of authentication, either none or hmac, defaults to none
+	Mode string `yaml:"mode"`
+
+	// APIKey is the identifier of the api key used for hmac mode
+	APIKey string `yaml:"api_key"`
+
+	// Secret is the secret of the api key used for hmac mode
+	Secret string `yaml:"secret"`
+}
+
+// DefaultRetryConfig is the retry policy of the calls classified safe
+// to be retried, when no retry policy is configured for the client
+var DefaultRetryConfig = RetryConfig{MaxAttempts: 3, Backoff: 200 * time.Millisecond}
+
+// RetryConfig describes the retry policy for idempotent requests
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// one disables retries, zero uses DefaultRetryConfig for the calls
+	// classified safe to be retried, see WithRetrySafe, and disables
+	// the retries otherwise
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// Backoff is the wait before the first retry, doubled for every
+	// subsequent retry, the backoff of DefaultRetryConfig if zero
+	Backoff time.Duration `yaml:"backoff"`
+
+	// MaxBackoff caps the wait between the retries, unbounded if zero
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+
+	// StatusCodes are the status codes of the responses to be retried,
+	// 429, 502, 503 and 504 if empty, transport errors are always
+	// retried
+	StatusCodes []int `yaml:"status_codes"`
+}
+
+// TLSConfig describes the TLS settings used to connect to the endpoint
+type TLSConfig struct {
+	// CAFile is the PEM encoded CA bundle used to verify the server,
+	// system roots are used when empty
+	CAFile string `yaml:"ca_file"`
+
+	// CertFile and KeyFile are the PEM encoded client certificate
+	// and key presented to the server for mutual TLS
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+
+	// ServerName overrides the name used to verify the server
+	ServerName string `yaml:"server_name"`
+}
+
+// Profile describes an environment like dev, staging or prod, any
+// of the settings provided overrides the top level ones
+type Profile struct {
+	// Endpoint is the base URL of the API server for the profile
+	Endpoint string `yaml:"endpoint"`
+
+	// Auth configures authentication of the requests for the profile
+	Auth *AuthConfig `yaml:"auth"`
+
+	// TLS settings for the profile
+	TLS *TLSConfig `yaml:"tls"`
+
+	// Insecure skips verification of the server certificate
+	Insecure *bool `yaml:"insecure"`
+}
+
+// Config for the SDK clients, typically loaded from a YAML or JSON
+// file using LoadConfig
+type Config struct {
+	// Endpoint is the base URL of the API server, including scheme,
+	// host and optional path prefix, or the unix domain socket the
+	// server listens on, like unix:///run/api.sock
+	Endpoint string `yaml:"endpoint"`
+
+	// Auth configures authentication of the requests
+	Auth AuthConfig `yaml:"auth"`
+
+	// Timeout for every request made, zero means no timeout
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Retry policy for the requests
+	Retry RetryConfig `yaml:"retry"`
+
+	// Proxy is the URL of the proxy to send the requests through,
+	// when empty proxy is picked from the environment, overridden by
+	// WithProxy
+	Proxy string `yaml:"proxy"`
+
+	// Insecure skips verification of the server certificate,
+	// should be used only for testing
+	Insecure bool `yaml:"insecure"`
+
+	// TLS settings used to connect to the endpoint
+	TLS TLSConfig `yaml:"tls"`
+
+	// Profile is the name of the profile used by default
+	Profile string `yaml:"profile"`
+
+	// Profiles is the set of named environment profiles
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// ForProfile returns the config to be used for the given profile,
+// with the profile settings overriding the top level ones
+func (c *Config) ForProfile(name string) (*Config, error) {
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		return nil, fmt.Errorf("profile %q not found in config", name)
+	}
+	cfg := *c
+	cfg.Profile = name
+	cfg.Profiles = nil
+	if p.Endpoint != "" {
+		cfg.Endpoint = p.Endpoint
+	}
+	if p.Auth != nil {
+		cfg.Auth = *p.Auth
+	}
+	if p.TLS != nil {
+		cfg.TLS = *p.TLS
+	}
+	if p.Insecure != nil {
+		cfg.Insecure = *p.Insecure
+	}
+	return &cfg, nil
+}
+
+// Validate ensures the config carries the information required
+// to create a client
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return fmt.Errorf("endpoint not configured")
+	}
+	return c.validateSettings()
+}
+
+// validateSettings validates the config apart from the endpoint,
+// which is not needed when the endpoints are discovered otherwise
+func (c *Config) validateSettings() error {
+	switch c.Auth.Mode {
+	case "", AuthModeNone:
+	case AuthModeHMAC:
+		if c.Auth.APIKey == "" || c.Auth.Secret == "" {
+			return fmt.Errorf("api_key and secret are required for auth mode %s", AuthModeHMAC)
+		}
+	default:
+		return fmt.Errorf("unsupported auth mode: %s", c.Auth.Mode)
+	}
+	if c.Retry.MaxAttempts < 0 {
+		return fmt.Errorf("invalid retry max_attempts: %d", c.Retry.MaxAttempts)
+	}
+	return nil
+}
+
+// envRef matches the references to the environment variables, ${VAR},
+// along with the escaped ones, $${VAR}
+var envRef = regexp.MustCompile(`\$?\$\{[A-Za-z_][A-Za-z0-9_]*\}`)
+
+// expandEnv replaces the references to the environment variables in the
+// string fields of the value, leaving the other dollar signs as is, like
+// the ones of the secrets, while $${VAR} results in ${VAR}
+func expandEnv(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			expandEnv(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			expandEnv(v.Field(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			expandEnv(v.MapIndex(key))
+		}
+	case reflect.String:
+		v.SetString(envRef.ReplaceAllStringFunc(v.String(), func(ref string) string {
+			if strings.HasPrefix(ref, "$$") {
+				return ref[1:]
+			}
+			return os.Getenv(ref[2 : len(ref)-1])
+		}))
+	}
+}
+
+// ParseConfig parses the config from YAML or JSON content, expanding
+// the environment variables referred as ${VAR} in the string settings,
+// $${VAR} escaping the reference
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	expandEnv(reflect.ValueOf(cfg))
+	if len(cfg.Profiles) == 0 {
+		if cfg.Profile != "" {
+			return nil, fmt.Errorf("profile %q not found in config", cfg.Profile)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+
+	// top level settings may be incomplete when profiles are used,
+	// ensure every profile results in a usable config instead
+	for name := range cfg.Profiles {
+		p, err := cfg.ForProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	if cfg.Profile != "" {
+		if _, ok := cfg.Profiles[cfg.Profile]; !ok {
+			return nil, fmt.Errorf("profile %q not found in config", cfg.Profile)
+		}
+	}
+	return cfg, nil
+}
+
+// LoadConfig loads the config from the given file, when path is
+// empty the file referred by SDK_CONFIG environment variable is used
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(ConfigEnv)
+		if path == "" {
+			return nil, fmt.Errorf("config path not provided and %s is not set", ConfigEnv)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %q: %w", path, err)
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %q: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Setenv("TEST_SDK_SECRET", "s3cr3t")
+	for _, spec := range []struct {
+		name    string
+		input   string
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "yaml with env expansion",
+			input: `
+endpoint: https://api.example.com
+auth:
+  mode: hmac
+  api_key: key-1
+  secret: ${TEST_SDK_SECRET}
+timeout: 30s
+retry:
+  max_attempts: 3
+  backoff: 100ms
+proxy: http://proxy.example.com:3128
+`,
+			want: Config{
+				Endpoint: "https://api.example.com",
+				Auth:     AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "s3cr3t"},
+				Timeout:  30 * time.Second,
+				Retry:    RetryConfig{MaxAttempts: 3, Backoff: 100 * time.Millisecond},
+				Proxy:    "http://proxy.example.com:3128",
+			},
+		},
+		{
+			name: "dollar signs in the secret",
+			input: `
+endpoint: https://api.example.com
+auth:
+  mode: hmac
+  api_key: key-1
+  secret: ab$cd$
+`,
+			want: Config{
+				Endpoint: "https://api.example.com",
+				Auth:     AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "ab$cd$"},
+			},
+		},
+		{
+			name: "escaped env reference",
+			input: `
+endpoint: https://api.example.com
+auth:
+  mode: hmac
+  api_key: key-1
+  secret: $${TEST_SDK_SECRET}-${TEST_SDK_SECRET}
+`,
+			want: Config{
+				Endpoint: "https://api.example.com",
+				Auth:     AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "${TEST_SDK_SECRET}-s3cr3t"},
+			},
+		},
+		{
+			name:  "json",
+			input: `{"endpoint": "http://localhost:8080", "insecure": true}`,
+			want: Config{
+				Endpoint: "http://localhost:8080",
+				Insecure: true,
+			},
+		},
+		{
+			name: "profiles",
+			input: `
+profile: dev
+auth:
+  mode: hmac
+  api_key: key-1
+  secret: s3cr3t
+profiles:
+  dev:
+    endpoint: http://localhost:8080
+    auth:
+      mode: none
+    insecure: true
+  prod:
+    endpoint: https://api.example.com
+    auth:
+      mode: hmac
+      api_key: key-2
+      secret: ${TEST_SDK_SECRET}
+    tls:
+      ca_file: /etc/sdk/ca.pem
+`,
+			want: Config{
+				Auth:    AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "s3cr3t"},
+				Profile: "dev",
+				Profiles: map[string]*Profile{
+					"dev": {
+						Endpoint: "http://localhost:8080",
+						Auth:     &AuthConfig{Mode: AuthModeNone},
+						Insecure: &[]bool{true}[0],
+					},
+					"prod": {
+						Endpoint: "https://api.example.com",
+						Auth:     &AuthConfig{Mode: AuthModeHMAC, APIKey: "key-2", Secret: "s3cr3t"},
+						TLS:      &TLSConfig{CAFile: "/etc/sdk/ca.pem"},
+					},
+				},
+			},
+		},
+		{
+			name:    "profile without endpoint",
+			input:   "profiles:\n  dev:\n    insecure: true\n",
+			wantErr: `profile "dev": endpoint not configured`,
+		},
+		{
+			name:    "unknown default profile",
+			input:   "profile: stage\nprofiles:\n  dev:\n    endpoint: http://localhost\n",
+			wantErr: `profile "stage" not found`,
+		},
+		{
+			name:    "missing endpoint",
+			input:   `timeout: 1s`,
+			wantErr: "endpoint not configured",
+		},
+		{
+			name:    "hmac without secret",
+			input:   "endpoint: http://localhost\nauth:\n  mode: hmac\n  api_key: key-1\n",
+			wantErr: "api_key and secret are required",
+		},
+		{
+			name:    "unknown auth mode",
+			input:   "endpoint: http://localhost\nauth:\n  mode: oauth\n",
+			wantErr: "unsupported auth mode",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			cfg, err := ParseConfig([]byte(spec.input))
+			if spec.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), spec.wantErr) {
+					t.Fatalf("ParseConfig() = %v; want error containing %q", err, spec.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseConfig() failed with %v; want success", err)
+			}
+			if !reflect.DeepEqual(*cfg, spec.want) {
+				t.Errorf("ParseConfig() = %+v; want %+v", *cfg, spec.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdk.yaml")
+	if err := os.WriteFile(path, []byte("endpoint: http://localhost:8080\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(ConfigEnv, "")
+	if _, err := LoadConfig(""); err == nil {
+		t.Errorf("LoadConfig(\"\") succeeded without %s; want error", ConfigEnv)
+	}
+
+	t.Setenv(ConfigEnv, path)
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig(\"\") failed with %v; want success", err)
+	}
+	if got, want := cfg.Endpoint, "http://localhost:8080"; got != want {
+		t.Errorf("cfg.Endpoint = %q; want %q", got, want)
+	}
+}
+
+func TestConfigForProfile(t *testing.T) {
+	cfg := &Config{
+		Endpoint: "https://api.example.com",
+		Auth:     AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "s3cr3t"},
+		Timeout:  time.Second,
+		Profiles: map[string]*Profile{
+			"staging": {Endpoint: "https://staging.example.com"},
+		},
+	}
+	got, err := cfg.ForProfile("staging")
+	if err != nil {
+		t.Fatalf("ForProfile() failed with %v; want success", err)
+	}
+	want := Config{
+		Endpoint: "https://staging.example.com",
+		Auth:     cfg.Auth,
+		Timeout:  time.Second,
+		Profile:  "staging",
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("ForProfile() = %+v; want %+v", *got, want)
+	}
+	if _, err := cfg.ForProfile("prod"); err == nil {
+		t.Errorf("ForProfile(\"prod\") succeeded; want error")
+	}
+}
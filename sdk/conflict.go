@@ -0,0 +1,105 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// ErrConflict is matched by the errors of the conditional writes
+// rejected by the server with 409 Conflict or 412 Precondition Failed,
+// as the version sent is not the current version of the resource
+//
+//	_, err := svc.UpdateObject(ctx, req)
+//	var conflict *sdk.ConflictError
+//	if errors.As(err, &conflict) {
+//		// refetch the object at conflict.CurrentVersion and retry
+//	}
+var ErrConflict = errors.New("version conflict")
+
+const (
+	// VersionConflictReason is the reason of the google.rpc.ErrorInfo
+	// detail sent by the servers reporting the current version of the
+	// resource along with the conflict
+	VersionConflictReason = "VERSION_CONFLICT"
+
+	// CurrentVersionKey is the metadata key of the google.rpc.ErrorInfo
+	// detail carrying the current version of the resource
+	CurrentVersionKey = "current_version"
+)
+
+// ConflictError is the error of the conditional write rejected due to
+// the version conflict, matching ErrConflict
+type ConflictError struct {
+	*APIError
+
+	// CurrentVersion is the current version of the resource as sent by
+	// the server using the ETag header, or the google.rpc.ErrorInfo
+	// detail with VersionConflictReason, empty if not sent
+	CurrentVersion string
+}
+
+// Is reports whether the target is ErrConflict
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Unwrap returns the underlying *APIError
+func (e *ConflictError) Unwrap() error {
+	return e.APIError
+}
+
+// WithIfMatch sends the version as the If-Match header of the call,
+// making it conditional on the version being the current version of
+// the resource, empty version sends the call unconditionally. The
+// generated SDK sets it using the field marked with (api.version)
+func WithIfMatch(version string) CallOption {
+	if version == "" {
+		return func(*callOptions) {}
+	}
+	return WithHeader("If-Match", quoteETag(version))
+}
+
+// DecodeConflict returns the *ConflictError for the 409 and the 412
+// responses of the conditional writes, called by the generated SDK
+func DecodeConflict(statusCode int, header http.Header, body []byte) error {
+	apiErr, _ := DecodeError(statusCode, body).(*APIError)
+	conflict := &ConflictError{
+		APIError:       apiErr,
+		CurrentVersion: unquoteETag(header.Get("ETag")),
+	}
+	if conflict.CurrentVersion != "" {
+		return conflict
+	}
+	for _, detail := range apiErr.Details {
+		info := &errdetails.ErrorInfo{}
+		if detail.UnmarshalTo(info) == nil && info.GetReason() == VersionConflictReason {
+			conflict.CurrentVersion = info.GetMetadata()[CurrentVersionKey]
+			break
+		}
+	}
+	return conflict
+}
+
+// quoteETag returns the version as the entity tag, retaining the ones
+// already quoted
+func quoteETag(version string) string {
+	if strings.HasPrefix(version, `"`) || strings.HasPrefix(version, `W/"`) {
+		return version
+	}
+	return `"` + version + `"`
+}
+
+// unquoteETag returns the version carried by the entity tag
+func unquoteETag(etag string) string {
+	etag = strings.TrimPrefix(etag, "W/")
+	if len(etag) >= 2 && strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`) {
+		return etag[1 : len(etag)-1]
+	}
+	return etag
+}
@@ -0,0 +1,79 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDecodeConflict(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		status  int
+		header  http.Header
+		body    string
+		version string
+		code    codes.Code
+	}{
+		{
+			name:    "etag header",
+			status:  http.StatusPreconditionFailed,
+			header:  http.Header{"Etag": {`W/"v3"`}},
+			version: "v3",
+			code:    codes.FailedPrecondition,
+		},
+		{
+			name:    "error info",
+			status:  http.StatusConflict,
+			body:    `{"code":10,"message":"object was modified","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"VERSION_CONFLICT","metadata":{"current_version":"v4"}}]}`,
+			version: "v4",
+			code:    codes.Aborted,
+		},
+		{
+			name:   "no version",
+			status: http.StatusConflict,
+			code:   codes.Aborted,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			err := fmt.Errorf("update object: %w", DecodeConflict(spec.status, spec.header, []byte(spec.body)))
+			if !errors.Is(err, ErrConflict) {
+				t.Fatalf("DecodeConflict() = %v; want ErrConflict", err)
+			}
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) || conflict.CurrentVersion != spec.version {
+				t.Errorf("DecodeConflict() = %+v; want current version %q", conflict, spec.version)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != spec.status {
+				t.Errorf("DecodeConflict() = %+v; want *APIError with status %d", err, spec.status)
+			}
+			if code := status.Code(err); code != spec.code {
+				t.Errorf("status.Code() = %v; want %v", code, spec.code)
+			}
+		})
+	}
+}
+
+func TestWithIfMatch(t *testing.T) {
+	for version, want := range map[string]string{
+		"":       "",
+		"v1":     `"v1"`,
+		`"v1"`:   `"v1"`,
+		`W/"v1"`: `W/"v1"`,
+	} {
+		r, _ := http.NewRequest(http.MethodPut, "/v1/object/a", nil)
+		r, cancel := ApplyCallOptions(r, WithIfMatch(version))
+		cancel()
+		if got := r.Header.Get("If-Match"); got != want {
+			t.Errorf("WithIfMatch(%q) sent If-Match %q; want %q", version, got, want)
+		}
+	}
+}
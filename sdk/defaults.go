@@ -0,0 +1,100 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallDefaults are the defaults of the unary calls made using the
+// context, set once using WithDefaults instead of passing the same call
+// options across the deep call stacks. The call options, including the
+// ones set by the generated SDK as per the options of the methods like
+// (api.timeout), take precedence over the defaults
+type CallDefaults struct {
+	// Header is set on the requests of the calls, like the header
+	// conveying the tenant
+	Header http.Header
+
+	// Locale is set as the Accept-Language of the requests, the routes
+	// parse it into the locale of the request when enabled
+	Locale string
+
+	// Timeout bounds the calls when the context has no deadline, same
+	// as WithDefaultTimeout
+	Timeout time.Duration
+}
+
+// defaultsKey is the context key carrying the defaults of the calls
+type defaultsKey struct{}
+
+// WithDefaults returns the context carrying the defaults of the calls
+// made using it, merged with the defaults of the parent context if any,
+// the ones provided taking precedence
+//
+//	ctx = sdk.WithDefaults(ctx, sdk.CallDefaults{
+//		Header: http.Header{"X-Tenant": {"acme"}},
+//		Locale: "fr-CA",
+//	})
+//	obj, err := svc.GetObject(ctx, req)
+func WithDefaults(ctx context.Context, defaults CallDefaults) context.Context {
+	if parent, ok := DefaultsFrom(ctx); ok {
+		header := parent.Header.Clone()
+		for key, values := range defaults.Header {
+			if header == nil {
+				header = http.Header{}
+			}
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+		defaults.Header = header
+		if defaults.Locale == "" {
+			defaults.Locale = parent.Locale
+		}
+		if defaults.Timeout == 0 {
+			defaults.Timeout = parent.Timeout
+		}
+	}
+	return context.WithValue(ctx, defaultsKey{}, defaults)
+}
+
+// DefaultsFrom returns the defaults of the calls carried by the context
+func DefaultsFrom(ctx context.Context) (CallDefaults, bool) {
+	defaults, ok := ctx.Value(defaultsKey{}).(CallDefaults)
+	return defaults, ok
+}
+
+// withDefaults returns the options of the call preceded by the ones
+// applying the defaults carried by the context, if any
+func withDefaults(ctx context.Context, opts []CallOption) []CallOption {
+	defaults, ok := DefaultsFrom(ctx)
+	if !ok {
+		return opts
+	}
+	var head []CallOption
+	for key, values := range defaults.Header {
+		for _, v := range values {
+			head = append(head, withHeaderValue(key, v))
+		}
+	}
+	if defaults.Locale != "" {
+		head = append(head, WithHeader("Accept-Language", defaults.Locale))
+	}
+	if defaults.Timeout > 0 {
+		head = append(head, WithDefaultTimeout(defaults.Timeout))
+	}
+	return append(head, opts...)
+}
+
+// withHeaderValue adds the value to the header of the request of the
+// call, retaining the multiple values of the header
+func withHeaderValue(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithDefaults(t *testing.T) {
+	ctx := WithDefaults(context.Background(), CallDefaults{
+		Header:  http.Header{"X-Tenant": {"acme"}, "X-Team": {"infra"}},
+		Locale:  "fr-CA",
+		Timeout: time.Minute,
+	})
+	// defaults of the nested context are merged with the parent ones
+	ctx = WithDefaults(ctx, CallDefaults{Header: http.Header{"x-team": {"billing"}}})
+
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/objects", nil)
+	r, cancel := ApplyCallOptions(r)
+	defer cancel()
+	for key, want := range map[string]string{"X-Tenant": "acme", "X-Team": "billing", "Accept-Language": "fr-CA"} {
+		if got := r.Header.Get(key); got != want {
+			t.Errorf("header %s = %q; want %q", key, got, want)
+		}
+	}
+	if _, ok := r.Context().Deadline(); !ok {
+		t.Errorf("call without deadline; want bounded by the default timeout")
+	}
+
+	// options of the call take precedence over the defaults
+	r, _ = http.NewRequestWithContext(ctx, http.MethodGet, "/v1/objects", nil)
+	r, cancel = ApplyCallOptions(r, WithHeader("X-Tenant", "globex"), WithDefaultTimeout(0))
+	defer cancel()
+	if got := r.Header.Get("X-Tenant"); got != "globex" {
+		t.Errorf("header X-Tenant = %q; want globex set for the call", got)
+	}
+	if _, ok := r.Context().Deadline(); ok {
+		t.Errorf("call with deadline; want the default timeout overridden")
+	}
+
+	if _, ok := DefaultsFrom(context.Background()); ok {
+		t.Errorf("DefaultsFrom() without WithDefaults reported the defaults")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultConnectionAttemptDelay is the delay between the connection
+// attempts raced by the dual-stack dialer, as recommended by RFC 8305
+const DefaultConnectionAttemptDelay = 250 * time.Millisecond
+
+// happyEyeballsDialer races the connection attempts across the
+// addresses of the host, interleaving IPv6 and IPv4 addresses as
+// described in RFC 8305, using the first connection established
+type happyEyeballsDialer struct {
+	delay  time.Duration
+	lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+	dial   func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+func newHappyEyeballsDialer(delay time.Duration) *happyEyeballsDialer {
+	if delay <= 0 {
+		delay = DefaultConnectionAttemptDelay
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return &happyEyeballsDialer{
+		delay:  delay,
+		lookup: net.DefaultResolver.LookupIPAddr,
+		dial:   dialer.DialContext,
+	}
+}
+
+// interleaveAddrs orders the addresses alternating between the address
+// families, starting with IPv6 when available
+func interleaveAddrs(addrs []net.IPAddr) []net.IPAddr {
+	var v6, v4 []net.IPAddr
+	for _, a := range addrs {
+		if a.IP.To4() != nil {
+			v4 = append(v4, a)
+		} else {
+			v6 = append(v6, a)
+		}
+	}
+	res := make([]net.IPAddr, 0, len(addrs))
+	for i := 0; i < len(v6) || i < len(v4); i++ {
+		if i < len(v6) {
+			res = append(res, v6[i])
+		}
+		if i < len(v4) {
+			res = append(res, v4[i])
+		}
+	}
+	return res
+}
+
+// dialResult is the outcome of a single connection attempt
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// DialContext connects to the address, racing the connection attempts
+// across the resolved addresses of the host
+func (d *happyEyeballsDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if network != "tcp" || net.ParseIP(host) != nil {
+		// nothing to race when address family is already decided
+		return d.dial(ctx, network, address)
+	}
+	addrs, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+	addrs = interleaveAddrs(addrs)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan dialResult, len(addrs))
+	next, pending := 0, 0
+	start := func() {
+		addr := net.JoinHostPort(addrs[next].String(), port)
+		next++
+		pending++
+		go func() {
+			conn, err := d.dial(ctx, network, addr)
+			results <- dialResult{conn: conn, err: err}
+		}()
+	}
+
+	start()
+	timer := time.NewTimer(d.delay)
+	defer timer.Stop()
+	var firstErr error
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				// close the connections of the attempts that are
+				// still in flight once they complete
+				go func(n int) {
+					for range n {
+						if late := <-results; late.conn != nil {
+							_ = late.conn.Close()
+						}
+					}
+				}(pending)
+				return r.conn, nil
+			}
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			// start the next attempt right away on failure
+			if next < len(addrs) {
+				start()
+				timer.Reset(d.delay)
+			}
+		case <-timer.C:
+			if next < len(addrs) {
+				start()
+				timer.Reset(d.delay)
+			}
+		}
+	}
+	return nil, firstErr
+}
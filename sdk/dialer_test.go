@@ -0,0 +1,119 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInterleaveAddrs(t *testing.T) {
+	addrs := []net.IPAddr{
+		{IP: net.ParseIP("10.0.0.1")},
+		{IP: net.ParseIP("10.0.0.2")},
+		{IP: net.ParseIP("10.0.0.3")},
+		{IP: net.ParseIP("2001:db8::1")},
+		{IP: net.ParseIP("2001:db8::2")},
+	}
+	var got []string
+	for _, a := range interleaveAddrs(addrs) {
+		got = append(got, a.String())
+	}
+	want := []string{"2001:db8::1", "10.0.0.1", "2001:db8::2", "10.0.0.2", "10.0.0.3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("interleaveAddrs() = %v; want %v", got, want)
+	}
+}
+
+// fakeConn is a connection identifying the address dialed
+type fakeConn struct {
+	net.Conn
+	addr string
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func TestHappyEyeballsDialer(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		stalled map[string]bool
+		failed  map[string]bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "preferred ipv6",
+			want: "[2001:db8::1]:443",
+		},
+		{
+			name:    "stalled ipv6 races ipv4",
+			stalled: map[string]bool{"[2001:db8::1]:443": true},
+			want:    "10.0.0.1:443",
+		},
+		{
+			name:   "failed attempts fall through",
+			failed: map[string]bool{"[2001:db8::1]:443": true, "10.0.0.1:443": true},
+			want:   "10.0.0.2:443",
+		},
+		{
+			name: "all failed",
+			failed: map[string]bool{
+				"[2001:db8::1]:443": true, "10.0.0.1:443": true, "10.0.0.2:443": true,
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var attempts []string
+			d := newHappyEyeballsDialer(10 * time.Millisecond)
+			d.lookup = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+				return []net.IPAddr{
+					{IP: net.ParseIP("10.0.0.1")},
+					{IP: net.ParseIP("10.0.0.2")},
+					{IP: net.ParseIP("2001:db8::1")},
+				}, nil
+			}
+			d.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+				mu.Lock()
+				attempts = append(attempts, address)
+				mu.Unlock()
+				switch {
+				case spec.stalled[address]:
+					<-ctx.Done()
+					return nil, ctx.Err()
+				case spec.failed[address]:
+					return nil, fmt.Errorf("connection refused")
+				}
+				return &fakeConn{addr: address}, nil
+			}
+
+			conn, err := d.DialContext(context.Background(), "tcp", "api.example.com:443")
+			if spec.wantErr {
+				if err == nil {
+					t.Fatalf("DialContext() succeeded; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DialContext() failed with %v; want success", err)
+			}
+			if got := conn.(*fakeConn).addr; got != spec.want {
+				t.Errorf("DialContext() connected to %s; want %s", got, spec.want)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if attempts[0] != "[2001:db8::1]:443" {
+				t.Errorf("first attempt to %s; want IPv6 address first", attempts[0])
+			}
+		})
+	}
+}
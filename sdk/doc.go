@@ -0,0 +1,37 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+//
+// Package sdk provides the runtime used by the code generated by
+// protoc-gen-sdk, allowing generated clients to be configured
+// without code changes in the consuming tools.
+//
+// # Service options
+//
+// The generated constructors, New<Service>Service, take the client
+// triggering the requests, like the one created using NewClient or any
+// other Doer, along with the ServiceOptions configuring the wrapper:
+//
+//   - WithEndpoint selects the host the requests are sent to
+//   - WithTLSConfig and WithClientCert build the http client connecting
+//     to the gateways requiring mutual TLS, when no client is provided
+//   - WithBreaker guards the calls using a circuit breaker
+//   - WithInterceptors hooks into the requests sent
+//   - WithCompression and WithCompressionCodecs compress the request
+//     and the response bodies
+//   - WithBulkheads isolates the calls of the methods marked using
+//     (api.bulkhead)
+//   - WithCache caches the responses of the methods invalidated using
+//     (api.invalidates)
+//   - WithMarshaler and WithProtoNames configure the JSON encoding of
+//     the bodies, while WithProtobuf encodes them as protobuf binary
+//   - WithMetrics and WithObjectives observe the calls
+//   - WithCanary routes the calls to the canary of the backend
+//   - WithCancelNotifications notifies the server about the canceled
+//     calls
+//   - WithConn sends the unary calls natively over the grpc connection
+//   - WithKMS seals the request fields marked using (api.encrypted)
+//   - WithSignatureKeys verifies the responses of the methods marked
+//     using (api.signed)
+//
+// The wrappers hold no mutable state and are safe for concurrent use.
+package sdk
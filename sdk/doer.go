@@ -0,0 +1,42 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+
+	auth "github.com/go-core-stack/auth/client"
+)
+
+// Doer sends the requests made by the generated SDK wrappers, taking
+// care of the endpoint, the authentication and the transport. It is
+// satisfied by the client created using NewClient, by *http.Client,
+// and by the clients of the auth stack, such that the SDK can be used
+// with any of them
+//
+//	svc := example.NewHelloWorldService(&http.Client{Transport: transport})
+type Doer interface {
+	// Do sends the request, returning the response or the error of the
+	// transport
+	Do(*http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts the function to Doer
+//
+//	client := sdk.DoerFunc(func(r *http.Request) (*http.Response, error) {
+//		r.Header.Set("Authorization", "Bearer "+token)
+//		return http.DefaultClient.Do(r)
+//	})
+type DoerFunc func(*http.Request) (*http.Response, error)
+
+// Do calls the function
+func (f DoerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// FromAuthClient returns the Doer sending the requests using the client
+// of the auth stack, signing them with its credentials
+func FromAuthClient(client auth.Client) Doer {
+	return client
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	auth "github.com/go-core-stack/auth/client"
+)
+
+func TestDoer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + r.Header.Get("X-Api-Key-Id")))
+	}))
+	defer srv.Close()
+
+	authClient, err := auth.NewClient(srv.URL, "key", "secret", true)
+	if err != nil {
+		t.Fatalf("auth.NewClient() failed with %v; want success", err)
+	}
+	cfg := NewServiceConfig("example.Objects")
+	for name, spec := range map[string]struct {
+		client Doer
+		want   string
+	}{
+		"http client": {client: srv.Client()},
+		"func": {
+			client: DoerFunc(func(r *http.Request) (*http.Response, error) {
+				r.Header.Set("Authorization", "Bearer t1")
+				return srv.Client().Do(r)
+			}),
+			want: "Bearer t1",
+		},
+		"auth client": {client: FromAuthClient(authClient), want: "key"},
+	} {
+		r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/v1/objects", nil)
+		if err != nil {
+			t.Fatalf("NewRequest() failed with %v; want success", err)
+		}
+		resp, err := cfg.Do(spec.client, r)
+		if err != nil {
+			t.Errorf("Do() using %s failed with %v; want success", name, err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if !strings.Contains(string(body), spec.want) {
+			t.Errorf("Do() using %s sent credentials %q; want %q", name, body, spec.want)
+		}
+	}
+}
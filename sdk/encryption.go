@@ -0,0 +1,47 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-core-stack/grpc-core/envelope"
+)
+
+// WithKMS provides the KMS used by the generated SDK to seal the
+// request fields marked using (api.encrypted), calls to such methods
+// fail without a KMS
+func WithKMS(kms envelope.KMS) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.kms = kms
+	}
+}
+
+// KMS returns the KMS provided using WithKMS, nil if not provided
+func (c *ServiceConfig) KMS() envelope.KMS {
+	if c == nil {
+		return nil
+	}
+	return c.kms
+}
+
+// SealFields returns a copy of the request message with the given
+// fields sealed using the KMS provided for the service, leaving the
+// message provided by the caller untouched
+func SealFields[M proto.Message](ctx context.Context, cfg *ServiceConfig, msg M, fields ...string) (M, error) {
+	kms := cfg.KMS()
+	if kms == nil {
+		var zero M
+		return zero, errors.New("KMS is required to seal the encrypted fields, see sdk.WithKMS")
+	}
+	out := proto.Clone(msg).(M)
+	if err := envelope.SealFields(ctx, kms, out, fields...); err != nil {
+		var zero M
+		return zero, err
+	}
+	return out, nil
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/go-core-stack/grpc-core/envelope"
+)
+
+func TestSealFields(t *testing.T) {
+	ctx := context.Background()
+	kms, err := envelope.NewLocalKMS("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatalf("NewLocalKMS() failed with %v; want success", err)
+	}
+	msg := wrapperspb.String("secret")
+	if _, err := SealFields(ctx, NewServiceConfig("example.Test"), msg, "value"); err == nil {
+		t.Errorf("SealFields() without KMS succeeded; want error")
+	}
+
+	cfg := NewServiceConfig("example.Test", WithKMS(kms))
+	sealed, err := SealFields(ctx, cfg, msg, "value")
+	if err != nil {
+		t.Fatalf("SealFields() failed with %v; want success", err)
+	}
+	if !envelope.IsSealed(sealed.GetValue()) {
+		t.Errorf("SealFields() = %q; want sealed value", sealed.GetValue())
+	}
+	if got, want := msg.GetValue(), "secret"; got != want {
+		t.Errorf("SealFields() modified the request to %q; want %q", got, want)
+	}
+	if err := envelope.OpenFields(ctx, cfg.KMS(), sealed, "value"); err != nil || sealed.GetValue() != "secret" {
+		t.Errorf("OpenFields() = %q, %v; want %q", sealed.GetValue(), err, "secret")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/go-core-stack/grpc-core/errcode"
+)
+
+// APIError is the error returned for the unsuccessful responses,
+// decoded from the google.rpc.Status sent by the server. It is also a
+// grpc status error, such that status.FromError and status.Code work
+// as is
+//
+//	var apiErr *sdk.APIError
+//	if errors.As(err, &apiErr) && apiErr.Code == codes.NotFound {
+//		...
+//	}
+type APIError struct {
+	// StatusCode is the http status code of the response
+	StatusCode int
+
+	// Code is the grpc code as sent by the server, derived from the
+	// http status code using errcode.Default when not available
+	Code codes.Code
+
+	// Message describing the error
+	Message string
+
+	// Details are the error details sent by the server, dropped if any
+	// of them is of an unknown type
+	Details []*anypb.Any
+
+	// Body is the raw body of the response when it is not a
+	// google.rpc.Status, like the errors sent by the proxies
+	Body []byte
+}
+
+// Error returns the description of the error, formatted the same as
+// the grpc status errors
+func (e *APIError) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", e.Code, e.Message)
+}
+
+// GRPCStatus returns the grpc status of the error
+func (e *APIError) GRPCStatus() *status.Status {
+	return status.FromProto(&spb.Status{
+		Code:    int32(e.Code),
+		Message: e.Message,
+		Details: e.Details,
+	})
+}
+
+// DecodeError returns the *APIError for the unsuccessful response, the
+// google.rpc.Status encoded as JSON or as protobuf, as responded to the
+// calls configured using WithProtobuf. The grpc code and details are as
+// sent by the server, the code is derived from the http status code
+// using errcode.Default when not available
+func DecodeError(statusCode int, body []byte) error {
+	apiErr := &APIError{StatusCode: statusCode}
+	st := &spb.Status{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, st); err != nil && !decodeProtoStatus(body, st) {
+		// details of unknown types cannot be decoded, retain the
+		// code and the message alone
+		var fallback struct {
+			Code    int32  `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &fallback) != nil {
+			apiErr.Body = body
+		}
+		st = &spb.Status{Code: fallback.Code, Message: fallback.Message}
+	}
+	apiErr.Code = codes.Code(st.Code)
+	apiErr.Message = st.Message
+	apiErr.Details = st.Details
+	if apiErr.Code == codes.OK {
+		apiErr.Code = errcode.Default.Code(statusCode)
+		if apiErr.Code == codes.OK {
+			// unexpected status code even if successful
+			apiErr.Code = codes.Unknown
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected status code: %d", statusCode)
+	}
+	return apiErr
+}
+
+// decodeProtoStatus decodes the google.rpc.Status encoded as protobuf,
+// the bodies being valid JSON are not considered
+func decodeProtoStatus(body []byte, st *spb.Status) bool {
+	if len(body) == 0 || json.Valid(body) {
+		return false
+	}
+	proto.Reset(st)
+	if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, st); err != nil || st.Code == 0 {
+		proto.Reset(st)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeError(t *testing.T) {
+	pb, err := proto.Marshal(status.New(codes.FailedPrecondition, "object is in use").Proto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() failed with %v; want success", err)
+	}
+	for _, spec := range []struct {
+		name    string
+		status  int
+		body    string
+		code    codes.Code
+		message string
+	}{
+		{
+			name:    "status body",
+			status:  http.StatusBadRequest,
+			body:    `{"code":9,"message":"object is in use","details":[]}`,
+			code:    codes.FailedPrecondition,
+			message: "object is in use",
+		},
+		{
+			name:    "unknown details",
+			status:  http.StatusBadRequest,
+			body:    `{"code":9,"message":"object is in use","details":[{"@type":"type.example.com/unknown","value":"x"}]}`,
+			code:    codes.FailedPrecondition,
+			message: "object is in use",
+		},
+		{
+			name:    "protobuf status body",
+			status:  http.StatusBadRequest,
+			body:    string(pb),
+			code:    codes.FailedPrecondition,
+			message: "object is in use",
+		},
+		{
+			name:    "no body",
+			status:  http.StatusNotFound,
+			code:    codes.NotFound,
+			message: "unexpected status code: 404",
+		},
+		{
+			name:    "non status body",
+			status:  http.StatusBadGateway,
+			body:    `<html>bad gateway</html>`,
+			code:    codes.Unknown,
+			message: "unexpected status code: 502",
+		},
+		{
+			name:    "unexpected success",
+			status:  http.StatusNoContent,
+			code:    codes.Unknown,
+			message: "unexpected status code: 204",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			st, ok := status.FromError(DecodeError(spec.status, []byte(spec.body)))
+			if !ok {
+				t.Fatalf("DecodeError() is not a status error")
+			}
+			if st.Code() != spec.code || st.Message() != spec.message {
+				t.Errorf("DecodeError() = %v, %q; want %v, %q", st.Code(), st.Message(), spec.code, spec.message)
+			}
+		})
+	}
+}
+
+func TestDecodeAPIError(t *testing.T) {
+	body := `{"code":5,"message":"object not found","details":[{"@type":"type.googleapis.com/google.rpc.ResourceInfo","resourceName":"a"}]}`
+	err := fmt.Errorf("get object: %w", DecodeError(http.StatusNotFound, []byte(body)))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("DecodeError() = %T; want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != codes.NotFound || apiErr.Message != "object not found" || apiErr.Body != nil {
+		t.Errorf("DecodeError() = %+v; want 404, NotFound with message", apiErr)
+	}
+	if want := "rpc error: code = NotFound desc = object not found"; apiErr.Error() != want {
+		t.Errorf("apiErr.Error() = %q; want %q", apiErr.Error(), want)
+	}
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("status details = %v; want the resource info", details)
+	}
+	if info, ok := details[0].(*errdetails.ResourceInfo); !ok || info.GetResourceName() != "a" {
+		t.Errorf("status details = %v; want resource info of a", details)
+	}
+
+	// raw body is retained for the responses not carrying the status
+	err = DecodeError(http.StatusBadGateway, []byte("<html>bad gateway</html>"))
+	if !errors.As(err, &apiErr) || string(apiErr.Body) != "<html>bad gateway</html>" {
+		t.Errorf("DecodeError() = %+v; want the raw body retained", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/go-core-stack/grpc-core/compression"
+)
+
+// FakeMux serves the HTTP routes of the generated fake servers, decoding
+// the requests the same as grpc-gateway and encoding the responses and
+// the errors as expected by the SDK, such that the SDK consumers can run
+// the integration style tests against httptest.Server without a real
+// backend
+type FakeMux struct {
+	mux       *runtime.ServeMux
+	marshaler *runtime.JSONPb
+}
+
+// NewFakeMux creates the mux for the fake servers
+func NewFakeMux() *FakeMux {
+	return &FakeMux{
+		mux:       runtime.NewServeMux(),
+		marshaler: &runtime.JSONPb{},
+	}
+}
+
+// ServeHTTP serves the request using the registered routes, responding
+// with 404 Not Found for the unknown routes
+func (m *FakeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
+
+// FakeRoute describes the HTTP route of a method served by the fake
+type FakeRoute struct {
+	// Method is the fully qualified name of the method, reported in
+	// the Unimplemented errors
+	Method string
+	// HTTPMethod and Pattern are the http method and the path template
+	// of the binding, as per google.api.http
+	HTTPMethod string
+	Pattern    string
+	// Body is the field path the request body is mapped to, "*" for
+	// the request message, empty when there is no body
+	Body string
+	// ResponseBody is the field of the response the payload is mapped
+	// to, empty for the response message
+	ResponseBody string
+}
+
+// HandleFake registers the route calling the handler func, which is
+// looked up on every request such that it can be set once the route is
+// registered, responding with Unimplemented while it is nil. The func
+// is read without synchronization, it must not be set concurrently with
+// the requests served
+func HandleFake[Req, Resp proto.Message](m *FakeMux, route FakeRoute, fn *func(context.Context, Req) (Resp, error)) {
+	err := m.mux.HandlePath(route.HTTPMethod, route.Pattern, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		var zero Req
+		req := zero.ProtoReflect().New().Interface().(Req)
+		if err := m.decodeRequest(r, params, route.Body, req); err != nil {
+			m.writeError(w, r, err)
+			return
+		}
+		handler := *fn
+		if handler == nil {
+			m.writeError(w, r, status.Errorf(codes.Unimplemented, "method %s not implemented", route.Method))
+			return
+		}
+		resp, err := handler(r.Context(), req)
+		if err != nil {
+			m.writeError(w, r, err)
+			return
+		}
+		m.writeResponse(w, r, route.ResponseBody, resp)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("invalid route %s %s of %s: %v", route.HTTPMethod, route.Pattern, route.Method, err))
+	}
+}
+
+// decodeRequest populates the request from the body, the path params
+// and the query params, in that order, as done by grpc-gateway
+func (m *FakeMux) decodeRequest(r *http.Request, params map[string]string, body string, req proto.Message) error {
+	var filter [][]string
+	if body != "" {
+		if err := m.decodeBody(r, body, req); err != nil {
+			return err
+		}
+		filter = append(filter, strings.Split(body, "."))
+	}
+	for name, value := range params {
+		if err := runtime.PopulateFieldFromPath(req, name, value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", name, err)
+		}
+		filter = append(filter, strings.Split(name, "."))
+	}
+	if body == "*" {
+		return nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := runtime.PopulateQueryParameters(req, r.Form, utilities.NewDoubleArray(filter)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// decodeBody decodes the body, transparently decompressing the bodies
+// sent by the services using WithCompression
+func (m *FakeMux) decodeBody(r *http.Request, body string, req proto.Message) error {
+	var reader io.Reader = r.Body
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" {
+		codec, ok := compression.Lookup(encoding)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unsupported content encoding %q", encoding)
+		}
+		zr, err := codec.NewReader(r.Body)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s body: %v", encoding, err)
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read body: %v", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if body != "*" {
+		// decode the value of the field as part of the enclosing
+		// message, nesting it for the field paths
+		fields := strings.Split(body, ".")
+		for i := len(fields) - 1; i >= 0; i-- {
+			data = []byte(fmt.Sprintf("{%q:%s}", fields[i], data))
+		}
+	}
+	um := m.marshaler.UnmarshalOptions
+	um.DiscardUnknown = true
+	if err := um.Unmarshal(data, req); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decode body: %v", err)
+	}
+	return nil
+}
+
+// writeResponse writes the response, or the field of the response the
+// payload is mapped to
+func (m *FakeMux) writeResponse(w http.ResponseWriter, r *http.Request, responseBody string, resp proto.Message) {
+	if responseBody != "" {
+		// raw bytes of the body mapped to google.api.HttpBody written
+		// as is with its declared content type, as done by grpc-gateway
+		fd := resp.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(responseBody))
+		if fd != nil && !fd.IsList() && fd.Message() != nil && fd.Message().FullName() == "google.api.HttpBody" {
+			body, _ := resp.ProtoReflect().Get(fd).Message().Interface().(*httpbody.HttpBody)
+			w.Header().Set("Content-Type", body.GetContentType())
+			_, _ = w.Write(body.GetData())
+			return
+		}
+	}
+	data, err := m.marshaler.Marshal(resp)
+	if err != nil {
+		m.writeError(w, r, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	if responseBody != "" {
+		fd := resp.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(responseBody))
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil || fd == nil {
+			m.writeError(w, r, status.Errorf(codes.Internal, "failed to encode response body %s", responseBody))
+			return
+		}
+		data = fields[fd.JSONName()]
+		if data == nil {
+			data = []byte("null")
+		}
+	}
+	w.Header().Set("Content-Type", m.marshaler.ContentType(resp))
+	_, _ = w.Write(data)
+}
+
+// writeError writes the error as the google.rpc.Status with the http
+// status code of the grpc code, as done by grpc-gateway
+func (m *FakeMux) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	runtime.DefaultHTTPErrorHandler(r.Context(), m.mux, m.marshaler, w, r, err)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFakeMux(t *testing.T) {
+	type field = descriptorpb.FieldDescriptorProto
+	var create, get, update func(context.Context, *field) (*field, error)
+	mux := NewFakeMux()
+	HandleFake(mux, FakeRoute{Method: "example.Fields.Create", HTTPMethod: http.MethodPost, Pattern: "/v1/fields", Body: "*"}, &create)
+	HandleFake(mux, FakeRoute{Method: "example.Fields.Get", HTTPMethod: http.MethodGet, Pattern: "/v1/fields/{name}", ResponseBody: "type_name"}, &get)
+	HandleFake(mux, FakeRoute{Method: "example.Fields.Update", HTTPMethod: http.MethodPatch, Pattern: "/v1/fields/{name}", Body: "options"}, &update)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	call := func(method, path, body string, header http.Header) (int, string) {
+		t.Helper()
+		r, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest() failed with %v; want success", err)
+		}
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	// handlers not set yet are unimplemented
+	code, body := call(http.MethodPost, "/v1/fields", `{"name":"id"}`, nil)
+	if err := DecodeError(code, []byte(body)); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Create() without the handler = %v; want Unimplemented", err)
+	}
+
+	create = func(ctx context.Context, req *field) (*field, error) {
+		return req, nil
+	}
+	var zipped bytes.Buffer
+	zw := gzip.NewWriter(&zipped)
+	_, _ = zw.Write([]byte(`{"name":"id","number":1,"unknown":true}`))
+	_ = zw.Close()
+	want := &field{Name: proto.String("id"), Number: proto.Int32(1)}
+	if code, body := call(http.MethodPost, "/v1/fields", zipped.String(), http.Header{"Content-Encoding": {"gzip"}}); code != http.StatusOK || !equalJSON(body, want) {
+		t.Errorf("Create() = %d %s; want 200 with %v", code, body, want)
+	}
+
+	get = func(ctx context.Context, req *field) (*field, error) {
+		if req.GetName() != "id" || req.GetNumber() != 7 {
+			return nil, status.Errorf(codes.NotFound, "field %s/%d not found", req.GetName(), req.GetNumber())
+		}
+		return &field{Name: proto.String("id"), TypeName: proto.String(".example.Id")}, nil
+	}
+	if code, body := call(http.MethodGet, "/v1/fields/id?number=7", "", nil); code != http.StatusOK || body != `".example.Id"` {
+		t.Errorf("Get() = %d %s; want 200 with the response body field", code, body)
+	}
+	code, body = call(http.MethodGet, "/v1/fields/other", "", nil)
+	if err := DecodeError(code, []byte(body)); code != http.StatusNotFound || status.Code(err) != codes.NotFound {
+		t.Errorf("Get() of the unknown field = %d %v; want 404 NotFound", code, err)
+	}
+
+	update = func(ctx context.Context, req *field) (*field, error) {
+		return req, nil
+	}
+	want = &field{Name: proto.String("id"), Number: proto.Int32(3), Options: &descriptorpb.FieldOptions{Packed: proto.Bool(true)}}
+	if code, body := call(http.MethodPatch, "/v1/fields/id?number=3", `{"packed":true}`, nil); code != http.StatusOK || !equalJSON(body, want) {
+		t.Errorf("Update() = %d %s; want 200 with %v", code, body, want)
+	}
+
+	if code, _ := call(http.MethodGet, "/v2/fields", "", nil); code != http.StatusNotFound {
+		t.Errorf("unknown route = %d; want 404", code)
+	}
+}
+
+// equalJSON reports whether the JSON encoded message equals the message
+func equalJSON(data string, want proto.Message) bool {
+	got := want.ProtoReflect().New().Interface()
+	return protojson.Unmarshal([]byte(data), got) == nil && proto.Equal(got, want)
+}
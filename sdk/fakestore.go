@@ -0,0 +1,437 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Verbs of the (api.role) option the fakes serve with the CRUD semantics
+// using the FakeStore, the methods with the other verbs respond with the
+// request converted to the response
+const (
+	FakeVerbCreate = "create"
+	FakeVerbGet    = "get"
+	FakeVerbList   = "list"
+	FakeVerbUpdate = "update"
+	FakeVerbDelete = "delete"
+)
+
+// FakeStore holds the resources served by the fakes in memory, as the
+// JSON encoded messages keyed by the name of the resource and the key
+// of the resource, safe for concurrent use
+type FakeStore struct {
+	mu        sync.Mutex
+	resources map[string]map[string]json.RawMessage
+	seq       int
+}
+
+// NewFakeStore creates the empty store
+func NewFakeStore() *FakeStore {
+	return &FakeStore{resources: map[string]map[string]json.RawMessage{}}
+}
+
+// Seed loads the resources from the JSON fixtures, an object keyed by
+// the names of the resources holding the objects keyed by the keys of
+// the resources, the keys joining the values of the path params
+// identifying the resource using "/"
+//
+//	{"object": {"a": {"name": "a", "desc": "first"}}}
+func (s *FakeStore) Seed(r io.Reader) error {
+	var fixtures map[string]map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&fixtures); err != nil {
+		return fmt.Errorf("failed to decode fixtures: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for resource, items := range fixtures {
+		for key, item := range items {
+			s.collection(resource)[key] = item
+		}
+	}
+	return nil
+}
+
+// collection returns the resources of the name, called with the lock
+func (s *FakeStore) collection(resource string) map[string]json.RawMessage {
+	c, ok := s.resources[resource]
+	if !ok {
+		c = map[string]json.RawMessage{}
+		s.resources[resource] = c
+	}
+	return c
+}
+
+// FakeResource describes the resource a method of the fake acts on, as
+// per the (api.role) option of the method
+type FakeResource struct {
+	// Resource is the name of the resource
+	Resource string
+	// Verb is the verb of the method, one of the FakeVerb constants for
+	// the CRUD semantics
+	Verb string
+	// Parent are the paths of the fields of the request identifying the
+	// parent of the resource, the path params preceding the collection
+	// of the resource in the path template
+	Parent []string
+	// Keys are the paths of the fields of the request identifying the
+	// resource within the parent, the trailing path param of the method
+	Keys []string
+	// Body is the path of the field of the request carrying the
+	// resource, "*" or empty for the request message
+	Body string
+	// ID is the path of the field of the resource holding the identity
+	// of the resource, set by create with the key identifying the
+	// resource within the parent
+	ID string
+	// Filters are the paths of the fields of the list request, listing
+	// the resources having the fields of the same names equal to the
+	// ones set by the request
+	Filters []string
+	// Upsert marks the update as the upsert, as per the (api.upsert)
+	// option, creating the resource when missing and replacing it
+	// otherwise
+	Upsert bool
+}
+
+// FakeCRUD returns the function serving the method of the fake using
+// the store, with the semantics of the verb of the resource. Create
+// adds the resource, assigning a sequential key when the request does
+// not identify it, get, update and delete act on the resource
+// identified by the request, merging the fields set by the update or
+// replacing the resource by the upsert, creating it if missing, and
+// list responds with the resources of the parent matching the filters
+// set as the first repeated message field of the response. Responses
+// are converted from the resources matching the fields by name
+//
+//	fake := &FakeHelloWorldServer{}
+//	fake.GetObjectFunc = sdk.FakeCRUD[*PostRequest, *PostResponse](store, sdk.FakeResource{
+//		Resource: "object",
+//		Verb:     sdk.FakeVerbGet,
+//		Keys:     []string{"name"},
+//		ID:       "name",
+//	})
+func FakeCRUD[Req, Resp proto.Message](s *FakeStore, res FakeResource) func(context.Context, Req) (Resp, error) {
+	return func(ctx context.Context, req Req) (Resp, error) {
+		var zero Resp
+		resp := zero.ProtoReflect().New().Interface().(Resp)
+		parent, err := fakeKey(req.ProtoReflect(), res.Parent)
+		if err != nil {
+			return zero, err
+		}
+		id, err := fakeKey(req.ProtoReflect(), res.Keys)
+		if err != nil {
+			return zero, err
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		c := s.collection(res.Resource)
+		if res.Verb == FakeVerbList {
+			var filters map[string][]string
+			if filters, err = fakeFilters(req.ProtoReflect(), res.Filters); err != nil {
+				return zero, err
+			}
+			keys := make([]string, 0, len(c))
+			for k := range c {
+				if parent == "" || strings.HasPrefix(k, parent+"/") {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			items := make([]json.RawMessage, 0, len(keys))
+			for _, k := range keys {
+				if matchFakeFilters(c[k], filters) {
+					items = append(items, c[k])
+				}
+			}
+			if err := setFakeItems(resp.ProtoReflect(), items); err != nil {
+				return zero, err
+			}
+			return resp, nil
+		}
+
+		if res.Verb == FakeVerbCreate && id == "" {
+			s.seq++
+			id = strconv.Itoa(s.seq)
+		}
+		key := id
+		if parent != "" {
+			key = parent + "/" + id
+		}
+		idField := ""
+		if res.Verb == FakeVerbCreate || res.Upsert {
+			idField = res.ID
+		}
+		body, err := fakeBody(req.ProtoReflect(), res.Body, idField, id)
+		if err != nil {
+			return zero, err
+		}
+		var data json.RawMessage
+		switch res.Verb {
+		case FakeVerbCreate:
+			if _, ok := c[key]; ok {
+				return zero, status.Errorf(codes.AlreadyExists, "%s %s already exists", res.Resource, key)
+			}
+			data = body
+			c[key] = data
+		case FakeVerbGet, FakeVerbUpdate, FakeVerbDelete:
+			if res.Upsert && res.Verb == FakeVerbUpdate {
+				data = body
+				c[key] = data
+				break
+			}
+			stored, ok := c[key]
+			if !ok {
+				return zero, status.Errorf(codes.NotFound, "%s %s not found", res.Resource, key)
+			}
+			data = stored
+			switch res.Verb {
+			case FakeVerbUpdate:
+				data, err = mergeFakeJSON(stored, body)
+				if err != nil {
+					return zero, status.Errorf(codes.Internal, "failed to update %s %s: %v", res.Resource, key, err)
+				}
+				c[key] = data
+			case FakeVerbDelete:
+				delete(c, key)
+				return resp, nil
+			}
+		default:
+			data = body
+		}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, resp); err != nil {
+			return zero, status.Errorf(codes.Internal, "failed to convert %s %s: %v", res.Resource, key, err)
+		}
+		return resp, nil
+	}
+}
+
+// fakeKey returns the key of the resource identified by the request,
+// joining the values of the key fields using "/", empty if none is set
+func fakeKey(msg protoreflect.Message, keys []string) (string, error) {
+	values := make([]string, 0, len(keys))
+	set := false
+	for _, k := range keys {
+		v, fd, ok := fakeField(msg, k)
+		if !ok || fd.Message() != nil || fd.IsList() {
+			return "", status.Errorf(codes.Internal, "invalid key field %s of %s", k, msg.Descriptor().FullName())
+		}
+		set = set || !v.Equal(fd.Default())
+		values = append(values, fmt.Sprint(v.Interface()))
+	}
+	if !set {
+		return "", nil
+	}
+	return strings.Join(values, "/"), nil
+}
+
+// fakeFilters returns the values of the filters set by the list
+// request keyed by the normalized names of the fields, along with the
+// alternate forms of the values the fixtures may use, like the names
+// and the numbers of the enums
+func fakeFilters(msg protoreflect.Message, filters []string) (map[string][]string, error) {
+	values := map[string][]string{}
+	for _, f := range filters {
+		v, fd, ok := fakeField(msg, f)
+		if !ok || fd.Message() != nil || fd.IsList() || fd.IsMap() {
+			return nil, status.Errorf(codes.Internal, "invalid filter field %s of %s", f, msg.Descriptor().FullName())
+		}
+		if v.Equal(fd.Default()) {
+			continue
+		}
+		name := normalizeName(string(fd.Name()))
+		values[name] = []string{fmt.Sprint(v.Interface())}
+		if fd.Enum() != nil {
+			values[name] = []string{strconv.Itoa(int(v.Enum()))}
+			if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+				values[name] = append(values[name], string(ev.Name()))
+			}
+		}
+	}
+	return values, nil
+}
+
+// matchFakeFilters reports whether the resource has the fields of the
+// filters equal to one of the values of the filters
+func matchFakeFilters(item json.RawMessage, filters map[string][]string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	var fields map[string]any
+	d := json.NewDecoder(bytes.NewReader(item))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return false
+	}
+	matched := 0
+	for name, value := range fields {
+		want, ok := filters[normalizeName(name)]
+		if !ok {
+			continue
+		}
+		if !slices.Contains(want, fmt.Sprint(value)) {
+			return false
+		}
+		matched++
+	}
+	return matched == len(filters)
+}
+
+// fakeBody returns the JSON encoded field of the request carrying the
+// resource, with the field holding the identity of the resource set to
+// the id if any
+func fakeBody(msg protoreflect.Message, body, idField, id string) (json.RawMessage, error) {
+	if body != "" && body != "*" {
+		v, _, ok := fakeField(msg, body)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "invalid body field %s of %s", body, msg.Descriptor().FullName())
+		}
+		m, ok := v.Interface().(protoreflect.Message)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "body field %s of %s is not a message", body, msg.Descriptor().FullName())
+		}
+		msg = m
+	}
+	if idField != "" && id != "" {
+		msg = proto.Clone(msg.Interface()).ProtoReflect()
+		if err := setFakeID(msg, idField, id); err != nil {
+			return nil, err
+		}
+	}
+	data, err := protojson.Marshal(msg.Interface())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode %s: %v", msg.Descriptor().FullName(), err)
+	}
+	return data, nil
+}
+
+// setFakeID sets the field at the dotted path holding the identity of
+// the resource to the id, converted to the kind of the field
+func setFakeID(msg protoreflect.Message, path, id string) error {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return status.Errorf(codes.Internal, "invalid id field %s of %s", path, msg.Descriptor().FullName())
+		}
+		msg = msg.Mutable(fd).Message()
+	}
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return status.Errorf(codes.Internal, "invalid id field %s of %s", path, msg.Descriptor().FullName())
+	}
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(id)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(id, 10, 32)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q: %v", path, id, err)
+		}
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q: %v", path, id, err)
+		}
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q: %v", path, id, err)
+		}
+		v = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q: %v", path, id, err)
+		}
+		v = protoreflect.ValueOfUint64(n)
+	default:
+		return status.Errorf(codes.Internal, "id field %s of %s is not a string or an integer", path, msg.Descriptor().FullName())
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+// fakeField returns the value of the field at the dotted path of the
+// proto names of the fields, along with the field
+func fakeField(msg protoreflect.Message, path string) (protoreflect.Value, protoreflect.FieldDescriptor, bool) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return protoreflect.Value{}, nil, false
+		}
+		v := msg.Get(fd)
+		if i == len(names)-1 {
+			return v, fd, true
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return protoreflect.Value{}, nil, false
+		}
+		msg = v.Message()
+	}
+	return protoreflect.Value{}, nil, false
+}
+
+// setFakeItems sets the resources as the first repeated message field
+// of the response, left empty if there is none
+func setFakeItems(resp protoreflect.Message, items []json.RawMessage) error {
+	fields := resp.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !fd.IsList() || fd.Message() == nil {
+			continue
+		}
+		list := resp.Mutable(fd).List()
+		for _, item := range items {
+			elem := list.NewElement()
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(item, elem.Message().Interface()); err != nil {
+				return status.Errorf(codes.Internal, "failed to convert %s: %v", fd.Message().FullName(), err)
+			}
+			list.Append(elem)
+		}
+		return nil
+	}
+	return nil
+}
+
+// mergeFakeJSON returns the stored object with the fields set by the
+// update replaced, matching the fields irrespective of the JSON or the
+// proto naming, as the fixtures may use either
+func mergeFakeJSON(stored, update json.RawMessage) (json.RawMessage, error) {
+	var base, patch map[string]json.RawMessage
+	if err := json.Unmarshal(stored, &base); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(update, &patch); err != nil {
+		return nil, err
+	}
+	for key, value := range patch {
+		for existing := range base {
+			if normalizeName(existing) == normalizeName(key) {
+				delete(base, existing)
+			}
+		}
+		base[key] = value
+	}
+	return json.Marshal(base)
+}
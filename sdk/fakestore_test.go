@@ -0,0 +1,137 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFakeCRUD(t *testing.T) {
+	type field = descriptorpb.FieldDescriptorProto
+	type message = descriptorpb.DescriptorProto
+	ctx := context.Background()
+	store := NewFakeStore()
+	if err := store.Seed(strings.NewReader(`{"field": {"id": {"name": "id", "type_name": "string"}}}`)); err != nil {
+		t.Fatalf("Seed() failed with %v; want success", err)
+	}
+	crud := func(verb string, keys ...string) func(context.Context, *field) (*field, error) {
+		return FakeCRUD[*field, *field](store, FakeResource{Resource: "field", Verb: verb, Keys: keys, Body: "*"})
+	}
+	create, get, update, remove := crud(FakeVerbCreate, "name"), crud(FakeVerbGet, "name"), crud(FakeVerbUpdate, "name"), crud(FakeVerbDelete, "name")
+	list := FakeCRUD[*message, *message](store, FakeResource{Resource: "field", Verb: FakeVerbList})
+
+	if got, err := get(ctx, &field{Name: proto.String("id")}); err != nil || got.GetTypeName() != "string" {
+		t.Errorf("get(id) = %v, %v; want the seeded field", got, err)
+	}
+	if _, err := create(ctx, &field{Name: proto.String("id")}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("create(id) = %v; want AlreadyExists", err)
+	}
+	if _, err := create(ctx, &field{Name: proto.String("count"), Number: proto.Int32(2)}); err != nil {
+		t.Fatalf("create(count) failed with %v; want success", err)
+	}
+	// the fields set by the update replace the stored ones irrespective
+	// of the naming used by the fixtures
+	got, err := update(ctx, &field{Name: proto.String("id"), TypeName: proto.String("bytes")})
+	if err != nil || got.GetTypeName() != "bytes" {
+		t.Errorf("update(id) = %v, %v; want type_name bytes", got, err)
+	}
+	if _, err := update(ctx, &field{Name: proto.String("missing")}); status.Code(err) != codes.NotFound {
+		t.Errorf("update(missing) = %v; want NotFound", err)
+	}
+	all, err := list(ctx, &message{})
+	if err != nil || len(all.GetField()) != 2 || all.GetField()[0].GetName() != "count" || all.GetField()[1].GetTypeName() != "bytes" {
+		t.Errorf("list() = %v, %v; want count and id sorted by key", all, err)
+	}
+	if _, err := remove(ctx, &field{Name: proto.String("id")}); err != nil {
+		t.Errorf("delete(id) failed with %v; want success", err)
+	}
+	if _, err := get(ctx, &field{Name: proto.String("id")}); status.Code(err) != codes.NotFound {
+		t.Errorf("get(id) after delete = %v; want NotFound", err)
+	}
+
+	// resources not identified by the request get the sequential keys,
+	// while the other verbs respond with the request
+	anonymous := crud(FakeVerbCreate)
+	for i := 0; i < 2; i++ {
+		if _, err := anonymous(ctx, &field{Name: proto.String("dup")}); err != nil {
+			t.Errorf("create() without key failed with %v; want success", err)
+		}
+	}
+	if got, err := crud("poweroff", "name")(ctx, &field{Name: proto.String("x")}); err != nil || got.GetName() != "x" {
+		t.Errorf("poweroff(x) = %v, %v; want the request", got, err)
+	}
+
+	// upserts create the missing resources and replace the existing ones
+	upsert := FakeCRUD[*field, *field](store, FakeResource{Resource: "field", Verb: FakeVerbUpdate, Keys: []string{"name"}, Body: "*", ID: "name", Upsert: true})
+	for _, typeName := range []string{"int32", "string"} {
+		if _, err := upsert(ctx, &field{Name: proto.String("up"), TypeName: proto.String(typeName)}); err != nil {
+			t.Errorf("upsert(up) failed with %v; want success", err)
+		}
+	}
+	if got, err := get(ctx, &field{Name: proto.String("up")}); err != nil || got.GetTypeName() != "string" {
+		t.Errorf("get(up) = %v, %v; want type_name string", got, err)
+	}
+}
+
+func TestFakeCRUDParentAndFilters(t *testing.T) {
+	type field = descriptorpb.FieldDescriptorProto
+	type message = descriptorpb.DescriptorProto
+	ctx := context.Background()
+	store := NewFakeStore()
+	if err := store.Seed(strings.NewReader(`{"field": {"a/id": {"name": "id", "type": 9}}}`)); err != nil {
+		t.Fatalf("Seed() failed with %v; want success", err)
+	}
+	res := FakeResource{Resource: "field", Parent: []string{"extendee"}, Keys: []string{"name"}, Body: "*", ID: "name"}
+	create := FakeCRUD[*field, *field](store, FakeResource{Resource: "field", Verb: FakeVerbCreate, Parent: res.Parent, Body: "*", ID: "name"})
+	res.Verb = FakeVerbGet
+	get := FakeCRUD[*field, *field](store, res)
+	list := FakeCRUD[*field, *message](store, FakeResource{Resource: "field", Verb: FakeVerbList, Parent: res.Parent, Filters: []string{"type"}})
+
+	// create without the key assigns the id to the resource
+	created, err := create(ctx, &field{Extendee: proto.String("a"), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()})
+	if err != nil || created.GetName() != "1" {
+		t.Fatalf("create() = %v, %v; want name 1", created, err)
+	}
+	if _, err := create(ctx, &field{Extendee: proto.String("b"), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()}); err != nil {
+		t.Fatalf("create() failed with %v; want success", err)
+	}
+	if _, err := get(ctx, &field{Extendee: proto.String("a"), Name: proto.String("1")}); err != nil {
+		t.Errorf("get(a/1) failed with %v; want success", err)
+	}
+	if _, err := get(ctx, &field{Extendee: proto.String("b"), Name: proto.String("1")}); status.Code(err) != codes.NotFound {
+		t.Errorf("get(b/1) = %v; want NotFound", err)
+	}
+
+	for _, spec := range []struct {
+		req  *field
+		want []string
+	}{
+		{req: &field{}, want: []string{"1", "id", "2"}},
+		{req: &field{Extendee: proto.String("a")}, want: []string{"1", "id"}},
+		{req: &field{Extendee: proto.String("b")}, want: []string{"2"}},
+		// the fixtures may use the numbers of the enums
+		{req: &field{Extendee: proto.String("a"), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()}, want: []string{"id"}},
+		{req: &field{Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()}, want: []string{"1"}},
+	} {
+		got, err := list(ctx, spec.req)
+		if err != nil {
+			t.Errorf("list(%v) failed with %v; want success", spec.req, err)
+			continue
+		}
+		var names []string
+		for _, f := range got.GetField() {
+			names = append(names, f.GetName())
+		}
+		if strings.Join(names, ",") != strings.Join(spec.want, ",") {
+			t.Errorf("list(%v) = %v; want %v", spec.req, names, spec.want)
+		}
+	}
+}
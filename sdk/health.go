@@ -0,0 +1,101 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultProbeInterval is the interval between the health probes of
+// an endpoint, unless specified otherwise
+const DefaultProbeInterval = 10 * time.Second
+
+// healthProbe actively checks the health of the discovered endpoints
+type healthProbe struct {
+	path     string
+	interval time.Duration
+	client   *http.Client
+}
+
+// endpointHealth tracks the health of an endpoint, guarded by the
+// lock of the endpoint set
+type endpointHealth struct {
+	healthy bool
+	probing bool
+	probed  time.Time
+}
+
+// check probes the endpoint, any 2xx response is considered healthy
+func (p *healthProbe) check(endpoint *url.URL) bool {
+	timeout := min(p.interval, 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolve(endpoint, &url.URL{Path: p.path}).String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// healthyLocked returns the endpoints considered healthy, triggering
+// probes for the endpoints not probed within the interval, all the
+// endpoints are returned when none of them is healthy
+func (s *endpointSet) healthyLocked() []*url.URL {
+	now := time.Now()
+	health := make(map[string]*endpointHealth, len(s.endpoints))
+	var healthy []*url.URL
+	for _, e := range s.endpoints {
+		key := e.String()
+		h, ok := s.health[key]
+		if !ok {
+			// endpoints are assumed healthy till probed otherwise
+			h = &endpointHealth{healthy: true}
+		}
+		health[key] = h
+		if !h.probing && now.Sub(h.probed) >= s.probe.interval {
+			h.probing = true
+			go s.runProbe(e, h)
+		}
+		if h.healthy {
+			healthy = append(healthy, e)
+		}
+	}
+	// drop the state of the endpoints no longer resolved
+	s.health = health
+	if len(healthy) == 0 {
+		return s.endpoints
+	}
+	return healthy
+}
+
+// runProbe probes the endpoint in background, updating its health
+func (s *endpointSet) runProbe(endpoint *url.URL, h *endpointHealth) {
+	healthy := s.probe.check(endpoint)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h.healthy = healthy
+	h.probing = false
+	h.probed = time.Now()
+}
+
+// markUnhealthy marks the endpoint unhealthy on failure to reach it,
+// till a subsequent probe finds it healthy again
+func (s *endpointSet) markUnhealthy(endpoint *url.URL) {
+	if s.probe == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if h, ok := s.health[endpoint.String()]; ok {
+		h.healthy = false
+	}
+}
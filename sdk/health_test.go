@@ -0,0 +1,76 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientHealthProbe(t *testing.T) {
+	var healthy, unhealthy atomic.Int32
+	newServer := func(status int, hits *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				w.WriteHeader(status)
+				return
+			}
+			hits.Add(1)
+		}))
+	}
+	srvA := newServer(http.StatusOK, &healthy)
+	defer srvA.Close()
+	srvB := newServer(http.StatusServiceUnavailable, &unhealthy)
+	defer srvB.Close()
+
+	resolver, err := NewStaticResolver(srvA.URL, srvB.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(&Config{}, WithResolver(resolver, 0), WithHealthProbe("/healthz", time.Hour))
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func() {
+		t.Helper()
+		r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// first request triggers the probes, wait for them to complete
+	do()
+	set := c.(*client).set
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		set.mu.Lock()
+		probing := false
+		for _, h := range set.health {
+			probing = probing || h.probing
+		}
+		set.mu.Unlock()
+		if !probing {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("health probes did not complete")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	healthy.Store(0)
+	unhealthy.Store(0)
+	for range 4 {
+		do()
+	}
+	if healthy.Load() != 4 || unhealthy.Load() != 0 {
+		t.Errorf("hits = %d, %d; want requests only to the healthy endpoint", healthy.Load(), unhealthy.Load())
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+// defaultHTTPBodyContentType is the content type of the HttpBody bodies
+// not declaring one
+const defaultHTTPBodyContentType = "application/octet-stream"
+
+// NewHTTPBodyRequest creates the http request sending the data of the
+// google.api.HttpBody as is, with its declared content type, instead of
+// encoding the message as JSON, used for the request bodies mapped to
+// HttpBody like the file uploads
+func NewHTTPBodyRequest(ctx context.Context, method, uri string, body *httpbody.HttpBody) (*http.Request, error) {
+	r, err := http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(body.GetData()))
+	if err != nil {
+		return nil, err
+	}
+	contentType := body.GetContentType()
+	if contentType == "" {
+		contentType = defaultHTTPBodyContentType
+	}
+	r.Header.Set("Content-Type", contentType)
+	return r, nil
+}
+
+// FormFile is the file uploaded as part of the multipart form
+type FormFile struct {
+	// Field is the name of the form field carrying the file
+	Field string
+	// Name is the name of the file
+	Name string
+	// ContentType is the content type of the file, defaults to
+	// application/octet-stream
+	ContentType string
+	// Content is the content of the file
+	Content io.Reader
+}
+
+// NewMultipartBody encodes the values and the files of the form as
+// multipart/form-data in google.api.HttpBody, for the form uploads by the
+// methods mapping the request body to HttpBody
+//
+//	body, err := sdk.NewMultipartBody(url.Values{"comment": {"logo"}}, sdk.FormFile{
+//		Field:   "file",
+//		Name:    "logo.png",
+//		Content: f,
+//	})
+func NewMultipartBody(values url.Values, files ...FormFile) (*httpbody.HttpBody, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range values[k] {
+			if err := w.WriteField(k, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, f := range files {
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = defaultHTTPBodyContentType
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(f.Field), escapeQuotes(f.Name)))
+		h.Set("Content-Type", contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, f.Content); err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", f.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return &httpbody.HttpBody{
+		ContentType: w.FormDataContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// quoteEscaper escapes the quoted params of Content-Disposition, as done
+// by mime/multipart for the files
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// HTTPBodyOf returns the google.api.HttpBody carrying the raw bytes of
+// the response along with its content type, used by the generated SDK
+// for the response bodies mapped to HttpBody instead of decoding them
+func HTTPBodyOf(resp *http.Response, data []byte) *httpbody.HttpBody {
+	return &httpbody.HttpBody{
+		ContentType: resp.Header.Get("Content-Type"),
+		Data:        data,
+	}
+}
+
+// Download streams the raw bytes of the response body mapped to the
+// google.api.HttpBody, like the file downloads, instead of reading them
+// in memory. It must be closed once done, releasing the connection
+type Download struct {
+	io.ReadCloser
+	// ContentType is the declared content type of the body
+	ContentType string
+	// ContentLength is the size of the body, -1 if unknown
+	ContentLength int64
+}
+
+// NewDownload returns the download streaming the body of the successful
+// response, the error decoded from the body otherwise. The cancel
+// function of the call, if any, is called once the download is closed
+// or on failure
+func NewDownload(resp *http.Response, cancel context.CancelFunc) (*Download, error) {
+	if cancel == nil {
+		cancel = func() {}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		cancel()
+		return nil, DecodeError(resp.StatusCode, body)
+	}
+	return &Download{
+		ReadCloser:    &downloadBody{ReadCloser: resp.Body, cancel: cancel},
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}, nil
+}
+
+// downloadBody cancels the call once the body is closed
+type downloadBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *downloadBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
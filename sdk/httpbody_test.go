@@ -0,0 +1,127 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewHTTPBodyRequest(t *testing.T) {
+	for _, spec := range []struct {
+		body        *httpbody.HttpBody
+		contentType string
+		data        string
+	}{
+		{body: &httpbody.HttpBody{ContentType: "image/png", Data: []byte("png")}, contentType: "image/png", data: "png"},
+		{body: &httpbody.HttpBody{Data: []byte("raw")}, contentType: "application/octet-stream", data: "raw"},
+		{contentType: "application/octet-stream"},
+	} {
+		r, err := NewHTTPBodyRequest(context.Background(), http.MethodPut, "http://localhost/v1/objects/a/attachment", spec.body)
+		if err != nil {
+			t.Fatalf("NewHTTPBodyRequest() failed with %v; want success", err)
+		}
+		if got := r.Header.Get("Content-Type"); got != spec.contentType {
+			t.Errorf("NewHTTPBodyRequest() content type = %q; want %q", got, spec.contentType)
+		}
+		data, _ := io.ReadAll(r.Body)
+		if string(data) != spec.data {
+			t.Errorf("NewHTTPBodyRequest() body = %q; want %q", data, spec.data)
+		}
+		if r.GetBody == nil {
+			t.Errorf("NewHTTPBodyRequest() is not replayable for the retries")
+		}
+	}
+}
+
+func TestNewMultipartBody(t *testing.T) {
+	body, err := NewMultipartBody(url.Values{"comment": {"logo"}}, FormFile{
+		Field:       "file",
+		Name:        `logo "v2".png`,
+		ContentType: "image/png",
+		Content:     strings.NewReader("png"),
+	})
+	if err != nil {
+		t.Fatalf("NewMultipartBody() failed with %v; want success", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(body.GetContentType())
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("NewMultipartBody() content type = %q; want multipart/form-data", body.GetContentType())
+	}
+	r := multipart.NewReader(strings.NewReader(string(body.GetData())), params["boundary"])
+	form, err := r.ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("ReadForm() failed with %v; want success", err)
+	}
+	if got := form.Value["comment"]; len(got) != 1 || got[0] != "logo" {
+		t.Errorf("NewMultipartBody() values = %v; want comment=logo", form.Value)
+	}
+	files := form.File["file"]
+	if len(files) != 1 || files[0].Filename != `logo "v2".png` || files[0].Header.Get("Content-Type") != "image/png" {
+		t.Fatalf("NewMultipartBody() files = %v; want logo \"v2\".png as image/png", files)
+	}
+	f, _ := files[0].Open()
+	defer f.Close()
+	if data, _ := io.ReadAll(f); string(data) != "png" {
+		t.Errorf("NewMultipartBody() file content = %q; want png", data)
+	}
+}
+
+func TestNewDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/objects/missing/attachment" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":5,"message":"object not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	}))
+	defer srv.Close()
+
+	get := func(path string) (*Download, bool, error) {
+		t.Helper()
+		resp, err := srv.Client().Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("Get() failed with %v; want success", err)
+		}
+		canceled := false
+		d, err := NewDownload(resp, func() { canceled = true })
+		if d != nil {
+			data, _ := io.ReadAll(d)
+			if string(data) != "png" || d.ContentType != "image/png" || d.ContentLength != 3 {
+				t.Errorf("NewDownload() = %q as %q of %d bytes; want png as image/png of 3 bytes", data, d.ContentType, d.ContentLength)
+			}
+			if canceled {
+				t.Errorf("NewDownload() canceled the call before the download is closed")
+			}
+			_ = d.Close()
+		}
+		return d, canceled, err
+	}
+	if _, canceled, err := get("/v1/objects/a/attachment"); err != nil || !canceled {
+		t.Errorf("NewDownload() = %v, canceled on close %v; want success canceled on close", err, canceled)
+	}
+	d, canceled, err := get("/v1/objects/missing/attachment")
+	if d != nil || status.Code(err) != codes.NotFound || !canceled {
+		t.Errorf("NewDownload() not found = %v, canceled %v; want NotFound canceled", err, canceled)
+	}
+
+	resp := &http.Response{Header: http.Header{"Content-Type": {"text/plain"}}}
+	if body := HTTPBodyOf(resp, []byte("text")); body.GetContentType() != "text/plain" || string(body.GetData()) != "text" {
+		t.Errorf("HTTPBodyOf() = %v; want text as text/plain", body)
+	}
+}
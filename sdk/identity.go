@@ -0,0 +1,36 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-core-stack/grpc-core/internal/protopath"
+)
+
+// OmitFields returns the request message without the given fields, a
+// copy with the fields cleared when any of them is set, leaving the
+// message provided by the caller untouched. Fields are given as their
+// paths, like object.create_time, cleared in all of the elements of the
+// repeated and the map fields on the way. The generated SDK omits the
+// fields marked using (api.from_identity), populated by the routes from
+// the identity of the authenticated principal instead, and the ones
+// marked using (api.output_only), set by the server alone
+func OmitFields[M proto.Message](msg M, fields ...string) M {
+	m := msg.ProtoReflect()
+	var set []string
+	for _, path := range fields {
+		if protopath.Has(m, path) {
+			set = append(set, path)
+		}
+	}
+	if len(set) == 0 {
+		return msg
+	}
+	out := proto.Clone(msg).(M)
+	for _, path := range set {
+		protopath.Clear(out.ProtoReflect(), path)
+	}
+	return out
+}
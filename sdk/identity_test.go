@@ -0,0 +1,38 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestOmitFields(t *testing.T) {
+	msg := &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), JsonName: proto.String("owner")}
+	out := OmitFields(msg, "json_name", "type_name")
+	if out.JsonName != nil || out.GetName() != "id" {
+		t.Errorf("OmitFields() = %v; want json_name alone cleared", out)
+	}
+	if msg.GetJsonName() != "owner" {
+		t.Errorf("OmitFields() modified the message of the caller to %v", msg)
+	}
+	if got := OmitFields(msg, "type_name"); got != msg {
+		t.Errorf("OmitFields() without the fields set copied the message")
+	}
+
+	// nested fields are omitted by their paths
+	file := &descriptorpb.FileDescriptorProto{MessageType: []*descriptorpb.DescriptorProto{
+		{Name: proto.String("a"), Field: []*descriptorpb.FieldDescriptorProto{msg}},
+		{Name: proto.String("b")},
+	}}
+	nested := OmitFields(file, "message_type.field.json_name")
+	if got := nested.GetMessageType()[0].GetField()[0]; got.JsonName != nil || got.GetName() != "id" {
+		t.Errorf("OmitFields() nested = %v; want json_name alone cleared", got)
+	}
+	if msg.GetJsonName() != "owner" {
+		t.Errorf("OmitFields() nested modified the message of the caller to %v", msg)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ResourceEventHandler is notified of the changes to the objects cached
+// by the informer, any of the functions may be left nil
+type ResourceEventHandler[T any] struct {
+	// OnAdd is called for the objects added to the cache
+	OnAdd func(obj T)
+
+	// OnUpdate is called for the objects modified in the cache, as well
+	// as for all the cached objects on every resync with old and new
+	// being the same object
+	OnUpdate func(old, new T)
+
+	// OnDelete is called for the objects removed from the cache, with
+	// the last known state of the object
+	OnDelete func(obj T)
+}
+
+// InformerOption configures the informer
+type InformerOption func(*informerConfig)
+
+type informerConfig struct {
+	resync     time.Duration
+	backoff    time.Duration
+	maxBackoff time.Duration
+	onError    func(error)
+}
+
+// WithResyncPeriod sets the period to deliver all the cached objects to
+// the handlers as updates, allowing the controllers to reconcile the
+// missed changes. Resync is disabled by default
+func WithResyncPeriod(period time.Duration) InformerOption {
+	return func(c *informerConfig) {
+		c.resync = period
+	}
+}
+
+// WithInformerErrorHandler sets the function notified of the errors
+// listing or watching the objects, the informer keeps retrying with
+// exponential backoff regardless
+func WithInformerErrorHandler(onError func(error)) InformerOption {
+	return func(c *informerConfig) {
+		c.onError = onError
+	}
+}
+
+// Informer keeps the local cache of the objects in sync with the server,
+// listing the objects and then watching the changes, relisting whenever
+// the watch ends. Handlers are called sequentially from the goroutine
+// running the informer, while the cache is safe for concurrent use
+//
+//	informer := example.NewHelloWorldObjectInformer(svc, &example.ListRequest{},
+//		&example.ListRequest{}, sdk.WithResyncPeriod(10*time.Minute))
+//	informer.AddEventHandler(sdk.ResourceEventHandler[*example.PostResponse]{
+//		OnAdd: func(obj *example.PostResponse) { ... },
+//	})
+//	go informer.Run(ctx)
+//	if !informer.WaitForCacheSync(ctx) {
+//		return ctx.Err()
+//	}
+type Informer[T any] struct {
+	list  func(ctx context.Context) ([]T, error)
+	watch func(ctx context.Context) (Watcher[T], error)
+	key   func(T) string
+	cfg   informerConfig
+
+	mu       sync.RWMutex
+	items    map[string]T
+	handlers []ResourceEventHandler[T]
+	synced   chan struct{}
+	once     sync.Once
+}
+
+// NewInformer creates the informer listing the objects using list and
+// watching the changes using watch, caching the objects by the key
+func NewInformer[T any](list func(ctx context.Context) ([]T, error), watch func(ctx context.Context) (Watcher[T], error), key func(T) string, opts ...InformerOption) *Informer[T] {
+	cfg := informerConfig{
+		backoff:    500 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Informer[T]{
+		list:   list,
+		watch:  watch,
+		key:    key,
+		cfg:    cfg,
+		items:  map[string]T{},
+		synced: make(chan struct{}),
+	}
+}
+
+// AddEventHandler adds the handler notified of the changes, expected to
+// be added before running the informer
+func (i *Informer[T]) AddEventHandler(h ResourceEventHandler[T]) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, h)
+}
+
+// Run keeps the cache in sync until the context is done, returning the
+// error of the context
+func (i *Informer[T]) Run(ctx context.Context) error {
+	backoff := i.cfg.backoff
+	for {
+		err := i.listAndWatch(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			// watch ended by the server, relist right away
+			backoff = i.cfg.backoff
+			continue
+		}
+		if i.cfg.onError != nil {
+			i.cfg.onError(err)
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		backoff = min(2*backoff, i.cfg.maxBackoff)
+	}
+}
+
+// listAndWatch replaces the cache with the listed objects and applies
+// the watched changes till the watch ends
+func (i *Informer[T]) listAndWatch(ctx context.Context) error {
+	objs, err := i.list(ctx)
+	if err != nil {
+		return err
+	}
+	i.replace(objs)
+	i.once.Do(func() { close(i.synced) })
+
+	w, err := i.watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	var resync <-chan time.Time
+	if i.cfg.resync > 0 {
+		t := time.NewTicker(i.cfg.resync)
+		defer t.Stop()
+		resync = t.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-resync:
+			for _, obj := range i.List() {
+				i.notify(func(h ResourceEventHandler[T]) {
+					if h.OnUpdate != nil {
+						h.OnUpdate(obj, obj)
+					}
+				})
+			}
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return w.Err()
+			}
+			i.apply(event)
+		}
+	}
+}
+
+// replace replaces the cache with the listed objects, notifying the
+// handlers of the differences
+func (i *Informer[T]) replace(objs []T) {
+	items := make(map[string]T, len(objs))
+	for _, obj := range objs {
+		items[i.key(obj)] = obj
+	}
+	i.mu.Lock()
+	old := i.items
+	i.items = items
+	i.mu.Unlock()
+
+	for k, obj := range items {
+		prev, ok := old[k]
+		i.notify(func(h ResourceEventHandler[T]) {
+			switch {
+			case !ok && h.OnAdd != nil:
+				h.OnAdd(obj)
+			case ok && h.OnUpdate != nil:
+				h.OnUpdate(prev, obj)
+			}
+		})
+	}
+	for k, obj := range old {
+		if _, ok := items[k]; ok {
+			continue
+		}
+		i.notify(func(h ResourceEventHandler[T]) {
+			if h.OnDelete != nil {
+				h.OnDelete(obj)
+			}
+		})
+	}
+}
+
+// apply applies the watched change to the cache, notifying the handlers
+func (i *Informer[T]) apply(event Event[T]) {
+	k := i.key(event.Object)
+	i.mu.Lock()
+	prev, ok := i.items[k]
+	switch event.Type {
+	case Added, Modified:
+		i.items[k] = event.Object
+	case Deleted:
+		delete(i.items, k)
+	default:
+		// unknown events like bookmarks carry no change
+		i.mu.Unlock()
+		return
+	}
+	i.mu.Unlock()
+
+	i.notify(func(h ResourceEventHandler[T]) {
+		switch {
+		case event.Type == Deleted:
+			if h.OnDelete != nil {
+				// last known state of the object
+				if ok {
+					h.OnDelete(prev)
+				} else {
+					h.OnDelete(event.Object)
+				}
+			}
+		case ok && h.OnUpdate != nil:
+			h.OnUpdate(prev, event.Object)
+		case !ok && h.OnAdd != nil:
+			h.OnAdd(event.Object)
+		}
+	})
+}
+
+func (i *Informer[T]) notify(fn func(h ResourceEventHandler[T])) {
+	i.mu.RLock()
+	handlers := i.handlers
+	i.mu.RUnlock()
+	for _, h := range handlers {
+		fn(h)
+	}
+}
+
+// HasSynced reports whether the cache has been populated by the list
+func (i *Informer[T]) HasSynced() bool {
+	select {
+	case <-i.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitForCacheSync waits till the cache is populated by the list,
+// returns false if the context is done before
+func (i *Informer[T]) WaitForCacheSync(ctx context.Context) bool {
+	select {
+	case <-i.synced:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Get returns the cached object with the key
+func (i *Informer[T]) Get(key string) (T, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	obj, ok := i.items[key]
+	return obj, ok
+}
+
+// List returns the cached objects sorted by the key
+func (i *Informer[T]) List() []T {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	keys := make([]string, 0, len(i.items))
+	for k := range i.items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	objs := make([]T, 0, len(keys))
+	for _, k := range keys {
+		objs = append(objs, i.items[k])
+	}
+	return objs
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeWatcher delivers the events sent on the result channel
+type fakeWatcher struct {
+	result chan Event[string]
+}
+
+func (w *fakeWatcher) ResultChan() <-chan Event[string] { return w.result }
+func (w *fakeWatcher) Stop()                            {}
+func (w *fakeWatcher) Err() error                       { return nil }
+
+// objectKey returns the key of the objects named as key=value
+func objectKey(obj string) string {
+	k, _, _ := strings.Cut(obj, "=")
+	return k
+}
+
+func TestInformer(t *testing.T) {
+	lists := [][]string{{"a=1", "b=1"}, {"a=2", "c=1"}}
+	var listed int
+	watchers := make(chan *fakeWatcher)
+	var errs []error
+	informer := NewInformer(
+		func(ctx context.Context) ([]string, error) {
+			if listed == 1 {
+				listed++
+				return nil, errors.New("list failed")
+			}
+			objs := lists[min(listed/2, 1)]
+			listed++
+			return objs, nil
+		},
+		func(ctx context.Context) (Watcher[string], error) {
+			w := &fakeWatcher{result: make(chan Event[string])}
+			watchers <- w
+			return w, nil
+		},
+		objectKey,
+		WithInformerErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+	informer.cfg.backoff = time.Millisecond
+
+	events := make(chan string, 16)
+	informer.AddEventHandler(ResourceEventHandler[string]{
+		OnAdd:    func(obj string) { events <- "add " + obj },
+		OnUpdate: func(old, new string) { events <- "update " + old + " " + new },
+		OnDelete: func(obj string) { events <- "delete " + obj },
+	})
+	expect := func(want ...string) {
+		t.Helper()
+		var got []string
+		for range want {
+			select {
+			case e := <-events:
+				got = append(got, e)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("events = %v; want %v", got, want)
+			}
+		}
+		// events of the listed objects are delivered in no specific order
+		slices.Sort(got)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("events = %v; want %v", got, want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- informer.Run(ctx) }()
+	if !informer.WaitForCacheSync(ctx) || !informer.HasSynced() {
+		t.Fatalf("WaitForCacheSync() = false; want cache synced")
+	}
+	expect("add a=1", "add b=1")
+
+	w := <-watchers
+	w.result <- Event[string]{Type: Modified, Object: "b=2"}
+	w.result <- Event[string]{Type: Added, Object: "d=1"}
+	w.result <- Event[string]{Type: Deleted, Object: "a"}
+	w.result <- Event[string]{Type: "BOOKMARK", Object: "e=1"}
+	expect("update b=1 b=2", "add d=1", "delete a=1")
+	if got := informer.List(); !slices.Equal(got, []string{"b=2", "d=1"}) {
+		t.Errorf("informer.List() = %v; want [b=2 d=1]", got)
+	}
+	if obj, ok := informer.Get("b"); !ok || obj != "b=2" {
+		t.Errorf("informer.Get(b) = %q, %v; want b=2", obj, ok)
+	}
+
+	// watch ended by the server is followed by the relist, retried
+	// after the failure, with the differences delivered to the handlers
+	close(w.result)
+	w = <-watchers
+	expect("add a=2", "add c=1", "delete b=2", "delete d=1")
+	if len(errs) != 1 {
+		t.Errorf("errors reported = %v; want the list failure", errs)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("informer.Run() = %v; want context.Canceled", err)
+	}
+}
+
+func TestInformerResync(t *testing.T) {
+	informer := NewInformer(
+		func(ctx context.Context) ([]string, error) {
+			return []string{"a=1"}, nil
+		},
+		func(ctx context.Context) (Watcher[string], error) {
+			return &fakeWatcher{result: make(chan Event[string])}, nil
+		},
+		objectKey,
+		WithResyncPeriod(time.Millisecond),
+	)
+	updates := make(chan string, 1)
+	informer.AddEventHandler(ResourceEventHandler[string]{
+		OnUpdate: func(old, new string) {
+			select {
+			case updates <- old + " " + new:
+			default:
+			}
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = informer.Run(ctx) }()
+
+	select {
+	case u := <-updates:
+		if u != "a=1 a=1" {
+			t.Errorf("resync update = %q; want a=1 a=1", u)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no update delivered on resync")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// Invoker sends the request, either invoking the next interceptor in
+// the chain or the client for the last one
+type Invoker func(ctx context.Context, r *http.Request) (*http.Response, error)
+
+// Interceptor intercepts the requests sent by the generated SDK
+// wrappers, allowing to decorate the request, observe the response or
+// short circuit the call without invoking next
+type Interceptor func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error)
+
+// WithInterceptors appends the interceptors invoked for the unary and
+// server streaming calls of the service, in the order provided such
+// that the first one is the outermost. Interceptors wrap the circuit
+// breaker if configured, observing the calls rejected while it is open
+func WithInterceptors(interceptors ...Interceptor) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// chain returns the invoker running the interceptors before invoking
+// the given one
+func chain(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, r *http.Request) (*http.Response, error) {
+			if ctx != r.Context() {
+				r = r.WithContext(ctx)
+			}
+			return interceptor(ctx, r, next)
+		}
+	}
+	return invoker
+}
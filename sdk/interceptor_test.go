@@ -0,0 +1,76 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestServiceConfigInterceptors(t *testing.T) {
+	var calls atomic.Int32
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		token = r.Header.Get("X-Token")
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	type ctxKey struct{}
+	var order []string
+	trace := func(name string) Interceptor {
+		return func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+			order = append(order, name+">")
+			resp, err := next(ctx, r)
+			order = append(order, "<"+name)
+			return resp, err
+		}
+	}
+	decorate := func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+		r.Header.Set("X-Token", "secret")
+		return next(context.WithValue(ctx, ctxKey{}, "value"), r)
+	}
+	check := func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+		if r.Context().Value(ctxKey{}) != "value" {
+			t.Errorf("request context not replaced by the interceptor context")
+		}
+		return next(ctx, r)
+	}
+	cfg := NewServiceConfig("example.Service", WithInterceptors(trace("a"), decorate), WithInterceptors(check, trace("b")))
+
+	r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/objects", nil)
+	resp, err := cfg.Do(c, r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+	if got, want := strings.Join(order, " "), "a> b> <b <a"; got != want {
+		t.Errorf("interceptors invoked as %q; want %q", got, want)
+	}
+	if token != "secret" {
+		t.Errorf("request header X-Token = %q; want decorated by the interceptor", token)
+	}
+
+	// interceptors may short circuit the call
+	errInjected := errors.New("injected")
+	cfg = NewServiceConfig("example.Service", WithInterceptors(func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+		return nil, errInjected
+	}))
+	r, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/objects", nil)
+	if _, err := cfg.Do(c, r); !errors.Is(err, errInjected) {
+		t.Errorf("Do() = %v; want the error injected by the interceptor", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d; want 1", got)
+	}
+}
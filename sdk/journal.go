@@ -0,0 +1,391 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader carries the key identifying a logical call,
+// allowing the server to deduplicate the call when sent again
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultReplayInterval is the interval at which the journal attempts
+// replaying the queued calls, unless specified otherwise
+const DefaultReplayInterval = 30 * time.Second
+
+// JournalEntry is a mutating call queued for replay
+type JournalEntry struct {
+	// ID of the entry, ordering the entries by the time queued
+	ID string `json:"id"`
+
+	// Method is the http method of the call
+	Method string `json:"method"`
+
+	// URL of the call, relative to the endpoint of the client
+	URL string `json:"url"`
+
+	// Header of the call
+	Header http.Header `json:"header,omitempty"`
+
+	// Body of the call
+	Body []byte `json:"body,omitempty"`
+
+	// IdempotencyKey sent with the call and its replays
+	IdempotencyKey string `json:"idempotencyKey"`
+
+	// Queued is the time at which the call was queued
+	Queued time.Time `json:"queued"`
+}
+
+// JournalStore persists the queued calls for the journal
+type JournalStore interface {
+	// Append persists the entry
+	Append(ctx context.Context, e *JournalEntry) error
+
+	// List returns the persisted entries ordered by ID
+	List(ctx context.Context) ([]*JournalEntry, error)
+
+	// Remove deletes the entry with given ID
+	Remove(ctx context.Context, id string) error
+}
+
+// memoryJournalStore keeps the entries in memory, lost on restart
+type memoryJournalStore struct {
+	mu      sync.Mutex
+	entries map[string]*JournalEntry
+}
+
+// NewMemoryJournalStore creates a journal store keeping the entries in
+// memory, surviving the connectivity loss but not the process restart
+func NewMemoryJournalStore() JournalStore {
+	return &memoryJournalStore{
+		entries: map[string]*JournalEntry{},
+	}
+}
+
+func (s *memoryJournalStore) Append(ctx context.Context, e *JournalEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[e.ID] = e
+	return nil
+}
+
+func (s *memoryJournalStore) List(ctx context.Context) ([]*JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]*JournalEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (s *memoryJournalStore) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// fileJournalStore keeps every entry as a JSON file in the directory
+type fileJournalStore struct {
+	dir string
+}
+
+// NewFileJournalStore creates a journal store keeping the entries as
+// files in the given directory, created if missing
+func NewFileJournalStore(dir string) (JournalStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	return &fileJournalStore{dir: dir}, nil
+}
+
+func (s *fileJournalStore) Append(ctx context.Context, e *JournalEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, such that a crash never
+	// leaves a partial entry behind
+	tmp := filepath.Join(s.dir, "."+e.ID+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(s.dir, e.ID+".json"))
+}
+
+func (s *fileJournalStore) List(ctx context.Context) ([]*JournalEntry, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	list := make([]*JournalEntry, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal entry: %w", err)
+		}
+		e := &JournalEntry{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return nil, fmt.Errorf("invalid journal entry %s: %w", filepath.Base(f), err)
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+func (s *fileJournalStore) Remove(ctx context.Context, id string) error {
+	err := os.Remove(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// QueuedError is returned for the mutating calls that failed to reach
+// the server and are queued in the journal for replay
+type QueuedError struct {
+	// ID of the journal entry
+	ID string
+
+	// IdempotencyKey of the call, to correlate with the replay
+	IdempotencyKey string
+
+	// Err is the failure observed while sending the call
+	Err error
+}
+
+// Error returns the description of the error
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("call queued for replay as %s: %v", e.ID, e.Err)
+}
+
+// Unwrap returns the failure observed while sending the call
+func (e *QueuedError) Unwrap() error {
+	return e.Err
+}
+
+// JournalOption customizes the journal created using NewJournal
+type JournalOption func(*Journal)
+
+// WithRejectHandler sets the handler invoked for the queued calls that
+// the server rejects when replayed, such calls are removed from the
+// journal as sending them again is not expected to succeed
+func WithRejectHandler(fn func(e *JournalEntry, err error)) JournalOption {
+	return func(j *Journal) {
+		j.onReject = fn
+	}
+}
+
+// Journal wraps the client, queueing the mutating calls that fail to
+// reach the server into a pluggable store, to be replayed in order once
+// connectivity returns. Every mutating call is sent with an
+// IdempotencyKeyHeader, generated unless provided, such that the server
+// can deduplicate the replay of a call that had reached it.
+//
+//	store, err := sdk.NewFileJournalStore("/var/lib/agent/journal")
+//	if err != nil {
+//		return err
+//	}
+//	journal := sdk.NewJournal(client, store)
+//	go journal.Run(ctx, 0)
+//	svc := example.NewHelloWorldService(journal)
+type Journal struct {
+	client   Doer
+	store    JournalStore
+	onReject func(e *JournalEntry, err error)
+
+	// mu serializes the replays, preserving the order of the calls
+	mu sync.Mutex
+}
+
+// NewJournal creates the journal queueing the calls made using client
+// into the store
+func NewJournal(client Doer, store JournalStore, opts ...JournalOption) *Journal {
+	j := &Journal{
+		client: client,
+		store:  store,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Unwrap returns the wrapped client
+func (j *Journal) Unwrap() Doer {
+	return j.client
+}
+
+// Do sends the request using the wrapped client, returns *QueuedError
+// if a mutating request failed to reach the server and is queued
+func (j *Journal) Do(req *http.Request) (*http.Response, error) {
+	if isSafe(req.Method) {
+		return j.client.Do(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	r := req.Clone(req.Context())
+	setBody(r, body)
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key == "" {
+		key = newIdempotencyKey()
+		r.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	resp, err := j.client.Do(r)
+	if err == nil || req.Context().Err() != nil {
+		return resp, err
+	}
+	e := &JournalEntry{
+		ID:             newJournalID(),
+		Method:         r.Method,
+		URL:            r.URL.String(),
+		Header:         r.Header,
+		Body:           body,
+		IdempotencyKey: key,
+		Queued:         time.Now(),
+	}
+	if serr := j.store.Append(req.Context(), e); serr != nil {
+		return nil, errors.Join(err, fmt.Errorf("failed to queue call: %w", serr))
+	}
+	return nil, &QueuedError{ID: e.ID, IdempotencyKey: key, Err: err}
+}
+
+// Replay sends the queued calls in order, removing the calls that
+// reached the server. Stops at the first call failing to reach the
+// server, returning the failure as connectivity is yet to return
+func (j *Journal) Replay(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries, err := j.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		r, err := http.NewRequestWithContext(ctx, e.Method, e.URL, nil)
+		if err != nil {
+			// never going to succeed, drop the entry
+			j.reject(ctx, e, err)
+			continue
+		}
+		r.Header = e.Header.Clone()
+		if r.Header == nil {
+			r.Header = http.Header{}
+		}
+		r.Header.Set(IdempotencyKeyHeader, e.IdempotencyKey)
+		setBody(r, e.Body)
+
+		resp, err := j.client.Do(r)
+		if err != nil {
+			return err
+		}
+		data, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			j.reject(ctx, e, DecodeError(resp.StatusCode, data))
+			continue
+		}
+		if err := j.store.Remove(ctx, e.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Journal) reject(ctx context.Context, e *JournalEntry, err error) {
+	if j.onReject != nil {
+		j.onReject(e, err)
+	}
+	_ = j.store.Remove(ctx, e.ID)
+}
+
+// Run replays the queued calls at the given interval till the context
+// is cancelled, DefaultReplayInterval is used when the interval is not
+// positive
+func (j *Journal) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReplayInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		_ = j.Replay(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// isSafe reports whether the request with given http method does not
+// modify the state on the server, having nothing to replay
+func isSafe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// setBody sets the body of the request, allowing it to be resent
+func setBody(r *http.Request, body []byte) {
+	r.ContentLength = int64(len(body))
+	if body == nil {
+		r.Body, r.GetBody = nil, nil
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
+
+// newIdempotencyKey returns a random UUID to be used as the
+// idempotency key
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	s := hex.EncodeToString(b[:])
+	return strings.Join([]string{s[:8], s[8:12], s[12:16], s[16:20], s[20:]}, "-")
+}
+
+// newJournalID returns the ID for a new entry, sorting in the order
+// the entries are created
+func newJournalID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%020d-%s", time.Now().UnixNano(), hex.EncodeToString(b[:]))
+}
@@ -0,0 +1,86 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestJournalReplay(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			// drop the connection without a response
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r.Method+" "+string(body)+" "+r.Header.Get(IdempotencyKeyHeader))
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	store, err := NewFileJournalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileJournalStore() failed with %v; want success", err)
+	}
+	var rejected []string
+	journal := NewJournal(c, store, WithRejectHandler(func(e *JournalEntry, err error) {
+		rejected = append(rejected, e.Method)
+	}))
+
+	var keys []string
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		r, _ := http.NewRequest(method, "/v1/object", strings.NewReader("obj"))
+		_, err := journal.Do(r)
+		var qerr *QueuedError
+		if !errors.As(err, &qerr) {
+			t.Fatalf("journal.Do(%s) = %v; want queued error", method, err)
+		}
+		keys = append(keys, qerr.IdempotencyKey)
+	}
+	// safe methods are never queued
+	r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+	if _, err := journal.Do(r); err == nil || errors.As(err, new(*QueuedError)) {
+		t.Errorf("journal.Do(GET) = %v; want connectivity error", err)
+	}
+
+	if err := journal.Replay(context.Background()); err == nil {
+		t.Errorf("journal.Replay() succeeded while server is down; want error")
+	}
+	if entries, _ := store.List(context.Background()); len(entries) != 2 {
+		t.Fatalf("store.List() returned %d entries; want 2", len(entries))
+	}
+
+	down.Store(false)
+	if err := journal.Replay(context.Background()); err != nil {
+		t.Fatalf("journal.Replay() failed with %v; want success", err)
+	}
+	want := []string{"POST obj " + keys[0], "DELETE obj " + keys[1]}
+	if strings.Join(received, ",") != strings.Join(want, ",") {
+		t.Errorf("replayed %q; want %q", received, want)
+	}
+	if len(rejected) != 1 || rejected[0] != http.MethodDelete {
+		t.Errorf("rejected %q; want [DELETE]", rejected)
+	}
+	if entries, _ := store.List(context.Background()); len(entries) != 0 {
+		t.Errorf("store.List() returned %d entries after replay; want 0", len(entries))
+	}
+}
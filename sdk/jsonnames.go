@@ -0,0 +1,52 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// WithProtoNames encodes the JSON bodies of the requests using the
+// original names of the fields as declared in the proto file instead of
+// lowerCamelCase. It is set by the SDK generated for the packages using
+// the proto names, as per the (api.json_names) option or the json_names
+// plugin parameter, such that the bodies match the query params sent
+// using the same names. Responses are decoded irrespective of the names
+func WithProtoNames() ServiceOption {
+	return func(c *ServiceConfig) {
+		c.protoNames = true
+	}
+}
+
+// WithMarshaler encodes the JSON bodies of the requests and decodes the
+// JSON responses of the service using the marshaler, configured as
+// needed, like emitting the unpopulated fields or discarding the unknown
+// fields of the responses, instead of the default runtime.JSONPb. Naming
+// of the fields chosen for the package using WithProtoNames is retained
+// irrespective of the marshaler, keeping the bodies consistent with the
+// query params
+func WithMarshaler(m *runtime.JSONPb) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.marshaler = m
+	}
+}
+
+// JSONMarshaler returns the marshaler encoding the JSON bodies of the
+// requests, the one configured using WithMarshaler if any, using the
+// original names of the fields when configured using WithProtoNames,
+// lowerCamelCase otherwise
+func (c *ServiceConfig) JSONMarshaler() runtime.Marshaler {
+	m := &runtime.JSONPb{}
+	if c == nil {
+		return m
+	}
+	if c.marshaler != nil {
+		// copied, not to modify the marshaler shared by the services
+		*m = *c.marshaler
+	}
+	if c.protoNames {
+		m.UseProtoNames = true
+	}
+	return m
+}
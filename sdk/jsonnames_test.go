@@ -0,0 +1,74 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestJSONMarshaler(t *testing.T) {
+	msg := &descriptorpb.FieldDescriptorProto{TypeName: proto.String(".example.Object")}
+	for _, spec := range []struct {
+		opts []ServiceOption
+		want string
+	}{
+		{want: `{"typeName":".example.Object"}`},
+		{opts: []ServiceOption{WithProtoNames()}, want: `{"type_name":".example.Object"}`},
+		// JSON fallback of the protobuf encoding retains the names
+		{opts: []ServiceOption{WithProtoNames(), WithProtobuf()}, want: `{"type_name":".example.Object"}`},
+	} {
+		cfg := NewServiceConfig("example.Objects", spec.opts...)
+		data, err := cfg.JSONMarshaler().Marshal(msg)
+		if err != nil {
+			t.Fatalf("Marshal() failed with %v; want success", err)
+		}
+		if string(data) != spec.want {
+			t.Errorf("JSONMarshaler() encoded %s; want %s", data, spec.want)
+		}
+	}
+	var cfg *ServiceConfig
+	if data, _ := cfg.JSONMarshaler().Marshal(msg); string(data) != `{"typeName":".example.Object"}` {
+		t.Errorf("JSONMarshaler() of nil config encoded %s; want lowerCamelCase", data)
+	}
+}
+
+func TestWithMarshaler(t *testing.T) {
+	custom := &runtime.JSONPb{}
+	custom.EmitUnpopulated = true
+	custom.DiscardUnknown = true
+	msg := &descriptorpb.FieldDescriptorProto{TypeName: proto.String(".example.Object")}
+
+	cfg := NewServiceConfig("example.Objects", WithProtoNames(), WithMarshaler(custom))
+	m, ok := cfg.JSONMarshaler().(*runtime.JSONPb)
+	if !ok || !m.EmitUnpopulated || !m.DiscardUnknown || !m.UseProtoNames {
+		t.Fatalf("JSONMarshaler() = %+v; want the custom marshaler using the proto names", m)
+	}
+	if custom.UseProtoNames {
+		t.Errorf("JSONMarshaler() modified the marshaler provided using WithMarshaler")
+	}
+	data, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal() failed with %v; want success", err)
+	}
+	if !strings.Contains(string(data), `"type_name":".example.Object"`) || !strings.Contains(string(data), `"json_name":null`) {
+		t.Errorf("JSONMarshaler() encoded %s; want the unpopulated fields using the proto names", data)
+	}
+	if err := m.Unmarshal([]byte(`{"type_name":".example.Object","unknown":1}`), &descriptorpb.FieldDescriptorProto{}); err != nil {
+		t.Errorf("Unmarshal() with unknown field failed with %v; want discarded", err)
+	}
+
+	// responses served as JSON to the protobuf calls are decoded using
+	// the configured marshaler
+	cfg = NewServiceConfig("example.Objects", WithProtobuf(), WithMarshaler(custom))
+	resp := &http.Response{Header: http.Header{"Content-Type": {"application/json"}}}
+	if m, ok := ResponseMarshaler(resp, cfg.Marshaler()).(*runtime.JSONPb); !ok || !m.DiscardUnknown {
+		t.Errorf("ResponseMarshaler() of JSON response = %+v; want the custom marshaler", m)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// span is the offsets of a JSON value in the response
+type span struct {
+	start int
+	end   int
+}
+
+// listDecoder holds the scratch space used to split a list response
+// into its items, reused across the calls using listDecoders pool
+type listDecoder struct {
+	items []span
+	rest  []byte
+}
+
+var listDecoders = sync.Pool{
+	New: func() any {
+		return &listDecoder{}
+	},
+}
+
+// Unmarshaler decodes the response, satisfied by the grpc-gateway
+// runtime marshalers used by the generated code
+type Unmarshaler interface {
+	Unmarshal(data []byte, v any) error
+}
+
+// UnmarshalList decodes the JSON encoded list response into out apart
+// from the items of the given repeated message field, which are decoded
+// into a pre-sized slice allocated in a single batch and returned for
+// the caller to set on the field, instead of an allocation per item and
+// reflection based appends, reducing the GC pressure for consumers of
+// large lists
+//
+//	items, err := sdk.UnmarshalList[Item](marshaller, data, out, "items")
+//	if err != nil {
+//		return err
+//	}
+//	out.Items = items
+func UnmarshalList[T any, P interface {
+	*T
+	proto.Message
+}](u Unmarshaler, data []byte, out proto.Message, field string) ([]P, error) {
+	m := out.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || !fd.IsList() || fd.Message() == nil {
+		return nil, fmt.Errorf("%s is not a repeated message field of %s", field, m.Descriptor().FullName())
+	}
+	if name := P(new(T)).ProtoReflect().Descriptor().FullName(); name != fd.Message().FullName() {
+		return nil, fmt.Errorf("field %s holds %s, not %s", field, fd.Message().FullName(), name)
+	}
+
+	d := listDecoders.Get().(*listDecoder)
+	defer func() {
+		d.items = d.items[:0]
+		d.rest = d.rest[:0]
+		listDecoders.Put(d)
+	}()
+
+	// split the items of the list from the response without copying,
+	// decoding everything apart from the items as usual
+	found, err := d.split(data, fd.JSONName(), string(fd.Name()))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if err := u.Unmarshal(data, out); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if err := u.Unmarshal(d.rest, out); err != nil {
+		return nil, err
+	}
+	if len(d.items) == 0 {
+		return nil, nil
+	}
+
+	// decode the items directly with protojson when possible, skipping
+	// the per call overhead of the runtime marshaler
+	unmarshal := u.Unmarshal
+	if pb, ok := u.(*runtime.JSONPb); ok {
+		unmarshal = func(b []byte, v any) error {
+			return pb.UnmarshalOptions.Unmarshal(b, v.(proto.Message))
+		}
+	}
+	arena := make([]T, len(d.items))
+	items := make([]P, len(d.items))
+	for i, item := range d.items {
+		items[i] = P(&arena[i])
+		if err := unmarshal(data[item.start:item.end], items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// split locates the list member with any of the given names in the
+// JSON object, capturing the spans of its items and the rest of the
+// object without the member, reports whether the member was found
+func (d *listDecoder) split(data []byte, names ...string) (bool, error) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return false, fmt.Errorf("invalid list response, expected JSON object")
+	}
+	i = skipSpace(data, i+1)
+	prevComma := -1
+	for i < len(data) && data[i] != '}' {
+		memberStart := i
+		keyEnd, err := skipValue(data, i)
+		if err != nil {
+			return false, err
+		}
+		if data[i] != '"' {
+			return false, fmt.Errorf("invalid list response, expected key at offset %d", i)
+		}
+		key := string(data[i+1 : keyEnd-1])
+		i = skipSpace(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return false, fmt.Errorf("invalid list response, expected ':' at offset %d", i)
+		}
+		valueStart := skipSpace(data, i+1)
+		valueEnd, err := skipValue(data, valueStart)
+		if err != nil {
+			return false, err
+		}
+		i = skipSpace(data, valueEnd)
+
+		for _, name := range names {
+			if key != name {
+				continue
+			}
+			if err := d.splitItems(data, valueStart, valueEnd); err != nil {
+				return false, err
+			}
+			// drop the member along with one of the separators
+			start, end := memberStart, valueEnd
+			if i < len(data) && data[i] == ',' {
+				end = i + 1
+			} else if prevComma >= 0 {
+				start = prevComma
+			}
+			d.rest = append(d.rest, data[:start]...)
+			d.rest = append(d.rest, data[end:]...)
+			return true, nil
+		}
+
+		if i < len(data) && data[i] == ',' {
+			prevComma = i
+			i = skipSpace(data, i+1)
+		}
+	}
+	return false, nil
+}
+
+// splitItems captures the spans of the items of the JSON array
+func (d *listDecoder) splitItems(data []byte, start, end int) error {
+	if string(data[start:end]) == "null" {
+		return nil
+	}
+	if data[start] != '[' {
+		return fmt.Errorf("invalid list response, expected array at offset %d", start)
+	}
+	i := skipSpace(data, start+1)
+	for i < end-1 {
+		itemEnd, err := skipValue(data, i)
+		if err != nil {
+			return err
+		}
+		d.items = append(d.items, span{start: i, end: itemEnd})
+		i = skipSpace(data, itemEnd)
+		if i < end-1 && data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+	}
+	return nil
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipValue returns the offset right after the JSON value starting at
+// offset i, validation of the value is left to protojson
+func skipValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return i, fmt.Errorf("invalid list response, unexpected end of input")
+	}
+	depth := 0
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(data) {
+				return i, fmt.Errorf("invalid list response, unterminated string")
+			}
+		case '{', '[':
+			depth++
+			continue
+		case '}', ']':
+			depth--
+			if depth < 0 {
+				return i, nil
+			}
+		case ',', ' ', '\t', '\r', '\n', ':':
+			if depth == 0 {
+				return i, nil
+			}
+			continue
+		default:
+			continue
+		}
+		if depth == 0 {
+			return i + 1, nil
+		}
+	}
+	if depth != 0 {
+		return i, fmt.Errorf("invalid list response, unexpected end of input")
+	}
+	return i, nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func listResponse(n int) []byte {
+	set := &descriptorpb.FileDescriptorSet{}
+	for i := range n {
+		set.File = append(set.File, &descriptorpb.FileDescriptorProto{
+			Name:       proto.String(fmt.Sprintf("file-%d.proto", i)),
+			Package:    proto.String("example"),
+			Dependency: []string{"google/api/annotations.proto"},
+		})
+	}
+	data, err := protojson.Marshal(set)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestUnmarshalList(t *testing.T) {
+	fields := `[{"name": "a", "number": 1}, {"name": "b,]\\\"", "number": 2}]`
+	want := &descriptorpb.DescriptorProto{
+		Name:         proto.String("msg"),
+		ReservedName: []string{"x"},
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("a"), Number: proto.Int32(1)},
+			{Name: proto.String("b,]\\\""), Number: proto.Int32(2)},
+		},
+	}
+	for _, spec := range []struct {
+		name  string
+		input string
+	}{
+		{name: "list first", input: `{"field": ` + fields + `, "name": "msg", "reservedName": ["x"]}`},
+		{name: "list last", input: `{"name": "msg", "reservedName": ["x"], "field": ` + fields + `}`},
+		{name: "list in between", input: "{ \"name\" : \"msg\" ,\n \"field\" : " + fields + " ,\n \"reserved_name\": [\"x\"] }"},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			got := &descriptorpb.DescriptorProto{}
+			items, err := UnmarshalList[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, []byte(spec.input), got, "field")
+			if err != nil {
+				t.Fatalf("UnmarshalList() failed with %v; want success", err)
+			}
+			got.Field = items
+			if !proto.Equal(got, want) {
+				t.Errorf("UnmarshalList() = %v; want %v", got, want)
+			}
+		})
+	}
+
+	got := &descriptorpb.DescriptorProto{}
+	for _, input := range []string{`{"name": "msg"}`, `{"name": "msg", "field": null}`, `{"field": [], "name": "msg"}`} {
+		items, err := UnmarshalList[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, []byte(input), got, "field")
+		if err != nil {
+			t.Fatalf("UnmarshalList(%s) failed with %v; want success", input, err)
+		}
+		if len(items) != 0 || got.GetName() != "msg" {
+			t.Errorf("UnmarshalList(%s) = %v, %v; want no items", input, got, items)
+		}
+	}
+}
+
+func TestUnmarshalListErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name  string
+		input string
+		field string
+	}{
+		{name: "unknown field", input: `{"file": []}`, field: "unknown"},
+		{name: "not a list", input: `{"file": {}}`, field: "file"},
+		{name: "not an object", input: `[]`, field: "file"},
+		{name: "truncated", input: `{"file": [{"name": "a"}`, field: "file"},
+		{name: "unknown item field", input: `{"file": [{"bogus": 1}]}`, field: "file"},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			out := &descriptorpb.FileDescriptorSet{}
+			if _, err := UnmarshalList[descriptorpb.FileDescriptorProto](&runtime.JSONPb{}, []byte(spec.input), out, spec.field); err == nil {
+				t.Errorf("UnmarshalList() succeeded; want error")
+			}
+		})
+	}
+
+	out := &descriptorpb.FileDescriptorSet{}
+	if _, err := UnmarshalList[descriptorpb.DescriptorProto](&runtime.JSONPb{}, []byte(`{"file": []}`), out, "file"); err == nil {
+		t.Errorf("UnmarshalList() with mismatched item type succeeded; want error")
+	}
+}
+
+func BenchmarkUnmarshalList(b *testing.B) {
+	marshaller := &runtime.JSONPb{}
+	for _, n := range []int{10, 1000} {
+		data := listResponse(n)
+		b.Run(fmt.Sprintf("jsonpb/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				out := &descriptorpb.FileDescriptorSet{}
+				if err := marshaller.Unmarshal(data, out); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batched/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				out := &descriptorpb.FileDescriptorSet{}
+				items, err := UnmarshalList[descriptorpb.FileDescriptorProto](marshaller, data, out, "file")
+				if err != nil {
+					b.Fatal(err)
+				}
+				out.File = items
+			}
+		})
+	}
+}
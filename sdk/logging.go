@@ -0,0 +1,183 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-core-stack/grpc-core/internal/redact"
+)
+
+// DefaultMaxLoggedBodySize is the size of the bodies logged by the
+// logging interceptor unless configured otherwise
+const DefaultMaxLoggedBodySize = 4 << 10
+
+// LoggingConfig configures the logging interceptor
+type LoggingConfig struct {
+	// Logger the calls are logged to, slog.Default() if nil
+	Logger *slog.Logger
+
+	// Level of the successful calls, the failed calls and the error
+	// responses are logged at slog.LevelWarn
+	Level slog.Level
+
+	// Bodies enables logging the request and the response bodies, with
+	// the sensitive fields redacted, once the response body is closed
+	Bodies bool
+
+	// MaxBodySize is the size of the bodies logged, larger bodies are
+	// logged truncated unless they carry sensitive fields, in which
+	// case they are omitted, DefaultMaxLoggedBodySize if zero
+	MaxBodySize int
+}
+
+// Redaction describes the fields of the call carrying the sensitive
+// values, as the dotted paths of the fields, matched irrespective of
+// the JSON or the proto naming of the fields
+type Redaction struct {
+	// RequestFields are the paths of the sensitive fields of the request
+	// message, redacted from the request body and the query params
+	RequestFields []string
+
+	// RequestBody is the path of the field of the request sent as the
+	// body, * for the whole message and empty if none
+	RequestBody string
+
+	// ResponseFields are the paths of the sensitive fields of the
+	// response message, redacted from the response body
+	ResponseFields []string
+
+	// ResponseBody is the path of the field of the response received as
+	// the body, empty for the whole message
+	ResponseBody string
+}
+
+// WithRedaction sets the sensitive fields of the call, redacted from
+// the logs by the logging interceptor. The generated SDK sets it for
+// the methods with the fields marked using (api.sensitive)
+func WithRedaction(r *Redaction) CallOption {
+	return func(o *callOptions) {
+		o.redaction = r
+	}
+}
+
+// redactionKey is the context key carrying the redaction of the call
+type redactionKey struct{}
+
+// LoggingInterceptor logs the http method, the URL, the status and the
+// duration of the calls, redacting the values of the sensitive fields
+// from the query params and the bodies
+//
+//	svc := example.NewHelloWorldService(client, sdk.WithInterceptors(
+//		sdk.LoggingInterceptor(sdk.LoggingConfig{Bodies: true}),
+//	))
+func LoggingInterceptor(cfg LoggingConfig) Interceptor {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = DefaultMaxLoggedBodySize
+	}
+	return func(ctx context.Context, r *http.Request, next Invoker) (*http.Response, error) {
+		redaction, _ := ctx.Value(redactionKey{}).(*Redaction)
+		if redaction == nil {
+			redaction = &Redaction{}
+		}
+		attrs := []any{
+			"http_method", r.Method,
+			"url", redact.URL(r.URL, redaction.RequestFields),
+		}
+		if cfg.Bodies {
+			if body := requestBody(r, cfg.MaxBodySize); body != nil {
+				attrs = append(attrs, "request_body", redact.Body(body, cfg.MaxBodySize, redact.RelativePaths(redaction.RequestFields, redaction.RequestBody)))
+			}
+		}
+		start := time.Now()
+		resp, err := next(ctx, r)
+		if err != nil {
+			attrs = append(attrs, "duration", time.Since(start), "error", err)
+			cfg.Logger.WarnContext(ctx, "call failed", attrs...)
+			return resp, err
+		}
+		level := cfg.Level
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+		attrs = append(attrs, "status", resp.StatusCode)
+		if !cfg.Bodies || resp.Body == nil || resp.Body == http.NoBody {
+			attrs = append(attrs, "duration", time.Since(start))
+			cfg.Logger.Log(ctx, level, "call completed", attrs...)
+			return resp, nil
+		}
+		// the response body is logged once consumed, without holding the
+		// streamed responses
+		resp.Body = &loggedBody{
+			ReadCloser: resp.Body,
+			max:        cfg.MaxBodySize,
+			done: func(body []byte) {
+				attrs = append(attrs,
+					"duration", time.Since(start),
+					"response_body", redact.Body(body, cfg.MaxBodySize, redact.RelativePaths(redaction.ResponseFields, redaction.ResponseBody)),
+				)
+				cfg.Logger.Log(ctx, level, "call completed", attrs...)
+			},
+		}
+		return resp, nil
+	}
+}
+
+// requestBody returns the request body up to one byte beyond max, such
+// that the larger ones are detected, nil for the requests without the
+// body or with the streamed body which can not be read twice
+func requestBody(r *http.Request, max int) []byte {
+	if r.Body == nil || r.Body == http.NoBody || r.GetBody == nil {
+		return nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(io.LimitReader(body, int64(max)+1))
+	return data
+}
+
+// loggedBody captures the body up to one byte beyond max as it is read,
+// calling done once when closed
+type loggedBody struct {
+	io.ReadCloser
+	max    int
+	buf    bytes.Buffer
+	done   func(body []byte)
+	closed bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := b.max + 1 - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.done(b.buf.Bytes())
+	}
+	return err
+}
+
+// normalizeName returns the name irrespective of the JSON lowerCamel or
+// the proto snake_case naming
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/internal/redact"
+)
+
+func TestLoggingInterceptor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"a","secret":{"apiToken":"t1"},"items":[{"api_token":"t2","id":1}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	var logs bytes.Buffer
+	cfg := NewServiceConfig("example.Objects", WithInterceptors(LoggingInterceptor(LoggingConfig{
+		Logger: slog.New(slog.NewJSONHandler(&logs, nil)),
+		Bodies: true,
+	})))
+	call := func(method, path, body string, opts ...CallOption) map[string]any {
+		t.Helper()
+		logs.Reset()
+		r, err := http.NewRequestWithContext(context.Background(), method, path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest() failed with %v; want success", err)
+		}
+		r, cancel := ApplyCallOptions(r, opts...)
+		defer cancel()
+		resp, err := cfg.Do(c, r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		var entry map[string]any
+		if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+			t.Fatalf("logged %q; want a JSON entry: %v", logs.String(), err)
+		}
+		return entry
+	}
+
+	redaction := WithRedaction(&Redaction{
+		RequestFields:  []string{"password", "credentials.pin"},
+		RequestBody:    "*",
+		ResponseFields: []string{"secret.api_token", "items.api_token"},
+	})
+	entry := call(http.MethodPost, "/v1/objects?password=p1&name=a", `{"name":"a","password":"p2","credentials":{"pin":1234}}`, redaction)
+	for key, want := range map[string]any{
+		"msg":           "call completed",
+		"level":         "INFO",
+		"http_method":   http.MethodPost,
+		"url":           "/v1/objects?name=a&password=%5BREDACTED%5D",
+		"status":        float64(http.StatusOK),
+		"request_body":  `{"credentials":{"pin":"[REDACTED]"},"name":"a","password":"[REDACTED]"}`,
+		"response_body": `{"items":[{"api_token":"[REDACTED]","id":1}],"name":"a","secret":{"apiToken":"[REDACTED]"}}`,
+	} {
+		if got := entry[key]; got != want {
+			t.Errorf("logged %s = %v; want %v", key, got, want)
+		}
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Errorf("logged %v; want duration", entry)
+	}
+
+	// bodies mapped to a field are redacted relative to the field
+	entry = call(http.MethodPatch, "/v1/objects/a", `{"password":"p2"}`, WithRedaction(&Redaction{
+		RequestFields: []string{"object.password"},
+		RequestBody:   "object",
+	}))
+	if got, want := entry["request_body"], `{"password":"[REDACTED]"}`; got != want {
+		t.Errorf("logged request_body = %v; want %v", got, want)
+	}
+	entry = call(http.MethodPatch, "/v1/objects/a", `"p2"`, WithRedaction(&Redaction{
+		RequestFields: []string{"password"},
+		RequestBody:   "password",
+	}))
+	if got, want := entry["request_body"], redact.Value; got != want {
+		t.Errorf("logged request_body = %v; want %v", got, want)
+	}
+
+	// error responses are logged at warn
+	entry = call(http.MethodGet, "/v1/missing", "")
+	if entry["level"] != "WARN" || entry["status"] != float64(http.StatusNotFound) {
+		t.Errorf("logged %v; want WARN with 404", entry)
+	}
+}
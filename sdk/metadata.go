@@ -0,0 +1,41 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataHeaderPrefix is the prefix of the headers conveying the grpc
+// metadata, forwarded by grpc-gateway as the incoming metadata
+const MetadataHeaderPrefix = "Grpc-Metadata-"
+
+// SetMetadataHeaders sets the outgoing grpc metadata of the context of
+// the request as the Grpc-Metadata-* headers, such that the metadata
+// like the tracing ids survive the http hop. Values of the binary keys,
+// suffixed with -bin, are base64 encoded as expected by grpc-gateway
+func SetMetadataHeaders(r *http.Request) {
+	md, ok := metadata.FromOutgoingContext(r.Context())
+	if !ok {
+		return
+	}
+	for key, values := range md {
+		// pseudo headers are not part of the metadata
+		if strings.HasPrefix(key, ":") {
+			continue
+		}
+		header := MetadataHeaderPrefix + key
+		r.Header.Del(header)
+		for _, v := range values {
+			if strings.HasSuffix(key, "-bin") {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			r.Header.Add(header, v)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestSetMetadataHeaders(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-request-id", "abc",
+		"x-tenant", "t1",
+		"x-tenant", "t2",
+		"trace-bin", "\x01\x02",
+	)
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/objects", nil)
+	r.Header.Set("Grpc-Metadata-X-Request-Id", "stale")
+	SetMetadataHeaders(r)
+
+	for header, want := range map[string][]string{
+		"Grpc-Metadata-X-Request-Id": {"abc"},
+		"Grpc-Metadata-X-Tenant":     {"t1", "t2"},
+		"Grpc-Metadata-Trace-Bin":    {"AQI="},
+	} {
+		if got := r.Header.Values(header); !slices.Equal(got, want) {
+			t.Errorf("header %s = %v; want %v", header, got, want)
+		}
+	}
+
+	// requests without outgoing metadata are left as is
+	r, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/objects", nil)
+	SetMetadataHeaders(r)
+	if len(r.Header) != 0 {
+		t.Errorf("headers = %v; want none without metadata", r.Header)
+	}
+}
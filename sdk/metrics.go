@@ -0,0 +1,83 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// Metrics observes the calls made by the generated SDK, allowing the
+// clients to export the counts and the latencies of the calls per
+// method, see PrometheusMetrics for a ready made implementation
+type Metrics interface {
+	// ObserveCall is called once the response of the call is received,
+	// with the fully qualified name of the service, the name of the
+	// method, the status code of the response, zero if the call failed
+	// without one, and the duration of the call including the retries.
+	// Expected to be safe for concurrent use
+	ObserveCall(service, method string, code int, duration time.Duration)
+}
+
+// Objective is the service level objective of the method as per the
+// (api.slo) option
+type Objective struct {
+	// Latency is the target latency of the calls, zero if unset
+	Latency time.Duration
+
+	// LatencyPercentile is the percentile of the calls meeting the
+	// target latency, like 99 for the p99 latency
+	LatencyPercentile float64
+
+	// Availability is the target percentage of the successful calls,
+	// zero if unset
+	Availability float64
+}
+
+// ObjectiveMetrics is implemented by the Metrics tracking the calls
+// against the service level objectives of the methods, such that the
+// SLO tooling is bootstrapped from the API definitions, see
+// PrometheusMetrics for a ready made implementation
+type ObjectiveMetrics interface {
+	Metrics
+
+	// DeclareObjective is called with the objective of every method of
+	// the service annotated using (api.slo), once the wrapper of the
+	// service is constructed, before any of its calls is observed.
+	// Expected to be safe for concurrent use
+	DeclareObjective(service, method string, slo Objective)
+}
+
+// WithObjectives sets the service level objectives of the methods of
+// the service, keyed by the name of the method, declared to the metrics
+// implementing ObjectiveMetrics, used by the generated constructors
+func WithObjectives(objectives map[string]Objective) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.objectives = objectives
+	}
+}
+
+// WithMetrics reports the calls of the service to the metrics
+func WithMetrics(m Metrics) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.metrics = m
+	}
+}
+
+// Observe starts observing the call of the method, returning the
+// function to be called with the outcome of the call, invoked by the
+// generated methods around sending the request
+func (c *ServiceConfig) Observe(method string) func(resp *http.Response, err error) {
+	if c == nil || c.metrics == nil {
+		return func(*http.Response, error) {}
+	}
+	start := time.Now()
+	return func(resp *http.Response, err error) {
+		code := 0
+		if err == nil {
+			code = resp.StatusCode
+		}
+		c.metrics.ObserveCall(c.service, method, code, time.Since(start))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordedCall is the call observed by the metrics
+type recordedCall struct {
+	service, method string
+	code            int
+}
+
+type recordingMetrics []recordedCall
+
+func (m *recordingMetrics) ObserveCall(service, method string, code int, duration time.Duration) {
+	*m = append(*m, recordedCall{service: service, method: method, code: code})
+}
+
+func TestServiceConfigObserve(t *testing.T) {
+	// observing without the metrics is a no-op
+	NewServiceConfig("example.Service").Observe("Get")(nil, errors.New("failed"))
+
+	var m recordingMetrics
+	cfg := NewServiceConfig("example.Service", WithMetrics(&m))
+	cfg.Observe("Get")(&http.Response{StatusCode: http.StatusNotFound}, nil)
+	cfg.Observe("List")(nil, errors.New("connection refused"))
+	want := []recordedCall{
+		{service: "example.Service", method: "Get", code: http.StatusNotFound},
+		{service: "example.Service", method: "List"},
+	}
+	if len(m) != len(want) || m[0] != want[0] || m[1] != want[1] {
+		t.Errorf("observed calls = %+v; want %+v", m, want)
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics(0.1, 0.05)
+	m.ObserveCall("example.Service", "Get", 200, 10*time.Millisecond)
+	m.ObserveCall("example.Service", "Get", 200, 70*time.Millisecond)
+	m.ObserveCall("example.Service", "Get", 503, 2*time.Second)
+	m.ObserveCall("example.Service", "Get\"", 0, 0)
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q; want Prometheus text format", ct)
+	}
+	want := `# HELP sdk_client_calls_total Total number of the calls made by the SDK.
+# TYPE sdk_client_calls_total counter
+sdk_client_calls_total{service="example.Service",method="Get",code="200"} 2
+sdk_client_calls_total{service="example.Service",method="Get",code="503"} 1
+sdk_client_calls_total{service="example.Service",method="Get\"",code="0"} 1
+# HELP sdk_client_call_duration_seconds Duration of the calls made by the SDK.
+# TYPE sdk_client_call_duration_seconds histogram
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="0.05"} 1
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="0.1"} 2
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="+Inf"} 3
+sdk_client_call_duration_seconds_sum{service="example.Service",method="Get"} 2.08
+sdk_client_call_duration_seconds_count{service="example.Service",method="Get"} 3
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get\"",le="0.05"} 1
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get\"",le="0.1"} 1
+sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get\"",le="+Inf"} 1
+sdk_client_call_duration_seconds_sum{service="example.Service",method="Get\""} 0
+sdk_client_call_duration_seconds_count{service="example.Service",method="Get\""} 1
+`
+	if got := w.Body.String(); got != want {
+		t.Errorf("metrics =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrometheusMetricsObjectives(t *testing.T) {
+	m := NewPrometheusMetrics(0.1, 0.5)
+	NewServiceConfig("example.Service", WithMetrics(m), WithObjectives(map[string]Objective{
+		"Get":   {Latency: 200 * time.Millisecond, LatencyPercentile: 99, Availability: 99.9},
+		"Watch": {Availability: 99.5},
+	}))
+	m.ObserveCall("example.Service", "Get", 200, 150*time.Millisecond)
+	m.ObserveCall("example.Service", "List", 200, 150*time.Millisecond)
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() failed with %v; want success", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="0.1"} 0` + "\n" +
+			`sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="0.2"} 1` + "\n" +
+			`sdk_client_call_duration_seconds_bucket{service="example.Service",method="Get",le="0.5"} 1` + "\n",
+		`sdk_client_call_duration_seconds_bucket{service="example.Service",method="List",le="0.1"} 0` + "\n" +
+			`sdk_client_call_duration_seconds_bucket{service="example.Service",method="List",le="0.5"} 1` + "\n",
+		"# TYPE sdk_client_slo_latency_seconds gauge\n" +
+			`sdk_client_slo_latency_seconds{service="example.Service",method="Get",percentile="99"} 0.2` + "\n",
+		"# TYPE sdk_client_slo_availability_ratio gauge\n" +
+			`sdk_client_slo_availability_ratio{service="example.Service",method="Get"} 0.999` + "\n" +
+			`sdk_client_slo_availability_ratio{service="example.Service",method="Watch"} 0.995` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("metrics =\n%s\nwant to contain\n%s", got, want)
+		}
+	}
+}
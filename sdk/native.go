@@ -0,0 +1,147 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-core-stack/grpc-core/errcode"
+)
+
+// ErrNoClient is the error of the calls requiring the http client made
+// using the SDK wrapper constructed using only the grpc connection,
+// like the streaming methods
+var ErrNoClient = errors.New("sdk: http client is required, not supported over the grpc connection")
+
+// NoClient is the Doer failing all the requests with ErrNoClient, used
+// by the generated constructors of the SDK wrappers sending the calls
+// only over the grpc connection
+var NoClient Doer = DoerFunc(func(*http.Request) (*http.Response, error) {
+	return nil, ErrNoClient
+})
+
+// createdMetadata is the header metadata reporting the resource created
+// by the upserts, as set by routes.SetCreated
+const createdMetadata = "x-resource-created"
+
+// WithConn sends the unary calls of the service natively over the grpc
+// connection, skipping the gateway hop for the internal services, when
+// the SDK is generated using the grpc_fallback option. The calls not
+// supported over the connection, like the streaming methods and the
+// ones relying on the routes for the fields of the request or the
+// signature of the response, are sent using the http client, while the
+// interceptors, the circuit breaker, the bulkheads, the cache and the
+// compression apply only to them, see Invoke
+//
+//	conn, err := grpc.NewClient("objects:9090", grpc.WithTransportCredentials(creds))
+//	svc := example.NewHelloWorldService(client, sdk.WithConn(conn))
+func WithConn(conn grpc.ClientConnInterface) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.conn = conn
+	}
+}
+
+// Native reports whether the unary calls are sent over the grpc
+// connection configured using WithConn
+func (c *ServiceConfig) Native() bool {
+	return c != nil && c.conn != nil
+}
+
+// Invoke calls the unary method, with the fully qualified grpc name, over
+// the grpc connection of the service, decoding the response into out.
+// The status code and the body of the equivalent http response are
+// returned for the unsuccessful calls, such that the errors decoded
+// using DecodeError are the same as for the calls over http. Headers
+// set using the call options and the defaults of the context are sent
+// as the metadata, while the options specific to http are ignored.
+//
+// The calls bypass the client side handling of the http calls, like the
+// interceptors, the circuit breaker, the bulkheads, the cache and the
+// compression, along with everything the generated routes apply on the
+// server side, which the grpc server is expected to handle itself when
+// needed:
+//
+//   - the authorization of the role of the method
+//   - the defaults of the request fields, (api.default)
+//   - the locale preferred by the client, (api.locale)
+//   - the immutable fields checked against the resource, (api.immutable)
+//   - the limit of the concurrent calls, (api.max_concurrency)
+//   - the feature flags guarding the method, (api.feature_flag)
+//   - the copies sent to the shadow target, (api.shadow_rate)
+//   - the debug only methods, (api.debug_only)
+//   - the sampling of the logs, (api.log_sample_rate)
+//
+// The generated SDK never sends natively the methods with the sealed
+// fields, (api.encrypted), the fields populated from the identity of
+// the principal, (api.from_identity), or the signed responses,
+// (api.signed), since only the routes open, populate and sign them
+func (c *ServiceConfig) Invoke(ctx context.Context, method string, req, out proto.Message, opts []CallOption) (int, []byte, error) {
+	opts = withDefaults(ctx, opts)
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	} else if _, ok := ctx.Deadline(); !ok && o.defaultTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.defaultTimeout)
+	}
+	defer cancel()
+	var pairs []string
+	for key, values := range o.header {
+		for _, v := range values {
+			pairs = append(pairs, strings.ToLower(key), v)
+		}
+	}
+	if o.idempotencyKey != nil && o.header.Get(IdempotencyKeyHeader) == "" {
+		key := *o.idempotencyKey
+		if key == "" {
+			key = newIdempotencyKey()
+		}
+		pairs = append(pairs, strings.ToLower(IdempotencyKeyHeader), key)
+	}
+	if len(pairs) != 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	}
+
+	start := time.Now()
+	var header metadata.MD
+	code, body, err := 0, []byte(nil), c.conn.Invoke(ctx, method, req, out, grpc.Header(&header))
+	switch {
+	case err == nil:
+		code = http.StatusOK
+		if values := header.Get(createdMetadata); len(values) != 0 && values[0] == "true" {
+			code = http.StatusCreated
+		}
+	case ctx.Err() != nil:
+		// same as the calls over http failing without a response
+		err = ctx.Err()
+	default:
+		st, ok := status.FromError(err)
+		if !ok {
+			break
+		}
+		data, merr := protojson.Marshal(st.Proto())
+		if merr != nil {
+			break
+		}
+		code, body, err = errcode.Default.HTTPStatus(st.Code()), data, nil
+	}
+	if c.metrics != nil {
+		c.metrics.ObserveCall(c.service, path.Base(method), code, time.Since(start))
+	}
+	return code, body, err
+}
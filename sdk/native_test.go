@@ -0,0 +1,104 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeClientConn serves the unary calls using the function
+type fakeClientConn struct {
+	invoke func(ctx context.Context, method string, req, out any) (metadata.MD, error)
+}
+
+func (c *fakeClientConn) Invoke(ctx context.Context, method string, req, out any, opts ...grpc.CallOption) error {
+	header, err := c.invoke(ctx, method, req, out)
+	for _, opt := range opts {
+		if h, ok := opt.(grpc.HeaderCallOption); ok {
+			*h.HeaderAddr = header
+		}
+	}
+	return err
+}
+
+func (c *fakeClientConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestInvoke(t *testing.T) {
+	var gotMethod string
+	var gotMD metadata.MD
+	conn := &fakeClientConn{invoke: func(ctx context.Context, method string, req, out any) (metadata.MD, error) {
+		gotMethod = method
+		gotMD, _ = metadata.FromOutgoingContext(ctx)
+		switch v := req.(*wrapperspb.StringValue).GetValue(); v {
+		case "created":
+			proto.Merge(out.(proto.Message), wrapperspb.String(v))
+			return metadata.Pairs(createdMetadata, "true"), nil
+		case "missing":
+			return nil, status.Error(codes.NotFound, "object missing not found")
+		}
+		proto.Merge(out.(proto.Message), wrapperspb.String("hello "+req.(*wrapperspb.StringValue).GetValue()))
+		return nil, nil
+	}}
+	cfg := NewServiceConfig("example.Objects", WithConn(conn))
+	if !cfg.Native() || NewServiceConfig("example.Objects").Native() {
+		t.Fatalf("Native() reported without the connection configured")
+	}
+
+	out := &wrapperspb.StringValue{}
+	code, _, err := cfg.Invoke(context.Background(), "/example.Objects/GetObject", wrapperspb.String("world"), out, []CallOption{
+		WithHeader("X-Tenant", "t1"),
+		WithIdempotencyKey("k1"),
+	})
+	if err != nil || code != http.StatusOK || out.GetValue() != "hello world" {
+		t.Errorf("Invoke() = %d, %q, %v; want 200, %q", code, out.GetValue(), err, "hello world")
+	}
+	if gotMethod != "/example.Objects/GetObject" {
+		t.Errorf("Invoke() called %q; want /example.Objects/GetObject", gotMethod)
+	}
+	if gotMD.Get("x-tenant")[0] != "t1" || gotMD.Get("idempotency-key")[0] != "k1" {
+		t.Errorf("Invoke() sent metadata %v; want the headers of the call options", gotMD)
+	}
+
+	code, _, err = cfg.Invoke(context.Background(), "/example.Objects/PutObject", wrapperspb.String("created"), &wrapperspb.StringValue{}, nil)
+	if err != nil || code != http.StatusCreated {
+		t.Errorf("Invoke() creating the resource = %d, %v; want 201", code, err)
+	}
+
+	code, body, err := cfg.Invoke(context.Background(), "/example.Objects/GetObject", wrapperspb.String("missing"), &wrapperspb.StringValue{}, nil)
+	if err != nil || code != http.StatusNotFound {
+		t.Fatalf("Invoke() failing = %d, %v; want 404", code, err)
+	}
+	var apiErr *APIError
+	if !errors.As(DecodeError(code, body), &apiErr) || apiErr.Code != codes.NotFound || apiErr.Message != "object missing not found" {
+		t.Errorf("DecodeError() of the failed call = %v; want the status sent by the server", DecodeError(code, body))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn.invoke = func(ctx context.Context, _ string, _, _ any) (metadata.MD, error) {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if _, _, err := cfg.Invoke(ctx, "/example.Objects/GetObject", wrapperspb.String("world"), &wrapperspb.StringValue{}, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Invoke() with the canceled context failed with %v; want context.Canceled", err)
+	}
+}
+
+func TestNoClient(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/v1/objects", nil)
+	if _, err := NoClient.Do(r); !errors.Is(err, ErrNoClient) {
+		t.Errorf("NoClient.Do() failed with %v; want ErrNoClient", err)
+	}
+}
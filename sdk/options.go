@@ -0,0 +1,106 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Option customizes the client created using NewClient
+type Option func(*options)
+
+type options struct {
+	profile  string
+	resolver Resolver
+	refresh  time.Duration
+
+	dualStack  bool
+	dialDelay  time.Duration
+	probePath  string
+	probeEvery time.Duration
+	redirect   *RedirectPolicy
+	transport  *TransportConfig
+	proxy      *string
+	dial       func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithProfile selects the named profile from the config, overriding
+// the profile selected using SDK_PROFILE environment or the config
+func WithProfile(name string) Option {
+	return func(o *options) {
+		o.profile = name
+	}
+}
+
+// WithResolver uses the resolver to discover the endpoints serving
+// the API instead of the configured endpoint, the endpoints are
+// refreshed after the given interval, DefaultRefreshInterval is used
+// when the interval is not positive
+func WithResolver(r Resolver, refresh time.Duration) Option {
+	return func(o *options) {
+		o.resolver = r
+		o.refresh = refresh
+	}
+}
+
+// WithHappyEyeballs races the connection attempts across the IPv6 and
+// IPv4 addresses of the endpoint as per RFC 8305, starting the next
+// attempt after the given delay, DefaultConnectionAttemptDelay is
+// used when the delay is not positive
+func WithHappyEyeballs(delay time.Duration) Option {
+	return func(o *options) {
+		o.dualStack = true
+		o.dialDelay = delay
+	}
+}
+
+// WithHealthProbe actively probes the endpoints discovered using the
+// resolver, by sending GET request to the given path at the given
+// interval, steering the requests away from the unhealthy ones,
+// DefaultProbeInterval is used when the interval is not positive
+func WithHealthProbe(path string, interval time.Duration) Option {
+	return func(o *options) {
+		o.probePath = path
+		o.probeEvery = interval
+	}
+}
+
+// WithRedirectPolicy sets the policy for handling redirect responses,
+// DefaultRedirectPolicy is used otherwise
+func WithRedirectPolicy(p RedirectPolicy) Option {
+	return func(o *options) {
+		o.redirect = &p
+	}
+}
+
+// WithProxy sends the requests through the proxy with the given URL,
+// like http://proxy.corp:3128, using the http, https, socks5 or socks5h
+// scheme, overriding the proxy of the config and the environment. Empty
+// URL connects to the endpoint directly, ignoring the environment
+func WithProxy(rawURL string) Option {
+	return func(o *options) {
+		o.proxy = &rawURL
+	}
+}
+
+// WithDialContext connects to the endpoint, or the proxy, using the dial
+// function, like the one of the dialer bound to a specific interface or
+// tunneling the connections, instead of net.Dialer. The addresses of the
+// endpoint are raced using the dial function when combined with
+// WithHappyEyeballs
+func WithDialContext(dial func(ctx context.Context, network, address string) (net.Conn, error)) Option {
+	return func(o *options) {
+		o.dial = dial
+	}
+}
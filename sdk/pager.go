@@ -0,0 +1,101 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Names of the fields of the list methods following the AIP-158
+// pagination convention, detected by the generated SDK
+const (
+	PageTokenField     = "page_token"
+	NextPageTokenField = "next_page_token"
+)
+
+// Pages returns an iterator over the pages of the list method following
+// the pagination convention, calling the method with the page_token of
+// the request set to the next_page_token of the previous page until
+// the token is empty. The request is left as is, while the iteration
+// stops after yielding the error of any page, including the token
+// repeated by the server which would otherwise never end. Used by the
+// generated Iterate helpers
+//
+//	for page, err := range sdk.Pages(ctx, req, svc.ListObjects) {
+//		if err != nil {
+//			return err
+//		}
+//		process(page.GetItems())
+//	}
+func Pages[Req, Resp proto.Message](ctx context.Context, req Req, list func(context.Context, Req, ...CallOption) (Resp, error), opts ...CallOption) iter.Seq2[Resp, error] {
+	return func(yield func(Resp, error) bool) {
+		var zero Resp
+		token := req.ProtoReflect().Descriptor().Fields().ByName(PageTokenField)
+		if token == nil || token.Kind() != protoreflect.StringKind || token.IsList() {
+			yield(zero, fmt.Errorf("%s has no %s field", req.ProtoReflect().Descriptor().FullName(), PageTokenField))
+			return
+		}
+		req = proto.Clone(req).(Req)
+		seen := map[string]bool{req.ProtoReflect().Get(token).String(): true}
+		for {
+			resp, err := list(ctx, req, opts...)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(resp, nil) {
+				return
+			}
+			next := nextPageToken(resp.ProtoReflect())
+			if next == "" {
+				return
+			}
+			if seen[next] {
+				yield(zero, fmt.Errorf("%s %q repeated by the server", NextPageTokenField, next))
+				return
+			}
+			seen[next] = true
+			req.ProtoReflect().Set(token, protoreflect.ValueOfString(next))
+		}
+	}
+}
+
+// Items returns an iterator over the items of the pages, as returned by
+// the function for each of the pages, stopping after yielding the error
+// of any page
+//
+//	for obj, err := range sdk.Items(sdk.Pages(ctx, req, svc.ListObjects), (*ListResponse).GetItems) {
+//		...
+//	}
+func Items[Resp, Item any](pages iter.Seq2[Resp, error], items func(Resp) []Item) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				var zero Item
+				yield(zero, err)
+				return
+			}
+			for _, item := range items(page) {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// nextPageToken returns the next_page_token of the response, empty if
+// the response has none
+func nextPageToken(resp protoreflect.Message) string {
+	fd := resp.Descriptor().Fields().ByName(NextPageTokenField)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return resp.Get(fd).String()
+}
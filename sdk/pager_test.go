@@ -0,0 +1,113 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// pagerFile has the list request and the response following the
+// pagination convention
+const pagerFile = `
+	name: "pager.proto"
+	package: "pager"
+	syntax: "proto3"
+	message_type <
+		name: "ListRequest"
+		field < name: "page_size" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "pageSize" >
+		field < name: "page_token" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "pageToken" >
+	>
+	message_type <
+		name: "ListResponse"
+		field < name: "items" number: 1 label: LABEL_REPEATED type: TYPE_STRING json_name: "items" >
+		field < name: "next_page_token" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "nextPageToken" >
+	>
+`
+
+func TestPages(t *testing.T) {
+	var fdp descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(pagerFile), &fdp); err != nil {
+		t.Fatalf("prototext.Unmarshal() failed with %v; want success", err)
+	}
+	fd, err := protodesc.NewFile(&fdp, nil)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() failed with %v; want success", err)
+	}
+	reqDesc, respDesc := fd.Messages().ByName("ListRequest"), fd.Messages().ByName("ListResponse")
+	field := func(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+		return md.Fields().ByName(protoreflect.Name(name))
+	}
+
+	// pages keyed by the token of the request, holding the items and the
+	// token of the next page
+	pages := map[string][2]string{"": {"a,b", "t1"}, "t1": {"c", "t2"}, "t2": {"d", ""}, "loop": {"e", "loop"}}
+	calls := 0
+	list := func(ctx context.Context, req *dynamicpb.Message, opts ...CallOption) (*dynamicpb.Message, error) {
+		calls++
+		page, ok := pages[req.Get(field(reqDesc, PageTokenField)).String()]
+		if !ok {
+			return nil, errors.New("invalid token")
+		}
+		resp := dynamicpb.NewMessage(respDesc)
+		items := resp.Mutable(field(respDesc, "items")).List()
+		for _, item := range strings.Split(page[0], ",") {
+			items.Append(protoreflect.ValueOfString(item))
+		}
+		resp.Set(field(respDesc, NextPageTokenField), protoreflect.ValueOfString(page[1]))
+		return resp, nil
+	}
+	itemsOf := func(resp *dynamicpb.Message) []string {
+		var items []string
+		l := resp.Get(field(respDesc, "items")).List()
+		for i := 0; i < l.Len(); i++ {
+			items = append(items, l.Get(i).String())
+		}
+		return items
+	}
+
+	req := dynamicpb.NewMessage(reqDesc)
+	var got []string
+	for item, err := range Items(Pages(context.Background(), req, list), itemsOf) {
+		if err != nil {
+			t.Fatalf("Items() failed with %v; want success", err)
+		}
+		got = append(got, item)
+	}
+	if strings.Join(got, ",") != "a,b,c,d" || calls != 3 {
+		t.Errorf("Items() = %v after %d calls; want a,b,c,d after 3 calls", got, calls)
+	}
+	if token := req.Get(field(reqDesc, PageTokenField)).String(); token != "" {
+		t.Errorf("Pages() modified the request page_token to %q; want as is", token)
+	}
+
+	// breaking out of the loop stops fetching the pages
+	calls = 0
+	for range Items(Pages(context.Background(), req, list), itemsOf) {
+		break
+	}
+	if calls != 1 {
+		t.Errorf("Items() fetched %d pages after break; want 1", calls)
+	}
+
+	// the token repeated by the server ends the iteration with the error
+	req.Set(field(reqDesc, PageTokenField), protoreflect.ValueOfString("loop"))
+	var errs int
+	for _, err := range Pages(context.Background(), req, list) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("Pages() with the repeated token yielded %d errors; want 1", errs)
+	}
+}
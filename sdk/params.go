@@ -0,0 +1,49 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FormatTimestamp formats the timestamp as RFC 3339 in UTC, such as
+// "2025-01-02T15:04:05.5Z", as parsed by grpc-gateway for the path and
+// query params, returns empty string for nil timestamp
+func FormatTimestamp(ts *timestamppb.Timestamp) string {
+	return formatJSONString(ts)
+}
+
+// FormatDuration formats the duration as seconds with the "s" suffix,
+// such as "1.500s", as parsed by grpc-gateway for the path and query
+// params, returns empty string for nil duration
+func FormatDuration(d *durationpb.Duration) string {
+	return formatJSONString(d)
+}
+
+// FormatFieldMask joins the paths of the field mask using comma, as
+// parsed by grpc-gateway for the query params
+func FormatFieldMask(fm *fieldmaskpb.FieldMask) string {
+	return strings.Join(fm.GetPaths(), ",")
+}
+
+// formatJSONString returns the JSON representation of the well known
+// type message that is encoded as a JSON string
+func formatJSONString(m proto.Message) string {
+	if !m.ProtoReflect().IsValid() {
+		return ""
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		// invalid values, such as out of range, are not expected
+		// to be accepted by the server either
+		return ""
+	}
+	return strings.Trim(string(data), `"`)
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormatParams(t *testing.T) {
+	ts := timestamppb.New(time.Date(2025, 1, 2, 15, 4, 5, 500000000, time.FixedZone("IST", 19800)))
+	if got, want := FormatTimestamp(ts), "2025-01-02T09:34:05.500Z"; got != want {
+		t.Errorf("FormatTimestamp() = %q; want %q", got, want)
+	}
+	d := durationpb.New(90*time.Second + 500*time.Millisecond)
+	if got, want := FormatDuration(d), "90.500s"; got != want {
+		t.Errorf("FormatDuration() = %q; want %q", got, want)
+	}
+	fm := &fieldmaskpb.FieldMask{Paths: []string{"name", "spec.display_name"}}
+	if got, want := FormatFieldMask(fm), "name,spec.display_name"; got != want {
+		t.Errorf("FormatFieldMask() = %q; want %q", got, want)
+	}
+	if got := FormatTimestamp(nil); got != "" {
+		t.Errorf("FormatTimestamp(nil) = %q; want empty", got)
+	}
+
+	// the formatted values are parsed back by grpc-gateway
+	pts, err := runtime.Timestamp(FormatTimestamp(ts))
+	if err != nil || !proto.Equal(pts, ts) {
+		t.Errorf("runtime.Timestamp() = %v, %v; want %v", pts, err, ts)
+	}
+	pd, err := runtime.Duration(FormatDuration(d))
+	if err != nil || !proto.Equal(pd, d) {
+		t.Errorf("runtime.Duration() = %v, %v; want %v", pd, err, d)
+	}
+	qd, err := time.ParseDuration(FormatDuration(d))
+	if err != nil || qd != d.AsDuration() {
+		t.Errorf("time.ParseDuration() = %v, %v; want %v", qd, err, d.AsDuration())
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Pool is a sync.Pool backed allocator of response messages, to be
+// used with the generated <Method>Into variants allowing hot paths to
+// reuse the allocations across calls, zero value is ready to use
+//
+//	var pool sdk.Pool[example.PostResponse, *example.PostResponse]
+//
+//	out := pool.Get()
+//	defer pool.Put(out)
+//	err := svc.GetObjectInto(ctx, req, out)
+type Pool[T any, P interface {
+	*T
+	proto.Message
+}] struct {
+	pool sync.Pool
+}
+
+// Get returns a message from the pool, allocating a new one when
+// the pool is empty
+func (p *Pool[T, P]) Get() P {
+	if m, ok := p.pool.Get().(P); ok {
+		return m
+	}
+	return P(new(T))
+}
+
+// Put resets the message and returns it to the pool, the message
+// must not be used after returning it to the pool
+func (p *Pool[T, P]) Put(m P) {
+	if m == nil {
+		return
+	}
+	proto.Reset(m)
+	p.pool.Put(m)
+}
@@ -0,0 +1,27 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPool(t *testing.T) {
+	var pool Pool[wrapperspb.StringValue, *wrapperspb.StringValue]
+	m := pool.Get()
+	if m == nil {
+		t.Fatalf("Get() = nil; want allocated message")
+	}
+	m.Value = "stale"
+	pool.Put(m)
+	if m.GetValue() != "" {
+		t.Errorf("message after Put() = %q; want reset message", m.GetValue())
+	}
+	pool.Put(nil)
+	if got := pool.Get(); got == nil || got.GetValue() != "" {
+		t.Errorf("Get() = %v; want empty message", got)
+	}
+}
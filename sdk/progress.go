@@ -0,0 +1,207 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ProgressInterval is the minimum interval between the progress reports
+// of a transfer, apart from the final one
+var ProgressInterval = 100 * time.Millisecond
+
+// ProgressDirection is the direction of the transfer being reported
+type ProgressDirection int
+
+const (
+	// ProgressUpload reports sending the request body
+	ProgressUpload ProgressDirection = iota
+	// ProgressDownload reports receiving the response body
+	ProgressDownload
+)
+
+// String returns the name of the direction
+func (d ProgressDirection) String() string {
+	if d == ProgressUpload {
+		return "upload"
+	}
+	return "download"
+}
+
+// Progress is the progress of the transfer of the request or the
+// response body of a call
+type Progress struct {
+	// Direction of the transfer
+	Direction ProgressDirection
+	// Bytes is the number of bytes transferred so far
+	Bytes int64
+	// Total is the length of the body, -1 if not known
+	Total int64
+	// Rate is the average rate of the transfer so far, in bytes per
+	// second
+	Rate float64
+	// Done reports the transfer as complete, the last report of the
+	// transfer
+	Done bool
+}
+
+// Percent returns the percentage of the body transferred so far, -1 if
+// the length of the body is not known
+func (p Progress) Percent() float64 {
+	if p.Total < 0 {
+		return -1
+	}
+	if p.Total == 0 {
+		return 100
+	}
+	return float64(p.Bytes) * 100 / float64(p.Total)
+}
+
+// ProgressFunc receives the progress reports of the transfers of a
+// call, called synchronously from the transfer and so expected to
+// return promptly
+type ProgressFunc func(Progress)
+
+// WithProgress reports the progress of sending the request body and
+// receiving the response body of the call to the function, at most
+// once every ProgressInterval apart from the final report of each
+// transfer, meant for the CLIs displaying the progress of the uploads
+// and the downloads of the large bodies. Retried attempts report the
+// upload from the start
+//
+//	_, err := svc.UploadArtifact(ctx, req, sdk.WithProgress(func(p sdk.Progress) {
+//		fmt.Printf("\r%s %.0f%% at %.0f B/s", p.Direction, p.Percent(), p.Rate)
+//	}))
+func WithProgress(fn ProgressFunc) CallOption {
+	return func(o *callOptions) {
+		o.progress = fn
+	}
+}
+
+// ProgressChan returns the function sending the progress reports to the
+// channel, dropping the intermediate reports while the channel is full
+// such that a slow consumer never stalls the transfer, while the final
+// reports are always delivered
+//
+//	updates := make(chan sdk.Progress, 1)
+//	go render(updates)
+//	_, err := svc.UploadArtifact(ctx, req, sdk.WithProgress(sdk.ProgressChan(updates)))
+func ProgressChan(ch chan<- Progress) ProgressFunc {
+	return func(p Progress) {
+		if p.Done {
+			ch <- p
+			return
+		}
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+// progressKey is the context key carrying the progress function of the
+// call, reporting the download once the response is received
+type progressKey struct{}
+
+// trackUpload reports the progress of sending the body of the request,
+// including the bodies of the retried attempts
+func trackUpload(r *http.Request, fn ProgressFunc) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	total := r.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	r.Body = newProgressReader(r.Body, ProgressUpload, total, fn)
+	if getBody := r.GetBody; getBody != nil {
+		r.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return newProgressReader(body, ProgressUpload, total, fn), nil
+		}
+	}
+}
+
+// trackDownload reports the progress of receiving the body of the
+// response to the request as per the progress function of the call if
+// any
+func trackDownload(r *http.Request, resp *http.Response, err error) (*http.Response, error) {
+	if err != nil || resp == nil || resp.Body == nil {
+		return resp, err
+	}
+	fn, ok := r.Context().Value(progressKey{}).(ProgressFunc)
+	if !ok {
+		return resp, err
+	}
+	total := resp.ContentLength
+	if total < 0 || resp.Uncompressed {
+		total = -1
+	}
+	resp.Body = newProgressReader(resp.Body, ProgressDownload, total, fn)
+	return resp, nil
+}
+
+// progressReader counts the bytes read from the body, reporting the
+// progress
+type progressReader struct {
+	io.ReadCloser
+	fn       ProgressFunc
+	progress Progress
+	start    time.Time
+	last     time.Time
+	once     sync.Once
+}
+
+func newProgressReader(body io.ReadCloser, d ProgressDirection, total int64, fn ProgressFunc) *progressReader {
+	now := time.Now()
+	return &progressReader{
+		ReadCloser: body,
+		fn:         fn,
+		progress:   Progress{Direction: d, Total: total},
+		start:      now,
+		last:       now,
+	}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.progress.Bytes += int64(n)
+	switch {
+	case errors.Is(err, io.EOF):
+		r.done()
+	case n > 0 && time.Since(r.last) >= ProgressInterval:
+		r.last = time.Now()
+		r.report()
+	}
+	return n, err
+}
+
+// Close closes the body, reporting the final progress unless already
+// reported
+func (r *progressReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.done()
+	return err
+}
+
+func (r *progressReader) done() {
+	r.once.Do(func() {
+		r.progress.Done = true
+		r.report()
+	})
+}
+
+func (r *progressReader) report() {
+	if elapsed := time.Since(r.start).Seconds(); elapsed > 0 {
+		r.progress.Rate = float64(r.progress.Bytes) / elapsed
+	}
+	r.fn(r.progress)
+}
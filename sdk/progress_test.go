@@ -0,0 +1,89 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	payload := strings.Repeat("x", 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	saved := ProgressInterval
+	ProgressInterval = 0
+	defer func() { ProgressInterval = saved }()
+
+	var reports []Progress
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/v1/artifacts", bytes.NewReader([]byte(payload)))
+	if err != nil {
+		t.Fatalf("NewRequest() failed with %v; want success", err)
+	}
+	r, cancel := ApplyCallOptions(r, WithProgress(func(p Progress) {
+		reports = append(reports, p)
+	}))
+	defer cancel()
+	resp, err := NewServiceConfig("example.Artifacts").Do(srv.Client(), r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != payload {
+		t.Fatalf("Do() responded %d bytes; want %d", len(body), len(payload))
+	}
+
+	done := map[ProgressDirection]Progress{}
+	for _, p := range reports {
+		if p.Done {
+			if _, ok := done[p.Direction]; ok {
+				t.Errorf("WithProgress() reported %s done twice", p.Direction)
+			}
+			done[p.Direction] = p
+		}
+	}
+	for _, d := range []ProgressDirection{ProgressUpload, ProgressDownload} {
+		p, ok := done[d]
+		if !ok {
+			t.Errorf("WithProgress() did not report %s done; got %v", d, reports)
+			continue
+		}
+		if p.Bytes != int64(len(payload)) || p.Total != int64(len(payload)) || p.Percent() != 100 {
+			t.Errorf("WithProgress() reported %s done with %d/%d bytes; want %d", d, p.Bytes, p.Total, len(payload))
+		}
+	}
+	if last := reports[len(reports)-1]; last.Direction != ProgressDownload || !last.Done {
+		t.Errorf("WithProgress() last reported %+v; want download done", last)
+	}
+}
+
+func TestProgressChan(t *testing.T) {
+	ch := make(chan Progress, 1)
+	fn := ProgressChan(ch)
+	fn(Progress{Bytes: 1})
+	// dropped while the channel is full
+	fn(Progress{Bytes: 2})
+	if p := <-ch; p.Bytes != 1 {
+		t.Errorf("ProgressChan() delivered %+v; want the first report", p)
+	}
+	fn(Progress{Bytes: 3, Done: true})
+	if p := <-ch; !p.Done {
+		t.Errorf("ProgressChan() delivered %+v; want the final report", p)
+	}
+	if p := (Progress{Bytes: 5, Total: -1}); p.Percent() != -1 {
+		t.Errorf("Percent() with unknown total = %v; want -1", p.Percent())
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the
+// buckets of the call duration histogram used when none are provided
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics implements Metrics, exporting the calls in the
+// Prometheus text format when served over http, as the counter
+// sdk_client_calls_total labelled by the service, the method and the
+// status code, and the histogram sdk_client_call_duration_seconds
+// labelled by the service and the method. The service level objectives
+// of the methods are exported as the gauges sdk_client_slo_latency_seconds
+// labelled by the percentile too, and sdk_client_slo_availability_ratio,
+// while the target latency is added to the buckets of the histogram of
+// the method, such that the ratio of the calls meeting it is exact
+//
+//	metrics := sdk.NewPrometheusMetrics()
+//	http.Handle("/metrics", metrics)
+//	svc := example.NewHelloWorldService(client, sdk.WithMetrics(metrics))
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mu         sync.Mutex
+	calls      map[callKey]uint64
+	durations  map[callKey]*histogram
+	objectives map[callKey]Objective
+}
+
+// callKey identifies the series of the calls
+type callKey struct {
+	service string
+	method  string
+	code    int
+}
+
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewPrometheusMetrics creates the metrics using the given upper bounds,
+// in seconds, for the buckets of the call duration histogram,
+// DefaultLatencyBuckets if none are provided
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusMetrics{
+		buckets:    buckets,
+		calls:      map[callKey]uint64{},
+		durations:  map[callKey]*histogram{},
+		objectives: map[callKey]Objective{},
+	}
+}
+
+// DeclareObjective records the service level objective of the method
+func (m *PrometheusMetrics) DeclareObjective(service, method string, slo Objective) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objectives[callKey{service: service, method: method}] = slo
+}
+
+// newHistogram creates the histogram of the method, with the buckets
+// including the target latency of the method if any
+func (m *PrometheusMetrics) newHistogram(key callKey) *histogram {
+	buckets := m.buckets
+	if slo := m.objectives[key]; slo.Latency > 0 {
+		target := slo.Latency.Seconds()
+		if i, found := slices.BinarySearch(buckets, target); !found {
+			buckets = slices.Insert(slices.Clone(buckets), i, target)
+		}
+	}
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+// ObserveCall records the call
+func (m *PrometheusMetrics) ObserveCall(service, method string, code int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls[callKey{service: service, method: method, code: code}]++
+
+	key := callKey{service: service, method: method}
+	h, ok := m.durations[key]
+	if !ok {
+		h = m.newHistogram(key)
+		m.durations[key] = h
+	}
+	seconds := duration.Seconds()
+	for i, le := range h.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format, with the
+// series sorted by the labels
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	m.mu.Lock()
+	b.WriteString("# HELP sdk_client_calls_total Total number of the calls made by the SDK.\n")
+	b.WriteString("# TYPE sdk_client_calls_total counter\n")
+	for _, k := range sortedKeys(m.calls) {
+		fmt.Fprintf(&b, "sdk_client_calls_total{service=%s,method=%s,code=\"%d\"} %d\n",
+			quoteLabel(k.service), quoteLabel(k.method), k.code, m.calls[k])
+	}
+	b.WriteString("# HELP sdk_client_call_duration_seconds Duration of the calls made by the SDK.\n")
+	b.WriteString("# TYPE sdk_client_call_duration_seconds histogram\n")
+	for _, k := range sortedKeys(m.durations) {
+		h := m.durations[k]
+		labels := fmt.Sprintf("service=%s,method=%s", quoteLabel(k.service), quoteLabel(k.method))
+		for i, le := range h.buckets {
+			fmt.Fprintf(&b, "sdk_client_call_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "sdk_client_call_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&b, "sdk_client_call_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "sdk_client_call_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+	if len(m.objectives) != 0 {
+		keys := sortedKeys(m.objectives)
+		b.WriteString("# HELP sdk_client_slo_latency_seconds Target latency of the calls as per the service level objective.\n")
+		b.WriteString("# TYPE sdk_client_slo_latency_seconds gauge\n")
+		for _, k := range keys {
+			if slo := m.objectives[k]; slo.Latency > 0 {
+				fmt.Fprintf(&b, "sdk_client_slo_latency_seconds{service=%s,method=%s,percentile=\"%s\"} %s\n",
+					quoteLabel(k.service), quoteLabel(k.method), strconv.FormatFloat(slo.LatencyPercentile, 'g', -1, 64),
+					strconv.FormatFloat(slo.Latency.Seconds(), 'g', -1, 64))
+			}
+		}
+		b.WriteString("# HELP sdk_client_slo_availability_ratio Target ratio of the successful calls as per the service level objective.\n")
+		b.WriteString("# TYPE sdk_client_slo_availability_ratio gauge\n")
+		for _, k := range keys {
+			// rounded, as the percentage is not exact in binary
+			if slo := m.objectives[k]; slo.Availability > 0 {
+				fmt.Fprintf(&b, "sdk_client_slo_availability_ratio{service=%s,method=%s} %s\n",
+					quoteLabel(k.service), quoteLabel(k.method), strconv.FormatFloat(slo.Availability/100, 'g', 12, 64))
+			}
+		}
+	}
+	m.mu.Unlock()
+	return b.WriteTo(w)
+}
+
+func sortedKeys[V any](m map[callKey]V) []callKey {
+	keys := make([]callKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+	return keys
+}
+
+// labelEscaper escapes the label values as per the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
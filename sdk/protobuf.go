@@ -0,0 +1,74 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ProtobufContentType is the content type of the bodies encoded as
+// protobuf binary, served by the routes configured using
+// routes.WithProtobuf
+const ProtobufContentType = "application/x-protobuf"
+
+// protoMarshaler encodes the messages as protobuf binary
+type protoMarshaler struct {
+	runtime.ProtoMarshaller
+	// json decodes the responses served as JSON, if set
+	json runtime.Marshaler
+}
+
+// ContentType returns the content type of the protobuf bodies
+func (*protoMarshaler) ContentType(_ any) string {
+	return ProtobufContentType
+}
+
+// WithProtobuf encodes the requests of the unary calls of the service as
+// protobuf binary instead of JSON, asking for the responses encoded the
+// same, cutting the size of the payloads and the CPU spent encoding them
+// for the large messages. The server is expected to accept the protobuf
+// requests, like the routes served using routes.WithProtobuf, while the
+// responses are decoded as per their content type. Methods mapping the
+// body to a non message field or the response body to a field continue
+// to use JSON
+func WithProtobuf() ServiceOption {
+	return func(c *ServiceConfig) {
+		c.protobuf = true
+	}
+}
+
+// Marshaler returns the marshaler encoding the requests of the unary
+// calls, protobuf when configured using WithProtobuf, JSON otherwise
+func (c *ServiceConfig) Marshaler() runtime.Marshaler {
+	if c != nil && c.protobuf {
+		return &protoMarshaler{json: c.JSONMarshaler()}
+	}
+	return c.JSONMarshaler()
+}
+
+// ResponseMarshaler returns the marshaler decoding the body of the
+// response as per its content type, the marshaler of the request when
+// the content type is not known
+func ResponseMarshaler(resp *http.Response, m runtime.Marshaler) runtime.Marshaler {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case ProtobufContentType:
+		if _, ok := m.(*protoMarshaler); ok {
+			return m
+		}
+		return &protoMarshaler{}
+	case "application/json":
+		if _, ok := m.(*runtime.JSONPb); ok {
+			return m
+		}
+		if pm, ok := m.(*protoMarshaler); ok && pm.json != nil {
+			return pm.json
+		}
+		return &runtime.JSONPb{}
+	}
+	return m
+}
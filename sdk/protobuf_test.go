@@ -0,0 +1,59 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestMarshaler(t *testing.T) {
+	if got := NewServiceConfig("example.Objects").Marshaler().ContentType(nil); got != "application/json" {
+		t.Errorf("Marshaler() by default = %q; want application/json", got)
+	}
+	cfg := NewServiceConfig("example.Objects", WithProtobuf())
+	m := cfg.Marshaler()
+	if got := m.ContentType(nil); got != ProtobufContentType {
+		t.Errorf("Marshaler() with protobuf = %q; want %q", got, ProtobufContentType)
+	}
+
+	msg := structpb.NewStringValue("hello")
+	data, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal() failed with %v; want success", err)
+	}
+	if want, _ := proto.Marshal(msg); string(data) != string(want) {
+		t.Errorf("Marshal() = %q; want protobuf binary %q", data, want)
+	}
+}
+
+func TestResponseMarshaler(t *testing.T) {
+	pb := NewServiceConfig("example.Objects", WithProtobuf()).Marshaler()
+	json := &runtime.JSONPb{}
+	for _, spec := range []struct {
+		name        string
+		contentType string
+		m           runtime.Marshaler
+		want        string
+	}{
+		{name: "protobuf", contentType: ProtobufContentType, m: json, want: ProtobufContentType},
+		{name: "json", contentType: "application/json; charset=utf-8", m: pb, want: "application/json"},
+		{name: "unknown", contentType: "text/plain", m: pb, want: ProtobufContentType},
+		{name: "missing", m: json, want: "application/json"},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if spec.contentType != "" {
+				resp.Header.Set("Content-Type", spec.contentType)
+			}
+			if got := ResponseMarshaler(resp, spec.m).ContentType(nil); got != spec.want {
+				t.Errorf("ResponseMarshaler() = %q; want %q", got, spec.want)
+			}
+		})
+	}
+}
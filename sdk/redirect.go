@@ -0,0 +1,71 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-core-stack/auth/hash"
+)
+
+// RedirectPolicy controls how the redirect responses are handled
+type RedirectPolicy struct {
+	// MaxRedirects is the number of redirects followed for a request,
+	// the redirect response is returned as is once exceeded, zero
+	// disables following the redirects
+	MaxRedirects int
+
+	// ForwardAuth forwards the authentication of the request while
+	// being redirected to a different origin
+	ForwardAuth bool
+}
+
+// DefaultRedirectPolicy follows up to 5 redirects, authenticating
+// only the redirects to the same origin as the original request
+var DefaultRedirectPolicy = RedirectPolicy{MaxRedirects: 5}
+
+// signatureHeaders are the headers added by the HMAC signer
+var signatureHeaders = []string{"x-signature", "x-api-key-id", "x-timestamp"}
+
+// credentialHeaders carry the credentials provided by the caller
+var credentialHeaders = []string{"Authorization", "Cookie"}
+
+// checkRedirect returns the redirect check, for the http client, as
+// per the policy, signing the redirected request again as the
+// signature is bound to the request path
+func (p RedirectPolicy) checkRedirect(signer hash.Generator) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > p.MaxRedirects {
+			return http.ErrUseLastResponse
+		}
+		for _, h := range signatureHeaders {
+			req.Header.Del(h)
+		}
+
+		orig := via[0]
+		if !p.ForwardAuth && !sameOrigin(req.URL, orig.URL) {
+			for _, h := range credentialHeaders {
+				req.Header.Del(h)
+			}
+			return nil
+		}
+		// http client drops the credentials for a different domain,
+		// restore them as forwarding is allowed
+		for _, h := range credentialHeaders {
+			if v := orig.Header.Values(h); len(v) != 0 {
+				req.Header[http.CanonicalHeaderKey(h)] = v
+			}
+		}
+		if signer != nil {
+			signer.AddAuthHeaders(req)
+		}
+		return nil
+	}
+}
+
+// sameOrigin reports whether both the URLs share scheme, host and port
+func sameOrigin(a, b *url.URL) bool {
+	return a.Scheme == b.Scheme && a.Host == b.Host
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientRedirectPolicy(t *testing.T) {
+	type seen struct {
+		path          string
+		keyID         string
+		authorization string
+	}
+	var got seen
+	record := func(w http.ResponseWriter, r *http.Request) {
+		got = seen{
+			path:          r.URL.Path,
+			keyID:         r.Header.Get("x-api-key-id"),
+			authorization: r.Header.Get("Authorization"),
+		}
+	}
+	other := httptest.NewServer(http.HandlerFunc(record))
+	defer other.Close()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/loop":
+			http.Redirect(w, r, "/v1/loop", http.StatusFound)
+		case "/v1/local":
+			http.Redirect(w, r, srv.URL+"/v1/final", http.StatusTemporaryRedirect)
+		case "/v1/remote":
+			http.Redirect(w, r, other.URL+"/v1/final", http.StatusTemporaryRedirect)
+		default:
+			record(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	auth := AuthConfig{Mode: AuthModeHMAC, APIKey: "key-1", Secret: "secret"}
+	for _, spec := range []struct {
+		name       string
+		opts       []Option
+		path       string
+		wantStatus int
+		want       seen
+	}{
+		{
+			name:       "same origin is signed",
+			path:       "/v1/local",
+			wantStatus: http.StatusOK,
+			want:       seen{path: "/v1/final", keyID: "key-1", authorization: "Bearer token"},
+		},
+		{
+			name:       "cross origin drops auth",
+			path:       "/v1/remote",
+			wantStatus: http.StatusOK,
+			want:       seen{path: "/v1/final"},
+		},
+		{
+			name:       "cross origin forwards auth",
+			opts:       []Option{WithRedirectPolicy(RedirectPolicy{MaxRedirects: 1, ForwardAuth: true})},
+			path:       "/v1/remote",
+			wantStatus: http.StatusOK,
+			want:       seen{path: "/v1/final", keyID: "key-1", authorization: "Bearer token"},
+		},
+		{
+			name:       "redirects disabled",
+			opts:       []Option{WithRedirectPolicy(RedirectPolicy{})},
+			path:       "/v1/local",
+			wantStatus: http.StatusTemporaryRedirect,
+		},
+		{
+			name:       "redirects exceeded",
+			path:       "/v1/loop",
+			wantStatus: http.StatusFound,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			got = seen{}
+			c, err := NewClient(&Config{Endpoint: srv.URL, Auth: auth}, spec.opts...)
+			if err != nil {
+				t.Fatalf("NewClient() failed with %v; want success", err)
+			}
+			r, _ := http.NewRequest(http.MethodGet, spec.path, nil)
+			r.Header.Set("Authorization", "Bearer token")
+			resp, err := c.Do(r)
+			if err != nil {
+				t.Fatalf("Do() failed with %v; want success", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != spec.wantStatus {
+				t.Errorf("Do() status = %d; want %d", resp.StatusCode, spec.wantStatus)
+			}
+			if got != spec.want {
+				t.Errorf("redirected request = %+v; want %+v", got, spec.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MissingFieldsError is returned by the generated SDK methods, before
+// dispatching the request, when the fields of the request marked using
+// the (api.required) option are not set
+type MissingFieldsError struct {
+	// Fields which are required but not set
+	Fields []string
+}
+
+// Error returns the description of the error
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("missing required parameters: %s", strings.Join(e.Fields, ", "))
+}
+
+// CheckRequired ensures the given fields of the message are set, fields
+// without explicit presence are treated as not set when holding the
+// zero value
+func CheckRequired(msg proto.Message, fields ...string) error {
+	m := msg.ProtoReflect()
+	var missing []string
+	for _, name := range fields {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !m.Has(fd) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		return &MissingFieldsError{Fields: missing}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckRequired(t *testing.T) {
+	msg := &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), Number: proto.Int32(0)}
+	if err := CheckRequired(msg, "name", "number"); err != nil {
+		t.Errorf("CheckRequired() failed with %v; want success", err)
+	}
+
+	err := CheckRequired(msg, "name", "type_name", "json_name")
+	var merr *MissingFieldsError
+	if !errors.As(err, &merr) {
+		t.Fatalf("CheckRequired() = %v; want *MissingFieldsError", err)
+	}
+	if want := []string{"type_name", "json_name"}; !reflect.DeepEqual(merr.Fields, want) {
+		t.Errorf("CheckRequired() missing = %v; want %v", merr.Fields, want)
+	}
+}
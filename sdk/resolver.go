@@ -0,0 +1,212 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRefreshInterval is the interval after which the endpoints
+// provided by a resolver are refreshed, unless specified otherwise
+const DefaultRefreshInterval = 30 * time.Second
+
+// Resolver provides the set of endpoints currently serving the API,
+// allowing the SDK to follow endpoint churn in dynamic environments
+type Resolver interface {
+	// Resolve returns the base URLs of the available endpoints
+	Resolve(ctx context.Context) ([]*url.URL, error)
+}
+
+// staticResolver always resolves to the same set of endpoints
+type staticResolver struct {
+	endpoints []*url.URL
+}
+
+func (r *staticResolver) Resolve(ctx context.Context) ([]*url.URL, error) {
+	return r.endpoints, nil
+}
+
+// NewStaticResolver creates a resolver for a fixed set of endpoints
+func NewStaticResolver(endpoints ...string) (Resolver, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints provided")
+	}
+	r := &staticResolver{}
+	for _, e := range endpoints {
+		u, err := url.Parse(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", e, err)
+		}
+		r.endpoints = append(r.endpoints, u)
+	}
+	return r, nil
+}
+
+// dnsResolver resolves the endpoints using DNS SRV records
+type dnsResolver struct {
+	scheme  string
+	service string
+	proto   string
+	name    string
+	lookup  func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+func (r *dnsResolver) Resolve(ctx context.Context) ([]*url.URL, error) {
+	_, records, err := r.lookup(ctx, r.service, r.proto, r.name)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []*url.URL
+	for _, srv := range records {
+		// records are sorted by priority, only the most preferred
+		// ones are used while they are available
+		if srv.Priority != records[0].Priority {
+			break
+		}
+		endpoints = append(endpoints, &url.URL{
+			Scheme: r.scheme,
+			Host:   net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))),
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no SRV records found for %s", r.name)
+	}
+	return endpoints, nil
+}
+
+// NewDNSResolver creates a resolver looking up the endpoints using
+// the DNS SRV records for _service._proto.name, the scheme is used
+// to build the endpoint URLs
+func NewDNSResolver(scheme, service, proto, name string) Resolver {
+	return &dnsResolver{
+		scheme:  scheme,
+		service: service,
+		proto:   proto,
+		name:    name,
+		lookup:  net.DefaultResolver.LookupSRV,
+	}
+}
+
+// consulResolver resolves the endpoints using the healthy instances
+// of a service registered with consul
+type consulResolver struct {
+	addr    string
+	service string
+	scheme  string
+	client  *http.Client
+}
+
+// consulEntry is the subset of consul health service entry used
+type consulEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+func (r *consulResolver) Resolve(ctx context.Context) ([]*url.URL, error) {
+	uri := strings.TrimSuffix(r.addr, "/") + "/v1/health/service/" + url.PathEscape(r.service) + "?passing=true"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("CONSUL_HTTP_TOKEN"); token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul lookup for %s failed with status %s", r.service, resp.Status)
+	}
+
+	var entries []consulEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode consul response: %w", err)
+	}
+	var endpoints []*url.URL
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		endpoints = append(endpoints, &url.URL{
+			Scheme: r.scheme,
+			Host:   net.JoinHostPort(host, strconv.Itoa(e.Service.Port)),
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no healthy instances found for %s", r.service)
+	}
+	return endpoints, nil
+}
+
+// NewConsulResolver creates a resolver providing the healthy instances
+// of the service as registered with the consul agent at addr, the
+// token in CONSUL_HTTP_TOKEN environment is used when set
+func NewConsulResolver(addr, service, scheme string) Resolver {
+	return &consulResolver{
+		addr:    addr,
+		service: service,
+		scheme:  scheme,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// endpointSet keeps the endpoints provided by the resolver, refreshing
+// them once stale and balancing the requests across them
+type endpointSet struct {
+	resolver Resolver
+	refresh  time.Duration
+	probe    *healthProbe
+
+	mu        sync.Mutex
+	endpoints []*url.URL
+	health    map[string]*endpointHealth
+	expiry    time.Time
+	next      atomic.Uint64
+}
+
+// pick returns the endpoint to be used for the next request
+func (s *endpointSet) pick(ctx context.Context) (*url.URL, error) {
+	s.mu.Lock()
+	if time.Now().After(s.expiry) {
+		endpoints, err := s.resolver.Resolve(ctx)
+		switch {
+		case err == nil && len(endpoints) != 0:
+			s.endpoints = endpoints
+		case len(s.endpoints) == 0:
+			s.mu.Unlock()
+			if err == nil {
+				err = fmt.Errorf("resolver returned no endpoints")
+			}
+			return nil, fmt.Errorf("failed to resolve endpoints: %w", err)
+		}
+		// on failure continue with the last known endpoints till
+		// the next refresh
+		s.expiry = time.Now().Add(s.refresh)
+	}
+	endpoints := s.endpoints
+	if s.probe != nil {
+		endpoints = s.healthyLocked()
+	}
+	s.mu.Unlock()
+	return endpoints[s.next.Add(1)%uint64(len(endpoints))], nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func urlStrings(endpoints []*url.URL) []string {
+	var res []string
+	for _, u := range endpoints {
+		res = append(res, u.String())
+	}
+	return res
+}
+
+func TestDNSResolver(t *testing.T) {
+	r := NewDNSResolver("https", "api", "tcp", "example.com").(*dnsResolver)
+	r.lookup = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		if service != "api" || proto != "tcp" || name != "example.com" {
+			return "", nil, fmt.Errorf("unexpected lookup %s %s %s", service, proto, name)
+		}
+		return "_api._tcp.example.com.", []*net.SRV{
+			{Target: "a.example.com.", Port: 8443, Priority: 10},
+			{Target: "b.example.com.", Port: 8443, Priority: 10},
+			{Target: "backup.example.com.", Port: 8443, Priority: 20},
+		}, nil
+	}
+	endpoints, err := r.Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve() failed with %v; want success", err)
+	}
+	want := []string{"https://a.example.com:8443", "https://b.example.com:8443"}
+	if got := urlStrings(endpoints); !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v; want %v", got, want)
+	}
+}
+
+func TestConsulResolver(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_TOKEN", "token-1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health/service/api" || r.URL.Query().Get("passing") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-Consul-Token") != "token-1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"Node": {"Address": "10.0.0.1"}, "Service": {"Address": "", "Port": 8080}},
+			{"Node": {"Address": "10.0.0.2"}, "Service": {"Address": "10.1.0.2", "Port": 8081}}
+		]`))
+	}))
+	defer srv.Close()
+
+	endpoints, err := NewConsulResolver(srv.URL, "api", "http").Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve() failed with %v; want success", err)
+	}
+	want := []string{"http://10.0.0.1:8080", "http://10.1.0.2:8081"}
+	if got := urlStrings(endpoints); !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v; want %v", got, want)
+	}
+
+	if _, err := NewConsulResolver(srv.URL, "unknown", "http").Resolve(context.Background()); err == nil {
+		t.Errorf("Resolve() for unknown service succeeded; want error")
+	}
+}
+
+// flakyResolver resolves to the endpoints, failing once they are
+// exhausted
+type flakyResolver struct {
+	calls     atomic.Int32
+	endpoints [][]string
+}
+
+func (r *flakyResolver) Resolve(ctx context.Context) ([]*url.URL, error) {
+	i := int(r.calls.Add(1)) - 1
+	if i >= len(r.endpoints) {
+		return nil, fmt.Errorf("resolver unavailable")
+	}
+	static, err := NewStaticResolver(r.endpoints[i]...)
+	if err != nil {
+		return nil, err
+	}
+	return static.Resolve(ctx)
+}
+
+func TestClientWithResolver(t *testing.T) {
+	var hitsA, hitsB atomic.Int32
+	srvA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsA.Add(1)
+	}))
+	defer srvA.Close()
+	srvB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsB.Add(1)
+	}))
+	defer srvB.Close()
+
+	resolver := &flakyResolver{endpoints: [][]string{
+		{srvA.URL, srvB.URL},
+		{srvB.URL},
+	}}
+	c, err := NewClient(&Config{}, WithResolver(resolver, time.Hour))
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	do := func() {
+		t.Helper()
+		r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	for range 4 {
+		do()
+	}
+	if hitsA.Load() != 2 || hitsB.Load() != 2 {
+		t.Errorf("hits = %d, %d; want requests balanced across endpoints", hitsA.Load(), hitsB.Load())
+	}
+
+	// force refresh, the churned endpoint set is picked up
+	set := c.(*client).set
+	set.expiry = time.Time{}
+	do()
+	do()
+	if hitsA.Load() != 2 || hitsB.Load() != 4 {
+		t.Errorf("hits = %d, %d; want requests only to the refreshed endpoints", hitsA.Load(), hitsB.Load())
+	}
+
+	// resolver failure keeps the last known endpoints
+	set.expiry = time.Time{}
+	do()
+	if got := resolver.calls.Load(); got != 3 {
+		t.Errorf("resolver calls = %d; want 3", got)
+	}
+	if hitsB.Load() != 5 {
+		t.Errorf("hits = %d; want last known endpoint to be used", hitsB.Load())
+	}
+}
+
+func TestClientWithFailingResolver(t *testing.T) {
+	c, err := NewClient(&Config{}, WithResolver(&flakyResolver{}, 0))
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	r, _ := http.NewRequest(http.MethodGet, "/v1/object", nil)
+	if _, err := c.Do(r); err == nil {
+		t.Errorf("Do() succeeded without endpoints; want error")
+	}
+}
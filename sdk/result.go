@@ -0,0 +1,33 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import "net/http"
+
+// Result is the outcome of a method having status codes, apart from
+// success, expected as part of business flow, allowing the caller to
+// handle them without inspecting errors, or of an upsert method
+// distinguishing the creation of the resource from its replacement
+type Result[T any] struct {
+	// StatusCode of the response
+	StatusCode int
+
+	// Response is the decoded response for the success status codes
+	Response *T
+
+	// Body is the raw response body for the expected status codes
+	// other than success
+	Body []byte
+}
+
+// OK reports whether the method succeeded with a 2xx status code
+func (r *Result[T]) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Created reports whether the call of the upsert method created the
+// resource, responded with 201 Created
+func (r *Result[T]) Created() bool {
+	return r.StatusCode == http.StatusCreated
+}
@@ -0,0 +1,209 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+
+	"github.com/go-core-stack/grpc-core/breaker"
+	"github.com/go-core-stack/grpc-core/envelope"
+	"github.com/go-core-stack/grpc-core/jws"
+)
+
+// ServiceOption configures the SDK wrapper of a service
+type ServiceOption func(*ServiceConfig)
+
+// ServiceOptions are the options of the SDK wrappers, a distinct type
+// for the generated dependency injection providers to be injected with
+type ServiceOptions []ServiceOption
+
+// ServiceConfig is the configuration of the SDK wrapper of a service,
+// created by the generated constructors using NewServiceConfig
+type ServiceConfig struct {
+	service    string
+	endpoint   string
+	newBreaker func(service string) breaker.Breaker
+	breaker    breaker.Breaker
+	// interceptors run for the calls, the first one outermost
+	interceptors []Interceptor
+	// compressionThreshold is the size of the request bodies to be
+	// compressed, compression is disabled if zero
+	compressionThreshold int
+	// compressionCodecs are the codings used by the calls, the first
+	// one encoding the requests, compression.DefaultCodecs if empty
+	compressionCodecs []string
+	bulkheads         *Bulkheads
+	metrics           Metrics
+	objectives        map[string]Objective
+	cache             Cache
+	// conn sends the unary calls natively if set
+	conn grpc.ClientConnInterface
+	// cancelNotify notifies the server about the canceled calls
+	cancelNotify bool
+	// protobuf encodes the unary calls as protobuf binary
+	protobuf bool
+	// protoNames encodes the JSON using the original field names
+	protoNames bool
+	// marshaler encodes and decodes the JSON bodies if set
+	marshaler *runtime.JSONPb
+	// tlsConfig and clientCerts configure the client built for the
+	// wrappers constructed without one
+	tlsConfig   *tls.Config
+	clientCerts []tls.Certificate
+	// kms seals the fields marked using (api.encrypted)
+	kms envelope.KMS
+	// signatureKeys verify the responses marked using (api.signed)
+	signatureKeys jws.KeySet
+}
+
+// WithEndpoint sets the base URL, along with the scheme, host, port and
+// an optional path prefix, the requests of the service are sent to.
+// By default the requests are sent relative to the server root,
+// resolved against the endpoint configured for the client created
+// using NewClient, while a custom client is expected to resolve them
+// on its own
+func WithEndpoint(baseURL string) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.endpoint = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithBreaker guards the calls of the service using the circuit breaker
+// created for the fully qualified name of the service, such that the
+// failures of a service do not trip the others constructed using the
+// same options. Calls fail fast with breaker.ErrOpen while the breaker
+// is open, transport errors and responses with 5xx or 429 status count
+// as the failures
+func WithBreaker(newBreaker func(service string) breaker.Breaker) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.newBreaker = newBreaker
+	}
+}
+
+// NewServiceConfig creates the configuration of the SDK wrapper of the
+// service with the given fully qualified name, applying the options
+func NewServiceConfig(service string, opts ...ServiceOption) *ServiceConfig {
+	c := &ServiceConfig{service: service}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.newBreaker != nil {
+		c.breaker = c.newBreaker(service)
+	}
+	if m, ok := c.metrics.(ObjectiveMetrics); ok {
+		for method, slo := range c.objectives {
+			m.DeclareObjective(service, method, slo)
+		}
+	}
+	return c
+}
+
+// Do sends the request using the client through the interceptors,
+// guarded by the circuit breaker of the service if configured
+func (c *ServiceConfig) Do(client Doer, r *http.Request) (*http.Response, error) {
+	if c != nil && c.cancelNotify {
+		resp, err := notifyCancel(client, r, func(r *http.Request) (*http.Response, error) {
+			return c.intercept(client, r)
+		})
+		return trackDownload(r, resp, err)
+	}
+	resp, err := c.intercept(client, r)
+	return trackDownload(r, resp, err)
+}
+
+// intercept sends the request using the client through the interceptors
+// of the service if any
+func (c *ServiceConfig) intercept(client Doer, r *http.Request) (*http.Response, error) {
+	if c == nil {
+		return client.Do(r)
+	}
+	if len(c.interceptors) == 0 {
+		return c.do(client, r)
+	}
+	return chain(c.interceptors, func(_ context.Context, r *http.Request) (*http.Response, error) {
+		return c.do(client, r)
+	})(r.Context(), r)
+}
+
+// do sends the request using the client, served from the cache of the
+// service if configured
+func (c *ServiceConfig) do(client Doer, r *http.Request) (*http.Response, error) {
+	if c.cache == nil {
+		return c.isolate(client, r)
+	}
+	return c.cached(client, r)
+}
+
+// isolate sends the request using the client, isolated in the bulkhead
+// of the call if configured
+func (c *ServiceConfig) isolate(client Doer, r *http.Request) (*http.Response, error) {
+	if c.bulkheads == nil {
+		return c.guard(client, r)
+	}
+	release, err := c.bulkheads.acquire(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.service, err)
+	}
+	resp, err := c.guard(client, r)
+	return releaseOnClose(resp, err, release)
+}
+
+// guard sends the request using the client, guarded by the circuit
+// breaker of the service if configured
+func (c *ServiceConfig) guard(client Doer, r *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.send(client, r)
+	}
+	done, err := c.breaker.Allow()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.service, err)
+	}
+	resp, err := c.send(client, r)
+	done(!isBreakerFailure(resp, err))
+	return resp, err
+}
+
+// send sends the request using the client, compressing the request and
+// decompressing the response if configured
+func (c *ServiceConfig) send(client Doer, r *http.Request) (*http.Response, error) {
+	if c.compressionThreshold == 0 {
+		return client.Do(r)
+	}
+	r, err := compress(r, c.compressionThreshold, c.compressionCodecs)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(resp)
+}
+
+// isBreakerFailure reports whether the outcome of the call indicates
+// the upstream failing, the calls canceled by the caller are not
+func isBreakerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// URL returns the url of the request with the given uri, relative to
+// the server root, joined with the endpoint if provided. Invalid
+// endpoints are reported while creating the request
+func (c *ServiceConfig) URL(uri string) string {
+	if c == nil || c.endpoint == "" {
+		return uri
+	}
+	return c.endpoint + uri
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-core-stack/grpc-core/breaker"
+)
+
+func TestServiceConfigURL(t *testing.T) {
+	for _, tc := range []struct {
+		opts []ServiceOption
+		want string
+	}{
+		{want: "/v1/object/a%2Fb"},
+		{opts: []ServiceOption{WithEndpoint("https://api.example.com:8443")}, want: "https://api.example.com:8443/v1/object/a%2Fb"},
+		{opts: []ServiceOption{WithEndpoint("http://localhost:8080/gw/")}, want: "http://localhost:8080/gw/v1/object/a%2Fb"},
+	} {
+		if got := NewServiceConfig("example.Service", tc.opts...).URL("/v1/object/a%2Fb"); got != tc.want {
+			t.Errorf("URL() = %q; want %q", got, tc.want)
+		}
+	}
+}
+
+func TestServiceConfigOverridesClientEndpoint(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	uri := NewServiceConfig("example.Service", WithEndpoint(srv.URL+"/gw")).URL("/v1/objects")
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() failed with %v; want success", err)
+	}
+	resp, err := c.Do(r)
+	if err != nil {
+		t.Fatalf("Do() failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+	if path != "/gw/v1/objects" {
+		t.Errorf("request sent to %q; want %q", path, "/gw/v1/objects")
+	}
+}
+
+func TestServiceConfigBreaker(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	var services []string
+	opt := WithBreaker(func(service string) breaker.Breaker {
+		services = append(services, service)
+		return breaker.New(breaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute})
+	})
+	cfg := NewServiceConfig("example.Service", opt)
+	other := NewServiceConfig("example.Other", opt)
+	if len(services) != 2 || services[0] != "example.Service" || services[1] != "example.Other" {
+		t.Fatalf("breakers created for %v; want one per service", services)
+	}
+
+	do := func(cfg *ServiceConfig) error {
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/objects", nil)
+		resp, err := cfg.Do(c, r)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	for range 2 {
+		if err := do(cfg); err != nil {
+			t.Fatalf("Do() while closed failed with %v; want response", err)
+		}
+	}
+	if err := do(cfg); !errors.Is(err, breaker.ErrOpen) {
+		t.Errorf("Do() after consecutive failures = %v; want breaker.ErrOpen", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("upstream calls = %d; want 2, failing fast once open", got)
+	}
+	// breaker of the other service is not tripped
+	if err := do(other); err != nil {
+		t.Errorf("Do() for other service failed with %v; want response", err)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-core-stack/grpc-core/jws"
+)
+
+// WithSignatureKeys provides the keys used by the generated SDK to
+// verify the responses of the methods marked using (api.signed), calls
+// to such methods fail without the keys
+func WithSignatureKeys(keys jws.KeySet) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.signatureKeys = keys
+	}
+}
+
+// SignatureKeys returns the keys provided using WithSignatureKeys, nil
+// if not provided
+func (c *ServiceConfig) SignatureKeys() jws.KeySet {
+	if c == nil {
+		return nil
+	}
+	return c.signatureKeys
+}
+
+// VerifySignature verifies the detached signature of the response body
+// against the keys provided for the service, the responses without the
+// signature are rejected
+func VerifySignature(cfg *ServiceConfig, header http.Header, body []byte) error {
+	keys := cfg.SignatureKeys()
+	if keys == nil {
+		return errors.New("keys are required to verify the signed responses, see sdk.WithSignatureKeys")
+	}
+	sig := header.Get(jws.Header)
+	if sig == "" {
+		return fmt.Errorf("%w: response is not signed", jws.ErrInvalidSignature)
+	}
+	return jws.Verify(keys, sig, body)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-core-stack/grpc-core/jws"
+)
+
+func TestVerifySignature(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer, _ := jws.NewSigner("k1", key)
+	body := []byte(`{"name":"abc"}`)
+	sig, err := jws.Sign(context.Background(), signer, body)
+	if err != nil {
+		t.Fatalf("Sign() failed with %v; want success", err)
+	}
+	header := http.Header{}
+	header.Set(jws.Header, sig)
+
+	if err := VerifySignature(NewServiceConfig("example.Test"), header, body); err == nil {
+		t.Errorf("VerifySignature() without keys succeeded; want error")
+	}
+
+	cfg := NewServiceConfig("example.Test", WithSignatureKeys(jws.KeySet{"k1": pub}))
+	if err := VerifySignature(cfg, header, body); err != nil {
+		t.Errorf("VerifySignature() failed with %v; want success", err)
+	}
+	if err := VerifySignature(cfg, header, []byte(`{"name":"xyz"}`)); !errors.Is(err, jws.ErrInvalidSignature) {
+		t.Errorf("VerifySignature() of tampered body = %v; want ErrInvalidSignature", err)
+	}
+	if err := VerifySignature(cfg, http.Header{}, body); !errors.Is(err, jws.ErrInvalidSignature) {
+		t.Errorf("VerifySignature() of unsigned body = %v; want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// StreamError is the error sent by the server as part of the stream,
+// terminating the stream
+type StreamError struct {
+	// Code is the grpc status code of the error
+	Code int32 `json:"code"`
+
+	// Message describing the error
+	Message string `json:"message"`
+}
+
+// Error returns the description of the error
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("stream error: code = %d desc = %s", e.Code, e.Message)
+}
+
+// Stream reads the messages of a server streaming method from the
+// http response, where the messages are sent as newline delimited or
+// chunked JSON, each optionally wrapped as {"result": ...} or
+// {"error": ...} as done by grpc-gateway
+//
+//	stream, err := svc.WatchObjects(ctx, req)
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for msg, err := range stream.All() {
+//		...
+//	}
+type Stream[T any, P interface {
+	*T
+	proto.Message
+}] struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+	u    Unmarshaler
+	err  error
+}
+
+// NewStream returns the stream reading the messages from the response
+// using the given unmarshaler, expects the response to be successful.
+// The stream owns the response body, to be released using Close
+func NewStream[T any, P interface {
+	*T
+	proto.Message
+}](u Unmarshaler, resp *http.Response) *Stream[T, P] {
+	return &Stream[T, P]{
+		body: resp.Body,
+		dec:  json.NewDecoder(resp.Body),
+		u:    u,
+	}
+}
+
+// Recv returns the next message of the stream, returns io.EOF once the
+// server has completed the stream, and *StreamError if the server
+// terminated the stream with an error
+func (s *Stream[T, P]) Recv() (P, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	m, err := s.recv()
+	if err != nil {
+		s.err = err
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Stream[T, P]) recv() (P, error) {
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+	return decodeFrame[T, P](s.u, raw)
+}
+
+// decodeFrame decodes the message sent as part of the stream, which is
+// optionally wrapped as {"result": ...} or {"error": ...}
+func decodeFrame[T any, P interface {
+	*T
+	proto.Message
+}](u Unmarshaler, raw []byte) (P, error) {
+	// messages not encoded as JSON objects, like the well known
+	// wrapper types, are never wrapped
+	var frame map[string]json.RawMessage
+	if json.Unmarshal(raw, &frame) == nil && len(frame) == 1 {
+		if data, ok := frame["error"]; ok {
+			serr := &StreamError{}
+			if err := json.Unmarshal(data, serr); err != nil {
+				return nil, fmt.Errorf("invalid error in stream: %w", err)
+			}
+			return nil, serr
+		}
+		if data, ok := frame["result"]; ok {
+			raw = data
+		}
+	}
+
+	m := P(new(T))
+	if err := u.Unmarshal(raw, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// All returns an iterator over the messages of the stream, ending once
+// the stream is completed, or after yielding the error terminating the
+// stream
+func (s *Stream[T, P]) All() iter.Seq2[P, error] {
+	return func(yield func(P, error) bool) {
+		for {
+			m, err := s.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(m, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the underlying response, terminating the stream
+func (s *Stream[T, P]) Close() error {
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	return s.body.Close()
+}
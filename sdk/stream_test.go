@@ -0,0 +1,96 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newStreamResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestStreamRecv(t *testing.T) {
+	body := `{"result":{"name":"first"}}
+{"result":{"name":"second"}}{"name":"third"}
+{"error":{"code":13,"message":"internal failure"}}
+{"result":{"name":"ignored"}}
+`
+	stream := NewStream[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, newStreamResponse(body))
+	defer stream.Close()
+
+	for _, want := range []string{"first", "second", "third"} {
+		m, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream.Recv() failed with %v; want %q", err, want)
+		}
+		if m.GetName() != want {
+			t.Errorf("stream.Recv() = %q; want %q", m.GetName(), want)
+		}
+	}
+
+	_, err := stream.Recv()
+	var serr *StreamError
+	if !errors.As(err, &serr) {
+		t.Fatalf("stream.Recv() = %v; want *StreamError", err)
+	}
+	if serr.Code != 13 || serr.Message != "internal failure" {
+		t.Errorf("stream.Recv() = %+v; want code 13 with message", serr)
+	}
+
+	// stream is expected to be terminated by the error
+	if _, err := stream.Recv(); !errors.As(err, &serr) {
+		t.Errorf("stream.Recv() after error = %v; want *StreamError", err)
+	}
+}
+
+func TestStreamAll(t *testing.T) {
+	body := `{"result":"a"}
+"b"
+{"result":"c"}
+`
+	stream := NewStream[wrapperspb.StringValue](&runtime.JSONPb{}, newStreamResponse(body))
+	defer stream.Close()
+
+	var got []string
+	for m, err := range stream.All() {
+		if err != nil {
+			t.Fatalf("stream.All() yielded %v; want success", err)
+		}
+		got = append(got, m.GetValue())
+	}
+	if strings.Join(got, ",") != "a,b,c" {
+		t.Errorf("stream.All() = %v; want [a b c]", got)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("stream.Recv() after completion = %v; want io.EOF", err)
+	}
+}
+
+func TestStreamInvalid(t *testing.T) {
+	stream := NewStream[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, newStreamResponse(`{"result":{"name":`))
+	defer stream.Close()
+
+	var count int
+	for _, err := range stream.All() {
+		count++
+		if err == nil || errors.Is(err, io.EOF) {
+			t.Errorf("stream.All() yielded %v; want decode error", err)
+		}
+	}
+	if count != 1 {
+		t.Errorf("stream.All() yielded %d times; want 1", count)
+	}
+}
@@ -0,0 +1,497 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultFirstEventTimeout is the time for which the first server
+	// sent event is awaited, before concluding that an intermediary is
+	// buffering the stream and falling back to long polling
+	DefaultFirstEventTimeout = 15 * time.Second
+
+	// DefaultLongPollWait is the time for which the server is asked to
+	// hold the long polling request when there are no new messages
+	DefaultLongPollWait = 30 * time.Second
+
+	// DefaultReconnectDelay is the delay before reconnecting once the
+	// event stream is interrupted or the long polling request returns
+	// no messages
+	DefaultReconnectDelay = time.Second
+)
+
+// ResumeTokenHeader conveys the resume token of the long polling
+// requests and responses, following the grpc-gateway convention of
+// mapping the "resume-token" grpc metadata to http headers
+const ResumeTokenHeader = "Grpc-Metadata-Resume-Token"
+
+// errStreamingUnsupported is observed when the server sent events are
+// not delivered as a stream, triggering the long polling fallback
+var errStreamingUnsupported = errors.New("server sent events are not streamed")
+
+// SubscribeOption customizes the subscription created using
+// NewSubscription
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	token      string
+	longPoll   bool
+	firstEvent time.Duration
+	wait       time.Duration
+	reconnect  time.Duration
+}
+
+func newSubscribeOptions(opts []SubscribeOption) *subscribeOptions {
+	o := &subscribeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.firstEvent <= 0 {
+		o.firstEvent = DefaultFirstEventTimeout
+	}
+	if o.wait <= 0 {
+		o.wait = DefaultLongPollWait
+	}
+	if o.reconnect <= 0 {
+		o.reconnect = DefaultReconnectDelay
+	}
+	return o
+}
+
+// WithResumeToken resumes the subscription after the message
+// identified by the token, as previously obtained using ResumeToken
+func WithResumeToken(token string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.token = token
+	}
+}
+
+// WithLongPolling skips the server sent events, using long polling
+// from the start, for the networks known to be hostile to streaming
+func WithLongPolling() SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.longPoll = true
+	}
+}
+
+// WithFirstEventTimeout overrides the time for which the first server
+// sent event is awaited, DefaultFirstEventTimeout is used when the
+// timeout is not positive
+func WithFirstEventTimeout(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.firstEvent = d
+	}
+}
+
+// WithLongPollWait overrides the time for which the server is asked to
+// hold the long polling requests, DefaultLongPollWait is used when the
+// wait is not positive
+func WithLongPollWait(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.wait = d
+	}
+}
+
+// WithReconnectDelay overrides the delay before reconnecting, unless
+// advised otherwise by the server, DefaultReconnectDelay is used when
+// the delay is not positive
+func WithReconnectDelay(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.reconnect = d
+	}
+}
+
+// RequestFunc creates the request of the subscription, invoked for
+// every connection attempt
+type RequestFunc func(ctx context.Context) (*http.Request, error)
+
+// Subscription receives the messages of a server streaming method as
+// server sent events, falling back to long polling when an intermediary
+// does not deliver the events as a stream. The position in the stream
+// is tracked using the resume tokens, sent by the server as the event
+// id or using ResumeTokenHeader, such that the interrupted connections
+// and the fallback resume transparently without losing messages.
+//
+// The server ends the subscription by responding with 204 No Content,
+// and is expected to send an event or a comment as soon as the event
+// stream is established, as otherwise the stream is considered to be
+// buffered once DefaultFirstEventTimeout elapses.
+//
+//	sub, err := svc.SubscribeWatchObjects(ctx, req)
+//	if err != nil {
+//		return err
+//	}
+//	defer sub.Close()
+//	for msg, err := range sub.All() {
+//		...
+//	}
+type Subscription[T any, P interface {
+	*T
+	proto.Message
+}] struct {
+	ctx        context.Context
+	client     Doer
+	newRequest RequestFunc
+	u          Unmarshaler
+	opts       *subscribeOptions
+
+	longPoll  bool
+	reconnect time.Duration
+
+	// body and events are of the active event stream, released
+	// using cancel
+	body   io.ReadCloser
+	events *bufio.Reader
+	cancel context.CancelFunc
+
+	// pending messages of the last long polling response, followed by
+	// the error terminating the response if any
+	pending    []P
+	pendingErr error
+
+	// mu guards the resume token, which may be obtained concurrently
+	mu    sync.Mutex
+	token string
+
+	err error
+}
+
+// NewSubscription establishes the subscription, creating the requests
+// using newRequest and decoding the messages using the unmarshaler.
+// The subscription is terminated when the context is cancelled and
+// must be released using Close
+func NewSubscription[T any, P interface {
+	*T
+	proto.Message
+}](ctx context.Context, client Doer, newRequest RequestFunc, u Unmarshaler, opts ...SubscribeOption) (*Subscription[T, P], error) {
+	o := newSubscribeOptions(opts)
+	s := &Subscription[T, P]{
+		ctx:        ctx,
+		client:     client,
+		newRequest: newRequest,
+		u:          u,
+		opts:       o,
+		longPoll:   o.longPoll,
+		reconnect:  o.reconnect,
+		token:      o.token,
+	}
+	if !s.longPoll {
+		if err := s.connect(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ResumeToken returns the token identifying the position of the last
+// message received, to resume a later subscription using
+// WithResumeToken
+func (s *Subscription[T, P]) ResumeToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+func (s *Subscription[T, P]) setToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// LongPolling reports whether the subscription has fallen back to long
+// polling
+func (s *Subscription[T, P]) LongPolling() bool {
+	return s.longPoll
+}
+
+// Recv returns the next message of the subscription, returns io.EOF
+// once the server has ended the subscription, and *StreamError if the
+// server terminated the subscription with an error
+func (s *Subscription[T, P]) Recv() (P, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	m, err := s.recv()
+	if err != nil {
+		s.err = err
+		s.closeEvents()
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Subscription[T, P]) recv() (P, error) {
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if s.longPoll {
+			if len(s.pending) != 0 {
+				m := s.pending[0]
+				s.pending = s.pending[1:]
+				return m, nil
+			}
+			if s.pendingErr != nil {
+				return nil, s.pendingErr
+			}
+			if err := s.poll(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if s.events == nil {
+			if err := s.wait(s.reconnect); err != nil {
+				return nil, err
+			}
+			if err := s.connect(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		data, err := s.readEvent()
+		if err != nil {
+			// the stream is interrupted, resume from the last
+			// event received
+			s.closeEvents()
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		return decodeFrame[T, P](s.u, data)
+	}
+}
+
+// connect establishes the event stream, switching to long polling if
+// the events are not streamed
+func (s *Subscription[T, P]) connect() error {
+	err := s.openEvents()
+	if errors.Is(err, errStreamingUnsupported) {
+		s.longPoll = true
+		return nil
+	}
+	return err
+}
+
+func (s *Subscription[T, P]) openEvents() error {
+	// an intermediary buffering the response delays the headers or
+	// the first event indefinitely
+	ctx, cancel := context.WithCancel(s.ctx)
+	var stalled atomic.Bool
+	timer := time.AfterFunc(s.opts.firstEvent, func() {
+		stalled.Store(true)
+		cancel()
+	})
+	resp, err := s.requestEvents(ctx)
+	var events *bufio.Reader
+	if err == nil {
+		events = bufio.NewReader(resp.Body)
+		if _, err = events.Peek(1); err != nil {
+			_ = resp.Body.Close()
+			err = fmt.Errorf("failed to read events: %w", err)
+		}
+	}
+	timer.Stop()
+	if err != nil {
+		cancel()
+		if stalled.Load() {
+			return errStreamingUnsupported
+		}
+		if cerr := s.ctx.Err(); cerr != nil {
+			return cerr
+		}
+		return err
+	}
+	s.body, s.events, s.cancel = resp.Body, events, cancel
+	return nil
+}
+
+func (s *Subscription[T, P]) requestEvents(ctx context.Context) (*http.Response, error) {
+	r, err := s.newRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Accept", "text/event-stream")
+	r.Header.Set("Cache-Control", "no-cache")
+	if token := s.ResumeToken(); token != "" {
+		r.Header.Set("Last-Event-ID", token)
+	}
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		_ = resp.Body.Close()
+		return nil, io.EOF
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, DecodeError(resp.StatusCode, body)
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "text/event-stream" {
+		// an intermediary has rewritten the response
+		_ = resp.Body.Close()
+		return nil, errStreamingUnsupported
+	}
+	return resp, nil
+}
+
+// readEvent returns the data of the next event, tracking the event id
+// as the resume token, returns nil data for the events without data
+func (s *Subscription[T, P]) readEvent() ([]byte, error) {
+	var data []byte
+	hasData := false
+	for {
+		line, err := s.events.ReadString('\n')
+		if err != nil {
+			// incomplete events are discarded
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if hasData {
+				return data, nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// comment, used as keep alive
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			s.setToken(value)
+		case "data":
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+			hasData = true
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.reconnect = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// poll triggers a long polling request, queueing the messages received
+func (s *Subscription[T, P]) poll() error {
+	r, err := s.newRequest(s.ctx)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Prefer", fmt.Sprintf("wait=%d", int(s.opts.wait.Seconds())))
+	if token := s.ResumeToken(); token != "" {
+		r.Header.Set(ResumeTokenHeader, token)
+	}
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusNoContent {
+		return io.EOF
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return DecodeError(resp.StatusCode, body)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.pendingErr = fmt.Errorf("failed to read stream: %w", err)
+			}
+			break
+		}
+		m, err := decodeFrame[T, P](s.u, raw)
+		if err != nil {
+			s.pendingErr = err
+			break
+		}
+		s.pending = append(s.pending, m)
+	}
+	if s.pendingErr == nil {
+		if token := resp.Header.Get(ResumeTokenHeader); token != "" {
+			s.setToken(token)
+		}
+	}
+	if len(s.pending) == 0 && s.pendingErr == nil {
+		// avoid spinning on the servers not holding the request
+		return s.wait(s.reconnect)
+	}
+	return nil
+}
+
+// wait sleeps for the given duration unless the context is cancelled
+func (s *Subscription[T, P]) wait(d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (s *Subscription[T, P]) closeEvents() {
+	if s.body != nil {
+		_ = s.body.Close()
+		s.cancel()
+		s.body, s.events, s.cancel = nil, nil, nil
+	}
+}
+
+// All returns an iterator over the messages of the subscription,
+// ending once the subscription is ended, or after yielding the error
+// terminating the subscription
+func (s *Subscription[T, P]) All() iter.Seq2[P, error] {
+	return func(yield func(P, error) bool) {
+		for {
+			m, err := s.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(m, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close terminates the subscription, releasing the active connection
+func (s *Subscription[T, P]) Close() error {
+	if s.err == nil {
+		s.err = io.EOF
+	}
+	s.closeEvents()
+	return nil
+}
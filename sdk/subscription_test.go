@@ -0,0 +1,127 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func subscribe(t *testing.T, h http.Handler, opts ...SubscribeOption) *Subscription[wrapperspb.StringValue, *wrapperspb.StringValue] {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(&Config{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, "/v1/objects:watch", nil)
+	}
+	opts = append([]SubscribeOption{WithReconnectDelay(time.Millisecond)}, opts...)
+	sub, err := NewSubscription[wrapperspb.StringValue](context.Background(), c, newRequest, &runtime.JSONPb{}, opts...)
+	if err != nil {
+		t.Fatalf("NewSubscription() failed with %v; want success", err)
+	}
+	t.Cleanup(func() {
+		_ = sub.Close()
+	})
+	return sub
+}
+
+func receiveAll(t *testing.T, sub *Subscription[wrapperspb.StringValue, *wrapperspb.StringValue]) string {
+	t.Helper()
+	var values []string
+	for msg, err := range sub.All() {
+		if err != nil {
+			t.Fatalf("sub.Recv() failed with %v; want success", err)
+		}
+		values = append(values, msg.GetValue())
+	}
+	return strings.Join(values, ",")
+}
+
+func TestSubscriptionResumesEvents(t *testing.T) {
+	// events sent on every connection, keyed by the last event id
+	events := map[string]string{
+		"":  "id: 1\ndata: {\"result\": \"a\"}\n\n: keep alive\n\nid: 2\ndata: \"b\"\n\n",
+		"2": "id: 3\ndata: \"c\"\n\n",
+	}
+	sub := subscribe(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := events[r.Header.Get("Last-Event-ID")]
+		if !ok || r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, data)
+	}))
+
+	if got, want := receiveAll(t, sub), "a,b,c"; got != want {
+		t.Errorf("sub.All() = %q; want %q", got, want)
+	}
+	if sub.LongPolling() {
+		t.Errorf("sub.LongPolling() = true; want false")
+	}
+	if got, want := sub.ResumeToken(), "3"; got != want {
+		t.Errorf("sub.ResumeToken() = %q; want %q", got, want)
+	}
+}
+
+func TestSubscriptionLongPollFallback(t *testing.T) {
+	polls := map[string]string{
+		"":  "1",
+		"1": "2",
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "text/event-stream" {
+			// intermediary buffering the stream, never delivering
+			// the events
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			<-r.Context().Done()
+			return
+		}
+		token := r.Header.Get(ResumeTokenHeader)
+		next, ok := polls[token]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set(ResumeTokenHeader, next)
+		fmt.Fprintf(w, "{\"result\": \"msg-%s\"}\n", next)
+	})
+	sub := subscribe(t, h, WithFirstEventTimeout(50*time.Millisecond))
+
+	if got, want := receiveAll(t, sub), "msg-1,msg-2"; got != want {
+		t.Errorf("sub.All() = %q; want %q", got, want)
+	}
+	if !sub.LongPolling() {
+		t.Errorf("sub.LongPolling() = false; want true")
+	}
+	if got, want := sub.ResumeToken(), "2"; got != want {
+		t.Errorf("sub.ResumeToken() = %q; want %q", got, want)
+	}
+}
+
+func TestSubscriptionError(t *testing.T) {
+	sub := subscribe(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"error\": {\"code\": 5, \"message\": \"not found\"}}\n\n")
+	}))
+
+	_, err := sub.Recv()
+	if serr, ok := err.(*StreamError); !ok || serr.Code != 5 {
+		t.Errorf("sub.Recv() = %v; want stream error with code 5", err)
+	}
+}
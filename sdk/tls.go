@@ -0,0 +1,58 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// WithTLSConfig connects to the server using the TLS config when the SDK
+// wrapper is constructed without a client, like the gateways requiring
+// a private CA or a specific server name, building the http client with
+// the config instead of using http.DefaultClient. The config is cloned,
+// such that it may be shared across the services. The option is ignored
+// when the wrapper is provided with the client, which is expected to be
+// configured on its own, like the one created using NewClient
+func WithTLSConfig(cfg *tls.Config) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithClientCert presents the certificate to the server for mutual TLS
+// when the SDK wrapper is constructed without a client, in addition to
+// the certificates of the config provided using WithTLSConfig if any.
+// Like WithTLSConfig, the option is ignored when the wrapper is provided
+// with the client
+//
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	...
+//	svc := example.NewHelloWorldService(nil, sdk.WithClientCert(cert))
+func WithClientCert(cert tls.Certificate) ServiceOption {
+	return func(c *ServiceConfig) {
+		c.clientCerts = append(c.clientCerts, cert)
+	}
+}
+
+// Client returns the client sending the requests of the service, the one
+// provided if any, otherwise the http client connecting to the server as
+// per the TLS settings configured using WithTLSConfig and WithClientCert,
+// http.DefaultClient if not configured
+func (c *ServiceConfig) Client(client Doer) Doer {
+	if client != nil {
+		return client
+	}
+	if c == nil || (c.tlsConfig == nil && len(c.clientCerts) == 0) {
+		return http.DefaultClient
+	}
+	tlsConfig := &tls.Config{}
+	if c.tlsConfig != nil {
+		tlsConfig = c.tlsConfig.Clone()
+	}
+	tlsConfig.Certificates = append(tlsConfig.Certificates, c.clientCerts...)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientWithTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// provided client is used as is
+	if got := NewServiceConfig("example.Objects", WithClientCert(tls.Certificate{})).Client(srv.Client()); got != srv.Client() {
+		t.Errorf("Client() with the client = %v; want the client", got)
+	}
+	if got := NewServiceConfig("example.Objects").Client(nil); got != http.DefaultClient {
+		t.Errorf("Client() without TLS options = %v; want http.DefaultClient", got)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	tlsConfig := &tls.Config{RootCAs: pool}
+	send := func(opts ...ServiceOption) error {
+		t.Helper()
+		client := NewServiceConfig("example.Objects", opts...).Client(nil)
+		r, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := client.Do(r)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("Do() responded %d; want %d", resp.StatusCode, http.StatusNoContent)
+		}
+		return nil
+	}
+	if err := send(WithTLSConfig(tlsConfig)); err == nil {
+		t.Errorf("Do() without the client certificate succeeded; want error")
+	}
+	if err := send(WithTLSConfig(tlsConfig), WithClientCert(srv.TLS.Certificates[0])); err != nil {
+		t.Errorf("Do() with the client certificate failed with %v; want success", err)
+	}
+	if len(tlsConfig.Certificates) != 0 {
+		t.Errorf("WithClientCert() modified the provided config; want cloned")
+	}
+}
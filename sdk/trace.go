@@ -0,0 +1,78 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestTrace describes how the request was constructed from the
+// message by the generated SDK, attached to the context of the request
+// when generated with request_tracing enabled for debugging
+//
+//	sdk.WithInterceptors(func(ctx context.Context, r *http.Request, next sdk.Invoker) (*http.Response, error) {
+//		if trace := sdk.RequestTraceFromContext(ctx); trace != nil {
+//			slog.DebugContext(ctx, "sending request", trace.LogFields()...)
+//		}
+//		return next(ctx, r)
+//	})
+type RequestTrace struct {
+	// Method is the full name of the grpc method, /package.Service/Method
+	Method string
+
+	// HTTPMethod is the http method of the binding
+	HTTPMethod string
+
+	// PathTemplate is the path template of the binding, as annotated
+	PathTemplate string
+
+	// PathParams are the values of the variables of the path template,
+	// as resolved from the message
+	PathParams map[string]string
+
+	// QueryParams are the fields of the message classified as the query
+	// params, sent if set
+	QueryParams []string
+
+	// Body is the field of the message sent as the body, * for the
+	// whole message and empty if none
+	Body string
+}
+
+// requestTraceKey is the context key carrying the trace of the request
+type requestTraceKey struct{}
+
+// WithRequestTrace returns the request carrying the trace in its
+// context, called by the generated SDK
+func WithRequestTrace(r *http.Request, trace *RequestTrace) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), requestTraceKey{}, trace))
+}
+
+// RequestTraceFromContext returns the trace of the request carried by
+// the context, nil if not available
+func RequestTraceFromContext(ctx context.Context) *RequestTrace {
+	trace, _ := ctx.Value(requestTraceKey{}).(*RequestTrace)
+	return trace
+}
+
+// LogFields returns the trace as the alternating keys and values, as
+// accepted by the structured loggers like log/slog
+func (t *RequestTrace) LogFields() []any {
+	fields := []any{
+		"rpc", t.Method,
+		"http_method", t.HTTPMethod,
+		"path_template", t.PathTemplate,
+	}
+	if len(t.PathParams) != 0 {
+		fields = append(fields, "path_params", t.PathParams)
+	}
+	if len(t.QueryParams) != 0 {
+		fields = append(fields, "query_params", t.QueryParams)
+	}
+	if t.Body != "" {
+		fields = append(fields, "body", t.Body)
+	}
+	return fields
+}
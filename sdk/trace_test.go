@@ -0,0 +1,35 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRequestTrace(t *testing.T) {
+	r, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/object/a", nil)
+	if trace := RequestTraceFromContext(r.Context()); trace != nil {
+		t.Errorf("RequestTraceFromContext() = %+v; want nil without trace", trace)
+	}
+	want := &RequestTrace{
+		Method:       "/example.HelloWorld/PostObject",
+		HTTPMethod:   http.MethodPost,
+		PathTemplate: "/v1/object/{name}",
+		PathParams:   map[string]string{"name": "a"},
+		Body:         "*",
+	}
+	r = WithRequestTrace(r, want)
+	if got := RequestTraceFromContext(r.Context()); got != want {
+		t.Errorf("RequestTraceFromContext() = %+v; want %+v", got, want)
+	}
+
+	got := fmt.Sprint(want.LogFields()...)
+	if wantFields := fmt.Sprint("rpc", "/example.HelloWorld/PostObject", "http_method", "POST", "path_template", "/v1/object/{name}",
+		"path_params", map[string]string{"name": "a"}, "body", "*"); got != wantFields {
+		t.Errorf("LogFields() = %s; want %s", got, wantFields)
+	}
+}
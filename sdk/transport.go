@@ -0,0 +1,71 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// TransportConfig tunes the reuse of the connections by the client
+// created using NewClient, the zero values retain the defaults of
+// http.DefaultTransport
+type TransportConfig struct {
+	// MaxIdleConns is the number of the idle connections kept across
+	// the hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the number of the idle connections kept
+	// per host, http.DefaultMaxIdleConnsPerHost by default, which is
+	// too small for the consumers sending many concurrent calls,
+	// resulting in the connections being closed and opened again
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost bounds the connections per host, including the
+	// ones in use, unbounded by default
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is the time an idle connection is kept before
+	// being closed
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 sends the requests using HTTP/1.1, instead of
+	// negotiating HTTP/2 over TLS as the client does by default with
+	// ForceAttemptHTTP2, like for the proxies mishandling HTTP/2
+	DisableHTTP2 bool
+}
+
+// WithTransportConfig tunes the connection pool and the HTTP version of
+// the transport of the client as per the config
+//
+//	client, err := sdk.NewClient(cfg, sdk.WithTransportConfig(sdk.TransportConfig{
+//		MaxIdleConnsPerHost: 64,
+//		IdleConnTimeout:     30 * time.Second,
+//	}))
+func WithTransportConfig(cfg TransportConfig) Option {
+	return func(o *options) {
+		o.transport = &cfg
+	}
+}
+
+// apply tunes the transport as per the config
+func (c *TransportConfig) apply(transport *http.Transport) {
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = c.MaxConnsPerHost
+	}
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP1(true)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTransportConfig(t *testing.T) {
+	var proto string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	send := func(opts ...Option) *http.Transport {
+		t.Helper()
+		c, err := NewClient(&Config{Endpoint: srv.URL, Insecure: true}, opts...)
+		if err != nil {
+			t.Fatalf("NewClient() failed with %v; want success", err)
+		}
+		r, _ := http.NewRequest(http.MethodGet, "/v1/objects", nil)
+		resp, err := c.Do(r)
+		if err != nil {
+			t.Fatalf("Do() failed with %v; want success", err)
+		}
+		_ = resp.Body.Close()
+		return c.(*client).client.Transport.(*http.Transport)
+	}
+
+	transport := send()
+	if proto != "HTTP/2.0" || transport.MaxIdleConnsPerHost != 0 {
+		t.Errorf("client without the transport config sent %s with %d idle conns per host; want HTTP/2.0 with the defaults", proto, transport.MaxIdleConnsPerHost)
+	}
+	transport = send(WithTransportConfig(TransportConfig{
+		MaxIdleConnsPerHost: 64,
+		MaxConnsPerHost:     128,
+		IdleConnTimeout:     30 * time.Second,
+		DisableHTTP2:        true,
+	}))
+	if proto != "HTTP/1.1" {
+		t.Errorf("client with HTTP/2 disabled sent %s; want HTTP/1.1", proto)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.MaxConnsPerHost != 128 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("transport tuned to %d idle and %d conns per host with %v idle timeout; want 64, 128 and 30s",
+			transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.MaxIdleConns != http.DefaultTransport.(*http.Transport).MaxIdleConns {
+		t.Errorf("transport MaxIdleConns = %d; want the default retained", transport.MaxIdleConns)
+	}
+}
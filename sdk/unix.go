@@ -0,0 +1,47 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+// UnixScheme is the scheme of the endpoint served over the unix domain
+// socket, like unix:///run/api.sock, for talking to the sidecars and the
+// servers on the same host without going through the network stack.
+// Requests are sent as plain HTTP with localhost as the host, the path
+// of the endpoint being the socket instead of the prefix of the requests
+const UnixScheme = "unix"
+
+// unixSocket returns the path of the socket of the endpoint using
+// UnixScheme, accepting the relative paths like unix:api.sock too
+func unixSocket(endpoint *url.URL) (string, error) {
+	socket := endpoint.Path
+	if socket == "" {
+		socket = endpoint.Opaque
+	}
+	if socket == "" {
+		return "", fmt.Errorf("invalid endpoint %q: socket path is missing", endpoint)
+	}
+	return socket, nil
+}
+
+// unixEndpoint is the endpoint the requests sent over the unix domain
+// socket are resolved against
+var unixEndpoint = &url.URL{Scheme: "http", Host: "localhost"}
+
+// dialUnix returns the dial function of the transport connecting to the
+// socket irrespective of the address of the request
+func dialUnix(socket string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+	}
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socket)
+	}
+}
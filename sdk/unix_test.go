@@ -0,0 +1,53 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClientUnixSocket(t *testing.T) {
+	// socket paths are limited in length, unlike the test directories
+	dir, err := os.MkdirTemp("", "sdk")
+	if err != nil {
+		t.Fatalf("MkdirTemp() failed with %v; want success", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Listen(%s) failed with %v; want success", socket, err)
+	}
+	var gotHost, gotPath string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotPath = r.Host, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.Listener = l
+	srv.Start()
+	defer srv.Close()
+
+	c, err := NewClient(&Config{Endpoint: "unix://" + socket, Proxy: "http://proxy.invalid:3128"})
+	if err != nil {
+		t.Fatalf("NewClient() failed with %v; want success", err)
+	}
+	r, _ := http.NewRequest(http.MethodGet, "/v1/objects", nil)
+	resp, err := c.Do(r)
+	if err != nil {
+		t.Fatalf("Do() over the socket failed with %v; want success", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent || gotHost != "localhost" || gotPath != "/v1/objects" {
+		t.Errorf("Do() over the socket responded %d for %s%s; want 204 for localhost/v1/objects", resp.StatusCode, gotHost, gotPath)
+	}
+
+	if _, err := NewClient(&Config{Endpoint: "unix://"}); err == nil {
+		t.Errorf("NewClient() without the socket path succeeded; want error")
+	}
+}
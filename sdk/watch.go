@@ -0,0 +1,136 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// EventType is the type of the change reported by a watch
+type EventType string
+
+const (
+	// Added reports the object being created
+	Added EventType = "ADDED"
+	// Modified reports the object being updated
+	Modified EventType = "MODIFIED"
+	// Deleted reports the object being deleted, carrying its last state
+	Deleted EventType = "DELETED"
+)
+
+// ParseEventType returns the event type for the value of the type field
+// of the streamed event, matching the enum values prefixed with the
+// enum name like EVENT_TYPE_ADDED, unknown values are returned as is
+func ParseEventType(value string) EventType {
+	value = strings.ToUpper(value)
+	for _, t := range []EventType{Added, Modified, Deleted} {
+		if value == string(t) || strings.HasSuffix(value, "_"+string(t)) {
+			return t
+		}
+	}
+	return EventType(value)
+}
+
+// Event is the change to the object reported by a watch
+type Event[T any] struct {
+	// Type of the change
+	Type EventType
+	// Object is the state of the object after the change, or the last
+	// state of the object if deleted
+	Object T
+}
+
+// Watcher provides the events of the methods annotated as watches, in
+// the style of the watch interface of the kubernetes client
+//
+//	w, err := svc.WatchObjects(ctx, req)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Stop()
+//	for event := range w.ResultChan() {
+//		switch event.Type {
+//		case sdk.Added:
+//			...
+//		}
+//	}
+//	return w.Err()
+type Watcher[T any] interface {
+	// ResultChan returns the channel of the events, closed once the
+	// watch ends
+	ResultChan() <-chan Event[T]
+
+	// Stop ends the watch, releasing the underlying stream
+	Stop()
+
+	// Err returns the error terminating the watch once the result
+	// channel is closed, nil if stopped or completed by the server
+	Err() error
+}
+
+// watcher delivers the events decoded from the stream
+type watcher[T any] struct {
+	result chan Event[T]
+	done   chan struct{}
+	once   sync.Once
+	// release closes the body of the stream, unblocking the pending read
+	release func() error
+	err     error
+}
+
+// NewWatcher returns the watcher delivering the events converted from
+// the messages of the stream, taking the ownership of the stream
+func NewWatcher[R any, P interface {
+	*R
+	proto.Message
+}, T any](stream *Stream[R, P], event func(P) Event[T]) Watcher[T] {
+	w := &watcher[T]{
+		result:  make(chan Event[T]),
+		done:    make(chan struct{}),
+		release: stream.body.Close,
+	}
+	go func() {
+		defer close(w.result)
+		defer func() { _ = stream.Close() }()
+		for {
+			m, err := stream.Recv()
+			if err != nil {
+				select {
+				case <-w.done:
+				default:
+					if !errors.Is(err, io.EOF) {
+						w.err = err
+					}
+				}
+				return
+			}
+			select {
+			case w.result <- event(m):
+			case <-w.done:
+				return
+			}
+		}
+	}()
+	return w
+}
+
+func (w *watcher[T]) ResultChan() <-chan Event[T] {
+	return w.result
+}
+
+func (w *watcher[T]) Stop() {
+	w.once.Do(func() {
+		close(w.done)
+		_ = w.release()
+	})
+}
+
+func (w *watcher[T]) Err() error {
+	return w.err
+}
@@ -0,0 +1,95 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldEvent converts the field descriptor to an event, carrying the
+// event type as the type name and the object as the name of the field
+func fieldEvent(m *descriptorpb.FieldDescriptorProto) Event[string] {
+	return Event[string]{Type: ParseEventType(m.GetTypeName()), Object: m.GetName()}
+}
+
+func TestParseEventType(t *testing.T) {
+	for value, want := range map[string]EventType{
+		"ADDED":                  Added,
+		"modified":               Modified,
+		"EVENT_TYPE_DELETED":     Deleted,
+		"EVENT_TYPE_BOOKMARK":    "EVENT_TYPE_BOOKMARK",
+		"EVENT_TYPE_UNSPECIFIED": "EVENT_TYPE_UNSPECIFIED",
+	} {
+		if got := ParseEventType(value); got != want {
+			t.Errorf("ParseEventType(%q) = %q; want %q", value, got, want)
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	body := `{"result":{"typeName":"EVENT_TYPE_ADDED","name":"a"}}
+{"result":{"typeName":"EVENT_TYPE_MODIFIED","name":"a"}}
+{"result":{"typeName":"EVENT_TYPE_DELETED","name":"a"}}
+{"error":{"code":13,"message":"internal failure"}}
+`
+	w := NewWatcher(NewStream[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, newStreamResponse(body)), fieldEvent)
+	defer w.Stop()
+
+	var got []Event[string]
+	for event := range w.ResultChan() {
+		got = append(got, event)
+	}
+	want := []Event[string]{{Added, "a"}, {Modified, "a"}, {Deleted, "a"}}
+	if len(got) != len(want) {
+		t.Fatalf("watch events = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("watch event %d = %v; want %v", i, got[i], want[i])
+		}
+	}
+	var serr *StreamError
+	if !errors.As(w.Err(), &serr) || serr.Code != 13 {
+		t.Errorf("w.Err() = %v; want *StreamError terminating the watch", w.Err())
+	}
+
+	// watch completed by the server ends without an error
+	w = NewWatcher(NewStream[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, newStreamResponse("")), fieldEvent)
+	for range w.ResultChan() {
+	}
+	if err := w.Err(); err != nil {
+		t.Errorf("w.Err() after completion = %v; want nil", err)
+	}
+}
+
+func TestWatcherStop(t *testing.T) {
+	r, pw := io.Pipe()
+	defer pw.Close()
+	w := NewWatcher(NewStream[descriptorpb.FieldDescriptorProto](&runtime.JSONPb{}, &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       r,
+	}), fieldEvent)
+	go func() {
+		_, _ = io.WriteString(pw, `{"result":{"typeName":"ADDED","name":"a"}}`+"\n")
+	}()
+	if event := <-w.ResultChan(); event.Type != Added || event.Object != "a" {
+		t.Errorf("first watch event = %v; want ADDED a", event)
+	}
+
+	// stop unblocks the pending read and closes the result channel
+	w.Stop()
+	w.Stop()
+	for event := range w.ResultChan() {
+		t.Errorf("watch event %v after stop; want none", event)
+	}
+	if err := w.Err(); err != nil {
+		t.Errorf("w.Err() after stop = %v; want nil", err)
+	}
+}
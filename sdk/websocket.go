@@ -0,0 +1,274 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// MaxWebSocketMessageSize is the maximum size of the message accepted
+// over the websocket connection, protecting the client against the
+// unbounded messages
+var MaxWebSocketMessageSize int64 = 32 << 20
+
+// websocketGUID is used to compute Sec-WebSocket-Accept, RFC 6455
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocket opcodes, RFC 6455 section 5.2
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// websocket close status codes, RFC 6455 section 7.4
+const (
+	closeNormal   = 1000
+	closeNoStatus = 1005
+)
+
+// WebSocketCloseError is reported once the server closes the websocket
+// connection with a status other than normal closure
+type WebSocketCloseError struct {
+	// Code is the websocket close status code
+	Code int
+
+	// Reason provided by the server
+	Reason string
+}
+
+// Error returns the description of the error
+func (e *WebSocketCloseError) Error() string {
+	return fmt.Sprintf("websocket closed: code = %d reason = %s", e.Code, e.Reason)
+}
+
+// dialWebSocket upgrades the connection to websocket using the client,
+// such that the handshake is authenticated and sent to the endpoint
+// resolved by the client. The http method of the request is conveyed
+// using the method query param, as understood by the websocket proxies
+// used with grpc-gateway
+func dialWebSocket(ctx context.Context, client Doer, method, uri string) (*wsConn, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed create request: %s", err)
+	}
+	if method != http.MethodGet {
+		q := r.URL.Query()
+		q.Set("method", method)
+		r.URL.RawQuery = q.Encode()
+	}
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", key)
+	SetMetadataHeaders(r)
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, DecodeError(resp.StatusCode, body)
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") || resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		_ = resp.Body.Close()
+		return nil, errors.New("invalid websocket handshake response")
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		// http client wraps the body when configured with a timeout
+		_ = resp.Body.Close()
+		return nil, errors.New("upgraded connection is not writable, client timeout must be disabled for websocket")
+	}
+	return newWSConn(rwc, true), nil
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value for the key
+func acceptKey(key string) string {
+	h := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// wsConn implements the websocket framing over the upgraded connection,
+// frames sent by the client are masked as mandated by the protocol
+type wsConn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	client bool
+
+	// wmu serializes the frames written to the connection
+	wmu    sync.Mutex
+	closed bool
+}
+
+func newWSConn(rwc io.ReadWriteCloser, client bool) *wsConn {
+	return &wsConn{
+		rwc:    rwc,
+		br:     bufio.NewReader(rwc),
+		client: client,
+	}
+}
+
+// writeFrame writes the payload as a single frame
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.closed {
+		return errStreamClosed
+	}
+	if op == opClose {
+		c.closed = true
+	}
+
+	header := make([]byte, 2, 14)
+	header[0] = 0x80 | op
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = byte(n)
+	case n <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	if c.client {
+		header[1] |= 0x80
+		var mask [4]byte
+		_, _ = rand.Read(mask[:])
+		header = append(header, mask[:]...)
+		masked := make([]byte, len(payload))
+		for i, b := range payload {
+			masked[i] = b ^ mask[i%4]
+		}
+		payload = masked
+	}
+	if _, err := c.rwc.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeClose writes the close frame with the given status code
+func (c *wsConn) writeClose(code int, reason string) error {
+	if code == closeNoStatus {
+		// never sent over the wire, conveyed using empty payload
+		return c.writeFrame(opClose, nil)
+	}
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	return c.writeFrame(opClose, append(payload, reason...))
+}
+
+// readMessage returns the next data message, assembling the fragments
+// and responding to the control frames, returns io.EOF once the peer
+// closes the connection normally
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil && !errors.Is(err, errStreamClosed) {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			code, reason := closeNoStatus, ""
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+				reason = string(payload[2:])
+			}
+			// acknowledge the close unless initiated by us
+			_ = c.writeClose(code, "")
+			if code == closeNormal || code == closeNoStatus {
+				return nil, io.EOF
+			}
+			return nil, &WebSocketCloseError{Code: code, Reason: reason}
+		case opText, opBinary, opContinuation:
+		default:
+			return nil, fmt.Errorf("unexpected websocket opcode %d", op)
+		}
+		if int64(len(msg)+len(payload)) > MaxWebSocketMessageSize {
+			return nil, fmt.Errorf("websocket message exceeds %d bytes", MaxWebSocketMessageSize)
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readFrame reads a single frame, unmasking the payload if masked
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op := header[0]&0x80 != 0, header[0]&0x0f
+	masked := header[1]&0x80 != 0
+	n := int64(header[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = int64(binary.BigEndian.Uint64(ext[:]) & (1<<63 - 1))
+	}
+	if n > MaxWebSocketMessageSize {
+		return false, 0, nil, fmt.Errorf("websocket message exceeds %d bytes", MaxWebSocketMessageSize)
+	}
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// Close closes the underlying connection
+func (c *wsConn) Close() error {
+	return c.rwc.Close()
+}
@@ -0,0 +1,269 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UpdateWireFixturesEnv is the environment variable, set to true, for
+// the wire compatibility tests generated using the wire_compat_tests
+// option to rewrite the fixtures with the requests sent, once the
+// change of the wire format is intended
+const UpdateWireFixturesEnv = "SDK_UPDATE_WIRE_FIXTURES"
+
+// WireRecord is the request sent by the SDK wrapper for a binding, as
+// pinned by the wire compatibility tests
+type WireRecord struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	// Body is the JSON body compacted, the other bodies are base64
+	// encoded in BodyBase64
+	Body       string `json:"body,omitempty"`
+	BodyBase64 string `json:"body_base64,omitempty"`
+}
+
+// WireRecorder is the Doer recording the requests sent by the SDK
+// wrappers, responding with an empty JSON object, used by the generated
+// wire compatibility tests to pin the URL, the query string and the body
+// sent for the canonical request of every binding
+type WireRecorder struct {
+	mu      sync.Mutex
+	name    string
+	records map[string]WireRecord
+}
+
+// NewWireRecorder creates the recorder without any requests recorded
+func NewWireRecorder() *WireRecorder {
+	return &WireRecorder{records: map[string]WireRecord{}}
+}
+
+// Record records the request sent by the call under the name, the
+// outcome of the call is not verified
+func (r *WireRecorder) Record(name string, call func()) {
+	r.mu.Lock()
+	r.name = name
+	r.mu.Unlock()
+	call()
+}
+
+// Do records the request under the name of the call being recorded
+func (r *WireRecorder) Do(req *http.Request) (*http.Response, error) {
+	rec := WireRecord{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		ContentType: req.Header.Get("Content-Type"),
+	}
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != 0 {
+			var compact bytes.Buffer
+			mediaType, _, _ := mime.ParseMediaType(rec.ContentType)
+			if mediaType == "application/json" && json.Compact(&compact, data) == nil {
+				// protojson randomizes the whitespace
+				rec.Body = compact.String()
+			} else {
+				rec.BodyBase64 = base64.StdEncoding.EncodeToString(data)
+			}
+		}
+	}
+	r.mu.Lock()
+	r.records[r.name] = rec
+	r.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// Compare compares the requests recorded with the ones of the fixture
+// at the path, reporting the differences as the error. The fixture is
+// written when missing or when UpdateWireFixturesEnv is set to true
+func (r *WireRecorder) Compare(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || os.Getenv(UpdateWireFixturesEnv) == "true" {
+		return r.write(path)
+	}
+	if err != nil {
+		return err
+	}
+	var want map[string]WireRecord
+	if err := json.Unmarshal(data, &want); err != nil {
+		return fmt.Errorf("invalid wire fixture %s: %w", path, err)
+	}
+	var diffs []string
+	for name, got := range r.records {
+		w, ok := want[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%s: sent %+v; not pinned", name, got))
+		case got != w:
+			diffs = append(diffs, fmt.Sprintf("%s: sent %+v; want %+v", name, got, w))
+		}
+	}
+	for name, w := range want {
+		if _, ok := r.records[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: not sent; want %+v", name, w))
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	slices.Sort(diffs)
+	return fmt.Errorf("wire format differs from %s, set %s=true to update once intended:\n%s", path, UpdateWireFixturesEnv, strings.Join(diffs, "\n"))
+}
+
+// write writes the requests recorded to the fixture at the path
+func (r *WireRecorder) write(path string) error {
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	// keeps the query strings readable in the fixtures
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.records); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data.Bytes(), 0o644)
+}
+
+// CanonicalMessage returns the message populated deterministically, for
+// the wire compatibility tests to send the same request across the
+// versions of the generator. Every field is set, the strings to the name
+// of the field, the numbers to the number of the field and the enums to
+// the first non zero value, the repeated fields get two elements, the
+// maps an entry and the oneofs the first field. Recursive messages are
+// populated once, while google.protobuf.Any is left unset
+func CanonicalMessage[M proto.Message](msg M) M {
+	if !skipMessage(msg.ProtoReflect().Descriptor(), nil) {
+		populate(msg.ProtoReflect(), map[protoreflect.FullName]bool{})
+	}
+	return msg
+}
+
+// populate sets the fields of the message, skipping the messages being
+// populated up the stack
+func populate(m protoreflect.Message, seen map[protoreflect.FullName]bool) {
+	desc := m.Descriptor()
+	seen[desc.FullName()] = true
+	defer delete(seen, desc.FullName())
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && oneof.Fields().Get(0) != fd {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if skipMessage(fd.MapValue().Message(), seen) {
+				continue
+			}
+			mp := m.Mutable(fd).Map()
+			value := mp.NewValue()
+			if fd.MapValue().Message() != nil {
+				populate(value.Message(), seen)
+			} else {
+				value = canonicalValue(fd.MapValue(), 0)
+			}
+			mp.Set(canonicalValue(fd.MapKey(), 0).MapKey(), value)
+		case fd.IsList():
+			if skipMessage(fd.Message(), seen) {
+				continue
+			}
+			list := m.Mutable(fd).List()
+			for k := range 2 {
+				if fd.Message() == nil {
+					list.Append(canonicalValue(fd, k))
+					continue
+				}
+				v := list.NewElement()
+				populate(v.Message(), seen)
+				list.Append(v)
+			}
+		case fd.Message() != nil:
+			if skipMessage(fd.Message(), seen) {
+				continue
+			}
+			populate(m.Mutable(fd).Message(), seen)
+		default:
+			m.Set(fd, canonicalValue(fd, 0))
+		}
+	}
+}
+
+// skipMessage reports whether the fields of the message type are left
+// unset, the recursive ones and google.protobuf.Any resolving the type
+func skipMessage(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) bool {
+	return md != nil && (seen[md.FullName()] || md.FullName() == "google.protobuf.Any")
+}
+
+// canonicalValue returns the value of the scalar field, for the element
+// at the index of the repeated fields
+func canonicalValue(fd protoreflect.FieldDescriptor, index int) protoreflect.Value {
+	n := int64(fd.Number()) + int64(index)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			if v := values.Get(i).Number(); v != 0 {
+				return protoreflect.ValueOfEnum(v)
+			}
+		}
+		return protoreflect.ValueOfEnum(0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(n))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(n) + 0.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(n) + 0.5)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(canonicalString(fd, index)))
+	default:
+		return protoreflect.ValueOfString(canonicalString(fd, index))
+	}
+}
+
+// canonicalString returns the string value of the field, suffixed for
+// the elements of the repeated fields beyond the first one
+func canonicalString(fd protoreflect.FieldDescriptor, index int) string {
+	if index == 0 {
+		return string(fd.Name())
+	}
+	return fmt.Sprintf("%s-%d", fd.Name(), index+1)
+}
@@ -0,0 +1,73 @@
+// Copyright © 2025 Prabhjot Singh Sethi, All Rights reserved
+// Author: Prabhjot Singh Sethi <prabhjot.sethi@gmail.com>
+
+package sdk
+
+import (
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCanonicalMessage(t *testing.T) {
+	mask := CanonicalMessage(&fieldmaskpb.FieldMask{})
+	if want := []string{"paths", "paths-2"}; !slices.Equal(mask.GetPaths(), want) {
+		t.Errorf("CanonicalMessage(FieldMask).Paths = %v; want %v", mask.GetPaths(), want)
+	}
+	d := CanonicalMessage(&durationpb.Duration{})
+	if d.GetSeconds() != 1 || d.GetNanos() != 2 {
+		t.Errorf("CanonicalMessage(Duration) = %v; want seconds 1 and nanos 2", d)
+	}
+	// Struct recurses through Value, populated once
+	s := CanonicalMessage(&structpb.Struct{})
+	if !proto.Equal(s, CanonicalMessage(&structpb.Struct{})) {
+		t.Errorf("CanonicalMessage(Struct) = %v; want deterministic", s)
+	}
+	if _, ok := s.GetFields()["key"]; !ok {
+		t.Errorf("CanonicalMessage(Struct) = %v; want the entry of key", s)
+	}
+}
+
+func TestWireRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "example.wire.json")
+	send := func(target, body string) *WireRecorder {
+		t.Helper()
+		rec := NewWireRecorder()
+		rec.Record("GetObject", func() {
+			r, _ := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			resp, err := rec.Do(r)
+			if err != nil || resp.StatusCode != http.StatusOK {
+				t.Fatalf("Do() = %v, %v; want success", resp, err)
+			}
+		})
+		return rec
+	}
+
+	if err := send("/v1/object/name?a=1&b=2", `{ "name": "name" }`).Compare(path); err != nil {
+		t.Fatalf("Compare() failed with %v; want the missing fixture written", err)
+	}
+	if err := send("/v1/object/name?a=1&b=2", `{"name":"name"}`).Compare(path); err != nil {
+		t.Errorf("Compare() failed with %v; want the same requests", err)
+	}
+	err := send("/v1/object/name?b=2&a=1", `{"name":"name"}`).Compare(path)
+	if err == nil || !strings.Contains(err.Error(), "GetObject") {
+		t.Errorf("Compare() = %v; want the difference of GetObject", err)
+	}
+
+	t.Setenv(UpdateWireFixturesEnv, "true")
+	if err := send("/v1/object/name?b=2&a=1", `{"name":"name"}`).Compare(path); err != nil {
+		t.Fatalf("Compare() failed with %v; want the fixture updated", err)
+	}
+	t.Setenv(UpdateWireFixturesEnv, "")
+	if err := send("/v1/object/name?b=2&a=1", `{"name":"name"}`).Compare(path); err != nil {
+		t.Errorf("Compare() failed with %v; want the updated requests", err)
+	}
+}